@@ -28,6 +28,8 @@ import (
 	"github.com/okex/okexchain/x/dex"
 	dexClient "github.com/okex/okexchain/x/dex/client"
 	distr "github.com/okex/okexchain/x/distribution"
+	"github.com/okex/okexchain/x/farm"
+	farmClient "github.com/okex/okexchain/x/farm/client"
 	"github.com/okex/okexchain/x/genutil"
 	"github.com/okex/okexchain/x/gov"
 	"github.com/okex/okexchain/x/gov/keeper"
@@ -64,7 +66,9 @@ var (
 		distr.AppModuleBasic{},
 		gov.NewAppModuleBasic(
 			upgradeClient.ProposalHandler, paramsclient.ProposalHandler,
-			dexClient.DelistProposalHandler, distr.ProposalHandler,
+			dexClient.DelistProposalHandler, dexClient.MatchEngineProposalHandler,
+			dexClient.PriceBandProposalHandler, distr.ProposalHandler,
+			farmClient.CommunityPoolRewardsProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -80,6 +84,7 @@ var (
 		stream.AppModuleBasic{},
 		debug.AppModuleBasic{},
 		ammswap.AppModuleBasic{},
+		farm.AppModuleBasic{},
 	)
 
 	// module account permissions for bankKeeper and supplyKeeper
@@ -95,6 +100,7 @@ var (
 		backend.ModuleName:        nil,
 		dex.ModuleName:            nil,
 		ammswap.ModuleName:        {supply.Minter, supply.Burner},
+		farm.ModuleName:           nil,
 	}
 )
 
@@ -125,6 +131,7 @@ type ProtocolV0 struct {
 	dexKeeper      dex.Keeper
 	orderKeeper    order.Keeper
 	swapKeeper     ammswap.Keeper
+	farmKeeper     farm.Keeper
 	protocolKeeper proto.ProtocolKeeper
 	backendKeeper  backend.Keeper
 	streamKeeper   stream.Keeper
@@ -304,18 +311,24 @@ func (p *ProtocolV0) produceKeepers() {
 
 	p.dexKeeper = dex.NewKeeper(auth.FeeCollectorName, p.supplyKeeper, dexSubspace, p.tokenKeeper, &stakingKeeper,
 		p.bankKeeper, p.keys[dex.StoreKey], p.keys[dex.TokenPairStoreKey], p.cdc)
+	p.dexKeeper.SetMatchEngines(order.GetMatchEngines())
 
 	p.orderKeeper = order.NewKeeper(
 		p.tokenKeeper, p.supplyKeeper, p.dexKeeper, orderSubspace, auth.FeeCollectorName,
 		p.keys[order.OrderStoreKey], p.cdc, appConfig.BackendConfig.EnableBackend, orderMetrics,
 	)
+	p.paramsKeeper.RegisterParamsValidator(order.DefaultParamspace, order.ValidateParamsSubspace)
 
-	p.swapKeeper = ammswap.NewKeeper(p.supplyKeeper, p.tokenKeeper, p.cdc, p.keys[ammswap.StoreKey], swapSubSpace)
+	p.swapKeeper = ammswap.NewKeeper(p.supplyKeeper, p.tokenKeeper, p.distrKeeper, p.cdc, p.keys[ammswap.StoreKey],
+		p.tkeys[ammswap.TStoreKey], swapSubSpace, appConfig.BackendConfig.EnableBackend)
+	p.orderKeeper.SetSwapKeeper(p.swapKeeper)
+
+	p.farmKeeper = farm.NewKeeper(p.supplyKeeper, p.tokenKeeper, p.distrKeeper, p.cdc, p.keys[farm.StoreKey])
 
 	p.streamKeeper = stream.NewKeeper(p.orderKeeper, p.tokenKeeper, &p.dexKeeper, &p.accountKeeper,
 		p.cdc, p.logger, appConfig, streamMetrics)
 
-	p.backendKeeper = backend.NewKeeper(p.orderKeeper, p.tokenKeeper, &p.dexKeeper, p.streamKeeper.GetMarketKeeper(),
+	p.backendKeeper = backend.NewKeeper(p.orderKeeper, p.tokenKeeper, &p.dexKeeper, p.swapKeeper, p.streamKeeper.GetMarketKeeper(),
 		p.cdc, p.logger, appConfig.BackendConfig)
 
 	// 3.register the proposal types
@@ -324,7 +337,8 @@ func (p *ProtocolV0) produceKeepers() {
 		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(&p.paramsKeeper)).
 		AddRoute(dex.RouterKey, dex.NewProposalHandler(&p.dexKeeper)).
 		AddRoute(upgrade.RouterKey, upgrade.NewAppUpgradeProposalHandler(&p.upgradeKeeper)).
-		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(p.distrKeeper))
+		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(p.distrKeeper)).
+		AddRoute(farm.RouterKey, farm.NewCommunityPoolRewardsProposalHandler(p.farmKeeper))
 	govProposalHandlerRouter := keeper.NewProposalHandlerRouter()
 	govProposalHandlerRouter.AddRoute(params.RouterKey, &p.paramsKeeper).
 		AddRoute(dex.RouterKey, &p.dexKeeper).
@@ -343,6 +357,13 @@ func (p *ProtocolV0) produceKeepers() {
 	p.upgradeKeeper = upgrade.NewKeeper(
 		p.cdc, p.keys[upgrade.StoreKey], p.protocolKeeper, p.stakingKeeper, p.bankKeeper, upgradeSubspace,
 	)
+	// 5.register the upgrade handlers, which set the params added by the upgrade at the upgrade height
+	p.upgradeKeeper.SetUpgradeHandler(uint64(version.ProtocolVersionV1), func(ctx sdk.Context) {
+		order.UpgradeParams(ctx, p.orderKeeper)
+	})
+	p.upgradeKeeper.SetUpgradeHandler(uint64(version.ProtocolVersionV1), func(ctx sdk.Context) {
+		ammswap.UpgradeParams(ctx, p.swapKeeper)
+	})
 	p.debugKeeper = debug.NewDebugKeeper(p.cdc, p.keys[debug.StoreKey], p.orderKeeper, p.stakingKeeper, auth.FeeCollectorName, p.Stop)
 }
 
@@ -363,7 +384,7 @@ func (p *ProtocolV0) setManager() {
 		genaccounts.NewAppModule(p.accountKeeper),
 		genutil.NewAppModule(p.accountKeeper, p.stakingKeeper, p.parent.DeliverTx),
 		auth.NewAppModule(p.accountKeeper),
-		bank.NewAppModule(p.bankKeeper, p.accountKeeper),
+		bank.NewAppModule(token.NewTransferCheckedBankKeeper(p.bankKeeper, p.tokenKeeper), p.accountKeeper),
 		crisis.NewAppModule(&p.crisisKeeper),
 		supply.NewAppModule(p.supplyKeeper, p.accountKeeper),
 		params.NewAppModule(p.paramsKeeper),
@@ -375,6 +396,7 @@ func (p *ProtocolV0) setManager() {
 		order.NewAppModule(version.ProtocolVersionV0, p.orderKeeper, p.supplyKeeper),
 		token.NewAppModule(version.ProtocolVersionV0, p.tokenKeeper, p.supplyKeeper),
 		ammswap.NewAppModule(p.swapKeeper),
+		farm.NewAppModule(p.farmKeeper),
 
 		// TODO
 		dex.NewAppModule(version.ProtocolVersionV0, p.dexKeeper, p.supplyKeeper),
@@ -395,6 +417,7 @@ func (p *ProtocolV0) setManager() {
 		distr.ModuleName,
 		slashing.ModuleName,
 		staking.ModuleName,
+		ammswap.ModuleName,
 	)
 
 	p.mm.SetOrderEndBlockers(
@@ -403,6 +426,7 @@ func (p *ProtocolV0) setManager() {
 		dex.ModuleName,
 		order.ModuleName,
 		staking.ModuleName,
+		token.ModuleName,
 		backend.ModuleName,
 		stream.ModuleName,
 		upgrade.ModuleName,
@@ -422,6 +446,7 @@ func (p *ProtocolV0) setManager() {
 		dex.ModuleName,
 		order.ModuleName,
 		ammswap.ModuleName,
+		farm.ModuleName,
 		upgrade.ModuleName,
 		crisis.ModuleName,
 		genutil.ModuleName,
@@ -504,6 +529,18 @@ func validateMsgHook(orderKeeper order.Keeper) auth.ValidateMsgHandler {
 					break
 				}
 				res = order.ValidateMsgCancelOrders(newCtx, orderKeeper, assertedMsg)
+			case order.MsgAmendOrders:
+				if len(msgs) > 1 {
+					res = wrongMsgRes
+					break
+				}
+				res = order.ValidateMsgAmendOrders(newCtx, orderKeeper, assertedMsg)
+			case order.MsgRouteOrder:
+				if len(msgs) > 1 {
+					res = wrongMsgRes
+					break
+				}
+				res = order.ValidateMsgRouteOrder(newCtx, orderKeeper, assertedMsg)
 			}
 
 			if !res.IsOK() {
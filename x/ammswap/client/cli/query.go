@@ -32,6 +32,10 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			GetCmdAllSwapTokenPairs(queryRoute, cdc),
 			GetCmdRedeemableAssets(queryRoute, cdc),
 			GetCmdQueryBuyAmount(queryRoute, cdc),
+			GetCmdQuerySwapRoute(queryRoute, cdc),
+			GetCmdQuerySellAmount(queryRoute, cdc),
+			GetCmdQueryPoolFees(queryRoute, cdc),
+			GetCmdQueryTWAP(queryRoute, cdc),
 		)...,
 	)
 
@@ -41,13 +45,15 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 //GetCmdSwapTokenPair query exchange with token name
 func GetCmdSwapTokenPair(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "pool [token]",
-		Short: "Query pool info by token name",
+		Use:   "pool [token|token-pair]",
+		Short: "Query pool info by token name or token pair name",
 		Long: strings.TrimSpace(
-			fmt.Sprintf(`Query pool info by token name.
+			fmt.Sprintf(`Query pool info by token name or token pair name.
+The pool of a single token name is quoted in okt.
 
 Example:
 $ okexchaincli query swap pool eth-355
+$ okexchaincli query swap pool btc-a69_eth-355
 
 `),
 		),
@@ -67,6 +73,81 @@ $ okexchaincli query swap pool eth-355
 	}
 }
 
+// GetCmdQueryPoolFees queries the cumulative swap fees earned by the pool
+func GetCmdQueryPoolFees(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pool-fees [token|token-pair]",
+		Short: "Query the cumulative swap fees earned by the pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the cumulative swap fees left to the liquidity providers and sent to the community pool
+by the pool of the token name or token pair name.
+
+Example:
+$ okexchaincli query swap pool-fees eth-355
+$ okexchaincli query swap pool-fees btc-a69_eth-355
+
+`),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryPoolFees, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryTWAP queries the time-weighted average prices of the pool
+func GetCmdQueryTWAP(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	var startHeight, endHeight, window int64
+	cmd := &cobra.Command{
+		Use:   "twap [token|token-pair]",
+		Short: "Query the time-weighted average prices of the pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the time-weighted average prices of the pool between two heights,
+or over a window of blocks before the end height. The end height is the latest height by default.
+
+Example:
+$ %s query swap twap eth-355 --start-height 100 --end-height 200
+$ %s query swap twap btc-a69_eth-355 --window 100
+
+`, version.ClientName, version.ClientName),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			params := types.QueryTWAPParams{
+				TokenPairName: args[0],
+				StartHeight:   startHeight,
+				EndHeight:     endHeight,
+				Window:        window,
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryTWAP), bz)
+			if err != nil {
+				return err
+			}
+
+			var twap types.TWAP
+			cdc.MustUnmarshalJSON(res, &twap)
+			return cliCtx.PrintOutput(twap)
+		},
+	}
+	cmd.Flags().Int64Var(&startHeight, flagStartHeight, 0, "The start height of the TWAP")
+	cmd.Flags().Int64Var(&endHeight, flagEndHeight, 0, "The end height of the TWAP, the latest height by default")
+	cmd.Flags().Int64Var(&window, flagWindow, 0, "The number of blocks before the end height to average over")
+	return cmd
+}
+
 // GetCmdQueryBuyAmount queries amount of base/quote token by the given amount of quote/base token
 func GetCmdQueryBuyAmount(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -108,6 +189,86 @@ $ %s query swap amount 100eth-245 xxb`, version.ClientName,
 	}
 }
 
+// GetCmdQuerySellAmount queries amount of token to sell for buying the given amount of token
+func GetCmdQuerySellAmount(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sell-amount [token-to-buy] [token-name-to-sell]",
+		Short: "Query how many token to sell for buying the given amount of token",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query how many token to sell for buying the given amount of token.
+
+Example:
+$ %s query swap sell-amount 100xxb eth-245`, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			buyToken, err := sdk.ParseDecCoin(args[0])
+			if err != nil {
+				return err
+			}
+			params := types.QuerySellAmountParams{
+				BoughtToken: buyToken,
+				TokenToSell: args[1],
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QuerySellAmount), bz)
+			if err != nil {
+				return err
+			}
+
+			var sellAmt sdk.Dec
+			cdc.MustUnmarshalJSON(res, &sellAmt)
+			return cliCtx.PrintOutput(sellAmt)
+		},
+	}
+}
+
+// GetCmdQuerySwapRoute queries the best route to swap the given amount of token to sell
+func GetCmdQuerySwapRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "route [token-to-sell] [token-name-to-buy]",
+		Short: "Query the best route to swap the given amount of token to sell",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the best route of token pairs to swap the given amount of token to sell, and the amount bought by every hop.
+
+Example:
+$ %s query swap route 100eth-245 xxb`, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sellToken, err := sdk.ParseDecCoin(args[0])
+			if err != nil {
+				return err
+			}
+			params := types.QueryBuyAmountParams{
+				SoldToken:  sellToken,
+				TokenToBuy: args[1],
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QuerySwapRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var swapRoute types.SwapRoute
+			cdc.MustUnmarshalJSON(res, &swapRoute)
+			return cliCtx.PrintOutput(swapRoute)
+		},
+	}
+}
+
 // GetCmdQueryParams queries the parameters of the AMM swap system
 func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -169,12 +330,13 @@ $ okexchaincli query swap pools
 //GetCmdRedeemableAssets query redeemable assets by specifying the number of lpt
 func GetCmdRedeemableAssets(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "redeemable-assets [base-token] [pool-token-amount]",
+		Use:   "redeemable-assets [base-token|token-pair] [pool-token-amount]",
 		Short: "Query redeemable assets by specifying pool token amount",
 		Long: 	strings.TrimSpace(
 			fmt.Sprintf(`Query redeemable assets by specifying pool token amount.
 Example:
 $ okexchaincli query swap redeemable-assets eth-355 1
+$ okexchaincli query swap redeemable-assets btc-a69_eth-355 1
 `),
 		),
 		Args:  cobra.ExactArgs(2),
@@ -26,9 +26,20 @@ const (
 	flagMinBaseAmount    = "min-base-amount"
 	flagMinQuoteAmount   = "min-quote-amount"
 	flagToken            = "token"
+	flagQuoteToken       = "quote-token"
 	flagSellAmount       = "sell-amount"
 	flagMinBuyAmount     = "min-buy-amount"
 	flagRecipient        = "recipient"
+	flagRoute            = "route"
+	flagMaxSellAmount    = "max-sell-amount"
+	flagBuyAmount        = "buy-amount"
+	flagStartHeight      = "start-height"
+	flagEndHeight        = "end-height"
+	flagWindow           = "window"
+	flagCurve            = "curve"
+	flagTokenWeight      = "token-weight"
+	flagAmplification    = "amplification"
+	flagPairedToken      = "paired-token"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -43,6 +54,10 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		getCmdRemoveLiquidity(cdc),
 		getCmdCreateExchange(cdc),
 		getCmdTokenSwap(cdc),
+		getCmdMultiHopSwap(cdc),
+		getCmdTokenSwapExactOutput(cdc),
+		getCmdZapIn(cdc),
+		getCmdZapOut(cdc),
 	)...)
 
 	return txCmd
@@ -159,6 +174,10 @@ $ okexchaincli tx swap remove-liquidity --liquidity 1 --min-base-amount 10eth-35
 func getCmdCreateExchange(cdc *codec.Codec) *cobra.Command {
 	// flags
 	var token string
+	var quoteToken string
+	var curveType string
+	var tokenWeight string
+	var amplification int64
 	cmd := &cobra.Command{
 		Use:   "create-pair",
 		Short: "create token pair",
@@ -167,6 +186,9 @@ func getCmdCreateExchange(cdc *codec.Codec) *cobra.Command {
 
 Example:
 $ okexchaincli tx swap create-pair --token eth-355 --fees 0.01okt 
+$ okexchaincli tx swap create-pair --token eth-355 --quote-token btc-a69 --fees 0.01okt
+$ okexchaincli tx swap create-pair --token eth-355 --curve weighted --token-weight 0.8 --fees 0.01okt
+$ okexchaincli tx swap create-pair --token usdk-017 --quote-token usdt-a2b --curve stable_swap --amplification 100 --fees 0.01okt
 
 `),
 		),
@@ -174,13 +196,30 @@ $ okexchaincli tx swap create-pair --token eth-355 --fees 0.01okt
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
-			msg := types.NewMsgCreateExchange(token, cliCtx.FromAddress)
+			tokenWeightDec := sdk.ZeroDec()
+			if curveType == types.CurveWeighted {
+				var err error
+				if tokenWeightDec, err = sdk.NewDecFromStr(tokenWeight); err != nil {
+					return err
+				}
+			}
+			if curveType != types.CurveStableSwap {
+				amplification = 0
+			}
+			msg := types.NewMsgCreateExchangeWithCurve(token, quoteToken, curveType, tokenWeightDec, amplification,
+				cliCtx.FromAddress)
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().StringVarP(&token, flagToken, "t", "", "Create an AMM swap pair by token name")
+	cmd.Flags().StringVarP(&quoteToken, flagQuoteToken, "", "", "The quote token of the AMM swap pair, okt by default")
+	cmd.Flags().StringVarP(&curveType, flagCurve, "", types.CurveConstantProduct,
+		"The curve of the pool: constant_product, weighted or stable_swap")
+	cmd.Flags().StringVarP(&tokenWeight, flagTokenWeight, "", "0.5", "The weight of the token in the weighted pool")
+	cmd.Flags().Int64VarP(&amplification, flagAmplification, "", 100,
+		"The amplification coefficient of the stable swap pool")
 	cmd.MarkFlagRequired(flagToken)
 	return cmd
 }
@@ -249,3 +288,241 @@ $ okexchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60okt
 
 	return cmd
 }
+
+func getCmdMultiHopSwap(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var soldTokenAmount string
+	var minBoughtTokenAmount string
+	var route string
+	var deadline string
+	var recipient string
+	cmd := &cobra.Command{
+		Use:   "multi-hop",
+		Short: "swap token through a route of token pairs",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`swap token through a route of token pairs, the best route is used if no route is given.
+
+Example:
+$ okexchaincli tx swap multi-hop --sell-amount 1eth-355 --min-buy-amount 60btc-a69 --route eth-355_okt,btc-a69_okt
+$ okexchaincli tx swap multi-hop --sell-amount 1eth-355 --min-buy-amount 60btc-a69
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			soldTokenAmount, err := sdk.ParseDecCoin(soldTokenAmount)
+			if err != nil {
+				return err
+			}
+			minBoughtTokenAmount, err := sdk.ParseDecCoin(minBoughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			var swapRoute []string
+			if route != "" {
+				swapRoute = strings.Split(route, ",")
+			}
+			dur, err := time.ParseDuration(deadline)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(dur).Unix()
+			var recip sdk.AccAddress
+			if recipient == "" {
+				recip = cliCtx.FromAddress
+			} else {
+				recip, err = sdk.AccAddressFromBech32(recipient)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgMultiHopSwap(soldTokenAmount, minBoughtTokenAmount, swapRoute,
+				deadline, recip, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&soldTokenAmount, flagSellAmount, "", "",
+		"Amount expected to sell")
+	cmd.Flags().StringVarP(&minBoughtTokenAmount, flagMinBuyAmount, "", "",
+		"Minimum amount expected to buy")
+	cmd.Flags().StringVarP(&route, flagRoute, "", "",
+		"Token pairs to swap through in order, separated by comma")
+	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
+		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
+		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	cmd.MarkFlagRequired(flagSellAmount)
+	cmd.MarkFlagRequired(flagMinBuyAmount)
+
+	return cmd
+}
+
+func getCmdTokenSwapExactOutput(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var maxSoldTokenAmount string
+	var boughtTokenAmount string
+	var deadline string
+	var recipient string
+	cmd := &cobra.Command{
+		Use:   "token-exact-output",
+		Short: "swap token to buy the exact amount",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`swap token to buy the exact amount.
+
+Example:
+$ okexchaincli tx swap token-exact-output --max-sell-amount 2eth-355 --buy-amount 60okt
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			maxSoldTokenAmount, err := sdk.ParseDecCoin(maxSoldTokenAmount)
+			if err != nil {
+				return err
+			}
+			boughtTokenAmount, err := sdk.ParseDecCoin(boughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			dur, err := time.ParseDuration(deadline)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(dur).Unix()
+			var recip sdk.AccAddress
+			if recipient == "" {
+				recip = cliCtx.FromAddress
+			} else {
+				recip, err = sdk.AccAddressFromBech32(recipient)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgTokenToTokenExactOutput(maxSoldTokenAmount, boughtTokenAmount,
+				deadline, recip, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&maxSoldTokenAmount, flagMaxSellAmount, "", "",
+		"Maximum amount expected to sell")
+	cmd.Flags().StringVarP(&boughtTokenAmount, flagBuyAmount, "", "",
+		"Amount expected to buy")
+	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
+		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
+		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	cmd.MarkFlagRequired(flagMaxSellAmount)
+	cmd.MarkFlagRequired(flagBuyAmount)
+
+	return cmd
+}
+
+func getCmdZapIn(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var soldTokenAmount string
+	var pairedToken string
+	var minLiquidity string
+	var deadlineDuration string
+	cmd := &cobra.Command{
+		Use:   "zap-in",
+		Short: "add liquidity with a single token",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`add liquidity with a single token, part of which is swapped for the paired token.
+
+Example:
+$ okexchaincli tx swap zap-in --sell-amount 100okt --paired-token eth-355 --min-liquidity 0.001
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			soldTokenAmountDecCoin, err := sdk.ParseDecCoin(soldTokenAmount)
+			if err != nil {
+				return err
+			}
+			minLiquidityDec, sdkErr := sdk.NewDecFromStr(minLiquidity)
+			if sdkErr != nil {
+				return sdkErr
+			}
+			duration, err := time.ParseDuration(deadlineDuration)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(duration).Unix()
+			msg := types.NewMsgZapIn(soldTokenAmountDecCoin, pairedToken, minLiquidityDec, deadline, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&soldTokenAmount, flagSellAmount, "", "", "Amount of the single token deposited. For example \"100okt\"")
+	cmd.Flags().StringVarP(&pairedToken, flagPairedToken, "", "", "The other token of the token pair")
+	cmd.Flags().StringVarP(&minLiquidity, flagMinLiquidity, "l", "", "Minimum number of pool tokens minted")
+	cmd.Flags().StringVarP(&deadlineDuration, flagDeadlineDuration, "d", "30s", "Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	cmd.MarkFlagRequired(flagSellAmount)
+	cmd.MarkFlagRequired(flagPairedToken)
+	cmd.MarkFlagRequired(flagMinLiquidity)
+	return cmd
+}
+
+func getCmdZapOut(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var liquidity string
+	var minBoughtTokenAmount string
+	var pairedToken string
+	var deadlineDuration string
+	cmd := &cobra.Command{
+		Use:   "zap-out",
+		Short: "remove liquidity for a single token",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`remove liquidity for a single token, the paired token withdrawn is swapped for it.
+
+Example:
+$ okexchaincli tx swap zap-out --liquidity 1 --min-buy-amount 90okt --paired-token eth-355
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			liquidityDec, sdkErr := sdk.NewDecFromStr(liquidity)
+			if sdkErr != nil {
+				return sdkErr
+			}
+			minBoughtTokenAmountDecCoin, err := sdk.ParseDecCoin(minBoughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			duration, err := time.ParseDuration(deadlineDuration)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(duration).Unix()
+			msg := types.NewMsgZapOut(liquidityDec, minBoughtTokenAmountDecCoin, pairedToken, deadline, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&liquidity, flagLiquidity, "l", "", "Liquidity amount of sender will burn")
+	cmd.Flags().StringVarP(&minBoughtTokenAmount, flagMinBuyAmount, "", "", "Minimum amount of the single token returned. For example \"90okt\"")
+	cmd.Flags().StringVarP(&pairedToken, flagPairedToken, "", "", "The other token of the token pair")
+	cmd.Flags().StringVarP(&deadlineDuration, flagDeadlineDuration, "d", "30s", "Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	cmd.MarkFlagRequired(flagLiquidity)
+	cmd.MarkFlagRequired(flagMinBuyAmount)
+	cmd.MarkFlagRequired(flagPairedToken)
+	return cmd
+}
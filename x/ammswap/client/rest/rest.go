@@ -9,4 +9,5 @@ import (
 // RegisterRoutes registers ammswap-related REST handlers to a router
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	registerTxRoutes(cliCtx, r)
+	registerQueryRoutes(cliCtx, r)
 }
@@ -7,7 +7,9 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/gorilla/mux"
 	"github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/common"
@@ -15,6 +17,125 @@ import (
 
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/ammswap/exchange", swapExchangeHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/ammswap/swap_exact_output", postSwapExactOutputHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/ammswap/zap_in", postZapInHandler(cliCtx)).Methods("POST")
+	r.HandleFunc("/ammswap/zap_out", postZapOutHandler(cliCtx)).Methods("POST")
+}
+
+// SwapExactOutputRequest defines the properties of a swap request to buy the exact amount of token
+type SwapExactOutputRequest struct {
+	BaseReq            rest.BaseReq   `json:"base_req" yaml:"base_req"`
+	MaxSoldTokenAmount sdk.DecCoin    `json:"max_sold_token_amount" yaml:"max_sold_token_amount"`
+	BoughtTokenAmount  sdk.DecCoin    `json:"bought_token_amount" yaml:"bought_token_amount"`
+	Deadline           int64          `json:"deadline" yaml:"deadline"`
+	Recipient          sdk.AccAddress `json:"recipient" yaml:"recipient"` // in bech32, the sender by default
+}
+
+func postSwapExactOutputHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SwapExactOutputRequest
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid address：%s", req.BaseReq.From))
+			return
+		}
+		recipient := req.Recipient
+		if recipient.Empty() {
+			recipient = fromAddr
+		}
+
+		msg := types.NewMsgTokenToTokenExactOutput(req.MaxSoldTokenAmount, req.BoughtTokenAmount, req.Deadline,
+			recipient, fromAddr)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// ZapInRequest defines the properties of a request to add liquidity with a single token
+type ZapInRequest struct {
+	BaseReq         rest.BaseReq `json:"base_req" yaml:"base_req"`
+	SoldTokenAmount sdk.DecCoin  `json:"sold_token_amount" yaml:"sold_token_amount"`
+	PairedToken     string       `json:"paired_token" yaml:"paired_token"`
+	MinLiquidity    sdk.Dec      `json:"min_liquidity" yaml:"min_liquidity"`
+	Deadline        int64        `json:"deadline" yaml:"deadline"`
+}
+
+func postZapInHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ZapInRequest
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid address：%s", req.BaseReq.From))
+			return
+		}
+
+		msg := types.NewMsgZapIn(req.SoldTokenAmount, req.PairedToken, req.MinLiquidity, req.Deadline, fromAddr)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// ZapOutRequest defines the properties of a request to remove liquidity for a single token
+type ZapOutRequest struct {
+	BaseReq              rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Liquidity            sdk.Dec      `json:"liquidity" yaml:"liquidity"`
+	MinBoughtTokenAmount sdk.DecCoin  `json:"min_bought_token_amount" yaml:"min_bought_token_amount"`
+	PairedToken          string       `json:"paired_token" yaml:"paired_token"`
+	Deadline             int64        `json:"deadline" yaml:"deadline"`
+}
+
+func postZapOutHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ZapOutRequest
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid address：%s", req.BaseReq.From))
+			return
+		}
+
+		msg := types.NewMsgZapOut(req.Liquidity, req.MinBoughtTokenAmount, req.PairedToken, req.Deadline, fromAddr)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
 }
 
 func swapExchangeHandler(cliCtx context.CLIContext) func(http.ResponseWriter, *http.Request) {
@@ -11,6 +11,9 @@ import (
 type GenesisState struct {
 	Params               Params          `json:"params"`
 	SwapTokenPairRecords []SwapTokenPair `json:"swap_token_pair_records"`
+	PoolFeesRecords      []PoolFees      `json:"pool_fees_records"`
+	// the latest cumulative prices of the pools, the earlier price observations are not exported
+	PriceAccumulatorRecords []types.PriceObservation `json:"price_accumulator_records"`
 }
 
 // nolint
@@ -20,6 +23,9 @@ func NewGenesisState(swapTokenPairRecords []SwapTokenPair) GenesisState {
 
 // ValidateGenesis validates the format of the specified genesisState
 func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
 	for _, record := range data.SwapTokenPairRecords {
 		if !record.QuotePooledCoin.IsValid() {
 			return fmt.Errorf("invalid SwapTokenPairRecord: QuotePooledCoin: %s", record.QuotePooledCoin.String())
@@ -30,6 +36,14 @@ func ValidateGenesis(data GenesisState) error {
 		if !types.ValidatePoolTokenName(record.PoolTokenName) {
 			return fmt.Errorf("invalid SwapTokenPairRecord: PoolToken: %s. Error: invalid PoolToken", record.PoolTokenName)
 		}
+		if err := record.Curve.Validate(); err != nil {
+			return fmt.Errorf("invalid SwapTokenPairRecord: Curve: %s. Error: %s", record.Curve, err)
+		}
+	}
+	for _, record := range data.PoolFeesRecords {
+		if !record.LPFees.IsValid() || !record.ProtocolFees.IsValid() {
+			return fmt.Errorf("invalid PoolFeesRecord: %s", record.TokenPairName)
+		}
 	}
 	return nil
 }
@@ -45,8 +59,21 @@ func DefaultGenesisState() GenesisState {
 // InitGenesis init genesis data to keeper
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	keeper.SetParams(ctx, data.Params)
+	var poolTokenSeq uint64
 	for _, record := range data.SwapTokenPairRecords {
 		keeper.SetSwapTokenPair(ctx, record.TokenPairName(), record)
+		if seq, ok := types.GetPoolTokenSeq(record.PoolTokenName); ok && seq > poolTokenSeq {
+			poolTokenSeq = seq
+		}
+	}
+	keeper.SetPoolTokenSeq(ctx, poolTokenSeq)
+	for _, record := range data.PoolFeesRecords {
+		keeper.SetPoolFees(ctx, record)
+	}
+	// the latest observations are kept as the start of the TWAP until the pools change
+	for _, record := range data.PriceAccumulatorRecords {
+		keeper.SetPriceAccumulator(ctx, record)
+		keeper.SetPriceObservation(ctx, record)
 	}
 }
 
@@ -61,5 +88,10 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 
 	}
 	params := k.GetParams(ctx)
-	return GenesisState{SwapTokenPairRecords: records, Params: params}
+	return GenesisState{
+		SwapTokenPairRecords:    records,
+		PoolFeesRecords:         k.GetAllPoolFees(ctx),
+		PriceAccumulatorRecords: k.GetAllPriceAccumulators(ctx),
+		Params:                  params,
+	}
 }
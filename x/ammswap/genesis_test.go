@@ -53,11 +53,20 @@ func TestInitAndExportGenesis(t *testing.T) {
 
 	defaultGenesisState := DefaultGenesisState()
 	testSwapTokenPair := types.GetTestSwapTokenPair()
+	sequencedSwapTokenPair := SwapTokenPair{
+		QuotePooledCoin: sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(0)),
+		BasePooledCoin:  sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(0)),
+		PoolTokenName:   types.GetPoolTokenName(types.TestBasePooledToken, types.TestBasePooledToken2, 5),
+		Curve:           types.DefaultPoolCurve(),
+	}
 	defaultGenesisState.SwapTokenPairRecords = []SwapTokenPair{
 		testSwapTokenPair,
+		sequencedSwapTokenPair,
 	}
 	InitGenesis(ctx, keeper, defaultGenesisState)
 	exportedGenesis := ExportGenesis(ctx, keeper)
 	require.Equal(t, defaultGenesisState, exportedGenesis)
+	// the sequence of the pool token names is restored from the records
+	require.Equal(t, uint64(5), keeper.GetPoolTokenSeq(ctx))
 
 }
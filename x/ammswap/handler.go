@@ -2,6 +2,7 @@ package ammswap
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/okex/okexchain/x/ammswap/keeper"
 	"github.com/okex/okexchain/x/ammswap/types"
@@ -38,6 +39,26 @@ func NewHandler(k Keeper) sdk.Handler {
 			handlerFun = func() sdk.Result {
 				return handleMsgTokenToTokenExchange(ctx, k, msg)
 			}
+		case types.MsgMultiHopSwap:
+			name = "handleMsgMultiHopSwap"
+			handlerFun = func() sdk.Result {
+				return handleMsgMultiHopSwap(ctx, k, msg)
+			}
+		case types.MsgTokenToTokenExactOutput:
+			name = "handleMsgTokenToTokenExactOutput"
+			handlerFun = func() sdk.Result {
+				return handleMsgTokenToTokenExactOutput(ctx, k, msg)
+			}
+		case types.MsgZapIn:
+			name = "handleMsgZapIn"
+			handlerFun = func() sdk.Result {
+				return handleMsgZapIn(ctx, k, msg)
+			}
+		case types.MsgZapOut:
+			name = "handleMsgZapOut"
+			handlerFun = func() sdk.Result {
+				return handleMsgZapOut(ctx, k, msg)
+			}
 		default:
 			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -49,7 +70,9 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgTokenToTokenExchange(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) sdk.Result {
-	if msg.SoldTokenAmount.Denom != sdk.DefaultBondDenom && msg.MinBoughtTokenAmount.Denom != sdk.DefaultBondDenom {
+	// swap in the pool of the two tokens if it exists, otherwise through the pools quoted in the native token
+	if _, err := k.GetSwapTokenPair(ctx, msg.GetSwapTokenPair()); err != nil &&
+		msg.SoldTokenAmount.Denom != sdk.DefaultBondDenom && msg.MinBoughtTokenAmount.Denom != sdk.DefaultBondDenom {
 		return handleMsgTokenToToken(ctx, k, msg)
 	}
 	return handleMsgTokenToNativeToken(ctx, k, msg)
@@ -57,15 +80,20 @@ func handleMsgTokenToTokenExchange(ctx sdk.Context, k Keeper, msg types.MsgToken
 
 func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExchange) sdk.Result {
 	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
-	err := k.IsTokenExist(ctx, msg.Token)
-	if err != nil {
-		return sdk.Result{
-			Code: sdk.CodeInternal,
-			Log:  err.Error(),
+	baseTokenName, quoteTokenName := types.GetBaseQuoteTokens(msg.Token, msg.GetQuoteToken())
+	for _, tokenName := range []string{baseTokenName, quoteTokenName} {
+		if tokenName == common.NativeToken {
+			continue
+		}
+		if err := k.IsTokenExist(ctx, tokenName); err != nil {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  err.Error(),
+			}
 		}
 	}
 
-	tokenPair := msg.Token + "_" + common.NativeToken
+	tokenPair := msg.GetSwapTokenPair()
 
 	swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPair)
 	if err == nil {
@@ -75,9 +103,9 @@ func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExcha
 		}
 	}
 
-	poolName := types.PoolTokenPrefix + msg.Token
-	baseToken := sdk.NewDecCoinFromDec(msg.Token, sdk.ZeroDec())
-	quoteToken := sdk.NewDecCoinFromDec(common.NativeToken, sdk.ZeroDec())
+	poolName := k.NextPoolTokenName(ctx, baseTokenName, quoteTokenName)
+	baseToken := sdk.NewDecCoinFromDec(baseTokenName, sdk.ZeroDec())
+	quoteToken := sdk.NewDecCoinFromDec(quoteTokenName, sdk.ZeroDec())
 	poolToken, err := k.GetPoolTokenInfo(ctx, poolName)
 	if err == nil {
 		return sdk.Result{
@@ -90,10 +118,13 @@ func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExcha
 	swapTokenPair.BasePooledCoin = baseToken
 	swapTokenPair.QuotePooledCoin = quoteToken
 	swapTokenPair.PoolTokenName = poolName
+	swapTokenPair.Curve = msg.GetPoolCurve()
 
 	k.SetSwapTokenPair(ctx, tokenPair, swapTokenPair)
+	k.AddSwapTokenPairInfo(ctx, msg.Sender, swapTokenPair)
 
 	event = event.AppendAttributes(sdk.NewAttribute("token-pair", tokenPair))
+	event = event.AppendAttributes(sdk.NewAttribute("curve", swapTokenPair.Curve.String()))
 	ctx.EventManager().EmitEvent(event)
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
@@ -177,6 +208,7 @@ func handleMsgAddLiquidity(ctx sdk.Context, k Keeper, msg types.MsgAddLiquidity)
 		}
 	}
 	// update swapTokenPair
+	k.UpdatePriceAccumulator(ctx, msg.GetSwapTokenPair())
 	swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.QuoteAmount)
 	swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(baseTokens)
 	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPair(), swapTokenPair)
@@ -190,6 +222,8 @@ func handleMsgAddLiquidity(ctx sdk.Context, k Keeper, msg types.MsgAddLiquidity)
 			Log:  "failed to mint pool token",
 		}
 	}
+	k.AddSwapLiquidityInfo(ctx, msg.Sender, msg.GetSwapTokenPair(), types.LiquidityTypeAdd, baseTokens,
+		msg.QuoteAmount, liquidity)
 
 	event.AppendAttributes(sdk.NewAttribute("liquidity", liquidity.String()))
 	event.AppendAttributes(sdk.NewAttribute("baseAmount", baseTokens.String()))
@@ -256,6 +290,7 @@ func handleMsgRemoveLiquidity(ctx sdk.Context, k Keeper, msg types.MsgRemoveLiqu
 		}
 	}
 	// update swapTokenPair
+	k.UpdatePriceAccumulator(ctx, msg.GetSwapTokenPair())
 	swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(quoteAmount)
 	swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(baseAmount)
 	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPair(), swapTokenPair)
@@ -269,6 +304,8 @@ func handleMsgRemoveLiquidity(ctx sdk.Context, k Keeper, msg types.MsgRemoveLiqu
 			Log:  "failed to burn pool token",
 		}
 	}
+	k.AddSwapLiquidityInfo(ctx, msg.Sender, msg.GetSwapTokenPair(), types.LiquidityTypeRemove, baseAmount,
+		quoteAmount, liquidity)
 
 	event.AppendAttributes(sdk.NewAttribute("quoteAmount", quoteAmount.String()))
 	event.AppendAttributes(sdk.NewAttribute("baseAmount", baseAmount.String()))
@@ -276,6 +313,7 @@ func handleMsgRemoveLiquidity(ctx sdk.Context, k Keeper, msg types.MsgRemoveLiqu
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
+// handleMsgTokenToNativeToken swaps the tokens in the pool of them
 func handleMsgTokenToNativeToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) sdk.Result {
 	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
 
@@ -300,7 +338,13 @@ func handleMsgTokenToNativeToken(ctx sdk.Context, k Keeper, msg types.MsgTokenTo
 		}
 	}
 	params := k.GetParams(ctx)
-	tokenBuy := keeper.CalculateTokenToBuy(swapTokenPair, msg.SoldTokenAmount, msg.MinBoughtTokenAmount.Denom, params)
+	tokenBuy, err := keeper.CalculateTokenToBuy(swapTokenPair, msg.SoldTokenAmount, msg.MinBoughtTokenAmount.Denom, params)
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
+	}
 	if tokenBuy.IsZero() {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
@@ -324,6 +368,7 @@ func handleMsgTokenToNativeToken(ctx sdk.Context, k Keeper, msg types.MsgTokenTo
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
+// handleMsgTokenToToken swaps the tokens through the pools of them quoted in the native token
 func handleMsgTokenToToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) sdk.Result {
 	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
 
@@ -361,7 +406,13 @@ func handleMsgTokenToToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken)
 	params := k.GetParams(ctx)
 	msgOne := msg
 	msgOne.MinBoughtTokenAmount = nativeAmount
-	tokenNative := keeper.CalculateTokenToBuy(swapTokenPairOne, msgOne.SoldTokenAmount, msgOne.MinBoughtTokenAmount.Denom, params)
+	tokenNative, err := keeper.CalculateTokenToBuy(swapTokenPairOne, msgOne.SoldTokenAmount, msgOne.MinBoughtTokenAmount.Denom, params)
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
+	}
 	if tokenNative.IsZero() {
 		return sdk.Result{
 			Code: sdk.CodeInternal,
@@ -370,7 +421,13 @@ func handleMsgTokenToToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken)
 	}
 	msgTwo := msg
 	msgTwo.SoldTokenAmount = tokenNative
-	tokenBuy := keeper.CalculateTokenToBuy(swapTokenPairTwo, msgTwo.SoldTokenAmount, msgTwo.MinBoughtTokenAmount.Denom, params)
+	tokenBuy, err := keeper.CalculateTokenToBuy(swapTokenPairTwo, msgTwo.SoldTokenAmount, msgTwo.MinBoughtTokenAmount.Denom, params)
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
+	}
 	// sanity check. user may set MinBoughtTokenAmount to zero on front end.
 	// if set zero,this will not return err
 	if tokenBuy.IsZero() {
@@ -423,17 +480,109 @@ func swapTokenNativeToken(
 	}
 
 	// update swapTokenPair
-	if msg.SoldTokenAmount.Denom == sdk.DefaultBondDenom {
-		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(msg.SoldTokenAmount)
-		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(tokenBuy)
-	} else {
-		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(tokenBuy)
-		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(msg.SoldTokenAmount)
+	if err := k.SwapPooledCoins(ctx, swapTokenPair, msg.SoldTokenAmount, tokenBuy, msg.Sender); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
 	}
-	k.SetSwapTokenPair(ctx, msg.GetSwapTokenPair(), swapTokenPair)
 	return sdk.Result{}
 }
 
+// handleMsgMultiHopSwap swaps the tokens through every token pair of the route in order, or of the best route
+// if no route is given
+func handleMsgMultiHopSwap(ctx sdk.Context, k Keeper, msg types.MsgMultiHopSwap) sdk.Result {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  "Failed: block time exceeded deadline",
+		}
+	}
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.DecCoins{msg.SoldTokenAmount}); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  err.Error(),
+		}
+	}
+
+	route := msg.SwapRoute
+	var amounts []sdk.DecCoin
+	var err error
+	if len(route) == 0 {
+		route, amounts, err = k.GetBestSwapRoute(ctx, msg.SoldTokenAmount, msg.MinBoughtTokenAmount.Denom)
+	} else {
+		amounts, err = k.GetSwapRouteAmounts(ctx, route, msg.SoldTokenAmount)
+	}
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
+	}
+	tokenBuy := amounts[len(amounts)-1]
+	if tokenBuy.IsZero() {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("Failed: selled token amount is too little to buy any token"),
+		}
+	}
+	if tokenBuy.Amount.LT(msg.MinBoughtTokenAmount.Amount) {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("Failed: expected minimum token to buy is %s but got %s", msg.MinBoughtTokenAmount, tokenBuy),
+		}
+	}
+
+	// transfer coins, the tokens between the hops stay in the pools
+	if err := k.SendCoinsToPool(ctx, sdk.DecCoins{msg.SoldTokenAmount}, msg.Sender); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  "insufficient Coins",
+		}
+	}
+	if err := k.SendCoinsFromPoolToAccount(ctx, sdk.DecCoins{tokenBuy}, msg.Recipient); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  "insufficient Coins",
+		}
+	}
+
+	// update swapTokenPairs
+	soldToken := msg.SoldTokenAmount
+	for i, tokenPairName := range route {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  err.Error(),
+			}
+		}
+		if err := k.SwapPooledCoins(ctx, swapTokenPair, soldToken, amounts[i], msg.Sender); err != nil {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  err.Error(),
+			}
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeSwapHop,
+			sdk.NewAttribute(types.AttributeKeyTokenPair, tokenPairName),
+			sdk.NewAttribute(types.AttributeKeySoldTokenAmount, soldToken.String()),
+			sdk.NewAttribute(types.AttributeKeyBoughtAmount, amounts[i].String()),
+		))
+		soldToken = amounts[i]
+	}
+
+	event = event.AppendAttributes(
+		sdk.NewAttribute("route", strings.Join(route, ",")),
+		sdk.NewAttribute("bought_token_amount", tokenBuy.String()),
+		sdk.NewAttribute("recipient", msg.Recipient.String()),
+	)
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
 func coinSort(coins sdk.DecCoins) sdk.DecCoins {
 	var newCoins sdk.DecCoins
 	for _, coin := range coins {
@@ -445,3 +594,220 @@ func coinSort(coins sdk.DecCoins) sdk.DecCoins {
 	return newCoins
 }
 
+// handleMsgTokenToTokenExactOutput swaps the tokens to buy exactly the amount of token, in the pool of them or
+// through the pools of them quoted in the native token
+func handleMsgTokenToTokenExactOutput(ctx sdk.Context, k Keeper, msg types.MsgTokenToTokenExactOutput) sdk.Result {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  "Failed: block time exceeded deadline",
+		}
+	}
+
+	route := k.GetDefaultSwapRoute(ctx, msg.MaxSoldTokenAmount.Denom, msg.BoughtTokenAmount.Denom)
+	amounts, err := k.GetSwapRouteSellAmounts(ctx, route, msg.BoughtTokenAmount, msg.MaxSoldTokenAmount.Denom)
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
+	}
+	tokenSell := amounts[0]
+	if tokenSell.Amount.GT(msg.MaxSoldTokenAmount.Amount) {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("Failed: expected maximum token to sell is %s but need %s", msg.MaxSoldTokenAmount, tokenSell),
+		}
+	}
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.DecCoins{tokenSell}); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  err.Error(),
+		}
+	}
+
+	// transfer coins
+	if err := k.SendCoinsToPool(ctx, sdk.DecCoins{tokenSell}, msg.Sender); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  "insufficient Coins",
+		}
+	}
+	if err := k.SendCoinsFromPoolToAccount(ctx, sdk.DecCoins{msg.BoughtTokenAmount}, msg.Recipient); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  "insufficient Coins",
+		}
+	}
+
+	// update swapTokenPairs
+	for i, tokenPairName := range route {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  err.Error(),
+			}
+		}
+		tokenBuy := msg.BoughtTokenAmount
+		if i+1 < len(amounts) {
+			tokenBuy = amounts[i+1]
+		}
+		if err := k.SwapPooledCoins(ctx, swapTokenPair, amounts[i], tokenBuy, msg.Sender); err != nil {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  err.Error(),
+			}
+		}
+	}
+
+	event = event.AppendAttributes(
+		sdk.NewAttribute("sold_token_amount", tokenSell.String()),
+		sdk.NewAttribute("recipient", msg.Recipient.String()),
+	)
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// handleMsgZapIn swaps the optimal part of the sold token for the paired token in the pool, and adds liquidity with
+// the rest of the sold token and the bought token
+func handleMsgZapIn(ctx sdk.Context, k Keeper, msg types.MsgZapIn) sdk.Result {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  "Failed: block time exceeded deadline",
+		}
+	}
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.DecCoins{msg.SoldTokenAmount}); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInsufficientCoins,
+			Log:  err.Error(),
+		}
+	}
+	swapTokenPair, err := k.GetSwapTokenPair(ctx, msg.GetSwapTokenPair())
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
+	}
+	if !swapTokenPair.BasePooledCoin.IsPositive() || !swapTokenPair.QuotePooledCoin.IsPositive() {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("Failed: no liquidity in the pool %s", swapTokenPair.TokenPairName()),
+		}
+	}
+
+	swapToken, boughtToken, err := keeper.CalculateZapInSwapAmount(swapTokenPair, msg.SoldTokenAmount,
+		msg.PairedToken, k.GetParams(ctx))
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
+	}
+	if boughtToken.IsZero() {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  "Failed: selled token amount is too little to buy any token",
+		}
+	}
+	swapMsg := types.NewMsgTokenToToken(swapToken, boughtToken, msg.Deadline, msg.Sender, msg.Sender)
+	if res := swapTokenNativeToken(ctx, k, swapTokenPair, boughtToken, swapMsg); !res.IsOK() {
+		return res
+	}
+
+	// deposit at the ratio of the pool after the swap, the dust of the sold token is left to the sender
+	if swapTokenPair, err = k.GetSwapTokenPair(ctx, msg.GetSwapTokenPair()); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
+	}
+	baseAmount, quoteAmount := msg.SoldTokenAmount.Sub(swapToken), boughtToken
+	if baseAmount.Denom != swapTokenPair.BasePooledCoin.Denom {
+		baseAmount, quoteAmount = quoteAmount, baseAmount
+	}
+	maxQuoteAmount := common.MulAndQuo(baseAmount.Amount, swapTokenPair.QuotePooledCoin.Amount,
+		swapTokenPair.BasePooledCoin.Amount)
+	if quoteAmount.Amount.GT(maxQuoteAmount) {
+		quoteAmount.Amount = maxQuoteAmount
+	}
+	addLiquidityMsg := types.NewMsgAddLiquidity(msg.MinLiquidity, baseAmount, quoteAmount, msg.Deadline, msg.Sender)
+	if res := handleMsgAddLiquidity(ctx, k, addLiquidityMsg); !res.IsOK() {
+		return res
+	}
+
+	event = event.AppendAttributes(sdk.NewAttribute("swapped_token_amount", swapToken.String()))
+	event = event.AppendAttributes(sdk.NewAttribute("bought_token_amount", boughtToken.String()))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// handleMsgZapOut removes liquidity from the pool, and swaps the paired token withdrawn for the bought token
+func handleMsgZapOut(ctx sdk.Context, k Keeper, msg types.MsgZapOut) sdk.Result {
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	baseAmount, quoteAmount, err := k.GetRedeemableAssets(ctx, msg.GetSwapTokenPair(), msg.Liquidity)
+	if err != nil {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  err.Error(),
+		}
+	}
+	removeLiquidityMsg := types.NewMsgRemoveLiquidity(msg.Liquidity,
+		sdk.NewDecCoinFromDec(baseAmount.Denom, sdk.ZeroDec()), sdk.NewDecCoinFromDec(quoteAmount.Denom, sdk.ZeroDec()),
+		msg.Deadline, msg.Sender)
+	if res := handleMsgRemoveLiquidity(ctx, k, removeLiquidityMsg); !res.IsOK() {
+		return res
+	}
+
+	boughtToken, pairedToken := baseAmount, quoteAmount
+	if boughtToken.Denom != msg.MinBoughtTokenAmount.Denom {
+		boughtToken, pairedToken = pairedToken, boughtToken
+	}
+	if pairedToken.IsPositive() {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, msg.GetSwapTokenPair())
+		if err != nil {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  err.Error(),
+			}
+		}
+		swappedToken, err := keeper.CalculateTokenToBuy(swapTokenPair, pairedToken, boughtToken.Denom, k.GetParams(ctx))
+		if err != nil {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  err.Error(),
+			}
+		}
+		if swappedToken.IsZero() {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  "Failed: withdrawn token amount is too little to buy any token",
+			}
+		}
+		swapMsg := types.NewMsgTokenToToken(pairedToken, swappedToken, msg.Deadline, msg.Sender, msg.Sender)
+		if res := swapTokenNativeToken(ctx, k, swapTokenPair, swappedToken, swapMsg); !res.IsOK() {
+			return res
+		}
+		boughtToken = boughtToken.Add(swappedToken)
+	}
+	if boughtToken.IsLT(msg.MinBoughtTokenAmount) {
+		return sdk.Result{
+			Code: sdk.CodeInternal,
+			Log: fmt.Sprintf("Failed: expected minimum token to buy is %s but got %s",
+				msg.MinBoughtTokenAmount, boughtToken),
+		}
+	}
+
+	event = event.AppendAttributes(sdk.NewAttribute("bought_token_amount", boughtToken.String()))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
@@ -10,6 +10,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/okex/okexchain/x/ammswap/types"
+	distr "github.com/okex/okexchain/x/distribution/types"
 	token "github.com/okex/okexchain/x/token/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -24,7 +25,7 @@ func TestHandleMsgCreateExchange(t *testing.T) {
 
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
-	msg := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
+	msg := types.NewMsgCreateExchange(testToken.Symbol, "", addrKeysSlice[0].Address)
 
 	// test case1: token is not exist
 	result := handler(ctx, msg)
@@ -76,9 +77,10 @@ func TestHandleMsgAddLiquidity(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
 	testToken := initToken(types.TestBasePooledToken)
 
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
-	msg := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
+	msg := types.NewMsgCreateExchange(testToken.Symbol, "", addrKeysSlice[0].Address)
 	mapp.tokenKeeper.NewToken(ctx, testToken)
 
 	result := handler(ctx, msg)
@@ -143,9 +145,10 @@ func TestHandleMsgRemoveLiquidity(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
 	testToken := initToken(types.TestBasePooledToken)
 
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
-	msg := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
+	msg := types.NewMsgCreateExchange(testToken.Symbol, "", addrKeysSlice[0].Address)
 	mapp.tokenKeeper.NewToken(ctx, testToken)
 
 	result := handler(ctx, msg)
@@ -222,8 +225,8 @@ func TestHandleMsgTokenToTokenExchange(t *testing.T) {
 
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
-	msgCreateExchange := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
-	msgCreateExchange2 := types.NewMsgCreateExchange(secondTestToken.Symbol, addrKeysSlice[0].Address)
+	msgCreateExchange := types.NewMsgCreateExchange(testToken.Symbol, "", addrKeysSlice[0].Address)
+	msgCreateExchange2 := types.NewMsgCreateExchange(secondTestToken.Symbol, "", addrKeysSlice[0].Address)
 	mapp.tokenKeeper.NewToken(ctx, testToken)
 	mapp.tokenKeeper.NewToken(ctx, secondTestToken)
 
@@ -315,6 +318,172 @@ func TestHandleMsgTokenToTokenExchange(t *testing.T) {
 	}
 }
 
+func TestHandleMsgsWithTokenQuote(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	mapp.tokenKeeper.NewToken(ctx, initToken(types.TestBasePooledToken))
+	mapp.tokenKeeper.NewToken(ctx, initToken(types.TestBasePooledToken2))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+
+	// the pair is named in the canonical order whatever the order of the tokens in the msg
+	result := handler(ctx, types.NewMsgCreateExchange(types.TestBasePooledToken2, types.TestBasePooledToken, addr))
+	require.Equal(t, "", result.Log)
+	tokenPairName := types.TestBasePooledToken + "_" + types.TestBasePooledToken2
+	poolTokenName := types.GetPoolTokenName(types.TestBasePooledToken, types.TestBasePooledToken2, 1)
+	require.True(t, types.ValidatePoolTokenName(poolTokenName))
+	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, tokenPairName)
+	require.Nil(t, err)
+	require.Equal(t, poolTokenName, swapTokenPair.PoolTokenName)
+	result = handler(ctx, types.NewMsgCreateExchange(types.TestBasePooledToken, types.TestBasePooledToken2, addr))
+	require.Equal(t, sdk.CodeInternal, result.Code)
+
+	// every pair without the native token gets a pool token of its own, an existing name is rejected
+	zzb := "zzb"
+	mapp.tokenKeeper.NewToken(ctx, initToken(zzb))
+	result = handler(ctx, types.NewMsgCreateExchange(types.TestBasePooledToken, zzb, addr))
+	require.Equal(t, "", result.Log)
+	swapTokenPair2, err := keeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(types.TestBasePooledToken, zzb))
+	require.Nil(t, err)
+	require.Equal(t, types.GetPoolTokenName(types.TestBasePooledToken, zzb, 2), swapTokenPair2.PoolTokenName)
+	mapp.tokenKeeper.NewToken(ctx, initToken(types.GetPoolTokenName(types.TestBasePooledToken2, zzb, 3)))
+	result = handler(ctx, types.NewMsgCreateExchange(types.TestBasePooledToken2, zzb, addr))
+	require.Equal(t, "Failed: pool token already exists", result.Log)
+
+	baseAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10000))
+	quoteAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10000))
+	result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), baseAmount, quoteAmount, deadLine, addr))
+	require.Equal(t, "", result.Log)
+
+	// swap in the pool without the native token
+	soldTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(100))
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(1))
+	result = handler(ctx, types.NewMsgTokenToToken(soldTokenAmount, minBoughtTokenAmount, deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+	swapTokenPair, err = keeper.GetSwapTokenPair(ctx, tokenPairName)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(10100), swapTokenPair.QuotePooledCoin.Amount)
+	boughtAmount := sdk.NewDec(10000).Sub(swapTokenPair.BasePooledCoin.Amount)
+	require.True(t, boughtAmount.IsPositive())
+
+	minBaseAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(1))
+	minQuoteAmount := sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(1))
+	result = handler(ctx, types.NewMsgRemoveLiquidity(sdk.OneDec(), minBaseAmount, minQuoteAmount, deadLine, addr))
+	require.Equal(t, "", result.Log)
+
+	acc := mapp.AccountKeeper.GetAccount(ctx, addr)
+	require.Equal(t, sdk.NewDec(100000), acc.GetCoins().AmountOf(types.TestQuotePooledToken))
+	require.Equal(t, sdk.NewDec(100000), acc.GetCoins().AmountOf(types.TestBasePooledToken))
+	require.Equal(t, sdk.NewDec(100000), acc.GetCoins().AmountOf(types.TestBasePooledToken2))
+	require.True(t, acc.GetCoins().AmountOf(poolTokenName).IsZero())
+}
+
+func TestHandleMsgMultiHopSwap(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+	xxb, yyb, zzb := types.TestBasePooledToken, types.TestBasePooledToken2, types.TestBasePooledToken3
+
+	// xxb_okt and yyb_okt are deep, xxb_zzb and yyb_zzb are shallow
+	pools := []struct {
+		base, quote             string
+		baseAmount, quoteAmount int64
+	}{
+		{xxb, types.TestQuotePooledToken, 10000, 10000},
+		{yyb, types.TestQuotePooledToken, 10000, 10000},
+		{xxb, zzb, 100, 100},
+		{yyb, zzb, 100, 100},
+	}
+	for _, token := range []string{xxb, yyb, zzb} {
+		mapp.tokenKeeper.NewToken(ctx, initToken(token))
+	}
+	for _, pool := range pools {
+		result := handler(ctx, types.NewMsgCreateExchange(pool.base, pool.quote, addr))
+		require.Equal(t, "", result.Log)
+		result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1),
+			sdk.NewDecCoinFromDec(pool.base, sdk.NewDec(pool.baseAmount)),
+			sdk.NewDecCoinFromDec(pool.quote, sdk.NewDec(pool.quoteAmount)), deadLine, addr))
+		require.Equal(t, "", result.Log)
+	}
+
+	soldTokenAmount := sdk.NewDecCoinFromDec(xxb, sdk.NewDec(10))
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(yyb, sdk.NewDec(1))
+	nativeRoute := []string{xxb + "_" + types.TestQuotePooledToken, yyb + "_" + types.TestQuotePooledToken}
+	zzbRoute := []string{xxb + "_" + zzb, yyb + "_" + zzb}
+
+	// the best route goes through the deep pools
+	route, amounts, err := keeper.GetBestSwapRoute(ctx, soldTokenAmount, yyb)
+	require.Nil(t, err)
+	require.Equal(t, nativeRoute, route)
+	zzbAmounts, err := keeper.GetSwapRouteAmounts(ctx, zzbRoute, soldTokenAmount)
+	require.Nil(t, err)
+	require.True(t, amounts[1].Amount.GT(zzbAmounts[1].Amount))
+
+	tests := []struct {
+		testCase             string
+		minBoughtTokenAmount sdk.DecCoin
+		route                []string
+		deadLine             int64
+		exceptResultCode     sdk.CodeType
+	}{
+		{"blockTime exceeded deadline", minBoughtTokenAmount, nil, 0, sdk.CodeInternal},
+		{"unknown token pair", minBoughtTokenAmount, []string{xxb + "_" + yyb}, deadLine, sdk.CodeInternal},
+		{"no route", sdk.NewDecCoinFromDec("abc", sdk.NewDec(1)), nil, deadLine, sdk.CodeInternal},
+		{"The available BoughtTokenAmount are less than minBoughtTokenAmount",
+			sdk.NewDecCoinFromDec(yyb, sdk.NewDec(10)), nil, deadLine, sdk.CodeInternal},
+		{"success with the explicit route", minBoughtTokenAmount, zzbRoute, deadLine, sdk.CodeOK},
+	}
+	for _, testCase := range tests {
+		msg := types.NewMsgMultiHopSwap(soldTokenAmount, testCase.minBoughtTokenAmount, testCase.route,
+			testCase.deadLine, addr, addr)
+		result := handler(ctx, msg)
+		require.Equal(t, testCase.exceptResultCode, result.Code, testCase.testCase)
+	}
+
+	// the reserves of every pool in the route are updated
+	xxbZzb, err := keeper.GetSwapTokenPair(ctx, zzbRoute[0])
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(110), xxbZzb.BasePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(100).Sub(zzbAmounts[0].Amount), xxbZzb.QuotePooledCoin.Amount)
+	yybZzb, err := keeper.GetSwapTokenPair(ctx, zzbRoute[1])
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(100).Add(zzbAmounts[0].Amount), yybZzb.QuotePooledCoin.Amount)
+	require.Equal(t, sdk.NewDec(100).Sub(zzbAmounts[1].Amount), yybZzb.BasePooledCoin.Amount)
+
+	// the best route is used without an explicit route, and every hop is reported in the events
+	acc := mapp.AccountKeeper.GetAccount(ctx, addr)
+	yybBalance := acc.GetCoins().AmountOf(yyb)
+	route, amounts, err = keeper.GetBestSwapRoute(ctx, soldTokenAmount, yyb)
+	require.Nil(t, err)
+	result := handler(ctx, types.NewMsgMultiHopSwap(soldTokenAmount, minBoughtTokenAmount, nil, deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+	acc = mapp.AccountKeeper.GetAccount(ctx, addr)
+	require.Equal(t, yybBalance.Add(amounts[1].Amount), acc.GetCoins().AmountOf(yyb))
+
+	var hops []sdk.Event
+	for _, event := range result.Events {
+		if event.Type == types.EventTypeSwapHop {
+			hops = append(hops, event)
+		}
+	}
+	require.Equal(t, len(route), len(hops))
+	for i, hop := range hops {
+		require.Equal(t, route[i], string(hop.Attributes[0].Value))
+		require.Equal(t, amounts[i].String(), string(hop.Attributes[2].Value))
+	}
+}
+
 func TestGetInputPrice(t *testing.T) {
 	defaultFeeRate := sdk.NewDecWithPrec(3, 3)
 	inputAmount := sdk.NewDecWithPrec(0, 8)
@@ -324,6 +493,399 @@ func TestGetInputPrice(t *testing.T) {
 	require.Equal(t, inputAmount, res)
 }
 
+func TestGetOutputPrice(t *testing.T) {
+	defaultFeeRate := sdk.NewDecWithPrec(3, 3)
+	inputReserve := sdk.NewDec(100)
+	outputReserve := sdk.NewDec(300)
+	for _, outputAmount := range []sdk.Dec{sdk.NewDecWithPrec(1, 8), sdk.NewDec(1), sdk.MustNewDecFromStr("123.45678901")} {
+		inputAmount := keeper.GetOutputPrice(outputAmount, inputReserve, outputReserve, defaultFeeRate)
+		// selling the input amount buys at least the output amount, and one unit less doesn't
+		require.True(t, keeper.GetInputPrice(inputAmount, inputReserve, outputReserve, defaultFeeRate).GTE(outputAmount))
+		lessInputAmount := inputAmount.Sub(sdk.NewDecWithPrec(1, 8))
+		require.True(t, keeper.GetInputPrice(lessInputAmount, inputReserve, outputReserve, defaultFeeRate).LT(outputAmount))
+	}
+}
+
+func TestHandleMsgTokenToTokenExactOutput(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+	xxb, yyb, okt := types.TestBasePooledToken, types.TestBasePooledToken2, types.TestQuotePooledToken
+
+	for _, token := range []string{xxb, yyb} {
+		mapp.tokenKeeper.NewToken(ctx, initToken(token))
+		result := handler(ctx, types.NewMsgCreateExchange(token, "", addr))
+		require.Equal(t, "", result.Log)
+		result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), sdk.NewDecCoinFromDec(token, sdk.NewDec(10000)),
+			sdk.NewDecCoinFromDec(okt, sdk.NewDec(10000)), deadLine, addr))
+		require.Equal(t, "", result.Log)
+	}
+
+	boughtTokenAmount := sdk.NewDecCoinFromDec(xxb, sdk.NewDec(100))
+	maxSoldTokenAmount := sdk.NewDecCoinFromDec(okt, sdk.NewDec(200))
+	boughtTokenAmount2 := sdk.NewDecCoinFromDec(yyb, sdk.NewDec(100))
+	maxSoldTokenAmount2 := sdk.NewDecCoinFromDec(xxb, sdk.NewDec(200))
+	tests := []struct {
+		testCase           string
+		maxSoldTokenAmount sdk.DecCoin
+		boughtTokenAmount  sdk.DecCoin
+		deadLine           int64
+		exceptResultCode   sdk.CodeType
+	}{
+		{"blockTime exceeded deadline", maxSoldTokenAmount, boughtTokenAmount, 0, sdk.CodeInternal},
+		{"unknown swapTokenPair", maxSoldTokenAmount, sdk.NewDecCoinFromDec("abc", sdk.NewDec(1)), deadLine, sdk.CodeInternal},
+		{"insufficient token in the pool", maxSoldTokenAmount, sdk.NewDecCoinFromDec(xxb, sdk.NewDec(10000)), deadLine,
+			sdk.CodeInternal},
+		{"The required SoldTokenAmount are greater than maxSoldTokenAmount",
+			sdk.NewDecCoinFromDec(okt, sdk.NewDec(100)), boughtTokenAmount, deadLine, sdk.CodeInternal},
+		{"insufficient coins", sdk.NewDecCoinFromDec(okt, sdk.NewDec(10000000)),
+			sdk.NewDecCoinFromDec(xxb, sdk.NewDec(9000)), deadLine, sdk.CodeInsufficientCoins},
+	}
+	for _, testCase := range tests {
+		msg := types.NewMsgTokenToTokenExactOutput(testCase.maxSoldTokenAmount, testCase.boughtTokenAmount,
+			testCase.deadLine, addr, addr)
+		result := handler(ctx, msg)
+		require.Equal(t, testCase.exceptResultCode, result.Code, testCase.testCase)
+	}
+
+	// buy exactly in the pool of the tokens and through the pools quoted in the native token
+	for _, amounts := range [][]sdk.DecCoin{{maxSoldTokenAmount, boughtTokenAmount},
+		{maxSoldTokenAmount2, boughtTokenAmount2}} {
+		acc := mapp.AccountKeeper.GetAccount(ctx, addr)
+		soldBalance, boughtBalance := acc.GetCoins().AmountOf(amounts[0].Denom), acc.GetCoins().AmountOf(amounts[1].Denom)
+		route := keeper.GetDefaultSwapRoute(ctx, amounts[0].Denom, amounts[1].Denom)
+		sellAmounts, err := keeper.GetSwapRouteSellAmounts(ctx, route, amounts[1], amounts[0].Denom)
+		require.Nil(t, err)
+
+		result := handler(ctx, types.NewMsgTokenToTokenExactOutput(amounts[0], amounts[1], deadLine, addr, addr))
+		require.Equal(t, "", result.Log)
+		acc = mapp.AccountKeeper.GetAccount(ctx, addr)
+		require.Equal(t, soldBalance.Sub(sellAmounts[0].Amount), acc.GetCoins().AmountOf(amounts[0].Denom))
+		require.Equal(t, boughtBalance.Add(amounts[1].Amount), acc.GetCoins().AmountOf(amounts[1].Denom))
+	}
+	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, yyb+"_"+okt)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(9900), swapTokenPair.BasePooledCoin.Amount)
+}
+
+func TestHandleMsgTokenToTokenExchangeWithProtocolFee(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	params := types.DefaultParams()
+	params.ProtocolFeeRate = sdk.NewDecWithPrec(2, 1)
+	mapp.swapKeeper.SetParams(ctx, params)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(keeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+	xxb, okt := types.TestBasePooledToken, types.TestQuotePooledToken
+	tokenPairName := xxb + "_" + okt
+
+	mapp.tokenKeeper.NewToken(ctx, initToken(xxb))
+	result := handler(ctx, types.NewMsgCreateExchange(xxb, "", addr))
+	require.Equal(t, "", result.Log)
+	result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), sdk.NewDecCoinFromDec(xxb, sdk.NewDec(10000)),
+		sdk.NewDecCoinFromDec(okt, sdk.NewDec(10000)), deadLine, addr))
+	require.Equal(t, "", result.Log)
+
+	// the swap fee is 3okt, 20% of which is sent to the community pool
+	result = handler(ctx, types.NewMsgTokenToToken(sdk.NewDecCoinFromDec(okt, sdk.NewDec(1000)),
+		sdk.NewDecCoinFromDec(xxb, sdk.NewDec(1)), deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, tokenPairName)
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("10999.4"), swapTokenPair.QuotePooledCoin.Amount)
+	communityPool := mapp.supplyKeeper.GetModuleAccount(ctx, distr.ModuleName).GetCoins()
+	require.Equal(t, sdk.MustNewDecFromStr("0.6"), communityPool.AmountOf(okt))
+
+	poolFees := keeper.GetPoolFees(ctx, tokenPairName)
+	require.Equal(t, sdk.MustNewDecFromStr("2.4"), poolFees.LPFees.AmountOf(okt))
+	require.Equal(t, sdk.MustNewDecFromStr("0.6"), poolFees.ProtocolFees.AmountOf(okt))
+
+	// the fees are accumulated in the denom of the sold token
+	result = handler(ctx, types.NewMsgTokenToToken(sdk.NewDecCoinFromDec(xxb, sdk.NewDec(500)),
+		sdk.NewDecCoinFromDec(okt, sdk.NewDec(1)), deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+	querier := NewQuerier(keeper)
+	res, sdkErr := querier(ctx, []string{types.QueryPoolFees, xxb}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	require.Nil(t, types.ModuleCdc.UnmarshalJSON(res, &poolFees))
+	require.Equal(t, sdk.MustNewDecFromStr("2.4"), poolFees.LPFees.AmountOf(okt))
+	require.Equal(t, sdk.MustNewDecFromStr("1.2"), poolFees.LPFees.AmountOf(xxb))
+	require.Equal(t, sdk.MustNewDecFromStr("0.3"), poolFees.ProtocolFees.AmountOf(xxb))
+
+	_, sdkErr = querier(ctx, []string{types.QueryPoolFees, "abc"}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+}
+
+func TestHandleMsgsWithCurves(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	swapKeeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(swapKeeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+	xxb, yyb, okt := types.TestBasePooledToken, types.TestBasePooledToken2, types.TestQuotePooledToken
+
+	// xxb weighs 80% of the weighted pool, yyb and okt are pegged in the stable swap pool
+	mapp.tokenKeeper.NewToken(ctx, initToken(xxb))
+	mapp.tokenKeeper.NewToken(ctx, initToken(yyb))
+	result := handler(ctx, types.NewMsgCreateExchangeWithCurve(okt, xxb, types.CurveWeighted,
+		sdk.NewDecWithPrec(2, 1), 0, addr))
+	require.Equal(t, "", result.Log)
+	result = handler(ctx, types.NewMsgCreateExchangeWithCurve(yyb, "", types.CurveStableSwap, sdk.ZeroDec(), 100, addr))
+	require.Equal(t, "", result.Log)
+	for _, token := range []string{xxb, yyb} {
+		result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), sdk.NewDecCoinFromDec(token, sdk.NewDec(10000)),
+			sdk.NewDecCoinFromDec(okt, sdk.NewDec(10000)), deadLine, addr))
+		require.Equal(t, "", result.Log)
+	}
+	weightedPair, err := swapKeeper.GetSwapTokenPair(ctx, xxb+"_"+okt)
+	require.Nil(t, err)
+	require.Equal(t, types.NewPoolCurve(types.CurveWeighted, sdk.NewDecWithPrec(8, 1), 0), weightedPair.Curve)
+
+	tests := []struct {
+		testCase        string
+		soldToken       sdk.DecCoin
+		boughtDenom     string
+		minBoughtAmount sdk.Dec
+	}{
+		// the spot price of xxb is 4okt in the weighted pool
+		{"sell on the weighted curve", sdk.NewDecCoinFromDec(xxb, sdk.NewDec(100)), okt, sdk.NewDec(385)},
+		{"buy on the weighted curve", sdk.NewDecCoinFromDec(okt, sdk.NewDec(100)), xxb, sdk.NewDecWithPrec(24, 0)},
+		// the constant product pool gives only 906yyb for 1000okt
+		{"swap on the stable swap curve", sdk.NewDecCoinFromDec(okt, sdk.NewDec(1000)), yyb, sdk.NewDec(990)},
+	}
+	for _, test := range tests {
+		swapTokenPair, err := swapKeeper.GetSwapTokenPair(ctx, types.GetSwapTokenPairName(test.soldToken.Denom, test.boughtDenom))
+		require.Nil(t, err)
+		expected, err := keeper.CalculateTokenToBuy(swapTokenPair, test.soldToken, test.boughtDenom, swapKeeper.GetParams(ctx))
+		require.Nil(t, err)
+		require.True(t, expected.Amount.GTE(test.minBoughtAmount), test.testCase)
+
+		balance := mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins().AmountOf(test.boughtDenom)
+		result = handler(ctx, types.NewMsgTokenToToken(test.soldToken,
+			sdk.NewDecCoinFromDec(test.boughtDenom, test.minBoughtAmount), deadLine, addr, addr))
+		require.Equal(t, "", result.Log, test.testCase)
+		require.Equal(t, balance.Add(expected.Amount),
+			mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins().AmountOf(test.boughtDenom), test.testCase)
+	}
+
+	// the weighted pool rejects swaps of more than half of the reserve
+	result = handler(ctx, types.NewMsgTokenToToken(sdk.NewDecCoinFromDec(xxb, sdk.NewDec(6000)),
+		sdk.NewDecCoinFromDec(okt, sdk.NewDec(1)), deadLine, addr, addr))
+	require.NotEqual(t, "", result.Log)
+}
+
+// getLastEventAttributes returns the attributes of the last event emitted by the handler
+func getLastEventAttributes(result sdk.Result) map[string]string {
+	attributes := make(map[string]string)
+	if len(result.Events) == 0 {
+		return attributes
+	}
+	for _, attribute := range result.Events[len(result.Events)-1].Attributes {
+		attributes[string(attribute.Key)] = string(attribute.Value)
+	}
+	return attributes
+}
+
+func TestHandleMsgZapInAndOut(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	swapKeeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(swapKeeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+	xxb, okt := types.TestBasePooledToken, types.TestQuotePooledToken
+	poolTokenName := types.GetPoolTokenName(xxb, okt, 0)
+
+	mapp.tokenKeeper.NewToken(ctx, initToken(xxb))
+	result := handler(ctx, types.NewMsgCreateExchange(xxb, "", addr))
+	require.Equal(t, "", result.Log)
+	result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), sdk.NewDecCoinFromDec(xxb, sdk.NewDec(10000)),
+		sdk.NewDecCoinFromDec(okt, sdk.NewDec(40000)), deadLine, addr))
+	require.Equal(t, "", result.Log)
+	getBalance := func(denom string) sdk.Dec {
+		return mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins().AmountOf(denom)
+	}
+	xxbBalance, oktBalance := getBalance(xxb), getBalance(okt)
+
+	// zap in with okt only, part of which is swapped for xxb
+	tests := []struct {
+		testCase         string
+		msg              types.MsgZapIn
+		exceptResultCode sdk.CodeType
+	}{
+		{"no liquidity minted as expected",
+			types.NewMsgZapIn(sdk.NewDecCoinFromDec(okt, sdk.NewDec(1000)), xxb, sdk.OneDec(), deadLine, addr),
+			sdk.CodeInternal},
+		{"no token pair",
+			types.NewMsgZapIn(sdk.NewDecCoinFromDec(okt, sdk.NewDec(1000)), "abc", sdk.ZeroDec(), deadLine, addr),
+			sdk.CodeInternal},
+		{"success",
+			types.NewMsgZapIn(sdk.NewDecCoinFromDec(okt, sdk.NewDec(1000)), xxb, sdk.NewDecWithPrec(1, 2), deadLine, addr),
+			sdk.CodeOK},
+	}
+	for _, test := range tests {
+		// the state of the failed message is discarded as in the transaction
+		cacheCtx, write := ctx.CacheContext()
+		result = handler(cacheCtx, test.msg)
+		require.Equal(t, test.exceptResultCode, result.Code, test.testCase)
+		if result.IsOK() {
+			write()
+			attributes := getLastEventAttributes(result)
+			require.NotEmpty(t, attributes["swapped_token_amount"])
+			require.NotEmpty(t, attributes["bought_token_amount"])
+		}
+	}
+	liquidity := getBalance(poolTokenName).Sub(sdk.OneDec())
+	require.True(t, liquidity.IsPositive())
+	require.True(t, getBalance(xxb).Sub(xxbBalance).LT(sdk.NewDecWithPrec(1, 6)))
+	require.True(t, oktBalance.Sub(sdk.NewDec(1000)).Sub(getBalance(okt)).Abs().LT(sdk.NewDecWithPrec(1, 4)))
+
+	// zap out for okt only, losing only the swap fees
+	cacheCtx, _ := ctx.CacheContext()
+	result = handler(cacheCtx, types.NewMsgZapOut(liquidity, sdk.NewDecCoinFromDec(okt, sdk.NewDec(1000)), xxb, deadLine,
+		addr))
+	require.Equal(t, sdk.CodeInternal, result.Code)
+	result = handler(ctx, types.NewMsgZapOut(liquidity, sdk.NewDecCoinFromDec(okt, sdk.NewDec(990)), xxb, deadLine, addr))
+	require.Equal(t, "", result.Log)
+	boughtToken, err := sdk.ParseDecCoin(getLastEventAttributes(result)["bought_token_amount"])
+	require.Nil(t, err)
+	require.Equal(t, okt, boughtToken.Denom)
+	require.True(t, boughtToken.Amount.GTE(sdk.NewDec(990)))
+	require.Equal(t, sdk.OneDec(), getBalance(poolTokenName))
+	require.True(t, getBalance(xxb).Sub(xxbBalance).LT(sdk.NewDecWithPrec(1, 6)))
+	require.True(t, getBalance(okt).GT(oktBalance.Sub(sdk.NewDec(10))))
+}
+
+func TestHandleMsgsBackendRecords(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000)
+	swapKeeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+	mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	handler := NewHandler(swapKeeper)
+	addr := addrKeysSlice[0].Address
+	deadLine := time.Now().Unix()
+	xxb, okt := types.TestBasePooledToken, types.TestQuotePooledToken
+
+	mapp.tokenKeeper.NewToken(ctx, initToken(xxb))
+	result := handler(ctx, types.NewMsgCreateExchange(xxb, "", addr))
+	require.Equal(t, "", result.Log)
+	result = handler(ctx, types.NewMsgAddLiquidity(sdk.NewDec(1), sdk.NewDecCoinFromDec(xxb, sdk.NewDec(10000)),
+		sdk.NewDecCoinFromDec(okt, sdk.NewDec(40000)), deadLine, addr))
+	require.Equal(t, "", result.Log)
+	result = handler(ctx, types.NewMsgTokenToToken(sdk.NewDecCoinFromDec(okt, sdk.NewDec(400)),
+		sdk.NewDecCoinFromDec(xxb, sdk.NewDec(1)), deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+
+	pairInfos := swapKeeper.GetSwapTokenPairInfos(ctx)
+	require.Equal(t, 1, len(pairInfos))
+	require.Equal(t, types.TestSwapTokenPairName, pairInfos[0].TokenPairName)
+	require.Equal(t, addr.String(), pairInfos[0].Creator)
+	liquidityInfos := swapKeeper.GetSwapLiquidityInfos(ctx)
+	require.Equal(t, 1, len(liquidityInfos))
+	require.Equal(t, types.LiquidityTypeAdd, liquidityInfos[0].Type)
+	require.Equal(t, sdk.NewDecCoinFromDec(okt, sdk.NewDec(40000)).String(), liquidityInfos[0].QuoteAmount)
+	swapInfos := swapKeeper.GetSwapInfos(ctx)
+	require.Equal(t, 1, len(swapInfos))
+	require.Equal(t, addr.String(), swapInfos[0].Address)
+	require.EqualValues(t, 400, swapInfos[0].Volume)
+	price := sdk.MustNewDecFromStr(swapInfos[0].Price)
+	require.True(t, price.GT(sdk.NewDec(4)) && price.LT(sdk.NewDec(5)))
+
+	// the records of a failed msg are dropped with its state, the zap in swaps before it fails to mint the
+	// liquidity expected
+	cacheCtx, _ := ctx.CacheContext()
+	result = handler(cacheCtx, types.NewMsgZapIn(sdk.NewDecCoinFromDec(okt, sdk.NewDec(1000)), xxb, sdk.OneDec(),
+		deadLine, addr))
+	require.Equal(t, sdk.CodeInternal, result.Code)
+	require.Equal(t, 2, len(swapKeeper.GetSwapInfos(cacheCtx)))
+	require.Equal(t, 1, len(swapKeeper.GetSwapInfos(ctx)))
+	require.Equal(t, 1, len(swapKeeper.GetSwapLiquidityInfos(ctx)))
+
+	// the records of a msg which succeeds are also dropped if a later msg of the tx fails
+	cacheCtx, _ = ctx.CacheContext()
+	result = handler(cacheCtx, types.NewMsgTokenToToken(sdk.NewDecCoinFromDec(okt, sdk.NewDec(400)),
+		sdk.NewDecCoinFromDec(xxb, sdk.NewDec(1)), deadLine, addr, addr))
+	require.Equal(t, "", result.Log)
+	require.Equal(t, 2, len(swapKeeper.GetSwapInfos(cacheCtx)))
+	result = handler(cacheCtx, types.NewMsgTokenToToken(sdk.NewDecCoinFromDec(okt, sdk.NewDec(400)),
+		sdk.NewDecCoinFromDec(xxb, sdk.NewDec(1000)), deadLine, addr, addr))
+	require.NotEqual(t, sdk.CodeOK, result.Code)
+	require.Equal(t, 1, len(swapKeeper.GetSwapInfos(ctx)))
+
+	// nothing is recorded in check tx
+	result = handler(ctx.WithIsCheckTx(true), types.NewMsgRemoveLiquidity(sdk.OneDec(),
+		sdk.NewDecCoinFromDec(xxb, sdk.ZeroDec()), sdk.NewDecCoinFromDec(okt, sdk.ZeroDec()), deadLine, addr))
+	require.Equal(t, "", result.Log)
+	require.Equal(t, 1, len(swapKeeper.GetSwapLiquidityInfos(ctx)))
+
+	// the records are dropped once the block is committed
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	ctx = mapp.BaseApp.NewContext(false, abci.Header{})
+	require.Equal(t, 0, len(swapKeeper.GetSwapInfos(ctx)))
+	require.Equal(t, 0, len(swapKeeper.GetSwapLiquidityInfos(ctx)))
+	require.Equal(t, 0, len(swapKeeper.GetSwapTokenPairInfos(ctx)))
+}
+
+func TestBackendRecordsGas(t *testing.T) {
+	xxb, okt := types.TestBasePooledToken, types.TestQuotePooledToken
+	deadLine := time.Now().Unix()
+	var gasConsumed [2][]sdk.Gas
+	for i, enableBackend := range []bool{true, false} {
+		mapp, addrKeysSlice := getMockAppWithBackend(t, 1, 100000, enableBackend)
+		mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+		ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Now())
+		mapp.swapKeeper.SetParams(ctx, types.DefaultParams())
+		mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+		mapp.tokenKeeper.NewToken(ctx, initToken(xxb))
+		handler := NewHandler(mapp.swapKeeper)
+		addr := addrKeysSlice[0].Address
+
+		for _, msg := range []sdk.Msg{
+			types.NewMsgCreateExchange(xxb, "", addr),
+			types.NewMsgAddLiquidity(sdk.NewDec(1), sdk.NewDecCoinFromDec(xxb, sdk.NewDec(10000)),
+				sdk.NewDecCoinFromDec(okt, sdk.NewDec(40000)), deadLine, addr),
+			types.NewMsgTokenToToken(sdk.NewDecCoinFromDec(okt, sdk.NewDec(400)),
+				sdk.NewDecCoinFromDec(xxb, sdk.NewDec(1)), deadLine, addr, addr),
+		} {
+			msgCtx := ctx.WithGasMeter(sdk.NewGasMeter(10000000))
+			result := handler(msgCtx, msg)
+			require.Equal(t, "", result.Log, msg.Type())
+			gasConsumed[i] = append(gasConsumed[i], msgCtx.GasMeter().GasConsumed())
+		}
+
+		recordsNum := 0
+		if enableBackend {
+			recordsNum = 1
+		}
+		require.Equal(t, recordsNum, len(mapp.swapKeeper.GetSwapTokenPairInfos(ctx)))
+		require.Equal(t, recordsNum, len(mapp.swapKeeper.GetSwapLiquidityInfos(ctx)))
+		require.Equal(t, recordsNum, len(mapp.swapKeeper.GetSwapInfos(ctx)))
+	}
+	// the records don't consume the gas of the tx
+	require.Equal(t, gasConsumed[0], gasConsumed[1])
+}
+
 func TestRandomData(t *testing.T) {
 	mapp, addrKeysSlice := getMockAppWithBalance(t, 1, 100000000)
 	keeper := mapp.swapKeeper
@@ -335,7 +897,7 @@ func TestRandomData(t *testing.T) {
 	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
 	handler := NewHandler(keeper)
 	mapp.tokenKeeper.NewToken(ctx, testToken)
-	msgCreateExchange := types.NewMsgCreateExchange(testToken.Symbol, addrKeysSlice[0].Address)
+	msgCreateExchange := types.NewMsgCreateExchange(testToken.Symbol, "", addrKeysSlice[0].Address)
 	result := handler(ctx, msgCreateExchange)
 	require.Equal(t, "", result.Log)
 	addr := addrKeysSlice[0].Address
@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// GetSwapInfos gets the swaps of the block. The records are kept in the transient store, so the ones of the failed
+// txs are dropped with their state
+func (k Keeper) GetSwapInfos(ctx sdk.Context) []*types.SwapInfo {
+	var infos []*types.SwapInfo
+	k.iterateBackendRecords(ctx, types.SwapInfoPrefixKey, func(bz []byte) {
+		var info types.SwapInfo
+		k.mustUnmarshalBackendRecord(bz, &info)
+		infos = append(infos, &info)
+	})
+	return infos
+}
+
+// GetSwapLiquidityInfos gets the liquidity changes of the block
+func (k Keeper) GetSwapLiquidityInfos(ctx sdk.Context) []*types.SwapLiquidityInfo {
+	var infos []*types.SwapLiquidityInfo
+	k.iterateBackendRecords(ctx, types.SwapLiquidityInfoPrefixKey, func(bz []byte) {
+		var info types.SwapLiquidityInfo
+		k.mustUnmarshalBackendRecord(bz, &info)
+		infos = append(infos, &info)
+	})
+	return infos
+}
+
+// GetSwapTokenPairInfos gets the pools created in the block
+func (k Keeper) GetSwapTokenPairInfos(ctx sdk.Context) []*types.SwapTokenPairInfo {
+	var infos []*types.SwapTokenPairInfo
+	k.iterateBackendRecords(ctx, types.SwapTokenPairInfoPrefixKey, func(bz []byte) {
+		var info types.SwapTokenPairInfo
+		k.mustUnmarshalBackendRecord(bz, &info)
+		infos = append(infos, &info)
+	})
+	return infos
+}
+
+// getBackendStore returns the transient store of the backend records. The records are only made by the nodes with
+// the backend enabled, so the store doesn't consume the gas of the tx, which must be the same on every node
+func (k Keeper) getBackendStore(ctx sdk.Context) sdk.KVStore {
+	return ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).TransientStore(k.tStoreKey)
+}
+
+func (k Keeper) iterateBackendRecords(ctx sdk.Context, prefix []byte, fn func(bz []byte)) {
+	iterator := sdk.KVStorePrefixIterator(k.getBackendStore(ctx), prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		fn(iterator.Value())
+	}
+}
+
+// addBackendRecord adds the record to the transient store in the order of the sequence. The records contain floats
+// which aren't supported by amino, so they are encoded in json
+func (k Keeper) addBackendRecord(ctx sdk.Context, prefix []byte, record interface{}) {
+	store := k.getBackendStore(ctx)
+	var seq uint64
+	if bz := store.Get(types.BackendRecordSeqKey); bz != nil {
+		seq = binary.BigEndian.Uint64(bz)
+	}
+	bz, err := json.Marshal(record)
+	if err != nil {
+		panic(err)
+	}
+	store.Set(types.GetBackendRecordKey(prefix, seq), bz)
+	store.Set(types.BackendRecordSeqKey, sdk.Uint64ToBigEndian(seq+1))
+}
+
+func (k Keeper) mustUnmarshalBackendRecord(bz []byte, record interface{}) {
+	if err := json.Unmarshal(bz, record); err != nil {
+		panic(err)
+	}
+}
+
+func (k Keeper) recordEnabled(ctx sdk.Context) bool {
+	return k.enableBackend && !ctx.IsCheckTx()
+}
+
+func getTxHash(ctx sdk.Context) string {
+	return fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes()))
+}
+
+// AddSwapInfo records the swap in the pool for the backend
+func (k Keeper) AddSwapInfo(ctx sdk.Context, address sdk.AccAddress, swapTokenPair types.SwapTokenPair,
+	soldToken, boughtToken sdk.DecCoin) {
+	if !k.recordEnabled(ctx) {
+		return
+	}
+	baseAmount, quoteAmount := soldToken.Amount, boughtToken.Amount
+	if soldToken.Denom == swapTokenPair.QuotePooledCoin.Denom {
+		baseAmount, quoteAmount = quoteAmount, baseAmount
+	}
+	price := sdk.ZeroDec()
+	if baseAmount.IsPositive() {
+		price = quoteAmount.Quo(baseAmount)
+	}
+	volume, err := strconv.ParseFloat(quoteAmount.String(), 64)
+	if err != nil {
+		volume = 0
+	}
+	k.addBackendRecord(ctx, types.SwapInfoPrefixKey, &types.SwapInfo{
+		TxHash:        getTxHash(ctx),
+		Address:       address.String(),
+		TokenPairName: swapTokenPair.TokenPairName(),
+		SellAmount:    soldToken.String(),
+		BuysAmount:    boughtToken.String(),
+		Price:         price.String(),
+		Volume:        volume,
+		Timestamp:     ctx.BlockTime().Unix(),
+	})
+}
+
+// AddSwapLiquidityInfo records adding liquidity to or removing liquidity from the pool for the backend
+func (k Keeper) AddSwapLiquidityInfo(ctx sdk.Context, address sdk.AccAddress, tokenPairName, liquidityType string,
+	baseAmount, quoteAmount sdk.DecCoin, liquidity sdk.Dec) {
+	if !k.recordEnabled(ctx) {
+		return
+	}
+	k.addBackendRecord(ctx, types.SwapLiquidityInfoPrefixKey, &types.SwapLiquidityInfo{
+		TxHash:        getTxHash(ctx),
+		Address:       address.String(),
+		TokenPairName: tokenPairName,
+		Type:          liquidityType,
+		BaseAmount:    baseAmount.String(),
+		QuoteAmount:   quoteAmount.String(),
+		Liquidity:     liquidity.String(),
+		Timestamp:     ctx.BlockTime().Unix(),
+	})
+}
+
+// AddSwapTokenPairInfo records the creation of the pool for the backend
+func (k Keeper) AddSwapTokenPairInfo(ctx sdk.Context, creator sdk.AccAddress, swapTokenPair types.SwapTokenPair) {
+	if !k.recordEnabled(ctx) {
+		return
+	}
+	k.addBackendRecord(ctx, types.SwapTokenPairInfoPrefixKey, &types.SwapTokenPairInfo{
+		TokenPairName: swapTokenPair.TokenPairName(),
+		BaseToken:     swapTokenPair.BasePooledCoin.Denom,
+		QuoteToken:    swapTokenPair.QuotePooledCoin.Denom,
+		PoolToken:     swapTokenPair.PoolTokenName,
+		Curve:         swapTokenPair.Curve.String(),
+		Creator:       creator.String(),
+		TxHash:        getTxHash(ctx),
+		Timestamp:     ctx.BlockTime().Unix(),
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/cosmos/cosmos-sdk/x/supply/exported"
 	"github.com/okex/okexchain/x/ammswap/types"
+	distr "github.com/okex/okexchain/x/distribution/types"
 	staking "github.com/okex/okexchain/x/staking/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -24,6 +25,7 @@ type TestInput struct {
 	*mock.App
 
 	keySwap   *sdk.KVStoreKey
+	tkeySwap  *sdk.TransientStoreKey
 	keyToken  *sdk.KVStoreKey
 	keyLock   *sdk.KVStoreKey
 	keySupply *sdk.KVStoreKey
@@ -34,6 +36,16 @@ type TestInput struct {
 	supplyKeeper supply.Keeper
 }
 
+// mockDistrKeeper sends the protocol fees to the distribution module account
+type mockDistrKeeper struct {
+	supplyKeeper supply.Keeper
+}
+
+func (k mockDistrKeeper) FundCommunityPoolFromModule(ctx sdk.Context, amount sdk.Coins,
+	senderModule string) sdk.Error {
+	return k.supplyKeeper.SendCoinsFromModuleToModule(ctx, senderModule, distr.ModuleName, amount)
+}
+
 func regCodec(cdc *codec.Codec) {
 	types.RegisterCodec(cdc)
 	token.RegisterCodec(cdc)
@@ -53,6 +65,7 @@ func getTestInputWithBalance(t *testing.T, numGenAccs int, balance int64) (mockA
 	mockApp = &TestInput{
 		App:       mapp,
 		keySwap:   sdk.NewKVStoreKey(types.StoreKey),
+		tkeySwap:  sdk.NewTransientStoreKey(types.TStoreKey),
 		keyToken:  sdk.NewKVStoreKey(token.StoreKey),
 		keyLock:   sdk.NewKVStoreKey(token.KeyLock),
 		keySupply: sdk.NewKVStoreKey(supply.StoreKey),
@@ -68,6 +81,7 @@ func getTestInputWithBalance(t *testing.T, numGenAccs int, balance int64) (mockA
 
 	maccPerms := map[string][]string{
 		auth.FeeCollectorName: nil,
+		distr.ModuleName:      nil,
 		token.ModuleName:      {supply.Minter, supply.Burner},
 		types.ModuleName:      {supply.Minter, supply.Burner},
 	}
@@ -87,9 +101,12 @@ func getTestInputWithBalance(t *testing.T, numGenAccs int, balance int64) (mockA
 	mockApp.swapKeeper = NewKeeper(
 		mockApp.supplyKeeper,
 		mockApp.tokenKeeper,
+		mockDistrKeeper{mockApp.supplyKeeper},
 		mockApp.Cdc,
 		mockApp.keySwap,
+		mockApp.tkeySwap,
 		mockApp.ParamsKeeper.Subspace(types.DefaultParamspace),
+		true,
 	)
 
 	mockApp.QueryRouter().AddRoute(types.QuerierRoute, NewQuerier(mockApp.swapKeeper))
@@ -111,6 +128,7 @@ func getTestInputWithBalance(t *testing.T, numGenAccs int, balance int64) (mockA
 	app := mockApp
 	require.NoError(t, app.CompleteSetup(
 		app.keySwap,
+		app.tkeySwap,
 		app.keyToken,
 		app.keyLock,
 		app.keySupply,
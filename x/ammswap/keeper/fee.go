@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// SwapPooledCoins adds the sold token to and removes the bought token from the pool of the token pair.
+// The protocol part of the swap fee is sent to the community pool, and the rest is left to the
+// liquidity providers in the pool. The swap is recorded for the backend as made by the sender.
+func (k Keeper) SwapPooledCoins(ctx sdk.Context, swapTokenPair types.SwapTokenPair,
+	soldToken, boughtToken sdk.DecCoin, sender sdk.AccAddress) error {
+	k.AddSwapInfo(ctx, sender, swapTokenPair, soldToken, boughtToken)
+	params := k.GetParams(ctx)
+	fee := soldToken.Amount.Mul(params.FeeRate)
+	protocolFee := sdk.NewDecCoinFromDec(soldToken.Denom, fee.Mul(params.ProtocolFeeRate))
+	lpFee := sdk.NewDecCoinFromDec(soldToken.Denom, fee.Sub(protocolFee.Amount))
+
+	tokenPairName := swapTokenPair.TokenPairName()
+	poolFees := k.GetPoolFees(ctx, tokenPairName)
+	if protocolFee.IsPositive() {
+		protocolFees := sdk.DecCoins{protocolFee}
+		if err := k.distrKeeper.FundCommunityPoolFromModule(ctx, protocolFees, types.ModuleName); err != nil {
+			return err
+		}
+		poolFees.ProtocolFees = poolFees.ProtocolFees.Add(protocolFees)
+		soldToken = soldToken.Sub(protocolFee)
+	}
+	if lpFee.IsPositive() {
+		poolFees.LPFees = poolFees.LPFees.Add(sdk.DecCoins{lpFee})
+	}
+	k.SetPoolFees(ctx, poolFees)
+
+	k.UpdatePriceAccumulator(ctx, tokenPairName)
+	if soldToken.Denom == swapTokenPair.QuotePooledCoin.Denom {
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(soldToken)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(boughtToken)
+	} else {
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(boughtToken)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(soldToken)
+	}
+	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	return nil
+}
+
+// SwapTokens sends the sold token from the sender to the pool and the bought token from the pool to the recipient,
+// then updates the pool of the token pair. It's for the modules that swap through the pool on behalf of users
+func (k Keeper) SwapTokens(ctx sdk.Context, swapTokenPair types.SwapTokenPair, soldToken, boughtToken sdk.DecCoin,
+	sender, recipient sdk.AccAddress) sdk.Error {
+	if err := k.SendCoinsToPool(ctx, sdk.DecCoins{soldToken}, sender); err != nil {
+		return sdk.ErrInsufficientCoins(err.Error())
+	}
+	if err := k.SendCoinsFromPoolToAccount(ctx, sdk.DecCoins{boughtToken}, recipient); err != nil {
+		return sdk.ErrInsufficientCoins(err.Error())
+	}
+	if err := k.SwapPooledCoins(ctx, swapTokenPair, soldToken, boughtToken, sender); err != nil {
+		return sdk.ErrInternal(err.Error())
+	}
+	return nil
+}
+
+// GetPoolFees gets the cumulative fees of the token pair
+func (k Keeper) GetPoolFees(ctx sdk.Context, tokenPairName string) types.PoolFees {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetPoolFeesKey(tokenPairName))
+	if bz == nil {
+		return types.NewPoolFees(tokenPairName)
+	}
+	var poolFees types.PoolFees
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &poolFees)
+	return poolFees
+}
+
+// SetPoolFees sets the cumulative fees of the token pair
+func (k Keeper) SetPoolFees(ctx sdk.Context, poolFees types.PoolFees) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetPoolFeesKey(poolFees.TokenPairName), k.cdc.MustMarshalBinaryLengthPrefixed(poolFees))
+}
+
+// GetAllPoolFees gets the cumulative fees of all the token pairs
+func (k Keeper) GetAllPoolFees(ctx sdk.Context) []types.PoolFees {
+	var result []types.PoolFees
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.PoolFeesPrefixKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var poolFees types.PoolFees
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &poolFees)
+		result = append(result, poolFees)
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package keeper
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"github.com/okex/okexchain/x/common"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -17,20 +18,28 @@ import (
 type Keeper struct {
 	supplyKeeper types.SupplyKeeper
 	tokenKeeper  types.TokenKeeper
+	distrKeeper  types.DistrKeeper
 
 	storeKey   sdk.StoreKey
+	tStoreKey  sdk.StoreKey // transient store of the backend records of the block
 	cdc        *codec.Codec
 	paramSpace types.ParamSubspace
+
+	enableBackend bool // whether open backend plugin
 }
 
 // NewKeeper creates a swap keeper
-func NewKeeper(supplyKeeper types.SupplyKeeper, tokenKeeper types.TokenKeeper, cdc *codec.Codec, key sdk.StoreKey, paramspace types.ParamSubspace) Keeper {
+func NewKeeper(supplyKeeper types.SupplyKeeper, tokenKeeper types.TokenKeeper, distrKeeper types.DistrKeeper, cdc *codec.Codec, key, tkey sdk.StoreKey, paramspace types.ParamSubspace, enableBackend bool) Keeper {
 	keeper := Keeper{
 		supplyKeeper: supplyKeeper,
 		tokenKeeper:  tokenKeeper,
+		distrKeeper:  distrKeeper,
 		storeKey:     key,
+		tStoreKey:    tkey,
 		cdc:          cdc,
 		paramSpace:   paramspace.WithKeyTable(types.ParamKeyTable()),
+
+		enableBackend: enableBackend,
 	}
 	return keeper
 }
@@ -93,6 +102,32 @@ func (k Keeper) NewPoolToken(ctx sdk.Context, symbol string) {
 	k.tokenKeeper.NewToken(ctx, poolToken)
 }
 
+// GetPoolTokenSeq gets the sequence of the latest pool token named by sequence
+func (k Keeper) GetPoolTokenSeq(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.PoolTokenSeqKey)
+	if bz == nil {
+		return 0
+	}
+	var seq uint64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &seq)
+	return seq
+}
+
+// SetPoolTokenSeq sets the sequence of the latest pool token named by sequence
+func (k Keeper) SetPoolTokenSeq(ctx sdk.Context, seq uint64) {
+	ctx.KVStore(k.storeKey).Set(types.PoolTokenSeqKey, k.cdc.MustMarshalBinaryLengthPrefixed(seq))
+}
+
+// NextPoolTokenName returns the pool token name of a new token pair, the sequence is increased if the name is sequenced
+func (k Keeper) NextPoolTokenName(ctx sdk.Context, baseToken, quoteToken string) string {
+	seq := k.GetPoolTokenSeq(ctx) + 1
+	poolTokenName := types.GetPoolTokenName(baseToken, quoteToken, seq)
+	if _, ok := types.GetPoolTokenSeq(poolTokenName); ok {
+		k.SetPoolTokenSeq(ctx, seq)
+	}
+	return poolTokenName
+}
+
 // GetPoolTokenInfo gets the token's info
 func (k Keeper) GetPoolTokenInfo(ctx sdk.Context, symbol string) (tokentypes.Token, error) {
 	poolToken := k.tokenKeeper.GetTokenInfo(ctx, symbol)
@@ -127,11 +162,17 @@ func (k Keeper) BurnPoolCoinsFromUser(ctx sdk.Context, coins sdk.DecCoins, addr
 
 // SendCoinsToPool sends coins from user account to module account
 func (k Keeper) SendCoinsToPool(ctx sdk.Context, coins sdk.DecCoins, addr sdk.AccAddress) error {
+	if err := k.tokenKeeper.CheckCoinsTransferable(ctx, addr, coins); err != nil {
+		return err
+	}
 	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName, coins)
 }
 
 // SendCoinsFromPoolToAccount sends coins from module account to user account
 func (k Keeper) SendCoinsFromPoolToAccount(ctx sdk.Context, coins sdk.DecCoins, addr sdk.AccAddress) error {
+	if err := k.tokenKeeper.CheckCoinsTransferable(ctx, addr, coins); err != nil {
+		return err
+	}
 	return k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, coins)
 }
 
@@ -151,9 +192,16 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
 }
 
+// SetParamIfMissing sets the param if it doesn't exist in the store, the params added by a software upgrade
+// are set to their default values in this way at the upgrade height
+func (k Keeper) SetParamIfMissing(ctx sdk.Context, key []byte, value interface{}) {
+	if !k.paramSpace.Has(ctx, key) {
+		k.paramSpace.Set(ctx, key, value)
+	}
+}
 
-func (k Keeper) GetRedeemableAssets(ctx sdk.Context,baseAmountName string, liquidity sdk.Dec) (baseAmount, quoteAmount sdk.DecCoin, err error) {
-	swapTokenPairName := baseAmountName + "_" + common.NativeToken
+// GetRedeemableAssets returns the base and quote tokens redeemable by the liquidity of the token pair
+func (k Keeper) GetRedeemableAssets(ctx sdk.Context, swapTokenPairName string, liquidity sdk.Dec) (baseAmount, quoteAmount sdk.DecCoin, err error) {
 	swapTokenPair, err := k.GetSwapTokenPair(ctx, swapTokenPairName)
 	if err != nil {
 		return baseAmount, quoteAmount, err
@@ -170,24 +218,83 @@ func (k Keeper) GetRedeemableAssets(ctx sdk.Context,baseAmountName string, liqui
 	return baseAmount, quoteAmount, nil
 }
 
-//CalculateTokenToBuy calculates the amount to buy
-func CalculateTokenToBuy(swapTokenPair types.SwapTokenPair, sellToken sdk.DecCoin, buyTokenDenom string, params types.Params) sdk.DecCoin {
+// CalculateTokenToBuy calculates the amount to buy on the curve of the pool, an error is returned if the curve
+// rejects the amount to sell
+func CalculateTokenToBuy(swapTokenPair types.SwapTokenPair, sellToken sdk.DecCoin, buyTokenDenom string, params types.Params) (sdk.DecCoin, error) {
 	var inputReserve, outputReserve sdk.Dec
-	if sellToken.Denom == sdk.DefaultBondDenom {
+	inputIsBase := sellToken.Denom != swapTokenPair.QuotePooledCoin.Denom
+	if !inputIsBase {
 		inputReserve = swapTokenPair.QuotePooledCoin.Amount
 		outputReserve = swapTokenPair.BasePooledCoin.Amount
 	} else {
 		inputReserve = swapTokenPair.BasePooledCoin.Amount
 		outputReserve = swapTokenPair.QuotePooledCoin.Amount
 	}
-	tokenBuyAmt := GetInputPrice(sellToken.Amount, inputReserve, outputReserve, params.FeeRate)
-	tokenBuy := sdk.NewDecCoinFromDec(buyTokenDenom, tokenBuyAmt)
-
-	return tokenBuy
+	var tokenBuyAmt sdk.Dec
+	var err error
+	switch curve := swapTokenPair.Curve; curve.GetType() {
+	case types.CurveWeighted:
+		tokenBuyAmt, err = types.GetWeightedInputPrice(curve, inputIsBase, sellToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	case types.CurveStableSwap:
+		tokenBuyAmt, err = types.GetStableSwapInputPrice(curve, sellToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	default:
+		tokenBuyAmt = GetInputPrice(sellToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	}
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	return sdk.NewDecCoinFromDec(buyTokenDenom, tokenBuyAmt), nil
 }
 
+// GetInputPrice returns the output amount of the constant product pool for the input amount
 func GetInputPrice(inputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sdk.Dec {
 	inputAmountWithFee := inputAmount.MulTruncate(sdk.OneDec().Sub(feeRate).MulTruncate(sdk.NewDec(1000)))
 	denominator := inputReserve.MulTruncate(sdk.NewDec(1000)).Add(inputAmountWithFee)
 	return common.MulAndQuo(inputAmountWithFee, outputReserve, denominator)
 }
+
+// CalculateTokenToSell calculates the amount to sell for buying the token on the curve of the pool, an error is
+// returned if the pool doesn't have enough token to buy or the curve rejects the amount
+func CalculateTokenToSell(swapTokenPair types.SwapTokenPair, buyToken sdk.DecCoin, sellTokenDenom string, params types.Params) (sdk.DecCoin, error) {
+	var inputReserve, outputReserve sdk.Dec
+	inputIsBase := buyToken.Denom == swapTokenPair.QuotePooledCoin.Denom
+	if inputIsBase {
+		inputReserve = swapTokenPair.BasePooledCoin.Amount
+		outputReserve = swapTokenPair.QuotePooledCoin.Amount
+	} else {
+		inputReserve = swapTokenPair.QuotePooledCoin.Amount
+		outputReserve = swapTokenPair.BasePooledCoin.Amount
+	}
+	if buyToken.Amount.GTE(outputReserve) {
+		return sdk.DecCoin{}, fmt.Errorf("insufficient %s in the pool %s", buyToken.Denom, swapTokenPair.TokenPairName())
+	}
+	var tokenSellAmt sdk.Dec
+	var err error
+	switch curve := swapTokenPair.Curve; curve.GetType() {
+	case types.CurveWeighted:
+		tokenSellAmt, err = types.GetWeightedOutputPrice(curve, inputIsBase, buyToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	case types.CurveStableSwap:
+		tokenSellAmt, err = types.GetStableSwapOutputPrice(curve, buyToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	default:
+		tokenSellAmt = GetOutputPrice(buyToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	}
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	return sdk.NewDecCoinFromDec(sellTokenDenom, tokenSellAmt), nil
+}
+
+// GetOutputPrice returns the input amount for buying the output amount, which is the inverse of GetInputPrice and
+// rounded up so that the pool never loses. The output amount must be less than the output reserve.
+func GetOutputPrice(outputAmount, inputReserve, outputReserve, feeRate sdk.Dec) sdk.Dec {
+	// inputAmount = inputReserve * outputAmount / ((outputReserve - outputAmount) * (1 - feeRate)),
+	// calculated on the integers of the decimals, which are all scaled by 10^Precision
+	numerator := new(big.Int).Mul(inputReserve.Int, outputAmount.Int)
+	numerator.Mul(numerator, sdk.OneDec().Int)
+	denominator := new(big.Int).Mul(outputReserve.Sub(outputAmount).Int, sdk.OneDec().Sub(feeRate).Int)
+	quo, rem := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if rem.Sign() > 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return sdk.NewDecFromBigIntWithPrec(quo, sdk.Precision)
+}
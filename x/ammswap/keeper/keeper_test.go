@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/common"
+	token "github.com/okex/okexchain/x/token/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -40,3 +41,23 @@ func TestKeeper_GetPoolTokenInfo(t *testing.T) {
 	balance := mapp.bankKeeper.GetCoins(ctx, sdk.AccAddress(addrTest))
 	require.NotNil(t, balance)
 }
+
+func TestKeeper_SendCoinsFrozen(t *testing.T) {
+	mapp, addrKeysSlice := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	addr := addrKeysSlice[0].Address
+
+	mapp.tokenKeeper.NewToken(ctx, token.Token{Symbol: common.TestToken, Owner: addr, Freezable: true})
+	coins := sdk.NewDecCoinsFromDec(common.TestToken, sdk.NewDec(1))
+	require.Nil(t, keeper.SendCoinsToPool(ctx, coins, addr))
+
+	// the swaps of the frozen account are rejected
+	mapp.tokenKeeper.SetFrozen(ctx, common.TestToken, addr, true)
+	require.NotNil(t, keeper.SendCoinsToPool(ctx, coins, addr))
+	require.NotNil(t, keeper.SendCoinsFromPoolToAccount(ctx, coins, addr))
+	mapp.tokenKeeper.SetFrozen(ctx, common.TestToken, addr, false)
+	require.Nil(t, keeper.SendCoinsFromPoolToAccount(ctx, coins, addr))
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"strings"
+
 	"github.com/okex/okexchain/x/common"
 	abci "github.com/tendermint/tendermint/abci/types"
 
@@ -22,6 +24,14 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryRedeemableAssets(ctx, path[1:], req, k)
 		case types.QueryBuyAmount:
 			return queryBuyAmount(ctx, path[1:], req, k)
+		case types.QuerySellAmount:
+			return querySellAmount(ctx, path[1:], req, k)
+		case types.QuerySwapRoute:
+			return querySwapRoute(ctx, path[1:], req, k)
+		case types.QueryPoolFees:
+			return queryPoolFees(ctx, path[1:], req, k)
+		case types.QueryTWAP:
+			return queryTWAP(ctx, path[1:], req, k)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown swap query endpoint")
 		}
@@ -32,8 +42,7 @@ func NewQuerier(k Keeper) sdk.Querier {
 func querySwapTokenPair(
 	ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper,
 ) (res []byte, err sdk.Error) {
-	tokenPairName := path[0] + "_" + common.NativeToken
-	tokenPair, errSwapTokenPair := keeper.GetSwapTokenPair(ctx, tokenPairName)
+	tokenPair, errSwapTokenPair := keeper.GetSwapTokenPair(ctx, parseSwapTokenPairName(path[0]))
 	if errSwapTokenPair != nil {
 		return nil, sdk.ErrUnknownRequest(errSwapTokenPair.Error())
 	}
@@ -41,6 +50,40 @@ func querySwapTokenPair(
 	return bz, nil
 }
 
+// queryPoolFees returns the cumulative swap fees earned by the pool of the token pair
+func queryPoolFees(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	tokenPairName := parseSwapTokenPairName(path[0])
+	if _, err := keeper.GetSwapTokenPair(ctx, tokenPairName); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(keeper.GetPoolFees(ctx, tokenPairName))
+	return bz, nil
+}
+
+// queryTWAP returns the time-weighted average prices of the token pair between two heights or over a window
+func queryTWAP(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QueryTWAPParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	tokenPairName := parseSwapTokenPairName(queryParams.TokenPairName)
+	endHeight := queryParams.EndHeight
+	if endHeight == 0 {
+		endHeight = ctx.BlockHeight()
+	}
+	startHeight := queryParams.StartHeight
+	if queryParams.Window > 0 {
+		startHeight = endHeight - queryParams.Window
+	}
+
+	twap, err := keeper.GetTWAP(ctx, tokenPairName, startHeight, endHeight)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(twap)
+	return bz, nil
+}
+
 // nolint
 func queryBuyAmount(
 	ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper,
@@ -53,20 +96,15 @@ func queryBuyAmount(
 
 	params := keeper.GetParams(ctx)
 	var buyAmount sdk.Dec
-	if (queryParams.SoldToken.Denom == sdk.DefaultBondDenom) {
-		tokenPairName := queryParams.TokenToBuy + "_" + queryParams.SoldToken.Denom
-		tokenPair, err := keeper.GetSwapTokenPair(ctx, tokenPairName)
+	tokenPairName := types.GetSwapTokenPairName(queryParams.SoldToken.Denom, queryParams.TokenToBuy)
+	if tokenPair, err := keeper.GetSwapTokenPair(ctx, tokenPairName); err == nil {
+		buyToken, err := CalculateTokenToBuy(tokenPair, queryParams.SoldToken, queryParams.TokenToBuy, params)
 		if err != nil {
-			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			return nil, sdk.ErrUnknownRequest(err.Error())
 		}
-		buyAmount = CalculateTokenToBuy(tokenPair, queryParams.SoldToken, queryParams.TokenToBuy, params).Amount
-	} else if (queryParams.TokenToBuy == sdk.DefaultBondDenom) {
-		tokenPairName := queryParams.SoldToken.Denom + "_" + queryParams.TokenToBuy
-		tokenPair, err := keeper.GetSwapTokenPair(ctx, tokenPairName)
-		if err != nil {
-			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
-		}
-		buyAmount = CalculateTokenToBuy(tokenPair, queryParams.SoldToken, queryParams.TokenToBuy, params).Amount
+		buyAmount = buyToken.Amount
+	} else if queryParams.SoldToken.Denom == sdk.DefaultBondDenom || queryParams.TokenToBuy == sdk.DefaultBondDenom {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
 	} else {
 		tokenPairName1 := queryParams.SoldToken.Denom + "_" + sdk.DefaultBondDenom
 		tokenPair1, err := keeper.GetSwapTokenPair(ctx, tokenPairName1)
@@ -80,8 +118,15 @@ func queryBuyAmount(
 			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
 		}
 
-		nativeToken := CalculateTokenToBuy(tokenPair1, queryParams.SoldToken, sdk.DefaultBondDenom, params)
-		buyAmount = CalculateTokenToBuy(tokenPair2, nativeToken, queryParams.TokenToBuy, params).Amount
+		nativeToken, err := CalculateTokenToBuy(tokenPair1, queryParams.SoldToken, sdk.DefaultBondDenom, params)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(err.Error())
+		}
+		buyToken, err := CalculateTokenToBuy(tokenPair2, nativeToken, queryParams.TokenToBuy, params)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(err.Error())
+		}
+		buyAmount = buyToken.Amount
 	}
 
 	bz := keeper.cdc.MustMarshalJSON(buyAmount)
@@ -89,6 +134,43 @@ func queryBuyAmount(
 	return bz, nil
 }
 
+// nolint
+func querySellAmount(
+	ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper,
+) ([]byte, sdk.Error) {
+	var queryParams types.QuerySellAmountParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	route := keeper.GetDefaultSwapRoute(ctx, queryParams.TokenToSell, queryParams.BoughtToken.Denom)
+	amounts, err := keeper.GetSwapRouteSellAmounts(ctx, route, queryParams.BoughtToken, queryParams.TokenToSell)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(amounts[0].Amount)
+	return bz, nil
+}
+
+// nolint
+func querySwapRoute(
+	ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper,
+) ([]byte, sdk.Error) {
+	var queryParams types.QueryBuyAmountParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	route, amounts, err := keeper.GetBestSwapRoute(ctx, queryParams.SoldToken, queryParams.TokenToBuy)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	bz := keeper.cdc.MustMarshalJSON(types.SwapRoute{Route: route, Amounts: amounts})
+	return bz, nil
+}
+
 func queryParams(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
 	return keeper.cdc.MustMarshalJSON(keeper.GetParams(ctx)), nil
 }
@@ -103,17 +185,27 @@ func querySwapTokenPairs(ctx sdk.Context, path []string, req abci.RequestQuery,
 // nolint
 func queryRedeemableAssets(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
 	err sdk.Error) {
-	baseTokenName := path[0]
+	tokenPairName := parseSwapTokenPairName(path[0])
 	liquidity, decErr := sdk.NewDecFromStr(path[1])
 	if decErr != nil {
 		return nil, sdk.ErrUnknownRequest("invalid params: liquidity")
 	}
 	var tokenList sdk.DecCoins
-	baseToken, quoteToken, redeemErr := keeper.GetRedeemableAssets(ctx, baseTokenName, liquidity)
+	baseToken, quoteToken, redeemErr := keeper.GetRedeemableAssets(ctx, tokenPairName, liquidity)
 	if redeemErr != nil {
 		return nil, sdk.ErrUnknownRequest(redeemErr.Error())
 	}
 	tokenList = append(tokenList, baseToken, quoteToken)
 	bz := keeper.cdc.MustMarshalJSON(tokenList)
 	return bz, nil
-}
\ No newline at end of file
+}
+
+// parseSwapTokenPairName returns the canonical name of the token pair given by "base_quote" in any order,
+// or by the base token only, which is quoted in the native token
+func parseSwapTokenPairName(name string) string {
+	tokens := strings.Split(name, "_")
+	if len(tokens) == 2 {
+		return types.GetSwapTokenPairName(tokens[0], tokens[1])
+	}
+	return name + "_" + common.NativeToken
+}
@@ -51,6 +51,64 @@ func TestNewQuerier(t *testing.T) {
 	require.Nil(t, tokenpair)
 }
 
+func TestQuerySwapTokenPairWithTokenQuote(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	keeper.SetParams(ctx, types.DefaultParams())
+	querier := NewQuerier(keeper)
+
+	swapTokenPair := types.SwapTokenPair{
+		BasePooledCoin:  sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(100)),
+		QuotePooledCoin: sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(100)),
+		PoolTokenName:   types.GetPoolTokenName(types.TestBasePooledToken, types.TestBasePooledToken2, 1),
+		Curve:           types.DefaultPoolCurve(),
+	}
+	keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+
+	// the token pair name is accepted in any order
+	for _, name := range []string{swapTokenPair.TokenPairName(),
+		types.TestBasePooledToken2 + "_" + types.TestBasePooledToken} {
+		bz, err := querier(ctx, []string{types.QuerySwapTokenPair, name}, abci.RequestQuery{})
+		require.Nil(t, err)
+		result := types.SwapTokenPair{}
+		keeper.cdc.MustUnmarshalJSON(bz, &result)
+		require.EqualValues(t, swapTokenPair, result)
+	}
+
+	// buy in the pool without the native token
+	params := types.QueryBuyAmountParams{
+		SoldToken:  sdk.NewDecCoinFromDec(types.TestBasePooledToken2, sdk.NewDec(10)),
+		TokenToBuy: types.TestBasePooledToken,
+	}
+	bz, err := querier(ctx, []string{types.QueryBuyAmount}, abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(params)})
+	require.Nil(t, err)
+	var buyAmount sdk.Dec
+	keeper.cdc.MustUnmarshalJSON(bz, &buyAmount)
+	expectedBuyToken, calcErr := CalculateTokenToBuy(swapTokenPair, params.SoldToken, params.TokenToBuy, keeper.GetParams(ctx))
+	require.Nil(t, calcErr)
+	require.Equal(t, expectedBuyToken.Amount, buyAmount)
+	require.True(t, buyAmount.IsPositive())
+
+	// sell for buying exactly in the pool without the native token
+	sellParams := types.QuerySellAmountParams{
+		BoughtToken: sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10)),
+		TokenToSell: types.TestBasePooledToken2,
+	}
+	bz, err = querier(ctx, []string{types.QuerySellAmount},
+		abci.RequestQuery{Data: keeper.cdc.MustMarshalJSON(sellParams)})
+	require.Nil(t, err)
+	var sellAmount sdk.Dec
+	keeper.cdc.MustUnmarshalJSON(bz, &sellAmount)
+	expectedSellToken, sdkErr := CalculateTokenToSell(swapTokenPair, sellParams.BoughtToken, sellParams.TokenToSell,
+		keeper.GetParams(ctx))
+	require.Nil(t, sdkErr)
+	require.Equal(t, expectedSellToken.Amount, sellAmount)
+	require.True(t, sellAmount.GT(sdk.NewDec(10)))
+}
+
 func initTokenPair(token string) types.SwapTokenPair {
 	poolName := types.PoolTokenPrefix + token
 	baseToken := sdk.NewDecCoinFromDec(token, sdk.ZeroDec())
@@ -0,0 +1,129 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/ammswap/types"
+	"github.com/okex/okexchain/x/common"
+)
+
+// GetDefaultSwapRoute returns the route through the pool of the two tokens if it exists,
+// or through the pools of them quoted in the native token otherwise
+func (k Keeper) GetDefaultSwapRoute(ctx sdk.Context, soldTokenDenom, boughtTokenDenom string) []string {
+	tokenPairName := types.GetSwapTokenPairName(soldTokenDenom, boughtTokenDenom)
+	if _, err := k.GetSwapTokenPair(ctx, tokenPairName); err == nil ||
+		soldTokenDenom == common.NativeToken || boughtTokenDenom == common.NativeToken {
+		return []string{tokenPairName}
+	}
+	return []string{
+		types.GetSwapTokenPairName(soldTokenDenom, common.NativeToken),
+		types.GetSwapTokenPairName(boughtTokenDenom, common.NativeToken),
+	}
+}
+
+// GetSwapRouteAmounts returns the amount of token bought by every hop of the route
+func (k Keeper) GetSwapRouteAmounts(ctx sdk.Context, route []string, sellToken sdk.DecCoin) ([]sdk.DecCoin, error) {
+	tokens, err := types.GetSwapRouteTokens(route, sellToken.Denom)
+	if err != nil {
+		return nil, err
+	}
+	params := k.GetParams(ctx)
+	amounts := make([]sdk.DecCoin, 0, len(route))
+	for i, tokenPairName := range route {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return nil, err
+		}
+		if sellToken, err = CalculateTokenToBuy(swapTokenPair, sellToken, tokens[i+1], params); err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, sellToken)
+	}
+	return amounts, nil
+}
+
+// GetSwapRouteSellAmounts returns the amount of token sold by every hop of the route for buying the token at last
+func (k Keeper) GetSwapRouteSellAmounts(ctx sdk.Context, route []string, buyToken sdk.DecCoin,
+	sellTokenDenom string) ([]sdk.DecCoin, error) {
+	tokens, err := types.GetSwapRouteTokens(route, sellTokenDenom)
+	if err != nil {
+		return nil, err
+	}
+	params := k.GetParams(ctx)
+	amounts := make([]sdk.DecCoin, len(route))
+	for i := len(route) - 1; i >= 0; i-- {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, route[i])
+		if err != nil {
+			return nil, err
+		}
+		if buyToken, err = CalculateTokenToSell(swapTokenPair, buyToken, tokens[i], params); err != nil {
+			return nil, err
+		}
+		amounts[i] = buyToken
+	}
+	return amounts, nil
+}
+
+// GetBestSwapRoute returns the route of at most MaxSwapHops token pairs which buys the most token,
+// and the amount of token bought by every hop of it
+func (k Keeper) GetBestSwapRoute(ctx sdk.Context, sellToken sdk.DecCoin, buyTokenDenom string) ([]string,
+	[]sdk.DecCoin, error) {
+	// token pairs with liquidity connected to every token
+	pairsMap := make(map[string][]types.SwapTokenPair)
+	for _, swapTokenPair := range k.GetSwapTokenPairs(ctx) {
+		if !swapTokenPair.BasePooledCoin.IsPositive() || !swapTokenPair.QuotePooledCoin.IsPositive() {
+			continue
+		}
+		baseDenom, quoteDenom := swapTokenPair.BasePooledCoin.Denom, swapTokenPair.QuotePooledCoin.Denom
+		pairsMap[baseDenom] = append(pairsMap[baseDenom], swapTokenPair)
+		pairsMap[quoteDenom] = append(pairsMap[quoteDenom], swapTokenPair)
+	}
+
+	params := k.GetParams(ctx)
+	var bestRoute []string
+	var bestAmounts []sdk.DecCoin
+	passed := map[string]bool{sellToken.Denom: true}
+	var route []string
+	var amounts []sdk.DecCoin
+
+	var search func(token sdk.DecCoin)
+	search = func(token sdk.DecCoin) {
+		if token.Denom == buyTokenDenom {
+			if bestAmounts == nil || token.Amount.GT(bestAmounts[len(bestAmounts)-1].Amount) {
+				bestRoute = append([]string{}, route...)
+				bestAmounts = append([]sdk.DecCoin{}, amounts...)
+			}
+			return
+		}
+		if len(route) == types.MaxSwapHops {
+			return
+		}
+		for _, swapTokenPair := range pairsMap[token.Denom] {
+			nextDenom := swapTokenPair.BasePooledCoin.Denom
+			if nextDenom == token.Denom {
+				nextDenom = swapTokenPair.QuotePooledCoin.Denom
+			}
+			if passed[nextDenom] {
+				continue
+			}
+			nextToken, err := CalculateTokenToBuy(swapTokenPair, token, nextDenom, params)
+			if err != nil {
+				continue
+			}
+			passed[nextDenom] = true
+			route = append(route, swapTokenPair.TokenPairName())
+			amounts = append(amounts, nextToken)
+			search(nextToken)
+			passed[nextDenom] = false
+			route = route[:len(route)-1]
+			amounts = amounts[:len(amounts)-1]
+		}
+	}
+	search(sellToken)
+
+	if bestRoute == nil {
+		return nil, nil, fmt.Errorf("no swap route from %s to %s", sellToken.Denom, buyTokenDenom)
+	}
+	return bestRoute, bestAmounts, nil
+}
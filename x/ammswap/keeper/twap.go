@@ -0,0 +1,138 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// UpdatePriceAccumulator accumulates the price of the token pair since the last observation and records the
+// observation at the current height. It's called before the first change of the pool in the block, so only the
+// pools changed have observations and the prices between two observations are the ones left by the last change.
+func (k Keeper) UpdatePriceAccumulator(ctx sdk.Context, tokenPairName string) {
+	height, blockTime := ctx.BlockHeight(), ctx.BlockTime().Unix()
+	accumulator, found := k.GetPriceAccumulator(ctx, tokenPairName)
+	if found && accumulator.Height == height {
+		return
+	}
+	swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+	if err != nil {
+		return
+	}
+	if found {
+		accumulator = accumulator.Accumulate(swapTokenPair, height, blockTime)
+	} else {
+		accumulator = types.NewPriceObservation(tokenPairName, height, blockTime)
+	}
+	k.SetPriceAccumulator(ctx, accumulator)
+	k.SetPriceObservation(ctx, accumulator)
+	k.prunePriceObservations(ctx, tokenPairName, height-k.GetParams(ctx).PriceObservationRetention)
+}
+
+// prunePriceObservations deletes the price observations of the token pair before the height, except the latest
+// one which is still needed by the TWAP starting at the height
+func (k Keeper) prunePriceObservations(ctx sdk.Context, tokenPairName string, height int64) {
+	if height <= 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.ReverseIterator(types.GetPriceObservationsPrefix(tokenPairName),
+		types.GetPriceObservationKey(tokenPairName, height))
+	defer iterator.Close()
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	for i := 1; i < len(keys); i++ {
+		store.Delete(keys[i])
+	}
+}
+
+// GetPriceAccumulator gets the latest cumulative prices of the token pair
+func (k Keeper) GetPriceAccumulator(ctx sdk.Context, tokenPairName string) (types.PriceObservation, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPriceAccumulatorKey(tokenPairName))
+	if bz == nil {
+		return types.PriceObservation{}, false
+	}
+	var accumulator types.PriceObservation
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &accumulator)
+	return accumulator, true
+}
+
+// SetPriceAccumulator sets the latest cumulative prices of the token pair
+func (k Keeper) SetPriceAccumulator(ctx sdk.Context, accumulator types.PriceObservation) {
+	ctx.KVStore(k.storeKey).Set(types.GetPriceAccumulatorKey(accumulator.TokenPairName),
+		k.cdc.MustMarshalBinaryLengthPrefixed(accumulator))
+}
+
+// GetAllPriceAccumulators gets the latest cumulative prices of all the token pairs
+func (k Keeper) GetAllPriceAccumulators(ctx sdk.Context) []types.PriceObservation {
+	var result []types.PriceObservation
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.PriceAccumulatorPrefixKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var accumulator types.PriceObservation
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &accumulator)
+		result = append(result, accumulator)
+	}
+	return result
+}
+
+// GetPriceObservation gets the cumulative prices of the token pair at the height
+func (k Keeper) GetPriceObservation(ctx sdk.Context, tokenPairName string, height int64) (types.PriceObservation,
+	bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPriceObservationKey(tokenPairName, height))
+	if bz == nil {
+		return types.PriceObservation{}, false
+	}
+	var observation types.PriceObservation
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &observation)
+	return observation, true
+}
+
+// SetPriceObservation sets the cumulative prices of the token pair at the height of the observation
+func (k Keeper) SetPriceObservation(ctx sdk.Context, observation types.PriceObservation) {
+	ctx.KVStore(k.storeKey).Set(types.GetPriceObservationKey(observation.TokenPairName, observation.Height),
+		k.cdc.MustMarshalBinaryLengthPrefixed(observation))
+}
+
+// getLatestPriceObservation gets the latest cumulative prices of the token pair at or before the height
+func (k Keeper) getLatestPriceObservation(ctx sdk.Context, tokenPairName string, height int64) (
+	types.PriceObservation, bool) {
+	iterator := ctx.KVStore(k.storeKey).ReverseIterator(types.GetPriceObservationsPrefix(tokenPairName),
+		types.GetPriceObservationKey(tokenPairName, height+1))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return types.PriceObservation{}, false
+	}
+	var observation types.PriceObservation
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &observation)
+	return observation, true
+}
+
+// GetTWAP returns the time-weighted average prices of the token pair between the two heights. The pools only have
+// observations at the heights they changed, so the latest observations at or before the heights are used, and the
+// prices since the last change are accumulated to the current block if the end height isn't before it.
+func (k Keeper) GetTWAP(ctx sdk.Context, tokenPairName string, startHeight, endHeight int64) (types.TWAP, error) {
+	if startHeight >= endHeight {
+		return types.TWAP{}, fmt.Errorf("start height %d should be less than end height %d", startHeight, endHeight)
+	}
+	start, found := k.getLatestPriceObservation(ctx, tokenPairName, startHeight)
+	if !found {
+		return types.TWAP{}, fmt.Errorf("no price observation of %s at or before height %d", tokenPairName,
+			startHeight)
+	}
+	var end types.PriceObservation
+	if endHeight >= ctx.BlockHeight() {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return types.TWAP{}, err
+		}
+		end, _ = k.GetPriceAccumulator(ctx, tokenPairName)
+		end = end.Accumulate(swapTokenPair, ctx.BlockHeight(), ctx.BlockTime().Unix())
+	} else {
+		end, _ = k.getLatestPriceObservation(ctx, tokenPairName, endHeight)
+	}
+	return types.NewTWAP(start, end)
+}
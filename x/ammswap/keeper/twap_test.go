@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/ammswap/types"
+	"github.com/okex/okexchain/x/common"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestTWAP(t *testing.T) {
+	mapp, _ := GetTestInput(t, 1)
+	keeper := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	blockTime := time.Now()
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(blockTime)
+	params := types.DefaultParams()
+	params.PriceObservationRetention = 3
+	keeper.SetParams(ctx, params)
+	querier := NewQuerier(keeper)
+
+	tokenPairName := common.TestToken + "_" + common.NativeToken
+	swapTokenPair := initTokenPair(common.TestToken)
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(100)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(200)
+	keeper.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+
+	// the price of base token is 2 from height 10 to 11, and 0.5 after the pool is changed at height 11
+	keeper.UpdatePriceAccumulator(ctx, tokenPairName)
+	ctx = ctx.WithBlockHeight(11).WithBlockTime(blockTime.Add(10 * time.Second))
+	keeper.UpdatePriceAccumulator(ctx, tokenPairName)
+	swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(200), sdk.NewDec(100)
+	keeper.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+	// only the first change of the pool in the block is observed
+	keeper.UpdatePriceAccumulator(ctx, tokenPairName)
+	observation, found := keeper.GetPriceObservation(ctx, tokenPairName, 11)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(20), observation.BasePriceCumulative)
+	// the pool isn't changed at height 12
+	ctx = ctx.WithBlockHeight(12).WithBlockTime(blockTime.Add(20 * time.Second))
+	_, found = keeper.GetPriceObservation(ctx, tokenPairName, 12)
+	require.False(t, found)
+
+	twap, err := keeper.GetTWAP(ctx, tokenPairName, 10, 11)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(2), twap.BasePrice)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), twap.QuotePrice)
+	twap, err = keeper.GetTWAP(ctx, tokenPairName, 10, 12)
+	require.Nil(t, err)
+	require.Equal(t, sdk.MustNewDecFromStr("1.25"), twap.BasePrice)
+	require.Equal(t, sdk.MustNewDecFromStr("1.25"), twap.QuotePrice)
+	_, err = keeper.GetTWAP(ctx, tokenPairName, 12, 10)
+	require.NotNil(t, err)
+	_, err = keeper.GetTWAP(ctx, tokenPairName, 9, 12)
+	require.NotNil(t, err)
+
+	// query over the window before the current height
+	bz := keeper.cdc.MustMarshalJSON(types.QueryTWAPParams{TokenPairName: common.TestToken, Window: 1})
+	res, sdkErr := querier(ctx, []string{types.QueryTWAP}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	keeper.cdc.MustUnmarshalJSON(res, &twap)
+	require.Equal(t, int64(11), twap.StartHeight)
+	require.Equal(t, int64(12), twap.EndHeight)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), twap.BasePrice)
+
+	bz = keeper.cdc.MustMarshalJSON(types.QueryTWAPParams{TokenPairName: "abc", Window: 1})
+	_, sdkErr = querier(ctx, []string{types.QueryTWAP}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+
+	// the observations beyond the retention are pruned except the latest one before it
+	ctx = ctx.WithBlockHeight(15).WithBlockTime(blockTime.Add(40 * time.Second))
+	keeper.UpdatePriceAccumulator(ctx, tokenPairName)
+	for height, found := range map[int64]bool{10: false, 11: true, 15: true} {
+		_, ok := keeper.GetPriceObservation(ctx, tokenPairName, height)
+		require.Equal(t, found, ok, height)
+	}
+	twap, err = keeper.GetTWAP(ctx, tokenPairName, 12, 15)
+	require.Nil(t, err)
+	require.Equal(t, int64(11), twap.StartHeight)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5"), twap.BasePrice)
+}
@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// CalculateZapInSwapAmount calculates the part of the sold token to swap for the paired token, so that the rest of
+// the sold token and the bought token match the ratio of the pool after the swap. The result is the largest part
+// with which the paired token limits the deposit, found by the binary search on the curve of the pool.
+func CalculateZapInSwapAmount(swapTokenPair types.SwapTokenPair, soldToken sdk.DecCoin, pairedTokenDenom string,
+	params types.Params) (swapToken, boughtToken sdk.DecCoin, err error) {
+	var inputReserve, outputReserve sdk.Dec
+	if soldToken.Denom == swapTokenPair.QuotePooledCoin.Denom {
+		inputReserve, outputReserve = swapTokenPair.QuotePooledCoin.Amount, swapTokenPair.BasePooledCoin.Amount
+	} else {
+		inputReserve, outputReserve = swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount
+	}
+	// the protocol fee leaves the pool with the swap
+	keptRate := sdk.OneDec().Sub(params.FeeRate.Mul(params.ProtocolFeeRate))
+
+	isBalanced := func(swapAmount sdk.Dec) bool {
+		bought, err := CalculateTokenToBuy(swapTokenPair, sdk.NewDecCoinFromDec(soldToken.Denom, swapAmount),
+			pairedTokenDenom, params)
+		if err != nil || bought.Amount.GTE(outputReserve) {
+			return false
+		}
+		rest := soldToken.Amount.Sub(swapAmount)
+		newInputReserve := inputReserve.Add(swapAmount.Mul(keptRate))
+		newOutputReserve := outputReserve.Sub(bought.Amount)
+		return rest.Mul(newOutputReserve).GTE(bought.Amount.Mul(newInputReserve))
+	}
+
+	low, high := big.NewInt(0), new(big.Int).Set(soldToken.Amount.Int)
+	for low.Cmp(high) < 0 {
+		mid := new(big.Int).Add(low, high)
+		mid.Add(mid, big.NewInt(1)).Rsh(mid, 1)
+		if isBalanced(sdk.NewDecFromBigIntWithPrec(mid, sdk.Precision)) {
+			low = mid
+		} else {
+			high = mid.Sub(mid, big.NewInt(1))
+		}
+	}
+
+	swapToken = sdk.NewDecCoinFromDec(soldToken.Denom, sdk.NewDecFromBigIntWithPrec(low, sdk.Precision))
+	boughtToken, err = CalculateTokenToBuy(swapTokenPair, swapToken, pairedTokenDenom, params)
+	return swapToken, boughtToken, err
+}
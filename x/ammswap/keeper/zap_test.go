@@ -0,0 +1,47 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateZapInSwapAmount(t *testing.T) {
+	params := types.DefaultParams()
+	xxb, okt := types.TestBasePooledToken, types.TestQuotePooledToken
+	curves := []types.PoolCurve{
+		types.DefaultPoolCurve(),
+		types.NewPoolCurve(types.CurveWeighted, sdk.NewDecWithPrec(8, 1), 0),
+		types.NewPoolCurve(types.CurveStableSwap, sdk.ZeroDec(), 100),
+	}
+	for _, curve := range curves {
+		swapTokenPair := types.SwapTokenPair{
+			BasePooledCoin:  sdk.NewDecCoinFromDec(xxb, sdk.NewDec(10000)),
+			QuotePooledCoin: sdk.NewDecCoinFromDec(okt, sdk.NewDec(40000)),
+			Curve:           curve,
+		}
+		for _, soldToken := range []sdk.DecCoin{sdk.NewDecCoinFromDec(okt, sdk.NewDec(1000)),
+			sdk.NewDecCoinFromDec(xxb, sdk.NewDec(1000))} {
+			pairedTokenDenom := xxb
+			if soldToken.Denom == xxb {
+				pairedTokenDenom = okt
+			}
+			swapToken, boughtToken, err := CalculateZapInSwapAmount(swapTokenPair, soldToken, pairedTokenDenom, params)
+			require.Nil(t, err)
+			require.True(t, swapToken.IsPositive() && swapToken.Amount.LT(soldToken.Amount), curve.String())
+
+			// the rest of the sold token and the bought token are at the ratio of the pool after the swap
+			inputReserve, outputReserve := swapTokenPair.QuotePooledCoin.Amount, swapTokenPair.BasePooledCoin.Amount
+			if soldToken.Denom == xxb {
+				inputReserve, outputReserve = outputReserve, inputReserve
+			}
+			inputReserve = inputReserve.Add(swapToken.Amount)
+			outputReserve = outputReserve.Sub(boughtToken.Amount)
+			restRatio := soldToken.Amount.Sub(swapToken.Amount).Quo(boughtToken.Amount)
+			poolRatio := inputReserve.Quo(outputReserve)
+			require.True(t, restRatio.Sub(poolRatio).Abs().LT(sdk.NewDecWithPrec(1, 6)), curve.String())
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/cosmos/cosmos-sdk/x/supply/exported"
 	"github.com/okex/okexchain/x/ammswap/types"
+	distr "github.com/okex/okexchain/x/distribution/types"
 	staking "github.com/okex/okexchain/x/staking/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -24,6 +25,7 @@ type MockApp struct {
 	*mock.App
 
 	keySwap   *sdk.KVStoreKey
+	tkeySwap  *sdk.TransientStoreKey
 	keyToken  *sdk.KVStoreKey
 	keyLock   *sdk.KVStoreKey
 	keySupply *sdk.KVStoreKey
@@ -34,6 +36,16 @@ type MockApp struct {
 	supplyKeeper supply.Keeper
 }
 
+// mockDistrKeeper sends the protocol fees to the distribution module account
+type mockDistrKeeper struct {
+	supplyKeeper supply.Keeper
+}
+
+func (k mockDistrKeeper) FundCommunityPoolFromModule(ctx sdk.Context, amount sdk.Coins,
+	senderModule string) sdk.Error {
+	return k.supplyKeeper.SendCoinsFromModuleToModule(ctx, senderModule, distr.ModuleName, amount)
+}
+
 func registerCodec(cdc *codec.Codec) {
 	RegisterCodec(cdc)
 	token.RegisterCodec(cdc)
@@ -44,8 +56,13 @@ func getMockApp(t *testing.T, numGenAccs int) (mockApp *MockApp, addrKeysSlice m
 	return getMockAppWithBalance(t, numGenAccs, 100)
 }
 
-// initialize the mock application for this module
 func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp *MockApp,
+	addrKeysSlice mock.AddrKeysSlice) {
+	return getMockAppWithBackend(t, numGenAccs, balance, true)
+}
+
+// initialize the mock application for this module
+func getMockAppWithBackend(t *testing.T, numGenAccs int, balance int64, enableBackend bool) (mockApp *MockApp,
 	addrKeysSlice mock.AddrKeysSlice) {
 	mapp := mock.NewApp()
 	registerCodec(mapp.Cdc)
@@ -53,6 +70,7 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 	mockApp = &MockApp{
 		App:       mapp,
 		keySwap:   sdk.NewKVStoreKey(StoreKey),
+		tkeySwap:  sdk.NewTransientStoreKey(TStoreKey),
 		keyToken:  sdk.NewKVStoreKey(token.StoreKey),
 		keyLock:   sdk.NewKVStoreKey(token.KeyLock),
 		keySupply: sdk.NewKVStoreKey(supply.StoreKey),
@@ -68,6 +86,7 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 
 	maccPerms := map[string][]string{
 		auth.FeeCollectorName: nil,
+		distr.ModuleName:      nil,
 		token.ModuleName:      {supply.Minter, supply.Burner},
 		ModuleName:            {supply.Minter, supply.Burner},
 	}
@@ -87,9 +106,12 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 	mockApp.swapKeeper = NewKeeper(
 		mockApp.supplyKeeper,
 		mockApp.tokenKeeper,
+		mockDistrKeeper{mockApp.supplyKeeper},
 		mockApp.Cdc,
 		mockApp.keySwap,
+		mockApp.tkeySwap,
 		mockApp.ParamsKeeper.Subspace(DefaultParamspace),
+		enableBackend,
 	)
 
 	mockApp.Router().AddRoute(RouterKey, NewHandler(mockApp.swapKeeper))
@@ -114,6 +136,7 @@ func getMockAppWithBalance(t *testing.T, numGenAccs int, balance int64) (mockApp
 	app := mockApp
 	require.NoError(t, app.CompleteSetup(
 		app.keySwap,
+		app.tkeySwap,
 		app.keyToken,
 		app.keyLock,
 		app.keySupply,
@@ -0,0 +1,43 @@
+package types
+
+// types of the liquidity changes recorded for the backend
+const (
+	LiquidityTypeAdd    = "add"
+	LiquidityTypeRemove = "remove"
+)
+
+// SwapInfo is the record of a swap in a pool for the backend
+type SwapInfo struct {
+	TxHash        string  `gorm:"index;type:varchar(80)" json:"tx_hash" v2:"tx_hash"`
+	Address       string  `gorm:"index;type:varchar(80)" json:"address" v2:"address"`
+	TokenPairName string  `gorm:"index;type:varchar(128)" json:"token_pair_name" v2:"token_pair_name"`
+	SellAmount    string  `gorm:"type:varchar(40)" json:"sell_amount" v2:"sell_amount"`
+	BuysAmount    string  `gorm:"type:varchar(40)" json:"buys_amount" v2:"buys_amount"`
+	Price         string  `gorm:"type:varchar(40)" json:"price" v2:"price"`   // price of the base token in the quote token
+	Volume        float64 `gorm:"type:DOUBLE" json:"volume" v2:"volume"`      // amount of the quote token swapped
+	Timestamp     int64   `gorm:"index;type:bigint" json:"timestamp" v2:"timestamp"`
+}
+
+// SwapLiquidityInfo is the record of adding liquidity to or removing liquidity from a pool for the backend
+type SwapLiquidityInfo struct {
+	TxHash        string `gorm:"index;type:varchar(80)" json:"tx_hash" v2:"tx_hash"`
+	Address       string `gorm:"index;type:varchar(80)" json:"address" v2:"address"`
+	TokenPairName string `gorm:"index;type:varchar(128)" json:"token_pair_name" v2:"token_pair_name"`
+	Type          string `gorm:"type:varchar(10)" json:"type" v2:"type"` // add or remove
+	BaseAmount    string `gorm:"type:varchar(40)" json:"base_amount" v2:"base_amount"`
+	QuoteAmount   string `gorm:"type:varchar(40)" json:"quote_amount" v2:"quote_amount"`
+	Liquidity     string `gorm:"type:varchar(40)" json:"liquidity" v2:"liquidity"`
+	Timestamp     int64  `gorm:"index;type:bigint" json:"timestamp" v2:"timestamp"`
+}
+
+// SwapTokenPairInfo is the record of the creation of a pool for the backend
+type SwapTokenPairInfo struct {
+	TokenPairName string `gorm:"PRIMARY_KEY;type:varchar(128)" json:"token_pair_name" v2:"token_pair_name"`
+	BaseToken     string `gorm:"type:varchar(80)" json:"base_token" v2:"base_token"`
+	QuoteToken    string `gorm:"type:varchar(80)" json:"quote_token" v2:"quote_token"`
+	PoolToken     string `gorm:"type:varchar(80)" json:"pool_token" v2:"pool_token"`
+	Curve         string `gorm:"type:varchar(40)" json:"curve" v2:"curve"`
+	Creator       string `gorm:"index;type:varchar(80)" json:"creator" v2:"creator"`
+	TxHash        string `gorm:"type:varchar(80)" json:"tx_hash" v2:"tx_hash"`
+	Timestamp     int64  `gorm:"type:bigint" json:"timestamp" v2:"timestamp"`
+}
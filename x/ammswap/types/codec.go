@@ -10,6 +10,10 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgRemoveLiquidity{}, "okexchain/ammswap/MsgRemoveLiquidity", nil)
 	cdc.RegisterConcrete(MsgCreateExchange{}, "okexchain/ammswap/MsgCreateExchange", nil)
 	cdc.RegisterConcrete(MsgTokenToToken{}, "okexchain/ammswap/MsgSwapToken", nil)
+	cdc.RegisterConcrete(MsgMultiHopSwap{}, "okexchain/ammswap/MsgMultiHopSwap", nil)
+	cdc.RegisterConcrete(MsgTokenToTokenExactOutput{}, "okexchain/ammswap/MsgSwapTokenExactOutput", nil)
+	cdc.RegisterConcrete(MsgZapIn{}, "okexchain/ammswap/MsgZapIn", nil)
+	cdc.RegisterConcrete(MsgZapOut{}, "okexchain/ammswap/MsgZapOut", nil)
 }
 
 // ModuleCdc defines the module codec
@@ -0,0 +1,346 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// curve types of the pools
+const (
+	CurveConstantProduct = "constant_product"
+	CurveWeighted        = "weighted"
+	CurveStableSwap      = "stable_swap"
+
+	// MaxAmplification is the max amplification coefficient of the stable swap pools
+	MaxAmplification = 1000000
+	// maxIterations bounds the iterations of the newton's methods and the power series
+	maxIterations = 255
+)
+
+var (
+	// MinWeight and MaxWeight limit the weight of a token in the weighted pools
+	MinWeight = sdk.NewDecWithPrec(2, 2)
+	MaxWeight = sdk.NewDecWithPrec(98, 2)
+	// MaxInRatio and MaxOutRatio limit the amount of a swap in the weighted pools relative to the reserves,
+	// out of which the power series converge too slowly
+	MaxInRatio  = sdk.NewDecWithPrec(5, 1)
+	MaxOutRatio = sdk.NewDecWithPrec(3, 1)
+
+	// the curve math is calculated on the fixed-point integers of 18 decimals
+	fixedOne = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	// decToFixed scales the integers of the decimals to the fixed-point integers
+	decToFixed = new(big.Int).Exp(big.NewInt(10), big.NewInt(18-sdk.Precision), nil)
+	// seriesPrecision is where the power series stops, and powPrecision bounds the error of the power which
+	// the weighted pools round up in the favor of the pool
+	seriesPrecision = big.NewInt(1e4)
+	powPrecision    = big.NewInt(1e8)
+)
+
+// PoolCurve defines the invariant of the pool, which is selected at the creation of the pool
+type PoolCurve struct {
+	Type          string  `json:"type"`          // constant_product, weighted or stable_swap
+	BaseWeight    sdk.Dec `json:"base_weight"`   // Weight of the base token in the weighted pool, the quote token weighs the rest
+	Amplification int64   `json:"amplification"` // Amplification coefficient of the stable swap pool
+}
+
+// NewPoolCurve is a constructor function for PoolCurve
+func NewPoolCurve(curveType string, baseWeight sdk.Dec, amplification int64) PoolCurve {
+	return PoolCurve{
+		Type:          curveType,
+		BaseWeight:    baseWeight,
+		Amplification: amplification,
+	}
+}
+
+// DefaultPoolCurve returns the constant product curve x*y=k
+func DefaultPoolCurve() PoolCurve {
+	return NewPoolCurve(CurveConstantProduct, sdk.ZeroDec(), 0)
+}
+
+// GetType returns the curve type, the pools created before the curves were introduced are constant product pools
+func (c PoolCurve) GetType() string {
+	if c.Type == "" {
+		return CurveConstantProduct
+	}
+	return c.Type
+}
+
+// Validate checks the parameters of the curve
+func (c PoolCurve) Validate() error {
+	switch c.GetType() {
+	case CurveConstantProduct:
+	case CurveWeighted:
+		if c.BaseWeight.IsNil() || c.BaseWeight.LT(MinWeight) || c.BaseWeight.GT(MaxWeight) {
+			return fmt.Errorf("invalid base weight: the weight should be between %s and %s", MinWeight, MaxWeight)
+		}
+	case CurveStableSwap:
+		if c.Amplification <= 0 || c.Amplification > MaxAmplification {
+			return fmt.Errorf("invalid amplification: the amplification should be between 1 and %d", MaxAmplification)
+		}
+	default:
+		return fmt.Errorf("invalid curve type: %s", c.Type)
+	}
+	return nil
+}
+
+// String implement fmt.Stringer
+func (c PoolCurve) String() string {
+	switch c.GetType() {
+	case CurveWeighted:
+		return fmt.Sprintf("%s(base weight %s)", CurveWeighted, c.BaseWeight)
+	case CurveStableSwap:
+		return fmt.Sprintf("%s(amplification %d)", CurveStableSwap, c.Amplification)
+	default:
+		return c.GetType()
+	}
+}
+
+// weights returns the weights of the input and output tokens in the weighted pool
+func (c PoolCurve) weights(inputIsBase bool) (inputWeight, outputWeight *big.Int) {
+	baseWeight := toFixed(c.BaseWeight)
+	quoteWeight := new(big.Int).Sub(fixedOne, baseWeight)
+	if inputIsBase {
+		return baseWeight, quoteWeight
+	}
+	return quoteWeight, baseWeight
+}
+
+// GetWeightedInputPrice returns the output amount of the weighted pool for the input amount:
+// outputAmount = outputReserve * (1 - (inputReserve / (inputReserve + inputAmount * (1 - feeRate))) ^ (wi / wo))
+func GetWeightedInputPrice(curve PoolCurve, inputIsBase bool, inputAmount, inputReserve, outputReserve,
+	feeRate sdk.Dec) (sdk.Dec, error) {
+	if inputAmount.GT(inputReserve.Mul(MaxInRatio)) {
+		return sdk.Dec{}, fmt.Errorf("the input amount exceeds %s of the reserve in the weighted pool", MaxInRatio)
+	}
+	inputWeight, outputWeight := curve.weights(inputIsBase)
+	inputWithFee := fixedMul(toFixed(inputAmount), new(big.Int).Sub(fixedOne, toFixed(feeRate)))
+	base := fixedDiv(toFixed(inputReserve), new(big.Int).Add(toFixed(inputReserve), inputWithFee))
+	ratio := fixedPowUp(base, fixedDiv(inputWeight, outputWeight))
+	if ratio.Cmp(fixedOne) >= 0 {
+		return sdk.ZeroDec(), nil
+	}
+	output := fixedMul(toFixed(outputReserve), new(big.Int).Sub(fixedOne, ratio))
+	return fromFixed(output, false), nil
+}
+
+// GetWeightedOutputPrice returns the input amount of the weighted pool for buying the output amount:
+// inputAmount = inputReserve * ((outputReserve / (outputReserve - outputAmount)) ^ (wo / wi) - 1) / (1 - feeRate)
+func GetWeightedOutputPrice(curve PoolCurve, inputIsBase bool, outputAmount, inputReserve, outputReserve,
+	feeRate sdk.Dec) (sdk.Dec, error) {
+	if outputAmount.GT(outputReserve.Mul(MaxOutRatio)) {
+		return sdk.Dec{}, fmt.Errorf("the output amount exceeds %s of the reserve in the weighted pool", MaxOutRatio)
+	}
+	inputWeight, outputWeight := curve.weights(inputIsBase)
+	base := fixedDivUp(toFixed(outputReserve), toFixed(outputReserve.Sub(outputAmount)))
+	ratio := fixedPowUp(base, fixedDiv(outputWeight, inputWeight))
+	input := fixedMulUp(toFixed(inputReserve), ratio.Sub(ratio, fixedOne))
+	input = fixedDivUp(input, new(big.Int).Sub(fixedOne, toFixed(feeRate)))
+	return fromFixed(input, true), nil
+}
+
+// GetStableSwapInputPrice returns the output amount of the stable swap pool for the input amount, keeping the
+// invariant 4A(x + y) + D = 4AD + D^3 / 4xy
+func GetStableSwapInputPrice(curve PoolCurve, inputAmount, inputReserve, outputReserve,
+	feeRate sdk.Dec) (sdk.Dec, error) {
+	x, y := toFixed(inputReserve), toFixed(outputReserve)
+	d, err := stableSwapD(curve.Amplification, x, y)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	inputWithFee := fixedMul(toFixed(inputAmount), new(big.Int).Sub(fixedOne, toFixed(feeRate)))
+	newY, err := stableSwapY(curve.Amplification, new(big.Int).Add(x, inputWithFee), d)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	// the extra unit covers the rounding of the newton's method
+	output := newY.Sub(y, newY)
+	output.Sub(output, big.NewInt(1))
+	if output.Sign() <= 0 {
+		return sdk.ZeroDec(), nil
+	}
+	return fromFixed(output, false), nil
+}
+
+// GetStableSwapOutputPrice returns the input amount of the stable swap pool for buying the output amount.
+// The output amount must be less than the output reserve.
+func GetStableSwapOutputPrice(curve PoolCurve, outputAmount, inputReserve, outputReserve,
+	feeRate sdk.Dec) (sdk.Dec, error) {
+	x, y := toFixed(inputReserve), toFixed(outputReserve)
+	d, err := stableSwapD(curve.Amplification, x, y)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	newX, err := stableSwapY(curve.Amplification, new(big.Int).Sub(y, toFixed(outputAmount)), d)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	input := newX.Sub(newX, x)
+	input.Add(input, big.NewInt(1))
+	input = fixedDivUp(input, new(big.Int).Sub(fixedOne, toFixed(feeRate)))
+	return fromFixed(input, true), nil
+}
+
+// SpotPrice returns the marginal price of the base token in the quote token as a fraction, both of the
+// reserves must be positive
+func (c PoolCurve) SpotPrice(baseReserve, quoteReserve sdk.Dec) (numerator, denominator sdk.Dec) {
+	switch c.GetType() {
+	case CurveWeighted:
+		// (quoteReserve / quoteWeight) / (baseReserve / baseWeight)
+		return quoteReserve.Mul(c.BaseWeight), baseReserve.Mul(sdk.OneDec().Sub(c.BaseWeight))
+	case CurveStableSwap:
+		// the ratio of the partial derivatives of the invariant: (4A + D^3/4x^2y) / (4A + D^3/4xy^2)
+		x, y := toFixed(baseReserve), toFixed(quoteReserve)
+		d, err := stableSwapD(c.Amplification, x, y)
+		if err != nil {
+			return quoteReserve, baseReserve
+		}
+		ann := new(big.Int).Mul(big.NewInt(c.Amplification*4), fixedOne)
+		dxy := fixedDiv(fixedMul(fixedMul(d, d), d), new(big.Int).Mul(big.NewInt(4), fixedMul(x, y)))
+		numerator := new(big.Int).Add(ann, fixedDiv(dxy, x))
+		denominator := new(big.Int).Add(ann, fixedDiv(dxy, y))
+		return fromFixed(numerator, false), fromFixed(denominator, false)
+	default:
+		return quoteReserve, baseReserve
+	}
+}
+
+// stableSwapD solves the invariant D of the stable swap pool by the newton's method:
+// D = (Ann * S + 2 * Dp) * D / ((Ann - 1) * D + 3 * Dp), where Ann = 4A, S = x + y and Dp = D^3 / 4xy
+func stableSwapD(amplification int64, x, y *big.Int) (*big.Int, error) {
+	if x.Sign() <= 0 || y.Sign() <= 0 {
+		return nil, errors.New("the stable swap pool is empty")
+	}
+	ann := big.NewInt(amplification * 4)
+	annMinusOne := big.NewInt(amplification*4 - 1)
+	sum := new(big.Int).Add(x, y)
+	d := new(big.Int).Set(sum)
+	for i := 0; i < maxIterations; i++ {
+		dp := new(big.Int).Set(d)
+		dp.Mul(dp, d).Quo(dp, new(big.Int).Lsh(x, 1))
+		dp.Mul(dp, d).Quo(dp, new(big.Int).Lsh(y, 1))
+		numerator := new(big.Int).Mul(ann, sum)
+		numerator.Add(numerator, new(big.Int).Lsh(dp, 1))
+		numerator.Mul(numerator, d)
+		denominator := new(big.Int).Mul(annMinusOne, d)
+		denominator.Add(denominator, new(big.Int).Mul(big.NewInt(3), dp))
+		prev := d
+		d = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(d, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			return d, nil
+		}
+	}
+	return nil, errors.New("the invariant of the stable swap pool doesn't converge")
+}
+
+// stableSwapY solves the reserve y of the stable swap pool with the reserve x and the invariant D by the
+// newton's method: y = (y^2 + c) / (2y + b - D), where c = D^3 / (4x * Ann) and b = x + D / Ann
+func stableSwapY(amplification int64, x, d *big.Int) (*big.Int, error) {
+	if x.Sign() <= 0 {
+		return nil, errors.New("insufficient reserve in the stable swap pool")
+	}
+	ann := big.NewInt(amplification * 4)
+	c := new(big.Int).Set(d)
+	c.Mul(c, d).Quo(c, new(big.Int).Lsh(x, 1))
+	c.Mul(c, d).Quo(c, new(big.Int).Lsh(ann, 1))
+	b := new(big.Int).Quo(d, ann)
+	b.Add(b, x)
+	y := new(big.Int).Set(d)
+	for i := 0; i < maxIterations; i++ {
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Lsh(y, 1)
+		denominator.Add(denominator, b).Sub(denominator, d)
+		if denominator.Sign() <= 0 {
+			return nil, errors.New("insufficient reserve in the stable swap pool")
+		}
+		prev := y
+		y = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(y, prev).CmpAbs(big.NewInt(1)) <= 0 {
+			return y, nil
+		}
+	}
+	return nil, errors.New("the reserve of the stable swap pool doesn't converge")
+}
+
+// fixedPow calculates base^exp of the fixed-point integers, by squaring for the integer part of the exponent
+// and the binomial series for the fractional part. The base should be in (0, 2).
+func fixedPow(base, exp *big.Int) *big.Int {
+	whole := new(big.Int).Quo(exp, fixedOne)
+	remain := new(big.Int).Sub(exp, new(big.Int).Mul(whole, fixedOne))
+
+	result := new(big.Int).Set(fixedOne)
+	square := new(big.Int).Set(base)
+	for n := whole.Uint64(); n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = fixedMul(result, square)
+		}
+		square = fixedMul(square, square)
+	}
+	if remain.Sign() == 0 {
+		return result
+	}
+
+	// (1 + a)^remain = 1 + remain*a + remain*(remain-1)/2!*a^2 + ...
+	a := new(big.Int).Sub(base, fixedOne)
+	term := new(big.Int).Set(fixedOne)
+	sum := new(big.Int).Set(fixedOne)
+	for k := int64(1); k <= maxIterations; k++ {
+		coefficient := new(big.Int).Sub(remain, new(big.Int).Mul(big.NewInt(k-1), fixedOne))
+		term = fixedMul(fixedMul(term, coefficient), a)
+		term.Quo(term, big.NewInt(k))
+		if term.CmpAbs(seriesPrecision) < 0 {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	return fixedMul(result, sum)
+}
+
+// fixedPowUp returns base^exp rounded up beyond the error of fixedPow
+func fixedPowUp(base, exp *big.Int) *big.Int {
+	result := fixedPow(base, exp)
+	margin := new(big.Int).Quo(new(big.Int).Mul(result, powPrecision), fixedOne)
+	return result.Add(result, margin).Add(result, powPrecision)
+}
+
+func toFixed(d sdk.Dec) *big.Int {
+	return new(big.Int).Mul(d.Int, decToFixed)
+}
+
+func fromFixed(x *big.Int, roundUp bool) sdk.Dec {
+	quo, rem := new(big.Int).QuoRem(x, decToFixed, new(big.Int))
+	if roundUp && rem.Sign() > 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return sdk.NewDecFromBigIntWithPrec(quo, sdk.Precision)
+}
+
+func fixedMul(a, b *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	return product.Quo(product, fixedOne)
+}
+
+func fixedMulUp(a, b *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	return divUp(product, fixedOne)
+}
+
+func fixedDiv(a, b *big.Int) *big.Int {
+	numerator := new(big.Int).Mul(a, fixedOne)
+	return numerator.Quo(numerator, b)
+}
+
+func fixedDivUp(a, b *big.Int) *big.Int {
+	return divUp(new(big.Int).Mul(a, fixedOne), b)
+}
+
+func divUp(a, b *big.Int) *big.Int {
+	quo, rem := new(big.Int).QuoRem(a, b, new(big.Int))
+	if rem.Sign() > 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return quo
+}
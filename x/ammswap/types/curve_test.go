@@ -0,0 +1,95 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolCurveValidate(t *testing.T) {
+	tests := []struct {
+		curve   PoolCurve
+		isValid bool
+	}{
+		{PoolCurve{}, true},
+		{DefaultPoolCurve(), true},
+		{NewPoolCurve(CurveWeighted, sdk.NewDecWithPrec(8, 1), 0), true},
+		{NewPoolCurve(CurveWeighted, sdk.NewDecWithPrec(1, 2), 0), false},
+		{NewPoolCurve(CurveWeighted, sdk.OneDec(), 0), false},
+		{NewPoolCurve(CurveWeighted, sdk.Dec{}, 0), false},
+		{NewPoolCurve(CurveStableSwap, sdk.ZeroDec(), 100), true},
+		{NewPoolCurve(CurveStableSwap, sdk.ZeroDec(), 0), false},
+		{NewPoolCurve(CurveStableSwap, sdk.ZeroDec(), MaxAmplification+1), false},
+		{NewPoolCurve("curve", sdk.ZeroDec(), 0), false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.isValid, test.curve.Validate() == nil, test.curve.String())
+	}
+}
+
+func TestWeightedPrice(t *testing.T) {
+	feeRate := sdk.NewDecWithPrec(3, 3)
+	reserve := sdk.NewDec(10000)
+
+	// the 50/50 weighted pool is the constant product pool
+	curve := NewPoolCurve(CurveWeighted, sdk.NewDecWithPrec(5, 1), 0)
+	output, err := GetWeightedInputPrice(curve, true, sdk.NewDec(100), reserve, reserve, feeRate)
+	require.Nil(t, err)
+	inputWithFee := sdk.NewDec(100).Mul(sdk.OneDec().Sub(feeRate))
+	require.True(t, inputWithFee.Mul(reserve).Quo(reserve.Add(inputWithFee)).Sub(output).LT(sdk.NewDecWithPrec(1, 5)))
+
+	// buying and selling are the inverse of each other, both rounded in the favor of the pool
+	curve = NewPoolCurve(CurveWeighted, sdk.NewDecWithPrec(8, 1), 0)
+	for _, inputIsBase := range []bool{true, false} {
+		input, err := GetWeightedOutputPrice(curve, inputIsBase, sdk.NewDec(100), reserve, reserve, feeRate)
+		require.Nil(t, err)
+		output, err := GetWeightedInputPrice(curve, inputIsBase, input, reserve, reserve, feeRate)
+		require.Nil(t, err)
+		require.True(t, output.Sub(sdk.NewDec(100)).Abs().LT(sdk.NewDecWithPrec(1, 5)))
+	}
+
+	_, err = GetWeightedInputPrice(curve, true, sdk.NewDec(5001), reserve, reserve, feeRate)
+	require.NotNil(t, err)
+	_, err = GetWeightedOutputPrice(curve, true, sdk.NewDec(3001), reserve, reserve, feeRate)
+	require.NotNil(t, err)
+}
+
+func TestStableSwapPrice(t *testing.T) {
+	feeRate := sdk.NewDecWithPrec(3, 3)
+	reserve := sdk.NewDec(10000)
+	curve := NewPoolCurve(CurveStableSwap, sdk.ZeroDec(), 100)
+
+	// the slippage of the stable swap pool is much less than the constant product pool
+	output, err := GetStableSwapInputPrice(curve, sdk.NewDec(1000), reserve, reserve, feeRate)
+	require.Nil(t, err)
+	require.True(t, output.GT(sdk.NewDec(995)) && output.LT(sdk.NewDec(997)))
+
+	input, err := GetStableSwapOutputPrice(curve, sdk.NewDec(1000), reserve, reserve, feeRate)
+	require.Nil(t, err)
+	output, err = GetStableSwapInputPrice(curve, input, reserve, reserve, feeRate)
+	require.Nil(t, err)
+	require.True(t, output.GTE(sdk.NewDec(1000)))
+	output, err = GetStableSwapInputPrice(curve, input.Sub(sdk.NewDecWithPrec(1, 4)), reserve, reserve, feeRate)
+	require.Nil(t, err)
+	require.True(t, output.LT(sdk.NewDec(1000)))
+
+	_, err = GetStableSwapInputPrice(curve, sdk.NewDec(1), sdk.ZeroDec(), reserve, feeRate)
+	require.NotNil(t, err)
+}
+
+func TestSpotPrice(t *testing.T) {
+	numerator, denominator := DefaultPoolCurve().SpotPrice(sdk.NewDec(100), sdk.NewDec(400))
+	require.Equal(t, sdk.NewDec(4), numerator.Quo(denominator))
+
+	numerator, denominator = NewPoolCurve(CurveWeighted, sdk.NewDecWithPrec(8, 1), 0).SpotPrice(sdk.NewDec(100),
+		sdk.NewDec(400))
+	require.Equal(t, sdk.NewDec(16), numerator.Quo(denominator))
+
+	curve := NewPoolCurve(CurveStableSwap, sdk.ZeroDec(), 100)
+	numerator, denominator = curve.SpotPrice(sdk.NewDec(10000), sdk.NewDec(10000))
+	require.Equal(t, sdk.OneDec(), numerator.Quo(denominator))
+	numerator, denominator = curve.SpotPrice(sdk.NewDec(11000), sdk.NewDec(9000))
+	price := numerator.Quo(denominator)
+	require.True(t, price.LT(sdk.OneDec()) && price.GT(sdk.NewDecWithPrec(99, 2)))
+}
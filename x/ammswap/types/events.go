@@ -3,4 +3,9 @@ package types
 // ammswap module event types
 const (
 	AttributeValueCategory = ModuleName
+
+	EventTypeSwapHop            = "swap_hop"
+	AttributeKeyTokenPair       = "token_pair"
+	AttributeKeySoldTokenAmount = "sold_token_amount"
+	AttributeKeyBoughtAmount    = "bought_token_amount"
 )
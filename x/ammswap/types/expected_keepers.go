@@ -11,6 +11,8 @@ import (
 type ParamSubspace interface {
 	WithKeyTable(table params.KeyTable) params.Subspace
 	Get(ctx sdk.Context, key []byte, ptr interface{})
+	Has(ctx sdk.Context, key []byte) bool
+	Set(ctx sdk.Context, key []byte, param interface{})
 	GetParamSet(ctx sdk.Context, ps params.ParamSet)
 	SetParamSet(ctx sdk.Context, ps params.ParamSet)
 }
@@ -32,6 +34,11 @@ type SupplyKeeper interface {
 	BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) sdk.Error
 }
 
+// DistrKeeper defines the expected distribution interface
+type DistrKeeper interface {
+	FundCommunityPoolFromModule(ctx sdk.Context, amount sdk.Coins, senderModule string) sdk.Error
+}
+
 // TokenKeeper defines the expected token interface
 type TokenKeeper interface {
 	GetTokenInfo(ctx sdk.Context, symbol string) token.Token
@@ -39,4 +46,5 @@ type TokenKeeper interface {
 	UpdateToken(ctx sdk.Context, token token.Token)
 	GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.DecCoins
 	TokenExist(ctx sdk.Context, symbol string) bool
+	CheckCoinsTransferable(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins) sdk.Error
 }
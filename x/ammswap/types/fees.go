@@ -0,0 +1,29 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PoolFees defines the cumulative swap fees earned by a token pair exchange pool
+type PoolFees struct {
+	TokenPairName string       `json:"token_pair_name"` // The name of the token pair
+	LPFees        sdk.DecCoins `json:"lp_fees"`         // The fees left in the pool for the liquidity providers
+	ProtocolFees  sdk.DecCoins `json:"protocol_fees"`   // The fees sent to the community pool
+}
+
+// NewPoolFees is a constructor function for PoolFees
+func NewPoolFees(tokenPairName string) PoolFees {
+	return PoolFees{
+		TokenPairName: tokenPairName,
+	}
+}
+
+// String implement fmt.Stringer
+func (f PoolFees) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`TokenPairName: %s
+LPFees: %s
+ProtocolFees: %s`, f.TokenPairName, f.LPFees.String(), f.ProtocolFees.String()))
+}
@@ -1,5 +1,7 @@
 package types
 
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "ammswap"
@@ -7,6 +9,9 @@ const (
 	// StoreKey to be used when creating the KVStore
 	StoreKey = ModuleName
 
+	// TStoreKey to be used for the backend records of the block, which are dropped with the state of a failed tx
+	TStoreKey = "transient_" + ModuleName
+
 	// RouterKey to be used for routing msgs
 	RouterKey = ModuleName
 
@@ -23,14 +28,65 @@ const (
 	QueryParams = "params"
 
 	QueryBuyAmount = "buy"
+
+	QuerySellAmount = "sell"
+
+	QuerySwapRoute = "route"
+
+	QueryPoolFees = "poolFees"
+
+	QueryTWAP = "twap"
 )
 
 var (
 	// TokenPairPrefixKey to be used for KVStore
 	TokenPairPrefixKey = []byte{0x01}
+	// PoolTokenSeqKey to be used for the sequence of the pool tokens named by sequence
+	PoolTokenSeqKey = []byte{0x02}
+	// PoolFeesPrefixKey to be used for the cumulative fees of every token pair
+	PoolFeesPrefixKey = []byte{0x03}
+	// PriceAccumulatorPrefixKey to be used for the latest cumulative prices of every token pair
+	PriceAccumulatorPrefixKey = []byte{0x04}
+	// PriceObservationPrefixKey to be used for the cumulative prices of every token pair at every height
+	PriceObservationPrefixKey = []byte{0x05}
+
+	// BackendRecordSeqKey to be used for the sequence of the backend records of the block in the transient store
+	BackendRecordSeqKey = []byte{0x01}
+	// SwapInfoPrefixKey to be used for the swaps of the block in the transient store
+	SwapInfoPrefixKey = []byte{0x02}
+	// SwapLiquidityInfoPrefixKey to be used for the liquidity changes of the block in the transient store
+	SwapLiquidityInfoPrefixKey = []byte{0x03}
+	// SwapTokenPairInfoPrefixKey to be used for the pools created in the block in the transient store
+	SwapTokenPairInfoPrefixKey = []byte{0x04}
 )
 
 // nolint
 func GetTokenPairKey(key string) []byte {
 	return append(TokenPairPrefixKey, []byte(key)...)
 }
+
+// GetPoolFeesKey returns the store key of the cumulative fees of the token pair
+func GetPoolFeesKey(tokenPairName string) []byte {
+	return append(PoolFeesPrefixKey, []byte(tokenPairName)...)
+}
+
+// GetPriceAccumulatorKey returns the store key of the latest cumulative prices of the token pair
+func GetPriceAccumulatorKey(tokenPairName string) []byte {
+	return append(PriceAccumulatorPrefixKey, []byte(tokenPairName)...)
+}
+
+// GetPriceObservationsPrefix returns the store key prefix of the price observations of the token pair.
+// The name is terminated by 0x00 which is not a valid denom character.
+func GetPriceObservationsPrefix(tokenPairName string) []byte {
+	return append(append(PriceObservationPrefixKey, []byte(tokenPairName)...), 0x00)
+}
+
+// GetPriceObservationKey returns the store key of the price observation of the token pair at the height
+func GetPriceObservationKey(tokenPairName string, height int64) []byte {
+	return append(GetPriceObservationsPrefix(tokenPairName), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetBackendRecordKey returns the transient store key of the backend record with the prefix and the sequence
+func GetBackendRecordKey(prefix []byte, seq uint64) []byte {
+	return append(append([]byte{}, prefix...), sdk.Uint64ToBigEndian(seq)...)
+}
@@ -17,7 +17,7 @@ func TestMsgCreateExchange(t *testing.T) {
 	addr, err := hex.DecodeString(addrStr)
 	require.Nil(t, err)
 	testToken := InitPoolToken(TestBasePooledToken)
-	msg := NewMsgCreateExchange(testToken.Symbol, addr)
+	msg := NewMsgCreateExchange(testToken.Symbol, "", addr)
 	require.Nil(t, msg.ValidateBasic())
 	require.Equal(t, RouterKey, msg.Route())
 	require.Equal(t, "create_exchange", msg.Type())
@@ -36,16 +36,22 @@ func TestMsgCreateExchangeInvalid(t *testing.T) {
 	tests := []struct {
 		testCase         string
 		symbol           string
+		quoteToken       string
 		addr             sdk.AccAddress
 		exceptResultCode sdk.CodeType
 	}{
-		{"success", "xxx", addr, sdk.CodeOK},
-		{"nil addr", "xxx", nil, sdk.CodeInvalidAddress},
-		{"invalid token", "1ab", addr, sdk.CodeUnknownRequest},
-		{"invalid token", sdk.DefaultBondDenom, addr, sdk.CodeUnknownRequest},
+		{"success", "xxx", "", addr, sdk.CodeOK},
+		{"success with quote token", "xxx", "yyy", addr, sdk.CodeOK},
+		{"success with native token as base", sdk.DefaultBondDenom, "yyy", addr, sdk.CodeOK},
+		{"nil addr", "xxx", "", nil, sdk.CodeInvalidAddress},
+		{"invalid token", "1ab", "", addr, sdk.CodeUnknownRequest},
+		{"invalid token", sdk.DefaultBondDenom, "", addr, sdk.CodeUnknownRequest},
+		{"invalid quote token", "xxx", "1ab", addr, sdk.CodeUnknownRequest},
+		{"pool token as quote token", "xxx", PoolTokenPrefix + "yyy", addr, sdk.CodeUnknownRequest},
+		{"same token", "xxx", "xxx", addr, sdk.CodeUnknownRequest},
 	}
 	for _, testCase := range tests {
-		msg := NewMsgCreateExchange(testCase.symbol, testCase.addr)
+		msg := NewMsgCreateExchange(testCase.symbol, testCase.quoteToken, testCase.addr)
 		err := msg.ValidateBasic()
 		if err == nil {
 			require.Equal(t, sdk.CodeOK, testCase.exceptResultCode)
@@ -55,6 +61,46 @@ func TestMsgCreateExchangeInvalid(t *testing.T) {
 	}
 }
 
+
+func TestMsgCreateExchangeWithCurve(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	tests := []struct {
+		testCase         string
+		symbol           string
+		quoteToken       string
+		curveType        string
+		tokenWeight      sdk.Dec
+		amplification    int64
+		expectedCurve    PoolCurve
+		exceptResultCode sdk.CodeType
+	}{
+		{"constant product by default", "xxx", "", "", sdk.Dec{}, 0, DefaultPoolCurve(), sdk.CodeOK},
+		{"weighted", "xxx", "", CurveWeighted, sdk.NewDecWithPrec(8, 1), 0,
+			NewPoolCurve(CurveWeighted, sdk.NewDecWithPrec(8, 1), 0), sdk.CodeOK},
+		{"weighted with token as quote", "yyy", "xxx", CurveWeighted, sdk.NewDecWithPrec(8, 1), 0,
+			NewPoolCurve(CurveWeighted, sdk.NewDecWithPrec(2, 1), 0), sdk.CodeOK},
+		{"stable swap", "xxx", "yyy", CurveStableSwap, sdk.Dec{}, 100,
+			NewPoolCurve(CurveStableSwap, sdk.ZeroDec(), 100), sdk.CodeOK},
+		{"invalid weight", "xxx", "", CurveWeighted, sdk.OneDec(), 0,
+			NewPoolCurve(CurveWeighted, sdk.OneDec(), 0), sdk.CodeUnknownRequest},
+		{"invalid amplification", "xxx", "", CurveStableSwap, sdk.Dec{}, 0,
+			NewPoolCurve(CurveStableSwap, sdk.ZeroDec(), 0), sdk.CodeUnknownRequest},
+		{"invalid curve", "xxx", "", "curve", sdk.Dec{}, 0,
+			NewPoolCurve("curve", sdk.ZeroDec(), 0), sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgCreateExchangeWithCurve(testCase.symbol, testCase.quoteToken, testCase.curveType,
+			testCase.tokenWeight, testCase.amplification, addr)
+		require.Equal(t, testCase.expectedCurve, msg.GetPoolCurve(), testCase.testCase)
+		err := msg.ValidateBasic()
+		if testCase.exceptResultCode == sdk.CodeOK {
+			require.Nil(t, err, testCase.testCase)
+		} else {
+			require.Equal(t, testCase.exceptResultCode, err.Code(), testCase.testCase)
+		}
+	}
+}
 func TestMsgAddLiquidity(t *testing.T) {
 	addr, err := hex.DecodeString(addrStr)
 	require.Nil(t, err)
@@ -89,7 +135,8 @@ func TestMsgAddLiquidityInvalid(t *testing.T) {
 	invalidMaxBaseAmount.Denom = "1add"
 	invalidQuoteAmount := sdk.NewDecCoinFromDec("bsa", sdk.NewDec(10000))
 	invalidQuoteAmount.Denom = "1dfdf"
-	notNativeQuoteAmount := sdk.NewDecCoinFromDec("abc", sdk.NewDec(10000))
+	nonCanonicalQuoteAmount := sdk.NewDecCoinFromDec("abc", sdk.NewDec(10000))
+	tokenQuoteAmount := sdk.NewDecCoinFromDec("zzz", sdk.NewDec(10000))
 	deadLine := time.Now().Unix()
 
 	tests := []struct {
@@ -105,7 +152,9 @@ func TestMsgAddLiquidityInvalid(t *testing.T) {
 		{"tokens must be positive", minLiquidity, maxBaseAmount, notPositiveQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"invalid MaxBaseAmount", minLiquidity, invalidMaxBaseAmount, quoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"invalid QuoteAmount", minLiquidity, maxBaseAmount, invalidQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
-		{"quote token only supports native token", minLiquidity, maxBaseAmount, notNativeQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
+		{"success with token quote", minLiquidity, maxBaseAmount, tokenQuoteAmount, deadLine, addr, 0},
+		{"token pair isn't in canonical order", minLiquidity, maxBaseAmount, nonCanonicalQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
+		{"the same base and quote token", minLiquidity, quoteAmount, quoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"empty sender", minLiquidity, maxBaseAmount, quoteAmount, deadLine, nil, sdk.CodeInvalidAddress},
 	}
 	for _, testCase := range tests {
@@ -158,7 +207,8 @@ func TestMsgRemoveLiquidityInvalid(t *testing.T) {
 	invalidMinBaseAmount.Denom = "1sss"
 	invalidMinQuoteAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(1))
 	invalidMinQuoteAmount.Denom = "1sss"
-	notNativeQuoteAmount := sdk.NewDecCoinFromDec("sss", sdk.NewDec(1))
+	nonCanonicalQuoteAmount := sdk.NewDecCoinFromDec("sss", sdk.NewDec(1))
+	tokenQuoteAmount := sdk.NewDecCoinFromDec("zzz", sdk.NewDec(1))
 
 	tests := []struct {
 		testCase         string
@@ -174,7 +224,8 @@ func TestMsgRemoveLiquidityInvalid(t *testing.T) {
 		{"coins must be positive", notPositiveLiquidity, minBaseAmount, minQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"invalid MinBaseAmount", liquidity, invalidMinBaseAmount, minQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 		{"invalid MinQuoteAmount", liquidity, minBaseAmount, invalidMinQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
-		{"quote token only supports native token", liquidity, minBaseAmount, notNativeQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
+		{"success with token quote", liquidity, minBaseAmount, tokenQuoteAmount, deadLine, addr, 0},
+		{"token pair isn't in canonical order", liquidity, minBaseAmount, nonCanonicalQuoteAmount, deadLine, addr, sdk.CodeUnknownRequest},
 	}
 	for _, testCase := range tests {
 		msg := NewMsgRemoveLiquidity(testCase.liquidity, testCase.minBaseAmount, testCase.minQuoteAmount, testCase.deadLine, testCase.addr)
@@ -209,6 +260,13 @@ func TestMsgTokenToToken(t *testing.T) {
 	require.Equal(t, expectTokenPair, msg.GetSwapTokenPair())
 	msg = NewMsgTokenToToken(minBoughtTokenAmount, soldTokenAmount, deadLine, addr, addr)
 	require.Equal(t, expectTokenPair, msg.GetSwapTokenPair())
+
+	tokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(1))
+	expectTokenPair = TestBasePooledToken + "_" + TestBasePooledToken2
+	msg = NewMsgTokenToToken(tokenAmount, minBoughtTokenAmount, deadLine, addr, addr)
+	require.Equal(t, expectTokenPair, msg.GetSwapTokenPair())
+	msg = NewMsgTokenToToken(minBoughtTokenAmount, tokenAmount, deadLine, addr, addr)
+	require.Equal(t, expectTokenPair, msg.GetSwapTokenPair())
 }
 
 func TestMsgTokenToTokenInvalid(t *testing.T) {
@@ -249,3 +307,174 @@ func TestMsgTokenToTokenInvalid(t *testing.T) {
 		require.Equal(t, testCase.exceptResultCode, err.Code())
 	}
 }
+
+func TestMsgMultiHopSwap(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(2))
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(1))
+	route := []string{TestBasePooledToken + "_" + TestQuotePooledToken, TestBasePooledToken2 + "_" + TestQuotePooledToken}
+	deadLine := time.Now().Unix()
+	msg := NewMsgMultiHopSwap(soldTokenAmount, minBoughtTokenAmount, route, deadLine, addr, addr)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgMultiHopSwap, msg.Type())
+
+	bytesMsg := msg.GetSignBytes()
+	resMsg := &MsgMultiHopSwap{}
+	err = json.Unmarshal(bytesMsg, resMsg)
+	require.Nil(t, err)
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	tests := []struct {
+		testCase             string
+		soldTokenAmount      sdk.DecCoin
+		minBoughtTokenAmount sdk.DecCoin
+		route                []string
+		addr                 sdk.AccAddress
+		exceptResultCode     sdk.CodeType
+	}{
+		{"success", soldTokenAmount, minBoughtTokenAmount, route, addr, sdk.CodeOK},
+		{"success with the best route", soldTokenAmount, minBoughtTokenAmount, nil, addr, sdk.CodeOK},
+		{"empty sender", soldTokenAmount, minBoughtTokenAmount, route, nil, sdk.CodeInvalidAddress},
+		{"the same token", soldTokenAmount, soldTokenAmount, nil, addr, sdk.CodeUnknownRequest},
+		{"invalid route", soldTokenAmount, minBoughtTokenAmount, route[:1], addr, sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgMultiHopSwap(testCase.soldTokenAmount, testCase.minBoughtTokenAmount, testCase.route, deadLine,
+			addr, testCase.addr)
+		err := msg.ValidateBasic()
+		if err == nil {
+			require.Equal(t, sdk.CodeOK, testCase.exceptResultCode, testCase.testCase)
+			continue
+		}
+		require.Equal(t, testCase.exceptResultCode, err.Code(), testCase.testCase)
+	}
+}
+
+func TestMsgTokenToTokenExactOutput(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	maxSoldTokenAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(2))
+	boughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	deadLine := time.Now().Unix()
+	msg := NewMsgTokenToTokenExactOutput(maxSoldTokenAmount, boughtTokenAmount, deadLine, addr, addr)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgTokenSwapExactOutput, msg.Type())
+	require.Equal(t, TestSwapTokenPairName, msg.GetSwapTokenPair())
+
+	bytesMsg := msg.GetSignBytes()
+	resMsg := &MsgTokenToTokenExactOutput{}
+	err = json.Unmarshal(bytesMsg, resMsg)
+	require.Nil(t, err)
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	invalidBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1))
+	invalidBoughtTokenAmount.Denom = "1aaa"
+	tests := []struct {
+		testCase           string
+		maxSoldTokenAmount sdk.DecCoin
+		boughtTokenAmount  sdk.DecCoin
+		recipient          sdk.AccAddress
+		exceptResultCode   sdk.CodeType
+	}{
+		{"success", maxSoldTokenAmount, boughtTokenAmount, addr, sdk.CodeOK},
+		{"empty recipient", maxSoldTokenAmount, boughtTokenAmount, nil, sdk.CodeInvalidAddress},
+		{"not positive BoughtTokenAmount", maxSoldTokenAmount, sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.ZeroDec()),
+			addr, sdk.CodeUnknownRequest},
+		{"invalid BoughtTokenAmount", maxSoldTokenAmount, invalidBoughtTokenAmount, addr, sdk.CodeUnknownRequest},
+		{"the same token", boughtTokenAmount, boughtTokenAmount, addr, sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgTokenToTokenExactOutput(testCase.maxSoldTokenAmount, testCase.boughtTokenAmount, deadLine,
+			testCase.recipient, addr)
+		err := msg.ValidateBasic()
+		if err == nil {
+			require.Equal(t, sdk.CodeOK, testCase.exceptResultCode, testCase.testCase)
+			continue
+		}
+		require.Equal(t, testCase.exceptResultCode, err.Code(), testCase.testCase)
+	}
+}
+
+func TestMsgZapIn(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(10))
+	deadLine := time.Now().Unix()
+	msg := NewMsgZapIn(soldTokenAmount, TestBasePooledToken, sdk.OneDec(), deadLine, addr)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgZapIn, msg.Type())
+	require.Equal(t, TestSwapTokenPairName, msg.GetSwapTokenPair())
+	require.Nil(t, json.Unmarshal(msg.GetSignBytes(), &MsgZapIn{}))
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	tests := []struct {
+		testCase         string
+		soldTokenAmount  sdk.DecCoin
+		pairedToken      string
+		minLiquidity     sdk.Dec
+		sender           sdk.AccAddress
+		exceptResultCode sdk.CodeType
+	}{
+		{"success", soldTokenAmount, TestBasePooledToken, sdk.ZeroDec(), addr, sdk.CodeOK},
+		{"empty sender", soldTokenAmount, TestBasePooledToken, sdk.ZeroDec(), nil, sdk.CodeInvalidAddress},
+		{"not positive SoldTokenAmount", sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.ZeroDec()),
+			TestBasePooledToken, sdk.ZeroDec(), addr, sdk.CodeUnknownRequest},
+		{"invalid PairedToken", soldTokenAmount, "1aaa", sdk.ZeroDec(), addr, sdk.CodeUnknownRequest},
+		{"the same token", soldTokenAmount, TestQuotePooledToken, sdk.ZeroDec(), addr, sdk.CodeUnknownRequest},
+		{"negative MinLiquidity", soldTokenAmount, TestBasePooledToken, sdk.NewDec(-1), addr, sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgZapIn(testCase.soldTokenAmount, testCase.pairedToken, testCase.minLiquidity, deadLine,
+			testCase.sender)
+		err := msg.ValidateBasic()
+		if err == nil {
+			require.Equal(t, sdk.CodeOK, testCase.exceptResultCode, testCase.testCase)
+			continue
+		}
+		require.Equal(t, testCase.exceptResultCode, err.Code(), testCase.testCase)
+	}
+}
+
+func TestMsgZapOut(t *testing.T) {
+	addr, err := hex.DecodeString(addrStr)
+	require.Nil(t, err)
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(10))
+	deadLine := time.Now().Unix()
+	msg := NewMsgZapOut(sdk.OneDec(), minBoughtTokenAmount, TestBasePooledToken, deadLine, addr)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgZapOut, msg.Type())
+	require.Equal(t, TestSwapTokenPairName, msg.GetSwapTokenPair())
+	require.Nil(t, json.Unmarshal(msg.GetSignBytes(), &MsgZapOut{}))
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+
+	tests := []struct {
+		testCase             string
+		liquidity            sdk.Dec
+		minBoughtTokenAmount sdk.DecCoin
+		pairedToken          string
+		sender               sdk.AccAddress
+		exceptResultCode     sdk.CodeType
+	}{
+		{"success", sdk.OneDec(), minBoughtTokenAmount, TestBasePooledToken, addr, sdk.CodeOK},
+		{"empty sender", sdk.OneDec(), minBoughtTokenAmount, TestBasePooledToken, nil, sdk.CodeInvalidAddress},
+		{"not positive Liquidity", sdk.ZeroDec(), minBoughtTokenAmount, TestBasePooledToken, addr,
+			sdk.CodeUnknownRequest},
+		{"invalid PairedToken", sdk.OneDec(), minBoughtTokenAmount, "1aaa", addr, sdk.CodeUnknownRequest},
+		{"the same token", sdk.OneDec(), minBoughtTokenAmount, TestQuotePooledToken, addr, sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		msg := NewMsgZapOut(testCase.liquidity, testCase.minBoughtTokenAmount, testCase.pairedToken, deadLine,
+			testCase.sender)
+		err := msg.ValidateBasic()
+		if err == nil {
+			require.Equal(t, sdk.CodeOK, testCase.exceptResultCode, testCase.testCase)
+			continue
+		}
+		require.Equal(t, testCase.exceptResultCode, err.Code(), testCase.testCase)
+	}
+}
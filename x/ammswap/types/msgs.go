@@ -9,6 +9,11 @@ import (
 const (
 	TypeMsgAddLiquidity = "add_liquidity"
 	TypeMsgTokenSwap    = "token_swap"
+	TypeMsgMultiHopSwap = "multi_hop_swap"
+
+	TypeMsgTokenSwapExactOutput = "token_swap_exact_output"
+	TypeMsgZapIn                = "zap_in"
+	TypeMsgZapOut               = "zap_out"
 )
 
 // MsgAddLiquidity Deposit quote_amount and base_amount at current ratio to mint pool tokens.
@@ -51,8 +56,9 @@ func (msg MsgAddLiquidity) ValidateBasic() sdk.Error {
 	if !msg.QuoteAmount.IsValid() {
 		return sdk.ErrUnknownRequest("invalid QuoteAmount")
 	}
-	if msg.QuoteAmount.Denom != common.NativeToken {
-		return sdk.ErrUnknownRequest("quote token only supports " + common.NativeToken)
+	if msg.GetSwapTokenPair() != GetSwapTokenPairName(msg.MaxBaseAmount.Denom, msg.QuoteAmount.Denom) ||
+		msg.MaxBaseAmount.Denom == msg.QuoteAmount.Denom {
+		return sdk.ErrUnknownRequest("invalid token pair " + msg.GetSwapTokenPair())
 	}
 	return nil
 }
@@ -72,7 +78,7 @@ func (msg MsgAddLiquidity) GetSwapTokenPair() string {
 	return msg.MaxBaseAmount.Denom + "_" + msg.QuoteAmount.Denom
 }
 
-// MsgRemoveLiquidity burns pool tokens to withdraw okt and Tokens at current ratio.
+// MsgRemoveLiquidity burns pool tokens to withdraw base and quote tokens at current ratio.
 type MsgRemoveLiquidity struct {
 	Liquidity      sdk.Dec        `json:"liquidity"`        // Amount of pool token burned.
 	MinBaseAmount  sdk.DecCoin    `json:"min_base_amount"`  // Minimum base amount.
@@ -112,8 +118,9 @@ func (msg MsgRemoveLiquidity) ValidateBasic() sdk.Error {
 	if !msg.MinQuoteAmount.IsValid() {
 		return sdk.ErrUnknownRequest("invalid MinQuoteAmount")
 	}
-	if msg.MinQuoteAmount.Denom != common.NativeToken {
-		return sdk.ErrUnknownRequest("quote token only supports " + common.NativeToken)
+	if msg.GetSwapTokenPair() != GetSwapTokenPairName(msg.MinBaseAmount.Denom, msg.MinQuoteAmount.Denom) ||
+		msg.MinBaseAmount.Denom == msg.MinQuoteAmount.Denom {
+		return sdk.ErrUnknownRequest("invalid token pair " + msg.GetSwapTokenPair())
 	}
 	return nil
 }
@@ -135,15 +142,33 @@ func (msg MsgRemoveLiquidity) GetSwapTokenPair() string {
 
 // MsgCreateExchange creates a new exchange with token
 type MsgCreateExchange struct {
-	Token  string         `json:"token"`  // Token
-	Sender sdk.AccAddress `json:"sender"` // Sender
+	Token         string         `json:"token"`         // Token
+	QuoteToken    string         `json:"quote_token"`   // Quote token, the native token if empty
+	CurveType     string         `json:"curve_type"`    // Curve of the pool, constant product if empty
+	TokenWeight   sdk.Dec        `json:"token_weight"`  // Weight of Token in the weighted pool
+	Amplification int64          `json:"amplification"` // Amplification coefficient of the stable swap pool
+	Sender        sdk.AccAddress `json:"sender"`        // Sender
 }
 
 // NewMsgCreateExchange create a new exchange with token
-func NewMsgCreateExchange(token string, sender sdk.AccAddress) MsgCreateExchange {
+func NewMsgCreateExchange(token, quoteToken string, sender sdk.AccAddress) MsgCreateExchange {
+	return MsgCreateExchange{
+		Token:      token,
+		QuoteToken: quoteToken,
+		Sender:     sender,
+	}
+}
+
+// NewMsgCreateExchangeWithCurve creates a new exchange with token on the curve
+func NewMsgCreateExchangeWithCurve(token, quoteToken, curveType string, tokenWeight sdk.Dec, amplification int64,
+	sender sdk.AccAddress) MsgCreateExchange {
 	return MsgCreateExchange{
-		Token:  token,
-		Sender: sender,
+		Token:         token,
+		QuoteToken:    quoteToken,
+		CurveType:     curveType,
+		TokenWeight:   tokenWeight,
+		Amplification: amplification,
+		Sender:        sender,
 	}
 }
 
@@ -158,12 +183,52 @@ func (msg MsgCreateExchange) ValidateBasic() sdk.Error {
 	if msg.Sender.Empty() {
 		return sdk.ErrInvalidAddress(msg.Sender.String())
 	}
-	if sdk.ValidateDenom(msg.Token) != nil || ValidatePoolTokenName(msg.Token) || msg.Token == sdk.DefaultBondDenom {
-		return sdk.ErrUnknownRequest("invalid Token")
+	quoteToken := msg.GetQuoteToken()
+	for _, token := range []string{msg.Token, quoteToken} {
+		if sdk.ValidateDenom(token) != nil || ValidatePoolTokenName(token) {
+			return sdk.ErrUnknownRequest("invalid Token")
+		}
+	}
+	if msg.Token == quoteToken {
+		return sdk.ErrUnknownRequest("invalid Token: the same as the quote token")
+	}
+	if err := msg.GetPoolCurve().Validate(); err != nil {
+		return sdk.ErrUnknownRequest(err.Error())
 	}
 	return nil
 }
 
+// GetPoolCurve returns the curve of the pool, with the weight of Token converted to the weight of the base token
+func (msg MsgCreateExchange) GetPoolCurve() PoolCurve {
+	switch msg.CurveType {
+	case "", CurveConstantProduct:
+		return DefaultPoolCurve()
+	case CurveWeighted:
+		baseWeight := msg.TokenWeight
+		if !baseWeight.IsNil() {
+			if baseToken, _ := GetBaseQuoteTokens(msg.Token, msg.GetQuoteToken()); baseToken != msg.Token {
+				baseWeight = sdk.OneDec().Sub(baseWeight)
+			}
+		}
+		return NewPoolCurve(CurveWeighted, baseWeight, 0)
+	default:
+		return NewPoolCurve(msg.CurveType, sdk.ZeroDec(), msg.Amplification)
+	}
+}
+
+// GetQuoteToken returns the quote token, the native token by default
+func (msg MsgCreateExchange) GetQuoteToken() string {
+	if msg.QuoteToken == "" {
+		return common.NativeToken
+	}
+	return msg.QuoteToken
+}
+
+// GetSwapTokenPair defines token pair
+func (msg MsgCreateExchange) GetSwapTokenPair() string {
+	return GetSwapTokenPairName(msg.Token, msg.GetQuoteToken())
+}
+
 // GetSignBytes encodes the message for signing
 func (msg MsgCreateExchange) GetSignBytes() []byte {
 	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
@@ -174,7 +239,7 @@ func (msg MsgCreateExchange) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Sender}
 }
 
-// MsgTokenToToken define the message for swap between token and DefaultBondDenom
+// MsgTokenToToken define the message for swap between two tokens
 type MsgTokenToToken struct {
 	SoldTokenAmount      sdk.DecCoin    `json:"sold_token_amount"`       // Amount of Tokens sold.
 	MinBoughtTokenAmount sdk.DecCoin    `json:"min_bought_token_amount"` // Minimum token purchased.
@@ -237,8 +302,266 @@ func (msg MsgTokenToToken) GetSigners() []sdk.AccAddress {
 
 // GetSwapTokenPair defines token pair
 func (msg MsgTokenToToken) GetSwapTokenPair() string {
-	if msg.SoldTokenAmount.Denom == sdk.DefaultBondDenom {
-		return msg.MinBoughtTokenAmount.Denom + "_" + msg.SoldTokenAmount.Denom
+	return GetSwapTokenPairName(msg.SoldTokenAmount.Denom, msg.MinBoughtTokenAmount.Denom)
+}
+
+// MsgMultiHopSwap define the message for swap through a route of token pairs
+type MsgMultiHopSwap struct {
+	SoldTokenAmount      sdk.DecCoin    `json:"sold_token_amount"`       // Amount of Tokens sold.
+	MinBoughtTokenAmount sdk.DecCoin    `json:"min_bought_token_amount"` // Minimum token purchased.
+	SwapRoute            []string       `json:"swap_route"`              // Token pairs to swap through in order, the best route is used if empty.
+	Deadline             int64          `json:"deadline"`                // Time after which this transaction can no longer be executed.
+	Recipient            sdk.AccAddress `json:"recipient"`               // Recipient address,transfer Tokens to recipient.default recipient is sender.
+	Sender               sdk.AccAddress `json:"sender"`                  // Sender
+}
+
+// NewMsgMultiHopSwap is a constructor function for MsgMultiHopSwap
+func NewMsgMultiHopSwap(
+	soldTokenAmount, minBoughtTokenAmount sdk.DecCoin, route []string, deadline int64, recipient, sender sdk.AccAddress,
+) MsgMultiHopSwap {
+	return MsgMultiHopSwap{
+		SoldTokenAmount:      soldTokenAmount,
+		MinBoughtTokenAmount: minBoughtTokenAmount,
+		SwapRoute:            route,
+		Deadline:             deadline,
+		Recipient:            recipient,
+		Sender:               sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgMultiHopSwap) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgMultiHopSwap) Type() string { return TypeMsgMultiHopSwap }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgMultiHopSwap) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.Recipient.Empty() {
+		return sdk.ErrInvalidAddress(msg.Recipient.String())
+	}
+	if !(msg.SoldTokenAmount.IsPositive()) {
+		return sdk.ErrUnknownRequest("token amount must be positive")
+	}
+	if !msg.SoldTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid SoldTokenAmount")
+	}
+	if !msg.MinBoughtTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid MinBoughtTokenAmount")
+	}
+	if msg.SoldTokenAmount.Denom == msg.MinBoughtTokenAmount.Denom {
+		return sdk.ErrUnknownRequest("the token to sell is the same as the token to buy")
+	}
+	if len(msg.SwapRoute) > 0 {
+		if err := ValidateSwapRoute(msg.SwapRoute, msg.SoldTokenAmount.Denom, msg.MinBoughtTokenAmount.Denom); err != nil {
+			return sdk.ErrUnknownRequest(err.Error())
+		}
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgMultiHopSwap) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgMultiHopSwap) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgTokenToTokenExactOutput define the message for swap to buy exactly the amount of token
+type MsgTokenToTokenExactOutput struct {
+	MaxSoldTokenAmount sdk.DecCoin    `json:"max_sold_token_amount"` // Maximum amount of Tokens sold.
+	BoughtTokenAmount  sdk.DecCoin    `json:"bought_token_amount"`   // Amount of Tokens purchased.
+	Deadline           int64          `json:"deadline"`              // Time after which this transaction can no longer be executed.
+	Recipient          sdk.AccAddress `json:"recipient"`             // Recipient address,transfer Tokens to recipient.default recipient is sender.
+	Sender             sdk.AccAddress `json:"sender"`                // Sender
+}
+
+// NewMsgTokenToTokenExactOutput is a constructor function for MsgTokenToTokenExactOutput
+func NewMsgTokenToTokenExactOutput(
+	maxSoldTokenAmount, boughtTokenAmount sdk.DecCoin, deadline int64, recipient, sender sdk.AccAddress,
+) MsgTokenToTokenExactOutput {
+	return MsgTokenToTokenExactOutput{
+		MaxSoldTokenAmount: maxSoldTokenAmount,
+		BoughtTokenAmount:  boughtTokenAmount,
+		Deadline:           deadline,
+		Recipient:          recipient,
+		Sender:             sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgTokenToTokenExactOutput) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgTokenToTokenExactOutput) Type() string { return TypeMsgTokenSwapExactOutput }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgTokenToTokenExactOutput) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.Recipient.Empty() {
+		return sdk.ErrInvalidAddress(msg.Recipient.String())
+	}
+	if !(msg.BoughtTokenAmount.IsPositive()) {
+		return sdk.ErrUnknownRequest("token amount must be positive")
+	}
+	if !msg.BoughtTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid BoughtTokenAmount")
+	}
+	if !msg.MaxSoldTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid MaxSoldTokenAmount")
 	}
-	return msg.SoldTokenAmount.Denom + "_" + msg.MinBoughtTokenAmount.Denom
+	if msg.MaxSoldTokenAmount.Denom == msg.BoughtTokenAmount.Denom {
+		return sdk.ErrUnknownRequest("the token to sell is the same as the token to buy")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgTokenToTokenExactOutput) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgTokenToTokenExactOutput) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetSwapTokenPair defines token pair
+func (msg MsgTokenToTokenExactOutput) GetSwapTokenPair() string {
+	return GetSwapTokenPairName(msg.MaxSoldTokenAmount.Denom, msg.BoughtTokenAmount.Denom)
+}
+
+// MsgZapIn swaps the optimal part of a single token for the paired token and deposits both of them
+type MsgZapIn struct {
+	SoldTokenAmount sdk.DecCoin    `json:"sold_token_amount"` // Amount of the single token deposited
+	PairedToken     string         `json:"paired_token"`      // The other token of the token pair
+	MinLiquidity    sdk.Dec        `json:"min_liquidity"`     // Minimum number of pool tokens minted
+	Deadline        int64          `json:"deadline"`          // Time after which this transaction can no longer be executed.
+	Sender          sdk.AccAddress `json:"sender"`            // Sender
+}
+
+// NewMsgZapIn is a constructor function for MsgZapIn
+func NewMsgZapIn(soldTokenAmount sdk.DecCoin, pairedToken string, minLiquidity sdk.Dec, deadline int64,
+	sender sdk.AccAddress) MsgZapIn {
+	return MsgZapIn{
+		SoldTokenAmount: soldTokenAmount,
+		PairedToken:     pairedToken,
+		MinLiquidity:    minLiquidity,
+		Deadline:        deadline,
+		Sender:          sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgZapIn) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgZapIn) Type() string { return TypeMsgZapIn }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgZapIn) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if !msg.SoldTokenAmount.IsPositive() {
+		return sdk.ErrUnknownRequest("token amount must be positive")
+	}
+	if !msg.SoldTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid SoldTokenAmount")
+	}
+	if sdk.ValidateDenom(msg.PairedToken) != nil {
+		return sdk.ErrUnknownRequest("invalid PairedToken")
+	}
+	if msg.SoldTokenAmount.Denom == msg.PairedToken {
+		return sdk.ErrUnknownRequest("the sold token is the same as the paired token")
+	}
+	if msg.MinLiquidity.IsNil() || msg.MinLiquidity.IsNegative() {
+		return sdk.ErrUnknownRequest("invalid MinLiquidity")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgZapIn) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgZapIn) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetSwapTokenPair defines token pair
+func (msg MsgZapIn) GetSwapTokenPair() string {
+	return GetSwapTokenPairName(msg.SoldTokenAmount.Denom, msg.PairedToken)
+}
+
+// MsgZapOut removes liquidity and swaps the paired token for the single token returned
+type MsgZapOut struct {
+	Liquidity            sdk.Dec        `json:"liquidity"`               // Amount of pool token burned.
+	MinBoughtTokenAmount sdk.DecCoin    `json:"min_bought_token_amount"` // Minimum amount of the single token returned
+	PairedToken          string         `json:"paired_token"`            // The other token of the token pair
+	Deadline             int64          `json:"deadline"`                // Time after which this transaction can no longer be executed.
+	Sender               sdk.AccAddress `json:"sender"`                  // Sender
+}
+
+// NewMsgZapOut is a constructor function for MsgZapOut
+func NewMsgZapOut(liquidity sdk.Dec, minBoughtTokenAmount sdk.DecCoin, pairedToken string, deadline int64,
+	sender sdk.AccAddress) MsgZapOut {
+	return MsgZapOut{
+		Liquidity:            liquidity,
+		MinBoughtTokenAmount: minBoughtTokenAmount,
+		PairedToken:          pairedToken,
+		Deadline:             deadline,
+		Sender:               sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgZapOut) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgZapOut) Type() string { return TypeMsgZapOut }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgZapOut) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.Liquidity.IsNil() || !msg.Liquidity.IsPositive() {
+		return sdk.ErrUnknownRequest("liquidity must be positive")
+	}
+	if !msg.MinBoughtTokenAmount.IsValid() {
+		return sdk.ErrUnknownRequest("invalid MinBoughtTokenAmount")
+	}
+	if sdk.ValidateDenom(msg.PairedToken) != nil {
+		return sdk.ErrUnknownRequest("invalid PairedToken")
+	}
+	if msg.MinBoughtTokenAmount.Denom == msg.PairedToken {
+		return sdk.ErrUnknownRequest("the bought token is the same as the paired token")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgZapOut) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgZapOut) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetSwapTokenPair defines token pair
+func (msg MsgZapOut) GetSwapTokenPair() string {
+	return GetSwapTokenPairName(msg.MinBoughtTokenAmount.Denom, msg.PairedToken)
 }
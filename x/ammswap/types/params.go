@@ -8,11 +8,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
-// FeeRate defines swap fee rate
+// FeeRate defines swap fee rate, ProtocolFeeRate defines the fraction of the swap fee sent to the community pool
 var (
-	defaultFeeRate = sdk.NewDecWithPrec(3, 3)
+	defaultFeeRate         = sdk.NewDecWithPrec(3, 3)
+	defaultProtocolFeeRate = sdk.ZeroDec()
 )
 
+// defaultPriceObservationRetention keeps the price observations of about one day
+const defaultPriceObservationRetention = int64(28800)
+
 // Default parameter namespace
 const (
 	DefaultParamspace = ModuleName
@@ -22,7 +26,10 @@ const (
 
 // Parameter store keys
 var (
-	KeyFeeRate = []byte("FeeRate")
+	KeyFeeRate         = []byte("FeeRate")
+	KeyProtocolFeeRate = []byte("ProtocolFeeRate")
+
+	KeyPriceObservationRetention = []byte("PriceObservationRetention")
 )
 
 // ParamKeyTable for swap module
@@ -32,30 +39,54 @@ func ParamKeyTable() params.KeyTable {
 
 // Params - used for initializing default parameter for swap at genesis
 type Params struct {
-	FeeRate sdk.Dec `json:"fee_rate"`
+	FeeRate         sdk.Dec `json:"fee_rate"`
+	ProtocolFeeRate sdk.Dec `json:"protocol_fee_rate"`
+	// the number of blocks to keep the price observations of the pools for the TWAP
+	PriceObservationRetention int64 `json:"price_observation_retention"`
 }
 
 // NewParams creates a new Params object
-func NewParams(feeRate sdk.Dec) Params {
+func NewParams(feeRate, protocolFeeRate sdk.Dec, priceObservationRetention int64) Params {
 	return Params{
-		FeeRate: feeRate,
+		FeeRate:                   feeRate,
+		ProtocolFeeRate:           protocolFeeRate,
+		PriceObservationRetention: priceObservationRetention,
 	}
 }
 
 // String implements the stringer interface for Params
 func (p Params) String() string {
 	return fmt.Sprintf(`Poolswap Params:
-  TradeFeeRate: %s`, p.FeeRate)
+  TradeFeeRate: %s
+  ProtocolFeeRate: %s
+  PriceObservationRetention: %d`, p.FeeRate, p.ProtocolFeeRate, p.PriceObservationRetention)
 }
 
 // ParamSetPairs implements params.ParamSet
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		{Key: KeyFeeRate, Value: &p.FeeRate},
+		{Key: KeyProtocolFeeRate, Value: &p.ProtocolFeeRate},
+		{Key: KeyPriceObservationRetention, Value: &p.PriceObservationRetention},
 	}
 }
 
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
-	return NewParams(defaultFeeRate)
+	return NewParams(defaultFeeRate, defaultProtocolFeeRate, defaultPriceObservationRetention)
+}
+
+// Validate checks that the fee rates are between zero and one and the price observations are retained
+func (p Params) Validate() error {
+	if p.FeeRate.IsNil() || p.FeeRate.IsNegative() || p.FeeRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("invalid fee rate: %s, it should be between 0 and 1", p.FeeRate)
+	}
+	if p.ProtocolFeeRate.IsNil() || p.ProtocolFeeRate.IsNegative() || p.ProtocolFeeRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("invalid protocol fee rate: %s, it should be between 0 and 1", p.ProtocolFeeRate)
+	}
+	if p.PriceObservationRetention <= 0 {
+		return fmt.Errorf("invalid price observation retention: %d, it should be positive",
+			p.PriceObservationRetention)
+	}
+	return nil
 }
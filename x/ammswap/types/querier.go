@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -8,3 +11,31 @@ type QueryBuyAmountParams struct {
 	SoldToken    sdk.DecCoin
 	TokenToBuy string
 }
+
+// QuerySellAmountParams defines the params to query the amount of token to sell for buying the token
+type QuerySellAmountParams struct {
+	BoughtToken sdk.DecCoin
+	TokenToSell string
+}
+
+// SwapRoute is the route of token pairs to swap through and the amount of token bought by every hop
+type SwapRoute struct {
+	Route   []string      `json:"route"`
+	Amounts []sdk.DecCoin `json:"amounts"`
+}
+
+// String implement fmt.Stringer
+func (r SwapRoute) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Route: %s
+Amounts: %s`, strings.Join(r.Route, ","), sdk.DecCoins(r.Amounts).String()))
+}
+
+// QueryTWAPParams defines the params to query the TWAP of the token pair. The TWAP is between the start and end
+// heights, or over the window of blocks before the end height if the window is positive. The latest height is used
+// if the end height is zero.
+type QueryTWAPParams struct {
+	TokenPairName string `json:"token_pair_name"`
+	StartHeight   int64  `json:"start_height"`
+	EndHeight     int64  `json:"end_height"`
+	Window        int64  `json:"window"`
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxSwapHops is the maximum number of token pairs in a swap route
+const MaxSwapHops = 3
+
+// GetSwapRouteTokens returns the tokens of a route starting from the sold token,
+// an error is returned if the token pairs of the route aren't connected
+func GetSwapRouteTokens(route []string, soldToken string) ([]string, error) {
+	tokens := []string{soldToken}
+	for _, tokenPairName := range route {
+		pairTokens := strings.Split(tokenPairName, "_")
+		if len(pairTokens) != 2 || GetSwapTokenPairName(pairTokens[0], pairTokens[1]) != tokenPairName {
+			return nil, fmt.Errorf("invalid token pair %s in the route", tokenPairName)
+		}
+		token := tokens[len(tokens)-1]
+		switch token {
+		case pairTokens[0]:
+			tokens = append(tokens, pairTokens[1])
+		case pairTokens[1]:
+			tokens = append(tokens, pairTokens[0])
+		default:
+			return nil, fmt.Errorf("token pair %s in the route doesn't contain %s", tokenPairName, token)
+		}
+	}
+	return tokens, nil
+}
+
+// ValidateSwapRoute checks whether the route swaps the sold token to the bought token through at most MaxSwapHops
+// token pairs, without passing any token twice
+func ValidateSwapRoute(route []string, soldToken, boughtToken string) error {
+	if len(route) == 0 || len(route) > MaxSwapHops {
+		return fmt.Errorf("the number of token pairs in the route must be between 1 and %d", MaxSwapHops)
+	}
+	tokens, err := GetSwapRouteTokens(route, soldToken)
+	if err != nil {
+		return err
+	}
+	if tokens[len(tokens)-1] != boughtToken {
+		return fmt.Errorf("the route doesn't end with %s", boughtToken)
+	}
+	passed := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		if passed[token] {
+			return fmt.Errorf("the route passes %s more than once", token)
+		}
+		passed[token] = true
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSwapRoute(t *testing.T) {
+	xxbOkt := GetSwapTokenPairName("xxb", common.NativeToken)
+	yybOkt := GetSwapTokenPairName("yyb", common.NativeToken)
+	xxbYyb := GetSwapTokenPairName("xxb", "yyb")
+	tests := []struct {
+		testCase  string
+		route     []string
+		expectErr bool
+	}{
+		{"one hop", []string{xxbYyb}, false},
+		{"two hops", []string{xxbOkt, yybOkt}, false},
+		{"empty route", nil, true},
+		{"too many hops", []string{xxbOkt, yybOkt, xxbYyb, xxbOkt}, true},
+		{"invalid token pair", []string{"xxb"}, true},
+		{"non canonical token pair", []string{"yyb_xxb"}, true},
+		{"disconnected token pairs", []string{xxbOkt, xxbYyb}, true},
+		{"ending with another token", []string{xxbOkt}, true},
+		{"passing a token twice", []string{xxbYyb, xxbYyb, xxbYyb}, true},
+	}
+	for _, test := range tests {
+		err := ValidateSwapRoute(test.route, "xxb", "yyb")
+		require.Equal(t, test.expectErr, err != nil, test.testCase)
+	}
+}
@@ -3,21 +3,28 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/okex/okexchain/x/common"
 	token "github.com/okex/okexchain/x/token/types"
 
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
-// PoolTokenPrefix defines pool token prefix name
-const PoolTokenPrefix = "ammswap-"
+const (
+	// PoolTokenPrefix defines pool token prefix name
+	PoolTokenPrefix = "ammswap-"
+	// poolTokenSeqPrefix prefixes the sequenced pool token names of the pairs without the native token
+	poolTokenSeqPrefix = "lp"
+)
 
 // SwapTokenPair defines token pair exchange
 type SwapTokenPair struct {
 	QuotePooledCoin sdk.DecCoin `json:"quote_pooled_coin"` // The volume of quote token in the token pair exchange pool
 	BasePooledCoin  sdk.DecCoin `json:"base_pooled_coin"`  // The volume of base token in the token pair exchange pool
 	PoolTokenName   string      `json:"pool_token_name"`   // The name of pool token
+	Curve           PoolCurve   `json:"curve"`             // The curve of the pool
 }
 
 // NewSwapTokenPair is a constructor function for SwapTokenPair
@@ -34,7 +41,8 @@ func NewSwapTokenPair(quotePooledCoin sdk.DecCoin, basePooledCoin sdk.DecCoin, p
 func (s SwapTokenPair) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`QuotePooledCoin: %s
 BasePooledCoin: %s
-PoolTokenName: %s`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName))
+PoolTokenName: %s
+Curve: %s`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName, s.Curve))
 }
 
 // TokenPairName defines token pair
@@ -42,6 +50,40 @@ func (s SwapTokenPair) TokenPairName() string {
 	return s.BasePooledCoin.Denom + "_" + s.QuotePooledCoin.Denom
 }
 
+// GetSwapTokenPairName returns the canonical name of the token pair of two tokens.
+// The native token is always the quote token, otherwise the tokens are ordered alphabetically.
+func GetSwapTokenPairName(token0, token1 string) string {
+	baseToken, quoteToken := GetBaseQuoteTokens(token0, token1)
+	return baseToken + "_" + quoteToken
+}
+
+// GetBaseQuoteTokens returns the base and quote tokens of two tokens by the canonical order
+func GetBaseQuoteTokens(token0, token1 string) (baseToken, quoteToken string) {
+	if token0 == common.NativeToken || (token1 != common.NativeToken && token0 > token1) {
+		return token1, token0
+	}
+	return token0, token1
+}
+
+// GetPoolTokenName returns the pool token name of the token pair. The pool token of a pair quoted in the
+// native token is named after the base token, the others are named by the sequence of such pools, which is
+// unique and fits the length limit of the denom.
+func GetPoolTokenName(baseToken, quoteToken string, seq uint64) string {
+	if quoteToken == common.NativeToken {
+		return PoolTokenPrefix + baseToken
+	}
+	return PoolTokenPrefix + poolTokenSeqPrefix + strconv.FormatUint(seq, 10)
+}
+
+// GetPoolTokenSeq returns the sequence of a sequenced pool token name, false is returned for the other names
+func GetPoolTokenSeq(poolTokenName string) (uint64, bool) {
+	seq, err := strconv.ParseUint(strings.TrimPrefix(poolTokenName, PoolTokenPrefix+poolTokenSeqPrefix), 10, 64)
+	if err != nil || !strings.HasPrefix(poolTokenName, PoolTokenPrefix+poolTokenSeqPrefix) {
+		return 0, false
+	}
+	return seq, true
+}
+
 // InitPoolToken default pool token
 func InitPoolToken(poolTokenName string) token.Token {
 	return token.Token{
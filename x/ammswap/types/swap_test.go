@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSwapTokenPairName(t *testing.T) {
+	tests := []struct {
+		token0, token1 string
+		expectedName   string
+	}{
+		{"xxb", common.NativeToken, "xxb_" + common.NativeToken},
+		{common.NativeToken, "xxb", "xxb_" + common.NativeToken},
+		{"xxb", "yyb", "xxb_yyb"},
+		{"yyb", "xxb", "xxb_yyb"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expectedName, GetSwapTokenPairName(test.token0, test.token1))
+	}
+}
+
+func TestGetPoolTokenName(t *testing.T) {
+	require.Equal(t, PoolTokenPrefix+"xxb", GetPoolTokenName("xxb", common.NativeToken, 1))
+	_, ok := GetPoolTokenSeq(PoolTokenPrefix + "xxb")
+	require.False(t, ok)
+
+	poolTokenName := GetPoolTokenName("aaa-a1b", "bbb-c2d", 1)
+	require.Equal(t, PoolTokenPrefix+"lp1", poolTokenName)
+	require.True(t, ValidatePoolTokenName(poolTokenName))
+	require.True(t, ValidatePoolTokenName(GetPoolTokenName("aaa-a1b", "bbb-c2d", 99999999)))
+	seq, ok := GetPoolTokenSeq(poolTokenName)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), seq)
+	require.NotEqual(t, poolTokenName, GetPoolTokenName("aaa-a1b", "ccc-c2d", 2))
+}
@@ -18,5 +18,6 @@ func GetTestSwapTokenPair() SwapTokenPair {
 		QuotePooledCoin: sdk.NewDecCoinFromDec(TestQuotePooledToken, sdk.NewDec(0)),
 		BasePooledCoin:  sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(0)),
 		PoolTokenName:   PoolTokenPrefix + TestBasePooledToken,
+		Curve:           DefaultPoolCurve(),
 	}
 }
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PriceObservation defines the cumulative prices of a token pair at a block. The cumulative price is the sum of
+// the price at the beginning of every block multiplied by the seconds elapsed until the next block.
+type PriceObservation struct {
+	TokenPairName        string  `json:"token_pair_name"`
+	Height               int64   `json:"height"`
+	BlockTime            int64   `json:"block_time"`             // unix time of the block in seconds
+	BasePriceCumulative  sdk.Dec `json:"base_price_cumulative"`  // cumulative price of base token in quote token
+	QuotePriceCumulative sdk.Dec `json:"quote_price_cumulative"` // cumulative price of quote token in base token
+}
+
+// NewPriceObservation is a constructor function for PriceObservation
+func NewPriceObservation(tokenPairName string, height, blockTime int64) PriceObservation {
+	return PriceObservation{
+		TokenPairName:        tokenPairName,
+		Height:               height,
+		BlockTime:            blockTime,
+		BasePriceCumulative:  sdk.ZeroDec(),
+		QuotePriceCumulative: sdk.ZeroDec(),
+	}
+}
+
+// Accumulate returns the observation at the block, with the prices of the pool since the last observation
+// accumulated. Nothing is accumulated while the pool is empty.
+func (o PriceObservation) Accumulate(swapTokenPair SwapTokenPair, height, blockTime int64) PriceObservation {
+	elapsed := blockTime - o.BlockTime
+	if elapsed > 0 && swapTokenPair.BasePooledCoin.IsPositive() && swapTokenPair.QuotePooledCoin.IsPositive() {
+		numerator, denominator := swapTokenPair.Curve.SpotPrice(swapTokenPair.BasePooledCoin.Amount,
+			swapTokenPair.QuotePooledCoin.Amount)
+		o.BasePriceCumulative = o.BasePriceCumulative.Add(numerator.MulInt64(elapsed).Quo(denominator))
+		o.QuotePriceCumulative = o.QuotePriceCumulative.Add(denominator.MulInt64(elapsed).Quo(numerator))
+	}
+	o.Height = height
+	o.BlockTime = blockTime
+	return o
+}
+
+// String implement fmt.Stringer
+func (o PriceObservation) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`TokenPairName: %s
+Height: %d
+BlockTime: %d
+BasePriceCumulative: %s
+QuotePriceCumulative: %s`, o.TokenPairName, o.Height, o.BlockTime, o.BasePriceCumulative, o.QuotePriceCumulative))
+}
+
+// TWAP defines the time-weighted average prices of a token pair between two heights
+type TWAP struct {
+	TokenPairName string  `json:"token_pair_name"`
+	StartHeight   int64   `json:"start_height"`
+	EndHeight     int64   `json:"end_height"`
+	BasePrice     sdk.Dec `json:"base_price"`  // average price of base token in quote token
+	QuotePrice    sdk.Dec `json:"quote_price"` // average price of quote token in base token
+}
+
+// NewTWAP returns the time-weighted average prices between two observations of the token pair
+func NewTWAP(start, end PriceObservation) (TWAP, error) {
+	elapsed := end.BlockTime - start.BlockTime
+	if elapsed <= 0 {
+		return TWAP{}, fmt.Errorf("no time elapsed between height %d and %d", start.Height, end.Height)
+	}
+	return TWAP{
+		TokenPairName: end.TokenPairName,
+		StartHeight:   start.Height,
+		EndHeight:     end.Height,
+		BasePrice:     end.BasePriceCumulative.Sub(start.BasePriceCumulative).QuoInt64(elapsed),
+		QuotePrice:    end.QuotePriceCumulative.Sub(start.QuotePriceCumulative).QuoInt64(elapsed),
+	}, nil
+}
+
+// String implement fmt.Stringer
+func (t TWAP) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`TokenPairName: %s
+StartHeight: %d
+EndHeight: %d
+BasePrice: %s
+QuotePrice: %s`, t.TokenPairName, t.StartHeight, t.EndHeight, t.BasePrice, t.QuotePrice))
+}
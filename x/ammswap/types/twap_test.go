@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceObservationAccumulate(t *testing.T) {
+	swapTokenPair := SwapTokenPair{
+		BasePooledCoin:  sdk.NewDecCoinFromDec("xxb", sdk.NewDec(100)),
+		QuotePooledCoin: sdk.NewDecCoinFromDec(common.NativeToken, sdk.NewDec(400)),
+	}
+	start := NewPriceObservation(swapTokenPair.TokenPairName(), 1, 1000)
+
+	end := start.Accumulate(swapTokenPair, 2, 1003)
+	require.Equal(t, int64(2), end.Height)
+	require.Equal(t, sdk.NewDec(12), end.BasePriceCumulative)
+	require.Equal(t, sdk.MustNewDecFromStr("0.75"), end.QuotePriceCumulative)
+
+	twap, err := NewTWAP(start, end)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(4), twap.BasePrice)
+	require.Equal(t, sdk.MustNewDecFromStr("0.25"), twap.QuotePrice)
+
+	// nothing is accumulated while the pool is empty
+	swapTokenPair.BasePooledCoin.Amount = sdk.ZeroDec()
+	end = end.Accumulate(swapTokenPair, 3, 1010)
+	require.Equal(t, sdk.NewDec(12), end.BasePriceCumulative)
+	require.Equal(t, int64(1010), end.BlockTime)
+
+	_, err = NewTWAP(end, end)
+	require.NotNil(t, err)
+}
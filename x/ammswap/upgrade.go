@@ -0,0 +1,16 @@
+package ammswap
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/ammswap/keeper"
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+// UpgradeParams sets the params added by the software upgrade to their default values, it's run by the upgrade
+// module at the upgrade height, as the params don't exist in the store of the running chain
+func UpgradeParams(ctx sdk.Context, k keeper.Keeper) {
+	defaultParams := types.DefaultParams()
+	k.SetParamIfMissing(ctx, types.KeyProtocolFeeRate, defaultParams.ProtocolFeeRate)
+	k.SetParamIfMissing(ctx, types.KeyPriceObservationRetention, defaultParams.PriceObservationRetention)
+}
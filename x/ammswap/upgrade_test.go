@@ -0,0 +1,40 @@
+package ammswap
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/okex/okexchain/x/ammswap/types"
+)
+
+func TestUpgradeParams(t *testing.T) {
+	mapp, _ := getMockApp(t, 1)
+	k := mapp.swapKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+
+	params := types.DefaultParams()
+	params.ProtocolFeeRate = sdk.NewDecWithPrec(1, 1)
+	k.SetParams(ctx, params)
+
+	// the params existing before the upgrade are kept
+	UpgradeParams(ctx, k)
+	require.Equal(t, params, k.GetParams(ctx))
+
+	// the params added by the upgrade don't exist in the store of the running chain
+	paramsStore := ctx.KVStore(mapp.KeyParams)
+	for _, key := range [][]byte{types.KeyProtocolFeeRate, types.KeyPriceObservationRetention} {
+		paramsStore.Delete(append([]byte(DefaultParamspace+"/"), key...))
+	}
+	require.Panics(t, func() {
+		k.GetParams(ctx)
+	})
+
+	UpgradeParams(ctx, k)
+	defaultParams := types.DefaultParams()
+	require.Equal(t, defaultParams.ProtocolFeeRate, k.GetParams(ctx).ProtocolFeeRate)
+	require.Equal(t, defaultParams.PriceObservationRetention, k.GetParams(ctx).PriceObservationRetention)
+}
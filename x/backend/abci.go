@@ -19,6 +19,7 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) {
 		updateOrders(ctx, keeper)
 		storeDealAndMatchResult(ctx, keeper)
 		storeFeeDetails(keeper)
+		keeper.StoreSwapRecords(ctx)
 		storeTransactions(keeper)
 		keeper.Flush()
 		keeper.Logger.Debug(fmt.Sprintf("end backend endblocker: block---%d", ctx.BlockHeight()))
@@ -150,6 +151,13 @@ func GetNewDealsAndMatchResultsAtEndBlock(ctx sdk.Context, orderKeeper types.Ord
 
 		for _, record := range matchResult.Deals {
 			order := orderKeeper.GetOrder(ctx, record.OrderID)
+			dealPrice := price
+			// the continuous auction fills every deal at the price of its maker
+			if !record.Price.IsNil() && record.Price.IsPositive() {
+				if p, err := strconv.ParseFloat(record.Price.String(), 64); err == nil {
+					dealPrice = p
+				}
+			}
 			if quantity, err := strconv.ParseFloat(record.Quantity.String(), 64); err == nil {
 
 				deal := &types.Deal{
@@ -158,7 +166,7 @@ func GetNewDealsAndMatchResultsAtEndBlock(ctx sdk.Context, orderKeeper types.Ord
 					Side:        record.Side,
 					Sender:      order.Sender.String(),
 					Product:     product,
-					Price:       price,
+					Price:       dealPrice,
 					Quantity:    quantity,
 					Fee:         record.Fee,
 					Timestamp:   ctx.BlockHeader().Time.Unix(),
@@ -194,6 +202,8 @@ func GetNewOrdersAtEndBlock(ctx sdk.Context, orderKeeper types.OrderKeeper) ([]*
 				FilledAvgPrice: order.FilledAvgPrice.String(),
 				RemainQuantity: order.RemainQuantity.String(),
 				Timestamp:      order.Timestamp,
+				ExpireHeight:   order.ExpireHeight,
+				ExpireTime:     order.ExpireTime,
 			}
 			orders = append(orders, orderDb)
 		} else {
@@ -222,6 +232,8 @@ func GetUpdatedOrdersAtEndBlock(ctx sdk.Context, orderKeeper types.OrderKeeper)
 				FilledAvgPrice: order.FilledAvgPrice.String(),
 				RemainQuantity: order.RemainQuantity.String(),
 				Timestamp:      order.Timestamp,
+				ExpireHeight:   order.ExpireHeight,
+				ExpireTime:     order.ExpireTime,
 			}
 			orders = append(orders, orderDb)
 		}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/backend/cache"
 	"github.com/okex/okexchain/x/backend/config"
 	"github.com/okex/okexchain/x/backend/orm"
@@ -26,6 +27,7 @@ type Keeper struct {
 	TokenKeeper  types.TokenKeeper  // The reference to the TokenKeeper to get fee details
 	marketKeeper types.MarketKeeper // The reference to the MarketKeeper to get fee details
 	dexKeeper    types.DexKeeper    // The reference to the DexKeeper to get tokenpair
+	SwapKeeper   types.SwapKeeper   // The reference to the SwapKeeper to get swaps and pools
 	cdc          *codec.Codec       // The wire codec for binary encoding/decoding.
 	Orm          *orm.ORM
 	stopChan     chan struct{}
@@ -37,12 +39,14 @@ type Keeper struct {
 }
 
 // NewKeeper creates new instances of the nameservice Keeper
-func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, marketKeeper types.MarketKeeper, cdc *codec.Codec, logger log.Logger, cfg *config.Config) Keeper {
+func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, swapKeeper types.SwapKeeper,
+	marketKeeper types.MarketKeeper, cdc *codec.Codec, logger log.Logger, cfg *config.Config) Keeper {
 	k := Keeper{
 		OrderKeeper:  orderKeeper,
 		TokenKeeper:  tokenKeeper,
 		marketKeeper: marketKeeper,
 		dexKeeper:    dexKeeper,
+		SwapKeeper:   swapKeeper,
 		cdc:          cdc,
 		Logger:       logger.With("module", "backend"),
 		Config:       cfg,
@@ -213,6 +217,16 @@ func (k Keeper) GetFeeDetails(ctx sdk.Context, addr string, offset, limit int) (
 	return k.Orm.GetFeeDetails(addr, offset, limit)
 }
 
+// GetSwapInfos returns the swaps of the address in the pool
+func (k Keeper) GetSwapInfos(ctx sdk.Context, addr, tokenPairName string, start, end int64, offset, limit int) ([]ammswap.SwapInfo, int) {
+	return k.Orm.GetSwapInfos(addr, tokenPairName, start, end, offset, limit)
+}
+
+// GetSwapLiquidityInfos returns the liquidity changes of the address in the pool
+func (k Keeper) GetSwapLiquidityInfos(ctx sdk.Context, addr, tokenPairName string, offset, limit int) ([]ammswap.SwapLiquidityInfo, int) {
+	return k.Orm.GetSwapLiquidityInfos(addr, tokenPairName, offset, limit)
+}
+
 // nolint
 func (k Keeper) GetOrderList(ctx sdk.Context, addr, product, side string, open bool,
 	offset, limit int, startTS, endTS int64, hideNoFill bool) ([]types.Order, int) {
@@ -67,6 +67,12 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			}
 		case types.QueryDexFeesList:
 			res, err = queryDexFees(ctx, path[1:], req, keeper)
+		case types.QuerySwapInfos:
+			res, err = querySwapInfos(ctx, path[1:], req, keeper)
+		case types.QuerySwapLiquidityInfos:
+			res, err = querySwapLiquidityInfos(ctx, path[1:], req, keeper)
+		case types.QuerySwapPools:
+			res, err = querySwapPools(ctx, path[1:], req, keeper)
 
 		case types.QueryTickerListV2:
 			if keeper.Config.EnableMktCompute {
@@ -207,6 +213,102 @@ func queryFeeDetails(ctx sdk.Context, path []string, req abci.RequestQuery, keep
 	return bz, nil
 }
 
+func querySwapInfos(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QuerySwapInfosParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.Address != "" {
+		if _, err = sdk.AccAddressFromBech32(params.Address); err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("invalid address", err.Error()))
+		}
+	}
+	if params.Page < 0 || params.PerPage < 0 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d or per_page %d", params.Page, params.PerPage))
+	}
+
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+	swapInfos, total := keeper.GetSwapInfos(ctx, params.Address, params.TokenPairName, params.Start, params.End,
+		offset, limit)
+	var response *common.ListResponse
+	if len(swapInfos) > 0 {
+		response = common.GetListResponse(total, params.Page, params.PerPage, swapInfos)
+	} else {
+		response = common.GetEmptyListResponse(total, params.Page, params.PerPage)
+	}
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+	return bz, nil
+}
+
+func querySwapLiquidityInfos(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QuerySwapLiquidityInfosParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.Address != "" {
+		if _, err = sdk.AccAddressFromBech32(params.Address); err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("invalid address", err.Error()))
+		}
+	}
+	if params.Page < 0 || params.PerPage < 0 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d or per_page %d", params.Page, params.PerPage))
+	}
+
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+	liquidityInfos, total := keeper.GetSwapLiquidityInfos(ctx, params.Address, params.TokenPairName, offset, limit)
+	var response *common.ListResponse
+	if len(liquidityInfos) > 0 {
+		response = common.GetListResponse(total, params.Page, params.PerPage, liquidityInfos)
+	} else {
+		response = common.GetEmptyListResponse(total, params.Page, params.PerPage)
+	}
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+	return bz, nil
+}
+
+func querySwapPools(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QuerySwapPoolsParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if params.Page < 0 || params.PerPage < 0 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d or per_page %d", params.Page, params.PerPage))
+	}
+	if keeper.SwapKeeper == nil {
+		return nil, sdk.ErrUnknownRequest("ammswap is not available")
+	}
+
+	poolInfos, err := keeper.GetSwapPoolInfos(ctx, params.TokenPairName)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	total := len(poolInfos)
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+	var response *common.ListResponse
+	if offset < total {
+		if offset+limit < total {
+			poolInfos = poolInfos[:offset+limit]
+		}
+		response = common.GetListResponse(total, params.Page, params.PerPage, poolInfos[offset:])
+	} else {
+		response = common.GetEmptyListResponse(total, params.Page, params.PerPage)
+	}
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+	return bz, nil
+}
+
 func queryCandleList(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
 
 	var params types.QueryKlinesParams
@@ -0,0 +1,109 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
+	"github.com/okex/okexchain/x/backend/types"
+)
+
+// GetSwapPoolInfos returns the stats of the ammswap pools, or of the pool of the token pair if it's given
+func (k Keeper) GetSwapPoolInfos(ctx sdk.Context, tokenPairName string) ([]types.SwapPoolInfo, error) {
+	var swapTokenPairs []ammswap.SwapTokenPair
+	if tokenPairName != "" {
+		swapTokenPair, err := k.SwapKeeper.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return nil, err
+		}
+		swapTokenPairs = append(swapTokenPairs, swapTokenPair)
+	} else {
+		swapTokenPairs = k.SwapKeeper.GetSwapTokenPairs(ctx)
+	}
+
+	ts := k.Orm.GetMaxBlockTimestamp()
+	volumes, err := k.Orm.GetSwapVolumes(ts-types.SecondsInADay, ts+1)
+	if err != nil {
+		return nil, err
+	}
+	creations := make(map[string]ammswap.SwapTokenPairInfo)
+	for _, info := range k.Orm.GetSwapTokenPairInfos() {
+		creations[info.TokenPairName] = info
+	}
+
+	params := k.SwapKeeper.GetParams(ctx)
+	poolInfos := make([]types.SwapPoolInfo, 0, len(swapTokenPairs))
+	for _, swapTokenPair := range swapTokenPairs {
+		name := swapTokenPair.TokenPairName()
+		volume, err := sdk.NewDecFromStr(strconv.FormatFloat(volumes[name], 'f', sdk.Precision, 64))
+		if err != nil {
+			return nil, fmt.Errorf("invalid volume of %s: %s", name, err.Error())
+		}
+		poolInfo := types.NewSwapPoolInfo(swapTokenPair, volume, params)
+		if creation, ok := creations[name]; ok {
+			poolInfo.Creator = creation.Creator
+			poolInfo.CreateTime = creation.Timestamp
+		}
+		poolInfos = append(poolInfos, poolInfo)
+	}
+	return poolInfos, nil
+}
+
+// pushSwapPoolReserves pushes the reserves of the pools changed in the block to the websocket
+func (k Keeper) pushSwapPoolReserves(ctx sdk.Context, tokenPairNames []string) {
+	pushed := make(map[string]bool)
+	for _, name := range tokenPairNames {
+		if pushed[name] {
+			continue
+		}
+		pushed[name] = true
+		swapTokenPair, err := k.SwapKeeper.GetSwapTokenPair(ctx, name)
+		if err != nil {
+			k.Logger.Error(fmt.Sprintf("[backend] failed to get swap token pair %s: %s", name, err.Error()))
+			continue
+		}
+		k.pushWSItem(types.NewSwapPoolReserve(swapTokenPair, ctx.BlockTime().Unix()))
+	}
+}
+
+// StoreSwapRecords stores the ammswap records of the block, and pushes the reserves of the pools changed
+func (k Keeper) StoreSwapRecords(ctx sdk.Context) {
+	if k.SwapKeeper == nil {
+		return
+	}
+	var tokenPairNames []string
+
+	if infos := k.SwapKeeper.GetSwapTokenPairInfos(ctx); len(infos) > 0 {
+		cnt, err := k.Orm.AddSwapTokenPairInfos(infos)
+		if err != nil {
+			k.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d swapTokenPairInfos, inserted Count %d, err: %+v", len(infos), cnt, err))
+		} else {
+			k.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d swapTokenPairInfos, inserted Count %d", len(infos), cnt))
+		}
+	}
+	if infos := k.SwapKeeper.GetSwapLiquidityInfos(ctx); len(infos) > 0 {
+		cnt, err := k.Orm.AddSwapLiquidityInfos(infos)
+		if err != nil {
+			k.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d swapLiquidityInfos, inserted Count %d, err: %+v", len(infos), cnt, err))
+		} else {
+			k.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d swapLiquidityInfos, inserted Count %d", len(infos), cnt))
+		}
+		for _, info := range infos {
+			tokenPairNames = append(tokenPairNames, info.TokenPairName)
+		}
+	}
+	if infos := k.SwapKeeper.GetSwapInfos(ctx); len(infos) > 0 {
+		cnt, err := k.Orm.AddSwapInfos(infos)
+		if err != nil {
+			k.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d swapInfos, inserted Count %d, err: %+v", len(infos), cnt, err))
+		} else {
+			k.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d swapInfos, inserted Count %d", len(infos), cnt))
+		}
+		for _, info := range infos {
+			tokenPairNames = append(tokenPairNames, info.TokenPairName)
+		}
+	}
+
+	k.pushSwapPoolReserves(ctx, tokenPairNames)
+}
@@ -13,6 +13,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/backend/types"
 	"github.com/okex/okexchain/x/common"
 	tokenTypes "github.com/okex/okexchain/x/token/types"
@@ -124,6 +125,98 @@ func TestKeeper_Tx(t *testing.T) {
 	require.EqualValues(t, 1, len(getTxs))
 }
 
+type mockSwapKeeper struct {
+	swapInfos          []*ammswap.SwapInfo
+	liquidityInfos     []*ammswap.SwapLiquidityInfo
+	swapTokenPairInfos []*ammswap.SwapTokenPairInfo
+	swapTokenPairs     map[string]ammswap.SwapTokenPair
+}
+
+func (k mockSwapKeeper) GetSwapInfos(ctx sdk.Context) []*ammswap.SwapInfo { return k.swapInfos }
+func (k mockSwapKeeper) GetSwapLiquidityInfos(ctx sdk.Context) []*ammswap.SwapLiquidityInfo {
+	return k.liquidityInfos
+}
+func (k mockSwapKeeper) GetSwapTokenPairInfos(ctx sdk.Context) []*ammswap.SwapTokenPairInfo {
+	return k.swapTokenPairInfos
+}
+func (k mockSwapKeeper) GetSwapTokenPair(ctx sdk.Context, tokenPairName string) (ammswap.SwapTokenPair, error) {
+	swapTokenPair, ok := k.swapTokenPairs[tokenPairName]
+	if !ok {
+		return swapTokenPair, fmt.Errorf("swap token pair %s does not exist", tokenPairName)
+	}
+	return swapTokenPair, nil
+}
+func (k mockSwapKeeper) GetSwapTokenPairs(ctx sdk.Context) []ammswap.SwapTokenPair {
+	var swapTokenPairs []ammswap.SwapTokenPair
+	for _, swapTokenPair := range k.swapTokenPairs {
+		swapTokenPairs = append(swapTokenPairs, swapTokenPair)
+	}
+	return swapTokenPairs
+}
+func (k mockSwapKeeper) GetParams(ctx sdk.Context) ammswap.Params {
+	return ammswap.NewParams(sdk.NewDecWithPrec(3, 3), sdk.ZeroDec(), 10)
+}
+
+func TestKeeper_StoreSwapRecords(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1, true, "")
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	now := time.Now().Unix()
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{Time: time.Unix(now, 0)}).WithBlockHeight(2)
+	addr := addrKeysSlice[0].Address.String()
+
+	name := ammswap.TestSwapTokenPairName
+	swapTokenPair := ammswap.SwapTokenPair{
+		BasePooledCoin:  sdk.NewDecCoin(ammswap.TestBasePooledToken, sdk.NewInt(100)),
+		QuotePooledCoin: sdk.NewDecCoin(ammswap.TestQuotePooledToken, sdk.NewInt(400)),
+		PoolTokenName:   ammswap.GetPoolTokenName(ammswap.TestBasePooledToken, ammswap.TestQuotePooledToken, 0),
+		Curve:           ammswap.DefaultPoolCurve(),
+	}
+	keeper := mapp.backendKeeper
+	keeper.SwapKeeper = mockSwapKeeper{
+		swapInfos: []*ammswap.SwapInfo{
+			{Address: addr, TokenPairName: name, Volume: 100, Timestamp: now},
+			{Address: addr, TokenPairName: name, Volume: 300, Timestamp: now},
+		},
+		liquidityInfos: []*ammswap.SwapLiquidityInfo{
+			{Address: addr, TokenPairName: name, Type: ammswap.LiquidityTypeAdd, Timestamp: now},
+		},
+		swapTokenPairInfos: []*ammswap.SwapTokenPairInfo{{TokenPairName: name, Creator: addr, Timestamp: now}},
+		swapTokenPairs:     map[string]ammswap.SwapTokenPair{name: swapTokenPair},
+	}
+	keeper.Orm.SetMaxBlockTimestamp(now)
+	keeper.StoreSwapRecords(ctx)
+
+	swapInfos, total := keeper.GetSwapInfos(ctx, addr, name, 0, 0, 0, 10)
+	require.Equal(t, 2, total)
+	require.Equal(t, 2, len(swapInfos))
+	_, total = keeper.GetSwapLiquidityInfos(ctx, addr, "", 0, 10)
+	require.Equal(t, 1, total)
+
+	// the fees of the liquidity providers in 24 hours are 400*0.003, over the liquidity of 800
+	poolInfos, err := keeper.GetSwapPoolInfos(ctx, "")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(poolInfos))
+	require.Equal(t, sdk.NewDec(4), poolInfos[0].Price)
+	require.Equal(t, sdk.NewDec(800), poolInfos[0].TVL)
+	require.Equal(t, sdk.NewDec(400), poolInfos[0].Volume24h)
+	require.Equal(t, sdk.MustNewDecFromStr("0.5475"), poolInfos[0].APY)
+	require.Equal(t, addr, poolInfos[0].Creator)
+	_, err = keeper.GetSwapPoolInfos(ctx, "abc_"+common.NativeToken)
+	require.NotNil(t, err)
+
+	// the reserves of the pool are pushed to the websocket
+	keeper.EmitAllWsItems(ctx)
+	found := false
+	for _, event := range ctx.EventManager().Events() {
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == "channel" && string(attr.Value) == "dex_spot/pool_reserve:"+name {
+				found = true
+			}
+		}
+	}
+	require.True(t, found)
+}
+
 func TestKeeper_CleanUpKlines(t *testing.T) {
 	o, _ := orm.MockSqlite3ORM()
 	ch := make(chan struct{}, 1)
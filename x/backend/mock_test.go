@@ -150,6 +150,7 @@ func getMockApp(t *testing.T, numGenAccs int, enableBackend bool, dbDir string)
 		mockApp.tokenKeeper,
 		&mockApp.dexKeeper,
 		nil,
+		nil,
 		mockApp.Cdc,
 		mockApp.Logger(),
 		cfg)
@@ -18,6 +18,7 @@ import (
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/backend/types"
 	"github.com/okex/okexchain/x/token"
 	"github.com/pkg/errors"
@@ -100,6 +101,9 @@ func New(enableLog bool, engineInfo *OrmEngineInfo, logger *log.Logger) (m *ORM,
 	orm.db.AutoMigrate(&token.FeeDetail{})
 	orm.db.AutoMigrate(&types.Order{})
 	orm.db.AutoMigrate(&types.Transaction{})
+	orm.db.AutoMigrate(&ammswap.SwapInfo{})
+	orm.db.AutoMigrate(&ammswap.SwapLiquidityInfo{})
+	orm.db.AutoMigrate(&ammswap.SwapTokenPairInfo{})
 
 	allKlinesMap := types.GetAllKlineMap()
 	for _, v := range allKlinesMap {
@@ -1458,3 +1462,148 @@ func (orm *ORM) GetTransactionListV2(address string, txType int, after string, b
 	query.Order("timestamp desc").Limit(limit).Find(&txs)
 	return txs
 }
+
+// AddSwapInfos insert into swap infos, return count
+func (orm *ORM) AddSwapInfos(swapInfos []*ammswap.SwapInfo) (addedCnt int, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	cnt := 0
+	tx := orm.db.Begin()
+	defer orm.deferRollbackTx(tx, err)
+
+	for _, swapInfo := range swapInfos {
+		if swapInfo != nil {
+			ret := tx.Create(swapInfo)
+			if ret.Error != nil {
+				return cnt, ret.Error
+			}
+			cnt++
+		}
+	}
+
+	tx.Commit()
+	return cnt, nil
+}
+
+// GetSwapInfos returns the swaps of the address in the pool, both filters are optional
+func (orm *ORM) GetSwapInfos(address, tokenPairName string, startTime, endTime int64, offset, limit int) ([]ammswap.SwapInfo, int) {
+	var swapInfos []ammswap.SwapInfo
+	query := orm.db.Model(ammswap.SwapInfo{})
+	if address != "" {
+		query = query.Where("address = ?", address)
+	}
+	if tokenPairName != "" {
+		query = query.Where("token_pair_name = ?", tokenPairName)
+	}
+	if startTime > 0 {
+		query = query.Where("timestamp >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("timestamp < ?", endTime)
+	}
+	var total int
+	query.Count(&total)
+	if offset >= total {
+		return swapInfos, total
+	}
+
+	query.Order("timestamp desc").Offset(offset).Limit(limit).Find(&swapInfos)
+	return swapInfos, total
+}
+
+// GetSwapVolumes returns the volumes in the quote token of every pool swapped in [startTime, endTime)
+func (orm *ORM) GetSwapVolumes(startTime, endTime int64) (map[string]float64, error) {
+	rows, err := orm.db.Model(ammswap.SwapInfo{}).
+		Select("token_pair_name, sum(volume) as volume").
+		Where("timestamp >= ? and timestamp < ?", startTime, endTime).
+		Group("token_pair_name").Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	volumes := make(map[string]float64)
+	for rows.Next() {
+		var tokenPairName string
+		var volume float64
+		if err := rows.Scan(&tokenPairName, &volume); err != nil {
+			return nil, err
+		}
+		volumes[tokenPairName] = volume
+	}
+	return volumes, nil
+}
+
+// AddSwapLiquidityInfos insert into swap liquidity infos, return count
+func (orm *ORM) AddSwapLiquidityInfos(liquidityInfos []*ammswap.SwapLiquidityInfo) (addedCnt int, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	cnt := 0
+	tx := orm.db.Begin()
+	defer orm.deferRollbackTx(tx, err)
+
+	for _, liquidityInfo := range liquidityInfos {
+		if liquidityInfo != nil {
+			ret := tx.Create(liquidityInfo)
+			if ret.Error != nil {
+				return cnt, ret.Error
+			}
+			cnt++
+		}
+	}
+
+	tx.Commit()
+	return cnt, nil
+}
+
+// GetSwapLiquidityInfos returns the liquidity changes of the address in the pool, both filters are optional
+func (orm *ORM) GetSwapLiquidityInfos(address, tokenPairName string, offset, limit int) ([]ammswap.SwapLiquidityInfo, int) {
+	var liquidityInfos []ammswap.SwapLiquidityInfo
+	query := orm.db.Model(ammswap.SwapLiquidityInfo{})
+	if address != "" {
+		query = query.Where("address = ?", address)
+	}
+	if tokenPairName != "" {
+		query = query.Where("token_pair_name = ?", tokenPairName)
+	}
+	var total int
+	query.Count(&total)
+	if offset >= total {
+		return liquidityInfos, total
+	}
+
+	query.Order("timestamp desc").Offset(offset).Limit(limit).Find(&liquidityInfos)
+	return liquidityInfos, total
+}
+
+// AddSwapTokenPairInfos insert into swap token pair infos, return count
+func (orm *ORM) AddSwapTokenPairInfos(swapTokenPairInfos []*ammswap.SwapTokenPairInfo) (addedCnt int, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	cnt := 0
+	tx := orm.db.Begin()
+	defer orm.deferRollbackTx(tx, err)
+
+	for _, swapTokenPairInfo := range swapTokenPairInfos {
+		if swapTokenPairInfo != nil {
+			ret := tx.Create(swapTokenPairInfo)
+			if ret.Error != nil {
+				return cnt, ret.Error
+			}
+			cnt++
+		}
+	}
+
+	tx.Commit()
+	return cnt, nil
+}
+
+// GetSwapTokenPairInfos returns the creation records of the pools
+func (orm *ORM) GetSwapTokenPairInfos() []ammswap.SwapTokenPairInfo {
+	var swapTokenPairInfos []ammswap.SwapTokenPairInfo
+	orm.db.Model(ammswap.SwapTokenPairInfo{}).Order("timestamp desc").Find(&swapTokenPairInfos)
+	return swapTokenPairInfos
+}
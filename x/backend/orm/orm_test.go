@@ -3,6 +3,7 @@ package orm
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"runtime/debug"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/backend/types"
 	"github.com/okex/okexchain/x/common"
 	"github.com/okex/okexchain/x/token"
@@ -350,14 +352,13 @@ func TestORM_Get(t *testing.T) {
 
 func TestCandles_NewKlinesFactory(t *testing.T) {
 
-	dbDir, err := os.Getwd()
-	require.Nil(t, err)
+	dbDir := copyLocalBackendDB(t)
 	orm, _ := NewSqlite3ORM(false, dbDir, "backend.db", nil)
 	klines, e := types.NewKlinesFactory("kline_m1")
 	assert.True(t, klines != nil && e == nil)
 
 	product := types.TestTokenPair
-	err = orm.GetLatestKlinesByProduct(product, 100, 0, klines)
+	err := orm.GetLatestKlinesByProduct(product, 100, 0, klines)
 	assert.True(t, err == nil)
 
 	iklines := types.ToIKlinesArray(klines, time.Now().Unix(), true)
@@ -377,6 +378,16 @@ func TestCandles_NewKlinesFactory(t *testing.T) {
 	require.Nil(t, err)
 }
 
+// copyLocalBackendDB copies the tracked backend.db fixture to the test output dir, so that the tests don't rewrite it
+func copyLocalBackendDB(t *testing.T) string {
+	dbDir := "test_output"
+	require.Nil(t, os.MkdirAll(dbDir, os.ModePerm))
+	data, err := ioutil.ReadFile("backend.db")
+	require.Nil(t, err)
+	require.Nil(t, ioutil.WriteFile(dbDir+"/backend.db", data, os.ModePerm))
+	return dbDir
+}
+
 func constructLocalBackendDB(orm *ORM) (err error) {
 	m := types.GetAllKlineMap()
 	crrTs := time.Now().Unix()
@@ -398,8 +409,7 @@ func constructLocalBackendDB(orm *ORM) (err error) {
 }
 
 func TestCandles_FromLocalDB(t *testing.T) {
-	dbDir, err := os.Getwd()
-	require.Nil(t, err)
+	dbDir := copyLocalBackendDB(t)
 	orm, err := NewSqlite3ORM(false, dbDir, "backend.db", nil)
 	require.Nil(t, err)
 	product := types.TestTokenPair
@@ -723,6 +733,58 @@ func TestSqlite3_Transactions(t *testing.T) {
 	testORMTransactions(t, orm)
 }
 
+// Swap
+func testORMSwapInfos(t *testing.T, orm *ORM) {
+	swapInfos := []*ammswap.SwapInfo{
+		{TxHash: "hash1", Address: "addr1", TokenPairName: types.TestTokenPair, Volume: 10, Timestamp: 100},
+		{TxHash: "hash2", Address: "addr1", TokenPairName: "btc_" + common.NativeToken, Volume: 20, Timestamp: 300},
+		{TxHash: "hash3", Address: "addr2", TokenPairName: types.TestTokenPair, Volume: 30, Timestamp: 200},
+	}
+	cnt, err := orm.AddSwapInfos(swapInfos)
+	require.EqualValues(t, 3, cnt)
+	require.Nil(t, err)
+
+	infos, total := orm.GetSwapInfos("addr1", "", 0, 0, 0, 10)
+	require.EqualValues(t, 2, total)
+	require.EqualValues(t, "hash2", infos[0].TxHash)
+	infos, total = orm.GetSwapInfos("", types.TestTokenPair, 150, 0, 0, 10)
+	require.EqualValues(t, 1, total)
+	require.EqualValues(t, swapInfos[2], &infos[0])
+	// too large offset
+	infos, total = orm.GetSwapInfos("", "", 0, 0, 3, 10)
+	require.EqualValues(t, 3, total)
+	require.EqualValues(t, 0, len(infos))
+
+	volumes, err := orm.GetSwapVolumes(100, 300)
+	require.Nil(t, err)
+	require.EqualValues(t, map[string]float64{types.TestTokenPair: 40}, volumes)
+
+	liquidityInfos := []*ammswap.SwapLiquidityInfo{
+		{TxHash: "hash4", Address: "addr1", TokenPairName: types.TestTokenPair, Type: ammswap.LiquidityTypeAdd, Timestamp: 100},
+		{TxHash: "hash5", Address: "addr1", TokenPairName: types.TestTokenPair, Type: ammswap.LiquidityTypeRemove, Timestamp: 200},
+	}
+	cnt, err = orm.AddSwapLiquidityInfos(liquidityInfos)
+	require.EqualValues(t, 2, cnt)
+	require.Nil(t, err)
+	liquidities, total := orm.GetSwapLiquidityInfos("addr1", types.TestTokenPair, 1, 10)
+	require.EqualValues(t, 2, total)
+	require.EqualValues(t, liquidityInfos[0], &liquidities[0])
+
+	tokenPairInfos := []*ammswap.SwapTokenPairInfo{
+		{TokenPairName: types.TestTokenPair, Creator: "addr1", Timestamp: 100},
+	}
+	cnt, err = orm.AddSwapTokenPairInfos(tokenPairInfos)
+	require.EqualValues(t, 1, cnt)
+	require.Nil(t, err)
+	require.EqualValues(t, []ammswap.SwapTokenPairInfo{*tokenPairInfos[0]}, orm.GetSwapTokenPairInfos())
+}
+
+func TestSqlite3_SwapInfos(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+	testORMSwapInfos(t, orm)
+}
+
 func Test_Time(t *testing.T) {
 	now := time.Now()
 	time.Sleep(time.Second)
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
 	dextypes "github.com/okex/okexchain/x/dex/types"
 	"github.com/okex/okexchain/x/order"
 	ordertypes "github.com/okex/okexchain/x/order/types"
@@ -39,3 +40,13 @@ type MarketKeeper interface {
 	GetTickerByProducts(products []string) ([]map[string]string, error)
 	GetKlineByProductID(productID uint64, granularity, size int) ([][]string, error)
 }
+
+// SwapKeeper expected ammswap keeper
+type SwapKeeper interface {
+	GetSwapInfos(ctx sdk.Context) []*ammswap.SwapInfo
+	GetSwapLiquidityInfos(ctx sdk.Context) []*ammswap.SwapLiquidityInfo
+	GetSwapTokenPairInfos(ctx sdk.Context) []*ammswap.SwapTokenPairInfo
+	GetSwapTokenPair(ctx sdk.Context, tokenPairName string) (ammswap.SwapTokenPair, error)
+	GetSwapTokenPairs(ctx sdk.Context) []ammswap.SwapTokenPair
+	GetParams(ctx sdk.Context) (params ammswap.Params)
+}
@@ -19,6 +19,10 @@ const (
 	QueryTickerList   = "tickers"
 	QueryDexFeesList  = "dexFees"
 
+	QuerySwapInfos          = "swapInfos"
+	QuerySwapLiquidityInfos = "swapLiquidityInfos"
+	QuerySwapPools          = "swapPools"
+
 	// v2
 	QueryTickerListV2   = "tickerListV2"
 	QueryTickerV2       = "tickerV2"
@@ -186,3 +186,71 @@ func NewQueryDexFeesParams(dexHandlingAddr, baseAsset, quoteAsset string, page,
 		PerPage:         perPage,
 	}
 }
+
+// nolint
+type QuerySwapInfosParams struct {
+	Address       string
+	TokenPairName string
+	Start         int64
+	End           int64
+	Page          int
+	PerPage       int
+}
+
+// NewQuerySwapInfosParams creates a new instance of QuerySwapInfosParams
+func NewQuerySwapInfosParams(addr, tokenPairName string, start, end int64, page, perPage int) QuerySwapInfosParams {
+	if page == 0 && perPage == 0 {
+		page = DefaultPage
+		perPage = DefaultPerPage
+	}
+	return QuerySwapInfosParams{
+		Address:       addr,
+		TokenPairName: tokenPairName,
+		Start:         start,
+		End:           end,
+		Page:          page,
+		PerPage:       perPage,
+	}
+}
+
+// nolint
+type QuerySwapLiquidityInfosParams struct {
+	Address       string
+	TokenPairName string
+	Page          int
+	PerPage       int
+}
+
+// NewQuerySwapLiquidityInfosParams creates a new instance of QuerySwapLiquidityInfosParams
+func NewQuerySwapLiquidityInfosParams(addr, tokenPairName string, page, perPage int) QuerySwapLiquidityInfosParams {
+	if page == 0 && perPage == 0 {
+		page = DefaultPage
+		perPage = DefaultPerPage
+	}
+	return QuerySwapLiquidityInfosParams{
+		Address:       addr,
+		TokenPairName: tokenPairName,
+		Page:          page,
+		PerPage:       perPage,
+	}
+}
+
+// nolint
+type QuerySwapPoolsParams struct {
+	TokenPairName string
+	Page          int
+	PerPage       int
+}
+
+// NewQuerySwapPoolsParams creates a new instance of QuerySwapPoolsParams
+func NewQuerySwapPoolsParams(tokenPairName string, page, perPage int) QuerySwapPoolsParams {
+	if page == 0 && perPage == 0 {
+		page = DefaultPage
+		perPage = DefaultPerPage
+	}
+	return QuerySwapPoolsParams{
+		TokenPairName: tokenPairName,
+		Page:          page,
+		PerPage:       perPage,
+	}
+}
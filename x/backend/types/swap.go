@@ -0,0 +1,101 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
+)
+
+// SwapPoolReserve is the websocket item of the reserves of an ammswap pool, pushed when the pool changes
+type SwapPoolReserve struct {
+	TokenPairName string      `json:"token_pair_name"`
+	BaseReserve   sdk.DecCoin `json:"base_reserve"`
+	QuoteReserve  sdk.DecCoin `json:"quote_reserve"`
+	Price         sdk.Dec     `json:"price"` // spot price of the base token in the quote token
+	Timestamp     int64       `json:"timestamp"`
+}
+
+// NewSwapPoolReserve creates the websocket item of the reserves of the pool
+func NewSwapPoolReserve(swapTokenPair ammswap.SwapTokenPair, timestamp int64) *SwapPoolReserve {
+	return &SwapPoolReserve{
+		TokenPairName: swapTokenPair.TokenPairName(),
+		BaseReserve:   swapTokenPair.BasePooledCoin,
+		QuoteReserve:  swapTokenPair.QuotePooledCoin,
+		Price:         GetSwapSpotPrice(swapTokenPair),
+		Timestamp:     timestamp,
+	}
+}
+
+func (r *SwapPoolReserve) GetTimestamp() int64 {
+	return r.Timestamp
+}
+
+func (r *SwapPoolReserve) GetChannelInfo() (channel, filter string, err error) {
+	return "dex_spot/pool_reserve", r.TokenPairName, nil
+}
+
+func (r *SwapPoolReserve) GetFullChannel() string {
+	return "dex_spot/pool_reserve" + ":" + r.TokenPairName
+}
+
+func (r *SwapPoolReserve) FormatResult() interface{} {
+	return map[string]string{
+		"token_pair_name": r.TokenPairName,
+		"base_reserve":    r.BaseReserve.String(),
+		"quote_reserve":   r.QuoteReserve.String(),
+		"price":           r.Price.String(),
+		"timestamp":       time.Unix(r.Timestamp, 0).UTC().Format("2006-01-02T15:04:05.000Z"),
+	}
+}
+
+// SwapPoolInfo is the stats of an ammswap pool
+type SwapPoolInfo struct {
+	TokenPairName string      `json:"token_pair_name"`
+	PoolToken     string      `json:"pool_token"`
+	Curve         string      `json:"curve"`
+	BaseReserve   sdk.DecCoin `json:"base_reserve"`
+	QuoteReserve  sdk.DecCoin `json:"quote_reserve"`
+	Price         sdk.Dec     `json:"price"`      // spot price of the base token in the quote token
+	TVL           sdk.Dec     `json:"tvl"`        // total value locked in the quote token
+	Volume24h     sdk.Dec     `json:"volume_24h"` // volume swapped in the last 24 hours in the quote token
+	APY           sdk.Dec     `json:"apy"`        // annual yield of the liquidity providers from the swap fees
+	Creator       string      `json:"creator"`
+	CreateTime    int64       `json:"create_time"`
+}
+
+// GetSwapSpotPrice returns the spot price of the base token in the quote token of the pool, zero if the pool is empty
+func GetSwapSpotPrice(swapTokenPair ammswap.SwapTokenPair) sdk.Dec {
+	if !swapTokenPair.BasePooledCoin.IsPositive() || !swapTokenPair.QuotePooledCoin.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	numerator, denominator := swapTokenPair.Curve.SpotPrice(swapTokenPair.BasePooledCoin.Amount,
+		swapTokenPair.QuotePooledCoin.Amount)
+	if !denominator.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	return numerator.Quo(denominator)
+}
+
+// NewSwapPoolInfo calculates the stats of the pool. The fees left to the liquidity providers of the last 24 hours
+// are annualized over the total value locked
+func NewSwapPoolInfo(swapTokenPair ammswap.SwapTokenPair, volume24h sdk.Dec, params ammswap.Params) SwapPoolInfo {
+	price := GetSwapSpotPrice(swapTokenPair)
+	tvl := swapTokenPair.QuotePooledCoin.Amount.Add(swapTokenPair.BasePooledCoin.Amount.Mul(price))
+	apy := sdk.ZeroDec()
+	if tvl.IsPositive() {
+		lpFeeRate := params.FeeRate.Mul(sdk.OneDec().Sub(params.ProtocolFeeRate))
+		apy = volume24h.Mul(lpFeeRate).MulInt64(365).Quo(tvl)
+	}
+	return SwapPoolInfo{
+		TokenPairName: swapTokenPair.TokenPairName(),
+		PoolToken:     swapTokenPair.PoolTokenName,
+		Curve:         swapTokenPair.Curve.String(),
+		BaseReserve:   swapTokenPair.BasePooledCoin,
+		QuoteReserve:  swapTokenPair.QuotePooledCoin,
+		Price:         price,
+		TVL:           tvl,
+		Volume24h:     volume24h,
+		APY:           apy,
+	}
+}
@@ -30,6 +30,11 @@ func GenerateTx(tx *auth.StdTx, txHash string, ctx sdk.Context, orderKeeper Orde
 				txHash, ctx, orderKeeper, timestamp)
 			txs = append(txs, transaction...)
 			idx++
+		case "amend": // order/amend
+			transaction := buildTransactionAmend(orderHandlerTxResult[idx], msg.(orderTypes.MsgAmendOrders),
+				txHash, ctx, orderKeeper, timestamp)
+			txs = append(txs, transaction...)
+			idx++
 		default: // In other cases, do nothing
 			continue
 		}
@@ -129,3 +134,43 @@ func buildTransactionCancel(handlerMsgResult bitset.BitSet, msg orderTypes.MsgCa
 
 	return result
 }
+
+// buildTransactionAmend builds the transactions of the amended orders, the fee is the cancel fee of the order
+// if it's replaced by a new order
+func buildTransactionAmend(handlerMsgResult bitset.BitSet, msg orderTypes.MsgAmendOrders, txHash string,
+	ctx sdk.Context, orderKeeper OrderKeeper, timestamp int64) []*Transaction {
+	var result []*Transaction
+
+	for idx, item := range msg.AmendItems {
+		if !handlerMsgResult.Test(uint(idx)) {
+			continue
+		}
+
+		order := orderKeeper.GetOrder(ctx, item.OrderID)
+		if order == nil {
+			continue
+		}
+		side := TxSideBuy
+		if order.Side == orderTypes.SellOrder {
+			side = TxSideSell
+		}
+		cancelFeeStr := order.GetExtraInfoWithKey(orderTypes.OrderExtraInfoKeyCancelFee)
+		if cancelFeeStr == "" {
+			cancelFeeStr = sdk.DecCoin{Denom: common.NativeToken, Amount: sdk.ZeroDec()}.String()
+		}
+		tx := Transaction{
+			TxHash:    txHash,
+			Address:   msg.Sender.String(),
+			Type:      TxTypeOrderAmend,
+			Side:      int64(side),
+			Symbol:    order.Product,
+			Quantity:  item.Quantity.String(),
+			Fee:       cancelFeeStr,
+			Timestamp: timestamp,
+		}
+
+		result = append(result, &tx)
+	}
+
+	return result
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/okex/okexchain/x/order"
 	orderKeeper "github.com/okex/okexchain/x/order/keeper"
+	orderTypes "github.com/okex/okexchain/x/order/types"
 	tokenKeeper "github.com/okex/okexchain/x/token"
 	token "github.com/okex/okexchain/x/token/types"
 	"github.com/stretchr/testify/require"
@@ -90,6 +91,47 @@ func TestGenerateTx(t *testing.T) {
 	GenerateTx(&tx, "", ctx, keeper, time.Now().Unix())
 }
 
+func TestGenerateTxAmend(t *testing.T) {
+	txbldr := auth.NewTxBuilder(auth.DefaultTxEncoder(auth.ModuleCdc), 1, 2, 3, 4, false, "okexchain", "memo", nil, nil)
+	testInput := orderKeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+
+	priKey := secp256k1.GenPrivKey()
+	acc := sdk.AccAddress(priKey.PubKey().Address())
+	or := &order.Order{
+		OrderID: "ORDER-123",
+		Product: TestTokenPair,
+		Side:    SellOrder,
+	}
+	keeper.SetOrder(ctx, or.OrderID, or)
+
+	amendMsg := orderTypes.NewMsgAmendOrders(acc, []orderTypes.AmendOrderItem{
+		orderTypes.NewAmendOrderItem(or.OrderID, "10", "2"),
+		orderTypes.NewAmendOrderItem("ORDER-456", "10", "2"),
+	})
+	amendMsgSig, _ := priKey.Sign(amendMsg.GetSignBytes())
+	sigs := []auth.StdSignature{
+		{
+			PubKey:    priKey.PubKey(),
+			Signature: amendMsgSig,
+		},
+	}
+	txSigMsg, _ := txbldr.BuildSignMsg([]sdk.Msg{amendMsg})
+	tx := auth.NewStdTx(txSigMsg.Msgs, txSigMsg.Fee, sigs, "")
+	var tmpBitset bitset.BitSet
+	tmpBitset.Set(0)
+	keeper.AddTxHandlerMsgResult(tmpBitset)
+
+	// only the amended order is recorded
+	txs := GenerateTx(&tx, "", ctx, keeper, time.Now().Unix())
+	require.EqualValues(t, 1, len(txs))
+	require.EqualValues(t, TxTypeOrderAmend, txs[0].Type)
+	require.EqualValues(t, TxSideSell, txs[0].Side)
+	require.EqualValues(t, TestTokenPair, txs[0].Symbol)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2").String(), txs[0].Quantity)
+}
+
 func TestTicker(t *testing.T) {
 	tiker1 := Ticker{
 		Symbol:           "btc",
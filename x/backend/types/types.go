@@ -11,6 +11,7 @@ const (
 	TxTypeTransfer    = 1
 	TxTypeOrderNew    = 2
 	TxTypeOrderCancel = 3
+	TxTypeOrderAmend  = 4
 
 	TxSideBuy  = 1
 	TxSideSell = 2
@@ -102,6 +103,8 @@ type Order struct {
 	FilledAvgPrice string `gorm:"type:varchar(40)" json:"filled_avg_price" v2:"filled_avg_price"`
 	RemainQuantity string `gorm:"type:varchar(40)" json:"remain_quantity" v2:"remain_quantity"`
 	Timestamp      int64  `gorm:"index;" json:"timestamp" v2:"timestamp"`
+	ExpireHeight   int64  `gorm:"" json:"expire_height" v2:"expire_height"`
+	ExpireTime     int64  `gorm:"" json:"expire_time" v2:"expire_time"`
 }
 
 type Transaction struct {
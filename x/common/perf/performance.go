@@ -20,6 +20,7 @@ const (
 	orderModule        = "order"
 	dexModule          = "dex"
 	swapModule         = "ammswap"
+	farmModule         = "farm"
 	tokenModule        = "token"
 	stakingModule      = "staking"
 	govModule          = "gov"
@@ -144,6 +145,7 @@ func newPerf() *performance {
 	p.moduleInfoMap[orderModule] = newHanlderMetrics()
 	p.moduleInfoMap[dexModule] = newHanlderMetrics()
 	p.moduleInfoMap[swapModule] = newHanlderMetrics()
+	p.moduleInfoMap[farmModule] = newHanlderMetrics()
 	p.moduleInfoMap[tokenModule] = newHanlderMetrics()
 	p.moduleInfoMap[govModule] = newHanlderMetrics()
 	p.moduleInfoMap[distributionModule] = newHanlderMetrics()
@@ -313,6 +315,7 @@ func (p *performance) OnCommitExit(height int64, seq uint64, logger log.Logger)
 	p.moduleInfoMap[orderModule] = newHanlderMetrics()
 	p.moduleInfoMap[dexModule] = newHanlderMetrics()
 	p.moduleInfoMap[swapModule] = newHanlderMetrics()
+	p.moduleInfoMap[farmModule] = newHanlderMetrics()
 	p.moduleInfoMap[tokenModule] = newHanlderMetrics()
 	p.moduleInfoMap[govModule] = newHanlderMetrics()
 	p.moduleInfoMap[distributionModule] = newHanlderMetrics()
@@ -21,6 +21,7 @@ import (
 	"github.com/okex/okexchain/x/common"
 	dexUtils "github.com/okex/okexchain/x/dex/client/utils"
 	"github.com/okex/okexchain/x/dex/types"
+	ordertypes "github.com/okex/okexchain/x/order/types"
 	"github.com/spf13/cobra"
 )
 
@@ -306,6 +307,111 @@ Where proposal.json contains:
 
 }
 
+// GetCmdSubmitMatchEngineProposal implements a command handler for submitting a proposal transaction
+// to change the match engine of a token pair
+func GetCmdSubmitMatchEngineProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "match-engine-proposal [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to change the match engine of a token pair",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to change the match engine of a token pair along with an initial deposit.
+The proposal details must be supplied via a JSON file. The match engine is %s or %s.
+
+Example:
+$ %s tx gov submit-proposal match-engine-proposal <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "match xxx/%s with continuous auction",
+ "description": "change the match engine of xxx/%s",
+ "base_asset": "xxx",
+ "quote_asset": "%s",
+ "match_engine": "continuousauction",
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, ordertypes.ContinuousAuction, ordertypes.PeriodicAuction, version.ClientName, sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom,
+				sdk.DefaultBondDenom,
+			)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := dexUtils.ParseMatchEngineProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewMatchEngineProposal(proposal.Title, proposal.Description, from, proposal.BaseAsset,
+				proposal.QuoteAsset, proposal.MatchEngine)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSubmitPriceBandProposal implements a command handler for submitting a proposal transaction
+// to change the price band of a token pair
+func GetCmdSubmitPriceBandProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "price-band-proposal [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to change the price band of a token pair",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to change the price band of a token pair along with an initial deposit.
+The proposal details must be supplied via a JSON file. The pressure rate moves the reference price of the periodic
+auction toward the pressure side, and the auction is halted for halt blocks if its price would move more than
+max price change from the last price. A zero max price change disables the circuit breaker.
+
+Example:
+$ %s tx gov submit-proposal price-band-proposal <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+ "title": "price band of xxx/%s",
+ "description": "change the price band of xxx/%s",
+ "base_asset": "xxx",
+ "quote_asset": "%s",
+ "price_band": {
+   "pressure_rate": "0.05",
+   "max_price_change": "0.1",
+   "halt_blocks": "100"
+ },
+ "deposit": [
+   {
+     "denom": "%s",
+     "amount": "100"
+   }
+ ]
+}
+`, version.ClientName, sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom,
+			)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := dexUtils.ParsePriceBandProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewPriceBandProposal(proposal.Title, proposal.Description, from, proposal.BaseAsset,
+				proposal.QuoteAsset, proposal.PriceBand)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 func getCmdRegisterOperator(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "register-operator",
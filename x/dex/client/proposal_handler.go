@@ -10,4 +10,10 @@ import (
 var (
 	// DelistProposalHandler alias gov NewProposalHandler
 	DelistProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitDelistProposal, rest.DelistProposalRESTHandler)
+	// MatchEngineProposalHandler alias gov NewProposalHandler
+	MatchEngineProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitMatchEngineProposal,
+		rest.MatchEngineProposalRESTHandler)
+	// PriceBandProposalHandler alias gov NewProposalHandler
+	PriceBandProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitPriceBandProposal,
+		rest.PriceBandProposalRESTHandler)
 )
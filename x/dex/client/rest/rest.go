@@ -183,3 +183,13 @@ func operatorsHandler(cliContext context.CLIContext) func(http.ResponseWriter, *
 func DelistProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
 	return govRest.ProposalRESTHandler{}
 }
+
+// MatchEngineProposalRESTHandler defines dex match engine proposal handler
+func MatchEngineProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
+
+// PriceBandProposalRESTHandler defines dex price band proposal handler
+func PriceBandProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/dex/types"
 )
 
 // DelistProposalJSON defines a DelistProposal with a deposit used
@@ -30,3 +32,53 @@ func ParseDelistProposalJSON(cdc *codec.Codec, proposalFilePath string) (proposa
 
 	return proposal, nil
 }
+
+// MatchEngineProposalJSON defines a MatchEngineProposal with a deposit used
+// to parse match engine proposals from a JSON file.
+type MatchEngineProposalJSON struct {
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	BaseAsset   string       `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset  string       `json:"quote_asset" yaml:"quote_asset"`
+	MatchEngine string       `json:"match_engine" yaml:"match_engine"`
+	Deposit     sdk.DecCoins `json:"deposit" yaml:"deposit"`
+}
+
+// ParseMatchEngineProposalJSON parse json from proposal file to MatchEngineProposalJSON struct
+func ParseMatchEngineProposalJSON(cdc *codec.Codec, proposalFilePath string) (proposal MatchEngineProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+// PriceBandProposalJSON defines a PriceBandProposal with a deposit used
+// to parse price band proposals from a JSON file.
+type PriceBandProposalJSON struct {
+	Title       string          `json:"title" yaml:"title"`
+	Description string          `json:"description" yaml:"description"`
+	BaseAsset   string          `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset  string          `json:"quote_asset" yaml:"quote_asset"`
+	PriceBand   types.PriceBand `json:"price_band" yaml:"price_band"`
+	Deposit     sdk.DecCoins    `json:"deposit" yaml:"deposit"`
+}
+
+// ParsePriceBandProposalJSON parse json from proposal file to PriceBandProposalJSON struct
+func ParsePriceBandProposalJSON(cdc *codec.Codec, proposalFilePath string) (proposal PriceBandProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
@@ -24,6 +24,7 @@ type Keeper struct {
 	bankKeeper        BankKeeper            // The reference to the bank keeper to check whether proposer can afford  proposal deposit
 	govKeeper         GovKeeper             // The reference to the gov keeper to handle proposal
 	observerKeeper    exported.StreamKeeper // The reference to the stream keeper
+	matchEngines      []string              // The names of the match engines which can be chosen by the token pairs
 	storeKey          sdk.StoreKey
 	tokenPairStoreKey sdk.StoreKey
 	paramSubspace     params.Subspace // The reference to the Paramstore to get and set gov modifiable params
@@ -445,6 +446,26 @@ func (k *Keeper) SetGovKeeper(gk GovKeeper) {
 	k.govKeeper = gk
 }
 
+// SetMatchEngines sets the names of the match engines which can be chosen by the token pairs
+func (k *Keeper) SetMatchEngines(names []string) {
+	k.matchEngines = names
+}
+
+// GetMatchEngines returns the names of the match engines which can be chosen by the token pairs
+func (k Keeper) GetMatchEngines() []string {
+	return k.matchEngines
+}
+
+// IsValidMatchEngine returns true if the match engine can be chosen by the token pairs
+func (k Keeper) IsValidMatchEngine(name string) bool {
+	for _, engine := range k.matchEngines {
+		if engine == name {
+			return true
+		}
+	}
+	return false
+}
+
 // GetMaxTokenPairID returns the max ID of token pair
 func (k Keeper) GetMaxTokenPairID(ctx sdk.Context) (tokenPairMaxID uint64) {
 	store := ctx.KVStore(k.tokenPairStoreKey)
@@ -13,7 +13,9 @@ import (
 
 // GetMinDeposit returns min deposit
 func (k Keeper) GetMinDeposit(ctx sdk.Context, content gov.Content) (minDeposit sdk.DecCoins) {
-	if _, ok := content.(types.DelistProposal); ok {
+	// match engine proposal and price band proposal share the deposit and voting params with delist proposal
+	switch content.(type) {
+	case types.DelistProposal, types.MatchEngineProposal, types.PriceBandProposal:
 		minDeposit = k.GetParams(ctx).DelistMinDeposit
 	}
 	return
@@ -21,7 +23,8 @@ func (k Keeper) GetMinDeposit(ctx sdk.Context, content gov.Content) (minDeposit
 
 // GetMaxDepositPeriod returns max deposit period
 func (k Keeper) GetMaxDepositPeriod(ctx sdk.Context, content gov.Content) (maxDepositPeriod time.Duration) {
-	if _, ok := content.(types.DelistProposal); ok {
+	switch content.(type) {
+	case types.DelistProposal, types.MatchEngineProposal, types.PriceBandProposal:
 		maxDepositPeriod = k.GetParams(ctx).DelistMaxDepositPeriod
 	}
 	return
@@ -29,7 +32,8 @@ func (k Keeper) GetMaxDepositPeriod(ctx sdk.Context, content gov.Content) (maxDe
 
 // GetVotingPeriod returns voting period
 func (k Keeper) GetVotingPeriod(ctx sdk.Context, content gov.Content) (votingPeriod time.Duration) {
-	if _, ok := content.(types.DelistProposal); ok {
+	switch content.(type) {
+	case types.DelistProposal, types.MatchEngineProposal, types.PriceBandProposal:
 		votingPeriod = k.GetParams(ctx).DelistVotingPeriod
 	}
 	return
@@ -69,11 +73,87 @@ func (k Keeper) checkMsgDelistProposal(ctx sdk.Context, delistProposal types.Del
 	return nil
 }
 
+// check msg match engine proposal
+func (k Keeper) checkMsgMatchEngineProposal(ctx sdk.Context, proposal types.MatchEngineProposal, proposer sdk.AccAddress, initialDeposit sdk.DecCoins) sdk.Error {
+	// check the proposer of the msg is a validator
+	if !k.stakingKeeper.IsValidator(ctx, proposer) {
+		return gov.ErrInvalidProposer(types.DefaultCodespace, "failed to submit proposal because the proposer of match engine proposal should be a validator")
+	}
+
+	// check the propose of the msg is equal the proposer in proposal content
+	if !proposer.Equals(proposal.Proposer) {
+		return gov.ErrInvalidProposer(types.DefaultCodespace, "failed to submit proposal because the proposer of proposal msg should be equal the proposer in proposal content")
+	}
+
+	if !k.IsValidMatchEngine(proposal.MatchEngine) {
+		return types.ErrInvalidMatchEngine(fmt.Sprintf("failed to submit proposal because match engine %s is not one of %v",
+			proposal.MatchEngine, k.GetMatchEngines()))
+	}
+
+	tokenPair := k.GetTokenPair(ctx, fmt.Sprintf("%s_%s", proposal.BaseAsset, proposal.QuoteAsset))
+	if tokenPair == nil {
+		return types.ErrTokenPairNotFound(fmt.Sprintf("failed to submit proposal because the asset with base asset '%s' and quote asset '%s' didn't exist on the Dex", proposal.BaseAsset, proposal.QuoteAsset))
+	}
+	if tokenPair.MatchEngine == proposal.MatchEngine {
+		return types.ErrInvalidMatchEngine(fmt.Sprintf("failed to submit proposal because the token pair %s already uses match engine %s", tokenPair.Name(), proposal.MatchEngine))
+	}
+
+	// check the initial deposit
+	localMinDeposit := k.GetParams(ctx).DelistMinDeposit.MulDec(sdk.NewDecWithPrec(1, 1))
+	err := common.HasSufficientCoins(proposer, initialDeposit, localMinDeposit)
+	if err != nil {
+		return types.ErrInvalidAsset(fmt.Sprintf("failed to submit proposal because initial deposit should be more than %s", localMinDeposit.String()))
+	}
+
+	// check whether the proposer can afford the initial deposit
+	err = common.HasSufficientCoins(proposer, k.bankKeeper.GetCoins(ctx, proposer), initialDeposit)
+	if err != nil {
+		return types.ErrInvalidBalanceNotEnough(fmt.Sprintf("failed to submit proposal because proposer %s didn't have enough coins to pay for the initial deposit %s", proposer, initialDeposit))
+	}
+	return nil
+}
+
+// check msg price band proposal
+func (k Keeper) checkMsgPriceBandProposal(ctx sdk.Context, proposal types.PriceBandProposal, proposer sdk.AccAddress, initialDeposit sdk.DecCoins) sdk.Error {
+	// check the proposer of the msg is a validator
+	if !k.stakingKeeper.IsValidator(ctx, proposer) {
+		return gov.ErrInvalidProposer(types.DefaultCodespace, "failed to submit proposal because the proposer of price band proposal should be a validator")
+	}
+
+	// check the propose of the msg is equal the proposer in proposal content
+	if !proposer.Equals(proposal.Proposer) {
+		return gov.ErrInvalidProposer(types.DefaultCodespace, "failed to submit proposal because the proposer of proposal msg should be equal the proposer in proposal content")
+	}
+
+	tokenPair := k.GetTokenPair(ctx, fmt.Sprintf("%s_%s", proposal.BaseAsset, proposal.QuoteAsset))
+	if tokenPair == nil {
+		return types.ErrTokenPairNotFound(fmt.Sprintf("failed to submit proposal because the asset with base asset '%s' and quote asset '%s' didn't exist on the Dex", proposal.BaseAsset, proposal.QuoteAsset))
+	}
+
+	// check the initial deposit
+	localMinDeposit := k.GetParams(ctx).DelistMinDeposit.MulDec(sdk.NewDecWithPrec(1, 1))
+	err := common.HasSufficientCoins(proposer, initialDeposit, localMinDeposit)
+	if err != nil {
+		return types.ErrInvalidAsset(fmt.Sprintf("failed to submit proposal because initial deposit should be more than %s", localMinDeposit.String()))
+	}
+
+	// check whether the proposer can afford the initial deposit
+	err = common.HasSufficientCoins(proposer, k.bankKeeper.GetCoins(ctx, proposer), initialDeposit)
+	if err != nil {
+		return types.ErrInvalidBalanceNotEnough(fmt.Sprintf("failed to submit proposal because proposer %s didn't have enough coins to pay for the initial deposit %s", proposer, initialDeposit))
+	}
+	return nil
+}
+
 // CheckMsgSubmitProposal validates MsgSubmitProposal
 func (k Keeper) CheckMsgSubmitProposal(ctx sdk.Context, msg govTypes.MsgSubmitProposal) (sdkErr sdk.Error) {
 	switch content := msg.Content.(type) {
 	case types.DelistProposal:
 		sdkErr = k.checkMsgDelistProposal(ctx, content, msg.Proposer, msg.InitialDeposit)
+	case types.MatchEngineProposal:
+		sdkErr = k.checkMsgMatchEngineProposal(ctx, content, msg.Proposer, msg.InitialDeposit)
+	case types.PriceBandProposal:
+		sdkErr = k.checkMsgPriceBandProposal(ctx, content, msg.Proposer, msg.InitialDeposit)
 	default:
 		errContent := fmt.Sprintf("unrecognized dex proposal content type: %T", content)
 		sdkErr = sdk.ErrUnknownRequest(errContent)
@@ -84,15 +164,20 @@ func (k Keeper) CheckMsgSubmitProposal(ctx sdk.Context, msg govTypes.MsgSubmitPr
 // nolint
 func (k Keeper) AfterSubmitProposalHandler(ctx sdk.Context, proposal govTypes.Proposal) {}
 
-// VoteHandler handles  delist proposal when voted
+// VoteHandler handles delist proposal and match engine proposal when voted
 func (k Keeper) VoteHandler(ctx sdk.Context, proposal govTypes.Proposal, vote govTypes.Vote) (string, sdk.Error) {
-	if _, ok := proposal.Content.(types.DelistProposal); ok {
-		delistProposal := proposal.Content.(types.DelistProposal)
-		tokenPairName := delistProposal.BaseAsset + "_" + delistProposal.QuoteAsset
-		if k.IsTokenPairLocked(ctx, tokenPairName) {
-			errContent := fmt.Sprintf("the trading pair (%s) is locked, please retry later", tokenPairName)
-			return "", sdk.ErrInternal(errContent)
-		}
+	var tokenPairName string
+	switch content := proposal.Content.(type) {
+	case types.DelistProposal:
+		tokenPairName = content.BaseAsset + "_" + content.QuoteAsset
+	case types.MatchEngineProposal:
+		tokenPairName = content.BaseAsset + "_" + content.QuoteAsset
+	default:
+		return "", nil
+	}
+	if k.IsTokenPairLocked(ctx, tokenPairName) {
+		errContent := fmt.Sprintf("the trading pair (%s) is locked, please retry later", tokenPairName)
+		return "", sdk.ErrInternal(errContent)
 	}
 	return "", nil
 }
@@ -91,6 +91,77 @@ func TestKeeper_CheckMsgSubmitProposal(t *testing.T) {
 
 }
 
+func TestKeeper_CheckMsgSubmitMatchEngineProposal(t *testing.T) {
+	testInput := createTestInputWithBalance(t, 1, 10000)
+	ctx := testInput.Ctx
+
+	testInput.DexKeeper.SetParams(ctx, *types.DefaultParams())
+	testInput.DexKeeper.SetMatchEngines([]string{ordertypes.ContinuousAuction, ordertypes.PeriodicAuction})
+	tokenPair := GetBuiltInTokenPair()
+
+	content := types.NewMatchEngineProposal("match engine of xxb_okb", "change the match engine", tokenPair.Owner,
+		tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, ordertypes.ContinuousAuction)
+	proposal := govTypes.NewMsgSubmitProposal(content, sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(150))}, tokenPair.Owner)
+
+	// error case : fail to check proposal because product(token pair) not exist
+	err := testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+
+	saveErr := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, saveErr)
+
+	// successful case : check proposal successfully
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.NoError(t, err)
+
+	// error case : fail to check proposal because the token pair already uses the match engine
+	tokenPair.MatchEngine = ordertypes.ContinuousAuction
+	testInput.DexKeeper.UpdateTokenPair(ctx, tokenPair.Name(), tokenPair)
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+
+	// error case : fail to check proposal because the match engine is unknown
+	content.MatchEngine = "unknown"
+	proposal2 := govTypes.NewMsgSubmitProposal(content, sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(150))}, tokenPair.Owner)
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal2)
+	require.Error(t, err)
+
+	// error case : fail to check proposal because initial deposit is too small
+	content.MatchEngine = ordertypes.PeriodicAuction
+	proposal1 := govTypes.NewMsgSubmitProposal(content, sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(1))}, tokenPair.Owner)
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal1)
+	require.Error(t, err)
+}
+
+func TestKeeper_CheckMsgSubmitPriceBandProposal(t *testing.T) {
+	testInput := createTestInputWithBalance(t, 1, 10000)
+	ctx := testInput.Ctx
+
+	testInput.DexKeeper.SetParams(ctx, *types.DefaultParams())
+	tokenPair := GetBuiltInTokenPair()
+
+	priceBand := types.NewPriceBand(sdk.NewDecWithPrec(1, 2), sdk.NewDecWithPrec(1, 1), 10)
+	content := types.NewPriceBandProposal("price band of xxb_okb", "change the price band", tokenPair.Owner,
+		tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, priceBand)
+	proposal := govTypes.NewMsgSubmitProposal(content, sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(150))}, tokenPair.Owner)
+
+	// error case : fail to check proposal because product(token pair) not exist
+	err := testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.Error(t, err)
+
+	saveErr := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, saveErr)
+
+	// successful case : check proposal successfully
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal)
+	require.NoError(t, err)
+
+	// error case : fail to check proposal because initial deposit is too small
+	proposal1 := govTypes.NewMsgSubmitProposal(content, sdk.DecCoins{sdk.NewDecCoin(common.NativeToken, sdk.NewInt(1))}, tokenPair.Owner)
+	err = testInput.DexKeeper.CheckMsgSubmitProposal(ctx, proposal1)
+	require.Error(t, err)
+}
+
 func TestKeeper_RejectedHandler(t *testing.T) {
 	testInput := createTestInputWithBalance(t, 1, 10000)
 	ctx := testInput.Ctx
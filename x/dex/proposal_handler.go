@@ -14,6 +14,10 @@ func NewProposalHandler(k *Keeper) govTypes.Handler {
 		switch c := proposal.Content.(type) {
 		case types.DelistProposal:
 			return handleDelistProposal(ctx, k, proposal)
+		case types.MatchEngineProposal:
+			return handleMatchEngineProposal(ctx, k, proposal)
+		case types.PriceBandProposal:
+			return handlePriceBandProposal(ctx, k, proposal)
 		default:
 			errMsg := fmt.Sprintf("unrecognized param proposal content type: %s", c)
 			return sdk.ErrUnknownRequest(errMsg)
@@ -56,3 +60,58 @@ func handleDelistProposal(ctx sdk.Context, keeper *Keeper, proposal *govTypes.Pr
 		))
 	return nil
 }
+
+func handleMatchEngineProposal(ctx sdk.Context, keeper *Keeper, proposal *govTypes.Proposal) (err sdk.Error) {
+	p := proposal.Content.(types.MatchEngineProposal)
+	logger := ctx.Logger().With("module", types.ModuleName)
+	logger.Debug("execute MatchEngineProposal begin")
+
+	if !keeper.IsValidMatchEngine(p.MatchEngine) {
+		return types.ErrInvalidMatchEngine(fmt.Sprintf("match engine %s is not one of %v", p.MatchEngine,
+			keeper.GetMatchEngines()))
+	}
+
+	tokenPairName := fmt.Sprintf("%s_%s", p.BaseAsset, p.QuoteAsset)
+	tokenPair := keeper.GetTokenPair(ctx, tokenPairName)
+	if tokenPair == nil {
+		return ErrTokenPairNotFound(fmt.Sprintf("%+v", p))
+	}
+	// the locked product is still being filled by its current match engine
+	if keeper.IsTokenPairLocked(ctx, tokenPairName) {
+		errContent := fmt.Sprintf("unexpected state, the trading pair (%s) is locked", tokenPairName)
+		return sdk.ErrInternal(errContent)
+	}
+
+	tokenPair.MatchEngine = p.MatchEngine
+	keeper.UpdateTokenPair(ctx, tokenPairName, tokenPair)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute("token-pair-match-engine", fmt.Sprintf("%s:%s", tokenPairName, p.MatchEngine)),
+		))
+	return nil
+}
+
+func handlePriceBandProposal(ctx sdk.Context, keeper *Keeper, proposal *govTypes.Proposal) (err sdk.Error) {
+	p := proposal.Content.(types.PriceBandProposal)
+	logger := ctx.Logger().With("module", types.ModuleName)
+	logger.Debug("execute PriceBandProposal begin")
+
+	tokenPairName := fmt.Sprintf("%s_%s", p.BaseAsset, p.QuoteAsset)
+	tokenPair := keeper.GetTokenPair(ctx, tokenPairName)
+	if tokenPair == nil {
+		return ErrTokenPairNotFound(fmt.Sprintf("%+v", p))
+	}
+
+	priceBand := p.PriceBand
+	tokenPair.PriceBand = &priceBand
+	keeper.UpdateTokenPair(ctx, tokenPairName, tokenPair)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute("token-pair-price-band", fmt.Sprintf("%s:%s", tokenPairName, priceBand)),
+		))
+	return nil
+}
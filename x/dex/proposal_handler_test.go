@@ -67,3 +67,77 @@ func TestProposal_NewProposalHandler(t *testing.T) {
 	require.Error(t, err)
 
 }
+
+func TestProposal_HandleMatchEngineProposal(t *testing.T) {
+	fakeTokenKeeper := newMockTokenKeeper()
+	fakeSupplyKeeper := newMockSupplyKeeper()
+
+	mApp, mDexKeeper, err := newMockApp(fakeTokenKeeper, fakeSupplyKeeper, 10)
+	require.True(t, err == nil)
+
+	mApp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mApp.BaseApp.NewContext(false, abci.Header{})
+
+	mDexKeeper.Keeper.SetMatchEngines([]string{ordertypes.ContinuousAuction, ordertypes.PeriodicAuction})
+	proposalHandler := NewProposalHandler(mDexKeeper.Keeper)
+	tokenPair := GetBuiltInTokenPair()
+
+	content := types.NewMatchEngineProposal("match engine of xxb_okb", "change the match engine",
+		tokenPair.Owner, tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, ordertypes.ContinuousAuction)
+	proposal := govTypes.Proposal{Content: content}
+
+	// error case : fail to handle proposal because product(token pair) not exist
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+
+	saveErr := mApp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, saveErr)
+
+	// successful case : the match engine of token pair is changed
+	err = proposalHandler(ctx, &proposal)
+	require.Nil(t, err)
+	require.Equal(t, ordertypes.ContinuousAuction, mDexKeeper.Keeper.GetTokenPair(ctx, ordertypes.TestTokenPair).MatchEngine)
+
+	// error case : the unknown match engine can't be chosen
+	unknownProposal := govTypes.Proposal{Content: types.NewMatchEngineProposal("match engine of xxb_okb",
+		"change the match engine", tokenPair.Owner, tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, "unknown")}
+	err = proposalHandler(ctx, &unknownProposal)
+	require.Error(t, err)
+
+	// error case : the locked token pair can't change its match engine
+	lock := ordertypes.ProductLock{}
+	mDexKeeper.LockTokenPair(ctx, ordertypes.TestTokenPair, &lock)
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+}
+
+func TestProposal_HandlePriceBandProposal(t *testing.T) {
+	fakeTokenKeeper := newMockTokenKeeper()
+	fakeSupplyKeeper := newMockSupplyKeeper()
+
+	mApp, mDexKeeper, err := newMockApp(fakeTokenKeeper, fakeSupplyKeeper, 10)
+	require.True(t, err == nil)
+
+	mApp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mApp.BaseApp.NewContext(false, abci.Header{})
+
+	proposalHandler := NewProposalHandler(mDexKeeper.Keeper)
+	tokenPair := GetBuiltInTokenPair()
+
+	priceBand := types.NewPriceBand(sdk.NewDecWithPrec(1, 2), sdk.NewDecWithPrec(1, 1), 10)
+	content := types.NewPriceBandProposal("price band of xxb_okb", "change the price band",
+		tokenPair.Owner, tokenPair.BaseAssetSymbol, tokenPair.QuoteAssetSymbol, priceBand)
+	proposal := govTypes.Proposal{Content: content}
+
+	// error case : fail to handle proposal because product(token pair) not exist
+	err = proposalHandler(ctx, &proposal)
+	require.Error(t, err)
+
+	saveErr := mApp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, saveErr)
+
+	// successful case : the price band of token pair is changed
+	err = proposalHandler(ctx, &proposal)
+	require.Nil(t, err)
+	require.Equal(t, priceBand, mDexKeeper.Keeper.GetTokenPair(ctx, ordertypes.TestTokenPair).GetPriceBand())
+}
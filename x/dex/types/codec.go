@@ -9,6 +9,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgWithdraw{}, "okexchain/dex/MsgWithdraw", nil)
 	cdc.RegisterConcrete(MsgTransferOwnership{}, "okexchain/dex/MsgTransferTradingPairOwnership", nil)
 	cdc.RegisterConcrete(DelistProposal{}, "okexchain/dex/DelistProposal", nil)
+	cdc.RegisterConcrete(MatchEngineProposal{}, "okexchain/dex/MatchEngineProposal", nil)
+	cdc.RegisterConcrete(PriceBandProposal{}, "okexchain/dex/PriceBandProposal", nil)
 	cdc.RegisterConcrete(MsgCreateOperator{}, "okexchain/dex/CreateOperator", nil)
 	cdc.RegisterConcrete(MsgUpdateOperator{}, "okexchain/dex/UpdateOperator", nil)
 }
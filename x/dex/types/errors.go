@@ -17,6 +17,8 @@ const (
 	codeExistOperator           sdk.CodeType = 7
 	codeInvalidWebsiteLength    sdk.CodeType = 8
 	codeInvalidWebsiteURL       sdk.CodeType = 9
+	codeInvalidMatchEngine      sdk.CodeType = 10
+	codeInvalidPriceBand        sdk.CodeType = 11
 )
 
 // CodeType to Message
@@ -74,6 +76,16 @@ func ErrInvalidWebsiteURL(msg string) sdk.Error {
 	return sdk.NewError(DefaultCodespace, codeInvalidWebsiteURL, fmt.Sprintf("invalid website URL: %s", msg))
 }
 
+// ErrInvalidMatchEngine returns an error when the match engine is not registered
+func ErrInvalidMatchEngine(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, codeInvalidMatchEngine, msg)
+}
+
+// ErrInvalidPriceBand returns an error when the price band is invalid
+func ErrInvalidPriceBand(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, codeInvalidPriceBand, fmt.Sprintf("invalid price band: %s", msg))
+}
+
 // ErrTokenPairExisted returns an error when the token pair is existed during the process of listing
 // ErrTokenPairExisted returns an error when the token pair is existing during the process of listing
 func ErrTokenPairExisted(baseAsset, quoteAsset string) sdk.Error {
@@ -23,6 +23,8 @@ type TokenPair struct {
 	Owner            sdk.AccAddress `json:"owner"`
 	Deposits         sdk.DecCoin    `json:"deposits"`
 	BlockHeight      int64          `json:"block_height"`
+	MatchEngine      string         `json:"match_engine"`         // empty means the default match engine of the chain
+	PriceBand        *PriceBand     `json:"price_band,omitempty"` // nil means the default price band without circuit breaker
 }
 
 // Name returns name of token pair
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultPressureRate is the rate that the reference price of rule-3 in periodic auction moves
+// toward the pressure side, it's used by the token pairs without price band
+var DefaultPressureRate = sdk.NewDecWithPrec(5, 2)
+
+// PriceBand is the price protection of a token pair, which is set by governance
+type PriceBand struct {
+	PressureRate   sdk.Dec `json:"pressure_rate"`    // rate of the reference price move in rule-3 of periodic auction
+	MaxPriceChange sdk.Dec `json:"max_price_change"` // max rate of the price move per block, zero means unlimited
+	HaltBlocks     int64   `json:"halt_blocks"`      // blocks to halt the auction when the max price change exceeded
+}
+
+// NewPriceBand creates a new price band
+func NewPriceBand(pressureRate, maxPriceChange sdk.Dec, haltBlocks int64) PriceBand {
+	return PriceBand{
+		PressureRate:   pressureRate,
+		MaxPriceChange: maxPriceChange,
+		HaltBlocks:     haltBlocks,
+	}
+}
+
+// DefaultPriceBand returns the price band of the token pairs which haven't set one, without circuit breaker
+func DefaultPriceBand() PriceBand {
+	return NewPriceBand(DefaultPressureRate, sdk.ZeroDec(), 0)
+}
+
+// ValidateBasic validates the rates and the halt blocks of the price band
+func (pb PriceBand) ValidateBasic() sdk.Error {
+	if pb.PressureRate.IsNil() || pb.PressureRate.IsNegative() || pb.PressureRate.GTE(sdk.OneDec()) {
+		return ErrInvalidPriceBand(fmt.Sprintf("pressure rate(%s) should be in [0, 1)", pb.PressureRate))
+	}
+	if pb.MaxPriceChange.IsNil() || pb.MaxPriceChange.IsNegative() {
+		return ErrInvalidPriceBand(fmt.Sprintf("max price change(%s) can't be negative", pb.MaxPriceChange))
+	}
+	if pb.MaxPriceChange.IsPositive() && pb.HaltBlocks <= 0 {
+		return ErrInvalidPriceBand(fmt.Sprintf("halt blocks(%d) should be positive with max price change",
+			pb.HaltBlocks))
+	}
+	if pb.HaltBlocks < 0 {
+		return ErrInvalidPriceBand(fmt.Sprintf("halt blocks(%d) can't be negative", pb.HaltBlocks))
+	}
+	return nil
+}
+
+// IsOutOfBand returns true if the price moves from the last price more than the max price change
+func (pb PriceBand) IsOutOfBand(lastPrice, price sdk.Dec) bool {
+	if pb.MaxPriceChange.IsNil() || !pb.MaxPriceChange.IsPositive() || !lastPrice.IsPositive() {
+		return false
+	}
+	return price.Sub(lastPrice).Abs().GT(lastPrice.Mul(pb.MaxPriceChange))
+}
+
+// String returns a human readable string representation of the price band
+func (pb PriceBand) String() string {
+	return fmt.Sprintf("PressureRate: %s, MaxPriceChange: %s, HaltBlocks: %d", pb.PressureRate, pb.MaxPriceChange,
+		pb.HaltBlocks)
+}
+
+// GetPriceBand returns the price band of the token pair, or the default one if it's not set
+func (tp *TokenPair) GetPriceBand() PriceBand {
+	if tp.PriceBand == nil {
+		return DefaultPriceBand()
+	}
+	return *tp.PriceBand
+}
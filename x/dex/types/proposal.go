@@ -9,13 +9,18 @@ import (
 )
 
 const (
-	proposalTypeDelist = "Delist"
+	proposalTypeDelist      = "Delist"
+	proposalTypeMatchEngine = "MatchEngine"
+	proposalTypePriceBand   = "PriceBand"
 )
 
 func init() {
 	govtypes.RegisterProposalType(proposalTypeDelist)
 	govtypes.RegisterProposalTypeCodec(DelistProposal{}, "okexchain/dex/DelistProposal")
-
+	govtypes.RegisterProposalType(proposalTypeMatchEngine)
+	govtypes.RegisterProposalTypeCodec(MatchEngineProposal{}, "okexchain/dex/MatchEngineProposal")
+	govtypes.RegisterProposalType(proposalTypePriceBand)
+	govtypes.RegisterProposalTypeCodec(PriceBandProposal{}, "okexchain/dex/PriceBandProposal")
 }
 
 // Assert DelistProposal implements govtypes.Content at compile-time
@@ -107,3 +112,195 @@ func (drp DelistProposal) String() string {
 		drp.BaseAsset, drp.QuoteAsset,
 	)
 }
+
+// Assert MatchEngineProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = (*MatchEngineProposal)(nil)
+
+// MatchEngineProposal represents the proposal object to change the match engine of a token pair
+type MatchEngineProposal struct {
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	BaseAsset   string         `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset  string         `json:"quote_asset" yaml:"quote_asset"`
+	MatchEngine string         `json:"match_engine" yaml:"match_engine"`
+}
+
+// NewMatchEngineProposal creates a new match engine proposal object
+func NewMatchEngineProposal(title, description string, proposer sdk.AccAddress, baseAsset, quoteAsset,
+	matchEngine string) MatchEngineProposal {
+	return MatchEngineProposal{
+		Title:       title,
+		Description: description,
+		Proposer:    proposer,
+		BaseAsset:   baseAsset,
+		QuoteAsset:  quoteAsset,
+		MatchEngine: matchEngine,
+	}
+}
+
+// GetTitle returns title of match engine proposal object
+func (mep MatchEngineProposal) GetTitle() string {
+	return mep.Title
+}
+
+// GetDescription returns description of match engine proposal object
+func (mep MatchEngineProposal) GetDescription() string {
+	return mep.Description
+}
+
+// ProposalRoute returns route key of match engine proposal object
+func (MatchEngineProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of match engine proposal object
+func (MatchEngineProposal) ProposalType() string {
+	return proposalTypeMatchEngine
+}
+
+// ValidateBasic validates match engine proposal
+func (mep MatchEngineProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(mep.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit match engine proposal because title is blank")
+	}
+	if len(mep.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit match engine proposal because title is longer than max length of %d", govtypes.MaxTitleLength))
+	}
+
+	if len(mep.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit match engine proposal because description is blank")
+	}
+
+	if len(mep.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit match engine proposal because description is longer than max length of %d", govtypes.MaxDescriptionLength))
+	}
+
+	if mep.ProposalType() != proposalTypeMatchEngine {
+		return govtypes.ErrInvalidProposalType(DefaultCodespace, mep.ProposalType())
+	}
+
+	if mep.Proposer.Empty() {
+		return sdk.ErrInvalidAddress(mep.Proposer.String())
+	}
+
+	if mep.BaseAsset == mep.QuoteAsset {
+		return sdk.ErrInvalidCoins("failed to submit match engine proposal because baseasset is same as quoteasset")
+	}
+
+	if len(strings.TrimSpace(mep.MatchEngine)) == 0 {
+		return ErrInvalidMatchEngine("failed to submit match engine proposal because match engine is blank")
+	}
+
+	return nil
+}
+
+// String converts match engine proposal object to string
+func (mep MatchEngineProposal) String() string {
+	return fmt.Sprintf(`MatchEngineProposal:
+ Title:               %s
+ Description:         %s
+ Type:                %s
+ Proposer:            %s
+ BaseAsset            %s
+ QuoteAsset           %s
+ MatchEngine          %s
+`, mep.Title, mep.Description,
+		mep.ProposalType(), mep.Proposer,
+		mep.BaseAsset, mep.QuoteAsset, mep.MatchEngine,
+	)
+}
+
+// Assert PriceBandProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = (*PriceBandProposal)(nil)
+
+// PriceBandProposal represents the proposal object to change the price band of a token pair
+type PriceBandProposal struct {
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	BaseAsset   string         `json:"base_asset" yaml:"base_asset"`
+	QuoteAsset  string         `json:"quote_asset" yaml:"quote_asset"`
+	PriceBand   PriceBand      `json:"price_band" yaml:"price_band"`
+}
+
+// NewPriceBandProposal creates a new price band proposal object
+func NewPriceBandProposal(title, description string, proposer sdk.AccAddress, baseAsset, quoteAsset string,
+	priceBand PriceBand) PriceBandProposal {
+	return PriceBandProposal{
+		Title:       title,
+		Description: description,
+		Proposer:    proposer,
+		BaseAsset:   baseAsset,
+		QuoteAsset:  quoteAsset,
+		PriceBand:   priceBand,
+	}
+}
+
+// GetTitle returns title of price band proposal object
+func (pbp PriceBandProposal) GetTitle() string {
+	return pbp.Title
+}
+
+// GetDescription returns description of price band proposal object
+func (pbp PriceBandProposal) GetDescription() string {
+	return pbp.Description
+}
+
+// ProposalRoute returns route key of price band proposal object
+func (PriceBandProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of price band proposal object
+func (PriceBandProposal) ProposalType() string {
+	return proposalTypePriceBand
+}
+
+// ValidateBasic validates price band proposal
+func (pbp PriceBandProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(pbp.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit price band proposal because title is blank")
+	}
+	if len(pbp.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit price band proposal because title is longer than max length of %d", govtypes.MaxTitleLength))
+	}
+
+	if len(pbp.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, "failed to submit price band proposal because description is blank")
+	}
+
+	if len(pbp.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent(DefaultCodespace, fmt.Sprintf("failed to submit price band proposal because description is longer than max length of %d", govtypes.MaxDescriptionLength))
+	}
+
+	if pbp.ProposalType() != proposalTypePriceBand {
+		return govtypes.ErrInvalidProposalType(DefaultCodespace, pbp.ProposalType())
+	}
+
+	if pbp.Proposer.Empty() {
+		return sdk.ErrInvalidAddress(pbp.Proposer.String())
+	}
+
+	if pbp.BaseAsset == pbp.QuoteAsset {
+		return sdk.ErrInvalidCoins("failed to submit price band proposal because baseasset is same as quoteasset")
+	}
+
+	return pbp.PriceBand.ValidateBasic()
+}
+
+// String converts price band proposal object to string
+func (pbp PriceBandProposal) String() string {
+	return fmt.Sprintf(`PriceBandProposal:
+ Title:               %s
+ Description:         %s
+ Type:                %s
+ Proposer:            %s
+ BaseAsset            %s
+ QuoteAsset           %s
+ PriceBand            %s
+`, pbp.Title, pbp.Description,
+		pbp.ProposalType(), pbp.Proposer,
+		pbp.BaseAsset, pbp.QuoteAsset, pbp.PriceBand,
+	)
+}
@@ -47,6 +47,105 @@ func TestDelistProposal_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestMatchEngineProposal_ValidateBasic(t *testing.T) {
+	addr, err := sdk.AccAddressFromBech32(TestTokenPairOwner)
+	require.Nil(t, err)
+
+	proposal := NewMatchEngineProposal("proposal", "right match engine proposal", addr, "eth", "btc",
+		"continuousauction")
+	require.Equal(t, "proposal", proposal.GetTitle())
+	require.Equal(t, "right match engine proposal", proposal.GetDescription())
+	require.Equal(t, RouterKey, proposal.ProposalRoute())
+	require.Equal(t, proposalTypeMatchEngine, proposal.ProposalType())
+	require.NotEmpty(t, proposal.String())
+
+	tests := []struct {
+		name   string
+		mep    MatchEngineProposal
+		result bool
+	}{
+		{"match-engine-proposal", proposal, true},
+
+		{"no-title", MatchEngineProposal{"", "proposal", addr, "eth", "btc", "continuousauction"}, false},
+		{"no-description", MatchEngineProposal{"proposal", "", addr, "eth", "btc", "continuousauction"}, false},
+		{"no-proposer", MatchEngineProposal{"proposal", "proposal", nil, "eth", "btc", "continuousauction"}, false},
+		{"no-product", MatchEngineProposal{"proposal", "proposal", addr, "btc", "btc", "continuousauction"}, false},
+		{"no-match-engine", MatchEngineProposal{"proposal", "proposal", addr, "eth", "btc", ""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result {
+				require.Nil(t, tt.mep.ValidateBasic(), "test: %v", tt.name)
+			} else {
+				require.NotNil(t, tt.mep.ValidateBasic(), "test: %v", tt.name)
+			}
+		})
+	}
+}
+
+func TestPriceBandProposal_ValidateBasic(t *testing.T) {
+	addr, err := sdk.AccAddressFromBech32(TestTokenPairOwner)
+	require.Nil(t, err)
+
+	priceBand := NewPriceBand(sdk.NewDecWithPrec(1, 2), sdk.NewDecWithPrec(1, 1), 10)
+	proposal := NewPriceBandProposal("proposal", "right price band proposal", addr, "eth", "btc", priceBand)
+	require.Equal(t, "proposal", proposal.GetTitle())
+	require.Equal(t, "right price band proposal", proposal.GetDescription())
+	require.Equal(t, RouterKey, proposal.ProposalRoute())
+	require.Equal(t, proposalTypePriceBand, proposal.ProposalType())
+	require.NotEmpty(t, proposal.String())
+
+	tests := []struct {
+		name   string
+		pbp    PriceBandProposal
+		result bool
+	}{
+		{"price-band-proposal", proposal, true},
+		{"no-circuit-breaker", NewPriceBandProposal("proposal", "proposal", addr, "eth", "btc",
+			DefaultPriceBand()), true},
+
+		{"no-title", NewPriceBandProposal("", "proposal", addr, "eth", "btc", priceBand), false},
+		{"no-description", NewPriceBandProposal("proposal", "", addr, "eth", "btc", priceBand), false},
+		{"no-proposer", NewPriceBandProposal("proposal", "proposal", nil, "eth", "btc", priceBand), false},
+		{"no-product", NewPriceBandProposal("proposal", "proposal", addr, "btc", "btc", priceBand), false},
+		{"no-pressure-rate", NewPriceBandProposal("proposal", "proposal", addr, "eth", "btc",
+			PriceBand{MaxPriceChange: sdk.ZeroDec()}), false},
+		{"pressure-rate-one", NewPriceBandProposal("proposal", "proposal", addr, "eth", "btc",
+			NewPriceBand(sdk.OneDec(), sdk.ZeroDec(), 0)), false},
+		{"negative-max-price-change", NewPriceBandProposal("proposal", "proposal", addr, "eth", "btc",
+			NewPriceBand(DefaultPressureRate, sdk.NewDec(-1), 10)), false},
+		{"no-halt-blocks", NewPriceBandProposal("proposal", "proposal", addr, "eth", "btc",
+			NewPriceBand(DefaultPressureRate, sdk.NewDecWithPrec(1, 1), 0)), false},
+		{"negative-halt-blocks", NewPriceBandProposal("proposal", "proposal", addr, "eth", "btc",
+			NewPriceBand(DefaultPressureRate, sdk.ZeroDec(), -1)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result {
+				require.Nil(t, tt.pbp.ValidateBasic(), "test: %v", tt.name)
+			} else {
+				require.NotNil(t, tt.pbp.ValidateBasic(), "test: %v", tt.name)
+			}
+		})
+	}
+}
+
+func TestPriceBand_IsOutOfBand(t *testing.T) {
+	require.False(t, DefaultPriceBand().IsOutOfBand(sdk.NewDec(10), sdk.NewDec(100)))
+
+	priceBand := NewPriceBand(DefaultPressureRate, sdk.NewDecWithPrec(1, 1), 10)
+	require.False(t, priceBand.IsOutOfBand(sdk.ZeroDec(), sdk.NewDec(100)))
+	require.False(t, priceBand.IsOutOfBand(sdk.NewDec(10), sdk.NewDec(11)))
+	require.False(t, priceBand.IsOutOfBand(sdk.NewDec(10), sdk.NewDec(9)))
+	require.True(t, priceBand.IsOutOfBand(sdk.NewDec(10), sdk.MustNewDecFromStr("11.01")))
+	require.True(t, priceBand.IsOutOfBand(sdk.NewDec(10), sdk.MustNewDecFromStr("8.99")))
+
+	tokenPair := &TokenPair{}
+	require.Equal(t, DefaultPriceBand(), tokenPair.GetPriceBand())
+	tokenPair.PriceBand = &priceBand
+	require.Equal(t, priceBand, tokenPair.GetPriceBand())
+}
+
 func getLongString(n int) (s string) {
 	str := "0123456789"
 	for i := 0; i < n; i++ {
@@ -106,3 +106,34 @@ func createTestVotes(ctx sdk.Context, sk staking.Keeper, test testAllocationPara
 	}
 	return votes
 }
+
+func TestFundCommunityPoolFromModule(t *testing.T) {
+	ctx, ak, k, _, _ := CreateTestInputDefault(t, false, 1000)
+	fees := NewTestDecCoins(123, 2)
+	setTestFees(t, ctx, k, ak, fees)
+
+	require.Nil(t, k.FundCommunityPoolFromModule(ctx, fees, k.feeCollectorName))
+	require.Equal(t, fees, k.GetFeePool(ctx).CommunityPool)
+	require.True(t, k.supplyKeeper.GetModuleAccount(ctx, k.feeCollectorName).GetCoins().IsZero())
+	require.Equal(t, fees, k.GetDistributionAccount(ctx).GetCoins())
+
+	// insufficient coins in the sender module
+	require.NotNil(t, k.FundCommunityPoolFromModule(ctx, fees, k.feeCollectorName))
+	require.Equal(t, fees, k.GetFeePool(ctx).CommunityPool)
+}
+
+func TestSpendCommunityPoolToModule(t *testing.T) {
+	ctx, ak, k, _, _ := CreateTestInputDefault(t, false, 1000)
+	fees := NewTestDecCoins(123, 2)
+	setTestFees(t, ctx, k, ak, fees)
+	require.Nil(t, k.FundCommunityPoolFromModule(ctx, fees, k.feeCollectorName))
+
+	// insufficient coins in the community pool
+	require.NotNil(t, k.SpendCommunityPoolToModule(ctx, fees.Add(fees), k.feeCollectorName))
+	require.Equal(t, fees, k.GetFeePool(ctx).CommunityPool)
+
+	require.Nil(t, k.SpendCommunityPoolToModule(ctx, fees, k.feeCollectorName))
+	require.True(t, k.GetFeePool(ctx).CommunityPool.IsZero())
+	require.True(t, k.GetDistributionAccount(ctx).GetCoins().IsZero())
+	require.Equal(t, fees, k.supplyKeeper.GetModuleAccount(ctx, k.feeCollectorName).GetCoins())
+}
@@ -103,3 +103,32 @@ func (k Keeper) WithdrawValidatorCommission(ctx sdk.Context, valAddr sdk.ValAddr
 
 	return commission, nil
 }
+
+// FundCommunityPoolFromModule moves coins from the module account to the community pool
+func (k Keeper) FundCommunityPoolFromModule(ctx sdk.Context, amount sdk.Coins, senderModule string) sdk.Error {
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, senderModule, types.ModuleName, amount); err != nil {
+		return err
+	}
+
+	feePool := k.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Add(amount)
+	k.SetFeePool(ctx, feePool)
+	return nil
+}
+
+// SpendCommunityPoolToModule moves coins from the community pool to the module account
+func (k Keeper) SpendCommunityPoolToModule(ctx sdk.Context, amount sdk.Coins, recipientModule string) sdk.Error {
+	feePool := k.GetFeePool(ctx)
+	newPool, negative := feePool.CommunityPool.SafeSub(amount)
+	if negative {
+		return types.ErrBadDistribution(k.codespace)
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, recipientModule, amount); err != nil {
+		return err
+	}
+
+	feePool.CommunityPool = newPool
+	k.SetFeePool(ctx, feePool)
+	return nil
+}
@@ -0,0 +1,37 @@
+package farm
+
+import (
+	"github.com/okex/okexchain/x/farm/keeper"
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+const (
+	// nolint
+	ModuleName   = types.ModuleName
+	RouterKey    = types.RouterKey
+	StoreKey     = types.StoreKey
+	QuerierRoute = types.QuerierRoute
+)
+
+var (
+	// functions aliases
+	// nolint
+	NewKeeper     = keeper.NewKeeper
+	NewQuerier    = keeper.NewQuerier
+	RegisterCodec = types.RegisterCodec
+
+	NewCommunityPoolRewardsProposal = types.NewCommunityPoolRewardsProposal
+
+	// variable aliases
+	// nolint
+	ModuleCdc = types.ModuleCdc
+)
+
+type (
+	// nolint
+	Keeper = keeper.Keeper
+
+	// nolint
+	FarmPool  = types.FarmPool
+	StakeInfo = types.StakeInfo
+)
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/farm/types"
+	"github.com/spf13/cobra"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	farmQueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("Querying commands for the %s module", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	farmQueryCmd.AddCommand(
+		flags.GetCommands(
+			getCmdQueryPool(queryRoute, cdc),
+			getCmdQueryPools(queryRoute, cdc),
+			getCmdQueryStakeInfo(queryRoute, cdc),
+			getCmdQueryEarnings(queryRoute, cdc),
+		)...,
+	)
+
+	return farmQueryCmd
+}
+
+func getCmdQueryPool(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pool [pool-name]",
+		Short: "Query a farm pool",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryPool, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var pool types.FarmPool
+			cdc.MustUnmarshalJSON(res, &pool)
+			return cliCtx.PrintOutput(pool)
+		},
+	}
+}
+
+func getCmdQueryPools(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pools",
+		Short: "Query all the farm pools",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryPools), nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+func getCmdQueryStakeInfo(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stake-info [pool-name] [address]",
+		Short: "Query the pool token staked by the address in a farm pool",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bz, err := queryStakeInfoParams(cdc, args)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryStakeInfo), bz)
+			if err != nil {
+				return err
+			}
+
+			var info types.StakeInfo
+			cdc.MustUnmarshalJSON(res, &info)
+			return cliCtx.PrintOutput(info)
+		},
+	}
+}
+
+func getCmdQueryEarnings(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "earnings [pool-name] [address]",
+		Short: "Query the staked amount and the pending rewards of the address in a farm pool",
+		Long: strings.TrimSpace(`Query the staked amount and the pending rewards of the address in a farm pool.
+
+Example:
+$ okexchaincli query farm earnings eth-pool okexchain1hw4r48aww06ldrfeuq2v438ujnl6alszzzqpph
+`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bz, err := queryStakeInfoParams(cdc, args)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryEarnings), bz)
+			if err != nil {
+				return err
+			}
+
+			var earnings types.Earnings
+			cdc.MustUnmarshalJSON(res, &earnings)
+			return cliCtx.PrintOutput(earnings)
+		},
+	}
+}
+
+func queryStakeInfoParams(cdc *codec.Codec, args []string) ([]byte, error) {
+	addr, err := sdk.AccAddressFromBech32(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return cdc.MarshalJSON(types.NewQueryStakeInfoParams(args[0], addr))
+}
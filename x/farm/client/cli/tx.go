@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/spf13/cobra"
+
+	"github.com/okex/okexchain/x/farm/types"
+	"github.com/okex/okexchain/x/gov"
+)
+
+// GetTxCmd returns the transaction commands for this module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   "farm",
+		Short: "Farm transactions subcommands",
+	}
+
+	txCmd.AddCommand(client.PostCommands(
+		getCmdCreatePool(cdc),
+		getCmdProvideRewards(cdc),
+		getCmdStake(cdc),
+		getCmdUnstake(cdc),
+		getCmdClaim(cdc),
+	)...)
+
+	return txCmd
+}
+
+func getCmdCreatePool(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create-pool [pool-name] [staked-token] [reward-token] [reward-per-block]",
+		Short: "create a farm pool rewarding the stakers of an ammswap pool token",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`create a farm pool rewarding the stakers of an ammswap pool token every block.
+
+Example:
+$ okexchaincli tx farm create-pool eth-pool ammswap-eth-355 okt 0.5 --fees 0.01okt
+
+`),
+		),
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			rewardPerBlock, err := sdk.NewDecFromStr(args[3])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgCreatePool(cliCtx.FromAddress, args[0], args[1], args[2], rewardPerBlock)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdProvideRewards(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "provide [pool-name] [amount]",
+		Short: "provide the reward token to a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`provide the reward token to a farm pool.
+
+Example:
+$ okexchaincli tx farm provide eth-pool 10000okt --fees 0.01okt
+
+`),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			amount, err := sdk.ParseDecCoin(args[1])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgProvideRewards(args[0], amount, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdStake(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stake [pool-name] [amount]",
+		Short: "stake the pool token to a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`stake the pool token to a farm pool, the pending rewards are claimed at the same time.
+
+Example:
+$ okexchaincli tx farm stake eth-pool 10ammswap-eth-355 --fees 0.01okt
+
+`),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			amount, err := sdk.ParseDecCoin(args[1])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgStake(args[0], amount, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdUnstake(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unstake [pool-name] [amount]",
+		Short: "withdraw the staked pool token from a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`withdraw the staked pool token from a farm pool, the pending rewards are claimed at the same time.
+
+Example:
+$ okexchaincli tx farm unstake eth-pool 10ammswap-eth-355 --fees 0.01okt
+
+`),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			amount, err := sdk.ParseDecCoin(args[1])
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgUnstake(args[0], amount, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+func getCmdClaim(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "claim [pool-name]",
+		Short: "claim the pending rewards of a farm pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`claim the pending rewards of a farm pool.
+
+Example:
+$ okexchaincli tx farm claim eth-pool --fees 0.01okt
+
+`),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			msg := types.NewMsgClaim(args[0], cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+// CommunityPoolRewardsProposalJSON defines a CommunityPoolRewardsProposal with a deposit
+type CommunityPoolRewardsProposalJSON struct {
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	PoolName    string       `json:"pool_name" yaml:"pool_name"`
+	Amount      sdk.DecCoin  `json:"amount" yaml:"amount"`
+	Deposit     sdk.DecCoins `json:"deposit" yaml:"deposit"`
+}
+
+// GetCmdSubmitCommunityPoolRewardsProposal implements the command to submit a community-pool-rewards proposal
+func GetCmdSubmitCommunityPoolRewardsProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "community-pool-rewards [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal providing the reward token to a farm pool from the community pool",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal providing the reward token to a farm pool from the community pool along with
+an initial deposit. The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal community-pool-rewards <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Community Pool Rewards",
+  "description": "Reward the stakers of eth-pool with %s",
+  "pool_name": "eth-pool",
+  "amount": {
+    "denom": "%s",
+    "amount": "10000"
+  },
+  "deposit": [
+    {
+      "denom": "%s",
+      "amount": "10000"
+    }
+  ]
+}
+`, version.ClientName, sdk.DefaultBondDenom, sdk.DefaultBondDenom, sdk.DefaultBondDenom),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			var proposal CommunityPoolRewardsProposalJSON
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+				return err
+			}
+
+			content := types.NewCommunityPoolRewardsProposal(proposal.Title, proposal.Description, proposal.PoolName,
+				proposal.Amount)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,13 @@
+package client
+
+import (
+	"github.com/okex/okexchain/x/farm/client/cli"
+	"github.com/okex/okexchain/x/farm/client/rest"
+	govclient "github.com/okex/okexchain/x/gov/client"
+)
+
+var (
+	// CommunityPoolRewardsProposalHandler alias gov NewProposalHandler
+	CommunityPoolRewardsProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitCommunityPoolRewardsProposal,
+		rest.CommunityPoolRewardsProposalRESTHandler)
+)
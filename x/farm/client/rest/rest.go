@@ -0,0 +1,18 @@
+package rest
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	govRest "github.com/okex/okexchain/x/gov/client/rest"
+)
+
+// RegisterRoutes registers farm-related REST handlers to a router
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	registerQueryRoutes(cliCtx, r)
+}
+
+// CommunityPoolRewardsProposalRESTHandler defines farm community pool rewards proposal handler
+func CommunityPoolRewardsProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
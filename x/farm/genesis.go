@@ -0,0 +1,72 @@
+package farm
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+// GenesisState stores genesis data of the farm pools and the tokens staked in them
+type GenesisState struct {
+	Pools      []FarmPool  `json:"pools"`
+	StakeInfos []StakeInfo `json:"stake_infos"`
+}
+
+// DefaultGenesisState returns the default genesis state of the farm module
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// ValidateGenesis validates the format of the specified genesisState
+func ValidateGenesis(data GenesisState) error {
+	totalStaked := make(map[string]sdk.Dec, len(data.Pools))
+	for _, pool := range data.Pools {
+		if err := types.ValidatePoolName(pool.Name); err != nil {
+			return err
+		}
+		if _, found := totalStaked[pool.Name]; found {
+			return fmt.Errorf("duplicate farm pool %s", pool.Name)
+		}
+		if !pool.RewardPerBlock.IsPositive() || pool.RemainingRewards.IsNegative() ||
+			pool.UnclaimedRewards.IsNegative() || pool.TotalStaked.IsNegative() || pool.AccRewardPerShare.IsNegative() {
+			return fmt.Errorf("invalid farm pool %s", pool.Name)
+		}
+		totalStaked[pool.Name] = sdk.ZeroDec()
+	}
+	for _, info := range data.StakeInfos {
+		staked, found := totalStaked[info.PoolName]
+		if !found {
+			return fmt.Errorf("stake info of non-existent farm pool %s", info.PoolName)
+		}
+		if info.Owner.Empty() || !info.Amount.IsPositive() || info.RewardDebt.IsNegative() {
+			return fmt.Errorf("invalid stake info of %s in farm pool %s", info.Owner, info.PoolName)
+		}
+		totalStaked[info.PoolName] = staked.Add(info.Amount)
+	}
+	for _, pool := range data.Pools {
+		if !pool.TotalStaked.Equal(totalStaked[pool.Name]) {
+			return fmt.Errorf("total staked of farm pool %s is %s, but %s staked", pool.Name, pool.TotalStaked,
+				totalStaked[pool.Name])
+		}
+	}
+	return nil
+}
+
+// InitGenesis init genesis data to keeper
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, pool := range data.Pools {
+		k.SetFarmPool(ctx, pool)
+	}
+	for _, info := range data.StakeInfos {
+		k.SetStakeInfo(ctx, info)
+	}
+}
+
+// ExportGenesis exports genesis from keeper
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return GenesisState{
+		Pools:      k.GetFarmPools(ctx),
+		StakeInfos: k.GetStakeInfos(ctx),
+	}
+}
@@ -0,0 +1,58 @@
+package farm
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/farm/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	require.Nil(t, ValidateGenesis(DefaultGenesisState()))
+
+	owner := sdk.AccAddress([]byte("owner"))
+	pool := types.NewFarmPool(testPoolName, owner, testStakedSymbol, testRewardSymbol, sdk.NewDec(10), 1)
+	pool.TotalStaked = sdk.NewDec(100)
+	info := types.NewStakeInfo(testPoolName, owner)
+	info.Amount = sdk.NewDec(100)
+	genesisState := GenesisState{Pools: []FarmPool{pool}, StakeInfos: []StakeInfo{info}}
+	require.Nil(t, ValidateGenesis(genesisState))
+
+	genesisState.Pools = []FarmPool{pool, pool}
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	invalidPool := pool
+	invalidPool.Name = "Invalid Name"
+	genesisState.Pools = []FarmPool{invalidPool}
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	invalidPool = pool
+	invalidPool.TotalStaked = sdk.NewDec(99)
+	genesisState.Pools = []FarmPool{invalidPool}
+	require.NotNil(t, ValidateGenesis(genesisState))
+
+	genesisState.Pools = nil
+	require.NotNil(t, ValidateGenesis(genesisState))
+}
+
+func TestInitAndExportGenesis(t *testing.T) {
+	mapp, ctx, addrs := getTestContext(t)
+	keeper := mapp.farmKeeper
+	handler := NewHandler(keeper)
+	result := handler(ctx, types.NewMsgCreatePool(addrs[0], testPoolName, testStakedSymbol, testRewardSymbol,
+		sdk.NewDec(10)))
+	require.True(t, result.IsOK(), result.Log)
+	result = handler(ctx, types.NewMsgStake(testPoolName, sdk.NewDecCoinFromDec(testStakedSymbol, sdk.NewDec(100)),
+		addrs[0]))
+	require.True(t, result.IsOK(), result.Log)
+
+	exportedGenesis := ExportGenesis(ctx, keeper)
+	require.Nil(t, ValidateGenesis(exportedGenesis))
+	require.Equal(t, 1, len(exportedGenesis.Pools))
+	require.Equal(t, 1, len(exportedGenesis.StakeInfos))
+
+	newMapp, newCtx, _ := getTestContext(t)
+	InitGenesis(newCtx, newMapp.farmKeeper, exportedGenesis)
+	require.Equal(t, exportedGenesis, ExportGenesis(newCtx, newMapp.farmKeeper))
+}
@@ -0,0 +1,112 @@
+package farm
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/common/perf"
+	"github.com/okex/okexchain/x/farm/types"
+	govtypes "github.com/okex/okexchain/x/gov/types"
+)
+
+// NewHandler creates an sdk.Handler for all the farm type messages
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		var handlerFun func() sdk.Result
+		var name string
+		switch msg := msg.(type) {
+		case types.MsgCreatePool:
+			name = "handleMsgCreatePool"
+			handlerFun = func() sdk.Result {
+				return handleMsgCreatePool(ctx, k, msg)
+			}
+		case types.MsgProvideRewards:
+			name = "handleMsgProvideRewards"
+			handlerFun = func() sdk.Result {
+				return handleMsgProvideRewards(ctx, k, msg)
+			}
+		case types.MsgStake:
+			name = "handleMsgStake"
+			handlerFun = func() sdk.Result {
+				rewards, err := k.Stake(ctx, msg.PoolName, msg.Amount, msg.Sender)
+				return rewardsResult(ctx, msg.PoolName, msg.Amount, rewards, err)
+			}
+		case types.MsgUnstake:
+			name = "handleMsgUnstake"
+			handlerFun = func() sdk.Result {
+				rewards, err := k.Unstake(ctx, msg.PoolName, msg.Amount, msg.Sender)
+				return rewardsResult(ctx, msg.PoolName, msg.Amount, rewards, err)
+			}
+		case types.MsgClaim:
+			name = "handleMsgClaim"
+			handlerFun = func() sdk.Result {
+				rewards, err := k.Claim(ctx, msg.PoolName, msg.Sender)
+				return rewardsResult(ctx, msg.PoolName, sdk.DecCoin{}, rewards, err)
+			}
+		default:
+			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+		seq := perf.GetPerf().OnDeliverTxEnter(ctx, types.ModuleName, name)
+		defer perf.GetPerf().OnDeliverTxExit(ctx, types.ModuleName, name, seq)
+		return handlerFun()
+	}
+}
+
+func handleMsgCreatePool(ctx sdk.Context, k Keeper, msg types.MsgCreatePool) sdk.Result {
+	if err := k.CreateFarmPool(ctx, msg.Owner, msg.PoolName, msg.StakedSymbol, msg.RewardSymbol,
+		msg.RewardPerBlock); err != nil {
+		return err.Result()
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyPoolName, msg.PoolName),
+	))
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgProvideRewards(ctx sdk.Context, k Keeper, msg types.MsgProvideRewards) sdk.Result {
+	if err := k.ProvideRewards(ctx, msg.PoolName, msg.Amount, msg.Sender); err != nil {
+		return err.Result()
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyPoolName, msg.PoolName),
+		sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.String()),
+	))
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// NewCommunityPoolRewardsProposalHandler creates a gov handler for the passed community pool rewards proposals
+func NewCommunityPoolRewardsProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, proposal *govtypes.Proposal) sdk.Error {
+		switch c := proposal.Content.(type) {
+		case types.CommunityPoolRewardsProposal:
+			if err := k.ProvideRewardsFromCommunityPool(ctx, c.PoolName, c.Amount); err != nil {
+				return err
+			}
+			k.Logger(ctx).Info(fmt.Sprintf("provided %s from the community pool to farm pool %s", c.Amount, c.PoolName))
+			return nil
+		default:
+			return sdk.ErrUnknownRequest(fmt.Sprintf("unrecognized farm proposal content type: %T", c))
+		}
+	}
+}
+
+// rewardsResult returns the result of the messages paying the pending rewards
+func rewardsResult(ctx sdk.Context, poolName string, amount, rewards sdk.DecCoin, err sdk.Error) sdk.Result {
+	if err != nil {
+		return err.Result()
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyPoolName, poolName),
+		sdk.NewAttribute(types.AttributeKeyRewards, rewards.String()),
+	)
+	if amount.Amount.Int != nil {
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyAmount, amount.String()))
+	}
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
@@ -0,0 +1,242 @@
+package farm
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/okex/okexchain/x/farm/types"
+	govtypes "github.com/okex/okexchain/x/gov/types"
+	token "github.com/okex/okexchain/x/token/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+const testPoolName = "xxb-pool"
+
+func initToken(name string) token.Token {
+	return token.Token{
+		Description:         name,
+		Symbol:              name,
+		OriginalSymbol:      name,
+		WholeName:           name,
+		OriginalTotalSupply: sdk.NewDec(0),
+		Type:                1,
+		Mintable:            true,
+	}
+}
+
+func getTestContext(t *testing.T) (*MockApp, sdk.Context, []sdk.AccAddress) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+	mapp.tokenKeeper.NewToken(ctx, initToken(testStakedSymbol))
+	mapp.tokenKeeper.NewToken(ctx, initToken(testRewardSymbol))
+	return mapp, ctx, []sdk.AccAddress{addrKeysSlice[0].Address, addrKeysSlice[1].Address}
+}
+
+func TestHandleMsgCreatePool(t *testing.T) {
+	mapp, ctx, addrs := getTestContext(t)
+	handler := NewHandler(mapp.farmKeeper)
+	rewardPerBlock := sdk.NewDec(10)
+
+	tests := []struct {
+		testCase         string
+		msg              types.MsgCreatePool
+		exceptResultCode sdk.CodeType
+	}{
+		{"success", types.NewMsgCreatePool(addrs[0], testPoolName, testStakedSymbol, testRewardSymbol, rewardPerBlock),
+			sdk.CodeOK},
+		{"pool already exists", types.NewMsgCreatePool(addrs[1], testPoolName, testStakedSymbol, testRewardSymbol,
+			rewardPerBlock), sdk.CodeUnknownRequest},
+		{"non-existent staked token", types.NewMsgCreatePool(addrs[0], "abc", "ammswap-abc", testRewardSymbol,
+			rewardPerBlock), sdk.CodeUnknownRequest},
+		{"non-existent reward token", types.NewMsgCreatePool(addrs[0], "abc", testStakedSymbol, "abc",
+			rewardPerBlock), sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		result := handler(ctx, testCase.msg)
+		require.Equal(t, testCase.exceptResultCode, result.Code, testCase.testCase)
+	}
+
+	pool, found := mapp.farmKeeper.GetFarmPool(ctx, testPoolName)
+	require.True(t, found)
+	require.Equal(t, addrs[0], pool.Owner)
+	require.Equal(t, int64(10), pool.LastRewardHeight)
+}
+
+func TestHandleMsgsStakeAndClaim(t *testing.T) {
+	mapp, ctx, addrs := getTestContext(t)
+	keeper := mapp.farmKeeper
+	handler := NewHandler(keeper)
+	staked := func(amount int64) sdk.DecCoin { return sdk.NewDecCoinFromDec(testStakedSymbol, sdk.NewDec(amount)) }
+	reward := func(amount int64) sdk.DecCoin { return sdk.NewDecCoinFromDec(testRewardSymbol, sdk.NewDec(amount)) }
+	balanceOf := func(addr sdk.AccAddress, denom string) sdk.Dec {
+		return mapp.AccountKeeper.GetAccount(ctx, addr).GetCoins().AmountOf(denom)
+	}
+
+	result := handler(ctx, types.NewMsgCreatePool(addrs[0], testPoolName, testStakedSymbol, testRewardSymbol,
+		sdk.NewDec(10)))
+	require.True(t, result.IsOK(), result.Log)
+
+	tests := []struct {
+		testCase         string
+		msg              sdk.Msg
+		exceptResultCode sdk.CodeType
+	}{
+		{"provide to non-existent pool", types.NewMsgProvideRewards("abc", reward(100), addrs[0]),
+			sdk.CodeUnknownRequest},
+		{"provide the staked token", types.NewMsgProvideRewards(testPoolName, staked(100), addrs[0]),
+			sdk.CodeUnknownRequest},
+		{"provide insufficient coins", types.NewMsgProvideRewards(testPoolName, reward(10000), addrs[0]),
+			sdk.CodeInsufficientCoins},
+		{"provide", types.NewMsgProvideRewards(testPoolName, reward(100), addrs[0]), sdk.CodeOK},
+		{"stake the reward token", types.NewMsgStake(testPoolName, reward(100), addrs[0]), sdk.CodeUnknownRequest},
+		{"stake insufficient coins", types.NewMsgStake(testPoolName, staked(10000), addrs[0]),
+			sdk.CodeInsufficientCoins},
+		{"stake", types.NewMsgStake(testPoolName, staked(100), addrs[0]), sdk.CodeOK},
+		{"unstake more than staked", types.NewMsgUnstake(testPoolName, staked(101), addrs[0]),
+			sdk.CodeInsufficientCoins},
+		{"claim without staking", types.NewMsgClaim(testPoolName, addrs[1]), sdk.CodeUnknownRequest},
+	}
+	for _, testCase := range tests {
+		result := handler(ctx, testCase.msg)
+		require.Equal(t, testCase.exceptResultCode, result.Code, testCase.testCase)
+	}
+
+	// addrs[0] earns all the rewards of block 10 and 11, then a quarter of block 12 and 13
+	ctx = ctx.WithBlockHeight(12)
+	result = handler(ctx, types.NewMsgStake(testPoolName, staked(300), addrs[1]))
+	require.True(t, result.IsOK(), result.Log)
+	ctx = ctx.WithBlockHeight(14)
+	earnings, err := keeper.GetEarnings(ctx, testPoolName, addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, reward(25), earnings.PendingRewards)
+
+	result = handler(ctx, types.NewMsgClaim(testPoolName, addrs[0]))
+	require.True(t, result.IsOK(), result.Log)
+	require.Equal(t, sdk.NewDec(925), balanceOf(addrs[0], testRewardSymbol))
+	result = handler(ctx, types.NewMsgUnstake(testPoolName, staked(300), addrs[1]))
+	require.True(t, result.IsOK(), result.Log)
+	require.Equal(t, sdk.NewDec(1015), balanceOf(addrs[1], testRewardSymbol))
+	require.Equal(t, sdk.NewDec(1000), balanceOf(addrs[1], testStakedSymbol))
+	_, found := keeper.GetStakeInfo(ctx, testPoolName, addrs[1])
+	require.False(t, found)
+
+	// the remaining 60 rewards are distributed to addrs[0] in 6 blocks, nothing more afterwards
+	ctx = ctx.WithBlockHeight(30)
+	earnings, err = keeper.GetEarnings(ctx, testPoolName, addrs[0])
+	require.Nil(t, err)
+	require.Equal(t, reward(60), earnings.PendingRewards)
+	result = handler(ctx, types.NewMsgUnstake(testPoolName, staked(100), addrs[0]))
+	require.True(t, result.IsOK(), result.Log)
+	require.Equal(t, sdk.NewDec(985), balanceOf(addrs[0], testRewardSymbol))
+	require.Equal(t, sdk.NewDec(1000), balanceOf(addrs[0], testStakedSymbol))
+
+	pool, found := keeper.GetFarmPool(ctx, testPoolName)
+	require.True(t, found)
+	require.True(t, pool.RemainingRewards.IsZero())
+	require.True(t, pool.UnclaimedRewards.IsZero())
+	require.True(t, pool.TotalStaked.IsZero())
+	require.True(t, mapp.supplyKeeper.GetModuleAccount(ctx, ModuleName).GetCoins().IsZero())
+
+	querier := NewQuerier(keeper)
+	res, sdkErr := querier(ctx, []string{types.QueryPool, testPoolName}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var queriedPool FarmPool
+	ModuleCdc.MustUnmarshalJSON(res, &queriedPool)
+	require.Equal(t, pool, queriedPool)
+	bz := ModuleCdc.MustMarshalJSON(types.NewQueryStakeInfoParams(testPoolName, addrs[0]))
+	_, sdkErr = querier(ctx, []string{types.QueryStakeInfo}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, sdkErr)
+	res, sdkErr = querier(ctx, []string{types.QueryEarnings}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	ModuleCdc.MustUnmarshalJSON(res, &earnings)
+	require.True(t, earnings.PendingRewards.IsZero())
+}
+
+func TestHandleMsgsWithFrozenToken(t *testing.T) {
+	mapp, ctx, addrs := getTestContext(t)
+	handler := NewHandler(mapp.farmKeeper)
+	staked := sdk.NewDecCoinFromDec(testStakedSymbol, sdk.NewDec(100))
+	reward := sdk.NewDecCoinFromDec(testRewardSymbol, sdk.NewDec(100))
+	rewardToken := mapp.tokenKeeper.GetTokenInfo(ctx, testRewardSymbol)
+	rewardToken.Freezable = true
+	mapp.tokenKeeper.UpdateToken(ctx, rewardToken)
+
+	result := handler(ctx, types.NewMsgCreatePool(addrs[0], testPoolName, testStakedSymbol, testRewardSymbol,
+		sdk.NewDec(10)))
+	require.True(t, result.IsOK(), result.Log)
+	result = handler(ctx, types.NewMsgStake(testPoolName, staked, addrs[1]))
+	require.True(t, result.IsOK(), result.Log)
+
+	// the frozen account can neither provide nor receive the reward token
+	mapp.tokenKeeper.SetFrozen(ctx, testRewardSymbol, addrs[0], true)
+	result = handler(ctx, types.NewMsgProvideRewards(testPoolName, reward, addrs[0]))
+	require.Equal(t, token.CodeTokenFrozen, result.Code, result.Log)
+	mapp.tokenKeeper.SetFrozen(ctx, testRewardSymbol, addrs[0], false)
+	result = handler(ctx, types.NewMsgProvideRewards(testPoolName, reward, addrs[0]))
+	require.True(t, result.IsOK(), result.Log)
+
+	ctx = ctx.WithBlockHeight(12)
+	mapp.tokenKeeper.SetFrozen(ctx, testRewardSymbol, addrs[1], true)
+	for _, msg := range []sdk.Msg{
+		types.NewMsgClaim(testPoolName, addrs[1]),
+		types.NewMsgStake(testPoolName, staked, addrs[1]),
+		types.NewMsgUnstake(testPoolName, staked, addrs[1]),
+	} {
+		result = handler(ctx, msg)
+		require.Equal(t, token.CodeTokenFrozen, result.Code, result.Log)
+	}
+
+	// the rewards can't be paid while the transfers are paused
+	mapp.tokenKeeper.SetFrozen(ctx, testRewardSymbol, addrs[1], false)
+	rewardToken.Paused = true
+	mapp.tokenKeeper.UpdateToken(ctx, rewardToken)
+	result = handler(ctx, types.NewMsgClaim(testPoolName, addrs[1]))
+	require.Equal(t, token.CodeTokenPaused, result.Code, result.Log)
+	rewardToken.Paused = false
+	mapp.tokenKeeper.UpdateToken(ctx, rewardToken)
+	result = handler(ctx, types.NewMsgClaim(testPoolName, addrs[1]))
+	require.True(t, result.IsOK(), result.Log)
+}
+
+func TestCommunityPoolRewardsProposalHandler(t *testing.T) {
+	mapp, ctx, addrs := getTestContext(t)
+	handler := NewHandler(mapp.farmKeeper)
+	proposalHandler := NewCommunityPoolRewardsProposalHandler(mapp.farmKeeper)
+	reward := func(amount int64) sdk.DecCoin { return sdk.NewDecCoinFromDec(testRewardSymbol, sdk.NewDec(amount)) }
+
+	result := handler(ctx, types.NewMsgCreatePool(addrs[0], testPoolName, testStakedSymbol, testRewardSymbol,
+		sdk.NewDec(10)))
+	require.True(t, result.IsOK(), result.Log)
+	require.Nil(t, mapp.supplyKeeper.SendCoinsFromAccountToModule(ctx, addrs[1], testCommunityPoolModule,
+		sdk.DecCoins{reward(500)}))
+
+	tests := []struct {
+		testCase string
+		content  types.CommunityPoolRewardsProposal
+		isValid  bool
+	}{
+		{"non-existent farm pool", types.NewCommunityPoolRewardsProposal("title", "description", "abc",
+			reward(100)), false},
+		{"not the reward token", types.NewCommunityPoolRewardsProposal("title", "description", testPoolName,
+			sdk.NewDecCoinFromDec(testStakedSymbol, sdk.NewDec(100))), false},
+		{"insufficient community pool", types.NewCommunityPoolRewardsProposal("title", "description", testPoolName,
+			reward(1000)), false},
+		{"success", types.NewCommunityPoolRewardsProposal("title", "description", testPoolName, reward(300)), true},
+	}
+	for _, test := range tests {
+		proposal := govtypes.Proposal{Content: test.content}
+		require.Equal(t, test.isValid, proposalHandler(ctx, &proposal) == nil, test.testCase)
+	}
+
+	pool, found := mapp.farmKeeper.GetFarmPool(ctx, testPoolName)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(300), pool.RemainingRewards)
+	require.Equal(t, sdk.NewDec(200),
+		mapp.supplyKeeper.GetModuleAccount(ctx, testCommunityPoolModule).GetCoins().AmountOf(testRewardSymbol))
+	require.Equal(t, sdk.NewDec(300),
+		mapp.supplyKeeper.GetModuleAccount(ctx, ModuleName).GetCoins().AmountOf(testRewardSymbol))
+}
@@ -0,0 +1,194 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/farm/types"
+)
+
+// GetUpdatedFarmPool gets the farm pool with the rewards until the current height distributed
+func (k Keeper) GetUpdatedFarmPool(ctx sdk.Context, poolName string) (types.FarmPool, sdk.Error) {
+	pool, found := k.GetFarmPool(ctx, poolName)
+	if !found {
+		return types.FarmPool{}, sdk.ErrUnknownRequest(fmt.Sprintf("non-existent farm pool: %s", poolName))
+	}
+	return pool.Update(ctx.BlockHeight()), nil
+}
+
+// CreateFarmPool creates a farm pool rewarding the stakers of the pool token from the current height
+func (k Keeper) CreateFarmPool(ctx sdk.Context, owner sdk.AccAddress, poolName, stakedSymbol, rewardSymbol string,
+	rewardPerBlock sdk.Dec) sdk.Error {
+	if _, found := k.GetFarmPool(ctx, poolName); found {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("farm pool %s already exists", poolName))
+	}
+	for _, symbol := range []string{stakedSymbol, rewardSymbol} {
+		if !k.tokenKeeper.TokenExist(ctx, symbol) {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("token %s does not exist", symbol))
+		}
+	}
+	k.SetFarmPool(ctx, types.NewFarmPool(poolName, owner, stakedSymbol, rewardSymbol, rewardPerBlock,
+		ctx.BlockHeight()))
+	return nil
+}
+
+// ProvideRewards adds the reward token from the sender to the rewards of the farm pool
+func (k Keeper) ProvideRewards(ctx sdk.Context, poolName string, amount sdk.DecCoin, sender sdk.AccAddress) sdk.Error {
+	return k.addRewards(ctx, poolName, amount, func() sdk.Error {
+		return k.sendCoinsToModule(ctx, sender, sdk.DecCoins{amount})
+	})
+}
+
+// ProvideRewardsFromCommunityPool adds the reward token from the community pool to the rewards of the farm pool
+func (k Keeper) ProvideRewardsFromCommunityPool(ctx sdk.Context, poolName string, amount sdk.DecCoin) sdk.Error {
+	return k.addRewards(ctx, poolName, amount, func() sdk.Error {
+		return k.distrKeeper.SpendCommunityPoolToModule(ctx, sdk.DecCoins{amount}, types.ModuleName)
+	})
+}
+
+// addRewards adds the reward token to the rewards of the farm pool, after it's transferred to the module account
+func (k Keeper) addRewards(ctx sdk.Context, poolName string, amount sdk.DecCoin, transfer func() sdk.Error) sdk.Error {
+	pool, err := k.GetUpdatedFarmPool(ctx, poolName)
+	if err != nil {
+		return err
+	}
+	if amount.Denom != pool.RewardSymbol {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("the reward token of farm pool %s is %s", poolName, pool.RewardSymbol))
+	}
+	if err := transfer(); err != nil {
+		return err
+	}
+	pool.RemainingRewards = pool.RemainingRewards.Add(amount.Amount)
+	k.SetFarmPool(ctx, pool)
+	return nil
+}
+
+// Stake stakes the pool token of the sender to the farm pool, and pays the pending rewards
+func (k Keeper) Stake(ctx sdk.Context, poolName string, amount sdk.DecCoin, sender sdk.AccAddress) (sdk.DecCoin,
+	sdk.Error) {
+	pool, err := k.GetUpdatedFarmPool(ctx, poolName)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	if amount.Denom != pool.StakedSymbol {
+		return sdk.DecCoin{}, sdk.ErrUnknownRequest(fmt.Sprintf("the staked token of farm pool %s is %s",
+			poolName, pool.StakedSymbol))
+	}
+	info, found := k.GetStakeInfo(ctx, poolName, sender)
+	if !found {
+		info = types.NewStakeInfo(poolName, sender)
+	}
+	rewards, err := k.payRewards(ctx, &pool, info)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	if err := k.sendCoinsToModule(ctx, sender, sdk.DecCoins{amount}); err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	info.Amount = info.Amount.Add(amount.Amount)
+	info.RewardDebt = pool.RewardsOf(info.Amount)
+	pool.TotalStaked = pool.TotalStaked.Add(amount.Amount)
+	k.SetStakeInfo(ctx, info)
+	k.SetFarmPool(ctx, pool)
+	return rewards, nil
+}
+
+// Unstake withdraws the staked pool token of the sender from the farm pool, and pays the pending rewards
+func (k Keeper) Unstake(ctx sdk.Context, poolName string, amount sdk.DecCoin, sender sdk.AccAddress) (sdk.DecCoin,
+	sdk.Error) {
+	pool, err := k.GetUpdatedFarmPool(ctx, poolName)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	info, found := k.GetStakeInfo(ctx, poolName, sender)
+	if !found || amount.Denom != pool.StakedSymbol || info.Amount.LT(amount.Amount) {
+		return sdk.DecCoin{}, sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient staked token in farm pool %s: %s",
+			poolName, amount))
+	}
+	rewards, err := k.payRewards(ctx, &pool, info)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	if err := k.sendCoinsFromModule(ctx, sender, sdk.DecCoins{amount}); err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	info.Amount = info.Amount.Sub(amount.Amount)
+	info.RewardDebt = pool.RewardsOf(info.Amount)
+	pool.TotalStaked = pool.TotalStaked.Sub(amount.Amount)
+	if info.Amount.IsZero() {
+		k.DeleteStakeInfo(ctx, poolName, sender)
+	} else {
+		k.SetStakeInfo(ctx, info)
+	}
+	k.SetFarmPool(ctx, pool)
+	return rewards, nil
+}
+
+// Claim pays the pending rewards of the sender in the farm pool
+func (k Keeper) Claim(ctx sdk.Context, poolName string, sender sdk.AccAddress) (sdk.DecCoin, sdk.Error) {
+	pool, err := k.GetUpdatedFarmPool(ctx, poolName)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	info, found := k.GetStakeInfo(ctx, poolName, sender)
+	if !found {
+		return sdk.DecCoin{}, sdk.ErrUnknownRequest(fmt.Sprintf("nothing staked in farm pool %s", poolName))
+	}
+	rewards, err := k.payRewards(ctx, &pool, info)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	info.RewardDebt = pool.RewardsOf(info.Amount)
+	k.SetStakeInfo(ctx, info)
+	k.SetFarmPool(ctx, pool)
+	return rewards, nil
+}
+
+// GetEarnings returns the staked amount and the pending rewards of the address in the farm pool
+func (k Keeper) GetEarnings(ctx sdk.Context, poolName string, addr sdk.AccAddress) (types.Earnings, sdk.Error) {
+	pool, err := k.GetUpdatedFarmPool(ctx, poolName)
+	if err != nil {
+		return types.Earnings{}, err
+	}
+	info, found := k.GetStakeInfo(ctx, poolName, addr)
+	if !found {
+		info = types.NewStakeInfo(poolName, addr)
+	}
+	return types.Earnings{
+		PoolName:       poolName,
+		StakedAmount:   sdk.NewDecCoinFromDec(pool.StakedSymbol, info.Amount),
+		PendingRewards: sdk.NewDecCoinFromDec(pool.RewardSymbol, info.PendingRewards(pool)),
+	}, nil
+}
+
+// payRewards sends the pending rewards to the staker and deducts them from the unclaimed rewards of the pool
+func (k Keeper) payRewards(ctx sdk.Context, pool *types.FarmPool, info types.StakeInfo) (sdk.DecCoin, sdk.Error) {
+	rewards := sdk.NewDecCoinFromDec(pool.RewardSymbol, info.PendingRewards(*pool))
+	if !rewards.IsPositive() {
+		return rewards, nil
+	}
+	if err := k.sendCoinsFromModule(ctx, info.Owner, sdk.DecCoins{rewards}); err != nil {
+		return sdk.DecCoin{}, err
+	}
+	pool.UnclaimedRewards = pool.UnclaimedRewards.Sub(rewards.Amount)
+	return rewards, nil
+}
+
+// sendCoinsToModule sends the coins from the account to the module account, unless they are frozen or paused
+func (k Keeper) sendCoinsToModule(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins) sdk.Error {
+	if err := k.tokenKeeper.CheckCoinsTransferable(ctx, addr, coins); err != nil {
+		return err
+	}
+	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName, coins)
+}
+
+// sendCoinsFromModule sends the coins from the module account to the account, unless they are frozen or paused
+func (k Keeper) sendCoinsFromModule(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins) sdk.Error {
+	if err := k.tokenKeeper.CheckCoinsTransferable(ctx, addr, coins); err != nil {
+		return err
+	}
+	return k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, coins)
+}
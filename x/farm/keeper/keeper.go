@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/farm/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// Keeper of the farm store
+type Keeper struct {
+	supplyKeeper types.SupplyKeeper
+	tokenKeeper  types.TokenKeeper
+	distrKeeper  types.DistrKeeper
+
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+}
+
+// NewKeeper creates a farm keeper
+func NewKeeper(supplyKeeper types.SupplyKeeper, tokenKeeper types.TokenKeeper, distrKeeper types.DistrKeeper,
+	cdc *codec.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		supplyKeeper: supplyKeeper,
+		tokenKeeper:  tokenKeeper,
+		distrKeeper:  distrKeeper,
+		storeKey:     key,
+		cdc:          cdc,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// GetFarmPool gets the farm pool with the name
+func (k Keeper) GetFarmPool(ctx sdk.Context, poolName string) (types.FarmPool, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetFarmPoolKey(poolName))
+	if bz == nil {
+		return types.FarmPool{}, false
+	}
+	var pool types.FarmPool
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &pool)
+	return pool, true
+}
+
+// SetFarmPool sets the farm pool
+func (k Keeper) SetFarmPool(ctx sdk.Context, pool types.FarmPool) {
+	ctx.KVStore(k.storeKey).Set(types.GetFarmPoolKey(pool.Name), k.cdc.MustMarshalBinaryLengthPrefixed(pool))
+}
+
+// GetFarmPools gets all the farm pools
+func (k Keeper) GetFarmPools(ctx sdk.Context) []types.FarmPool {
+	var result []types.FarmPool
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.FarmPoolPrefixKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var pool types.FarmPool
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &pool)
+		result = append(result, pool)
+	}
+	return result
+}
+
+// GetStakeInfo gets the tokens staked by the address in the farm pool
+func (k Keeper) GetStakeInfo(ctx sdk.Context, poolName string, addr sdk.AccAddress) (types.StakeInfo, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetStakeInfoKey(poolName, addr))
+	if bz == nil {
+		return types.StakeInfo{}, false
+	}
+	var info types.StakeInfo
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &info)
+	return info, true
+}
+
+// SetStakeInfo sets the tokens staked by the address in the farm pool
+func (k Keeper) SetStakeInfo(ctx sdk.Context, info types.StakeInfo) {
+	ctx.KVStore(k.storeKey).Set(types.GetStakeInfoKey(info.PoolName, info.Owner),
+		k.cdc.MustMarshalBinaryLengthPrefixed(info))
+}
+
+// DeleteStakeInfo deletes the tokens staked by the address in the farm pool
+func (k Keeper) DeleteStakeInfo(ctx sdk.Context, poolName string, addr sdk.AccAddress) {
+	ctx.KVStore(k.storeKey).Delete(types.GetStakeInfoKey(poolName, addr))
+}
+
+// GetStakeInfos gets the tokens staked by all the addresses in all the farm pools
+func (k Keeper) GetStakeInfos(ctx sdk.Context) []types.StakeInfo {
+	var result []types.StakeInfo
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.StakeInfoPrefixKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var info types.StakeInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &info)
+		result = append(result, info)
+	}
+	return result
+}
+
+// GetTokenKeeper returns the token keeper
+func (k Keeper) GetTokenKeeper() types.TokenKeeper {
+	return k.tokenKeeper
+}
@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/farm/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// NewQuerier creates a new querier for farm clients.
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case types.QueryPool:
+			return queryPool(ctx, path[1:], k)
+		case types.QueryPools:
+			return queryPools(ctx, k)
+		case types.QueryStakeInfo:
+			return queryStakeInfo(ctx, req, k)
+		case types.QueryEarnings:
+			return queryEarnings(ctx, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown farm query endpoint")
+		}
+	}
+}
+
+// queryPool returns the farm pool with the rewards until the current height distributed
+func queryPool(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("empty pool name")
+	}
+	pool, err := k.GetUpdatedFarmPool(ctx, path[0])
+	if err != nil {
+		return nil, err
+	}
+	return k.cdc.MustMarshalJSON(pool), nil
+}
+
+func queryPools(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	pools := k.GetFarmPools(ctx)
+	if pools == nil {
+		pools = []types.FarmPool{}
+	}
+	return k.cdc.MustMarshalJSON(pools), nil
+}
+
+func queryStakeInfo(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryStakeInfoParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	info, found := k.GetStakeInfo(ctx, params.PoolName, params.Address)
+	if !found {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("%s staked nothing in farm pool %s", params.Address,
+			params.PoolName))
+	}
+	return k.cdc.MustMarshalJSON(info), nil
+}
+
+func queryEarnings(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryStakeInfoParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	earnings, err := k.GetEarnings(ctx, params.PoolName, params.Address)
+	if err != nil {
+		return nil, err
+	}
+	return k.cdc.MustMarshalJSON(earnings), nil
+}
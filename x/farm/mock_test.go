@@ -0,0 +1,159 @@
+package farm
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/cosmos/cosmos-sdk/x/supply/exported"
+	"github.com/okex/okexchain/x/token"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+const (
+	testStakedSymbol = "ammswap-xxb"
+	testRewardSymbol = "xxb"
+
+	// the module account holding the community pool of mockDistrKeeper
+	testCommunityPoolModule = "distribution"
+)
+
+// mockDistrKeeper spends the coins of the community pool module account
+type mockDistrKeeper struct {
+	supplyKeeper supply.Keeper
+}
+
+func (k mockDistrKeeper) SpendCommunityPoolToModule(ctx sdk.Context, amount sdk.Coins,
+	recipientModule string) sdk.Error {
+	return k.supplyKeeper.SendCoinsFromModuleToModule(ctx, testCommunityPoolModule, recipientModule, amount)
+}
+
+type MockApp struct {
+	*mock.App
+
+	keyFarm   *sdk.KVStoreKey
+	keyToken  *sdk.KVStoreKey
+	keyLock   *sdk.KVStoreKey
+	keySupply *sdk.KVStoreKey
+
+	bankKeeper   bank.Keeper
+	farmKeeper   Keeper
+	tokenKeeper  token.Keeper
+	supplyKeeper supply.Keeper
+}
+
+func registerCodec(cdc *codec.Codec) {
+	RegisterCodec(cdc)
+	token.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+}
+
+// initialize the mock application for this module
+func getMockApp(t *testing.T, numGenAccs int) (mockApp *MockApp, addrKeysSlice mock.AddrKeysSlice) {
+	mapp := mock.NewApp()
+	registerCodec(mapp.Cdc)
+
+	mockApp = &MockApp{
+		App:       mapp,
+		keyFarm:   sdk.NewKVStoreKey(StoreKey),
+		keyToken:  sdk.NewKVStoreKey(token.StoreKey),
+		keyLock:   sdk.NewKVStoreKey(token.KeyLock),
+		keySupply: sdk.NewKVStoreKey(supply.StoreKey),
+	}
+
+	feeCollector := supply.NewEmptyModuleAccount(auth.FeeCollectorName)
+	blacklistedAddrs := make(map[string]bool)
+	blacklistedAddrs[feeCollector.String()] = true
+
+	mockApp.bankKeeper = bank.NewBaseKeeper(mockApp.AccountKeeper,
+		mockApp.ParamsKeeper.Subspace(bank.DefaultParamspace),
+		bank.DefaultCodespace, blacklistedAddrs)
+
+	maccPerms := map[string][]string{
+		auth.FeeCollectorName:   nil,
+		token.ModuleName:        {supply.Minter, supply.Burner},
+		ModuleName:              nil,
+		testCommunityPoolModule: nil,
+	}
+	mockApp.supplyKeeper = supply.NewKeeper(mockApp.Cdc, mockApp.keySupply, mockApp.AccountKeeper,
+		mockApp.bankKeeper, maccPerms)
+
+	mockApp.tokenKeeper = token.NewKeeper(
+		mockApp.bankKeeper,
+		mockApp.ParamsKeeper.Subspace(token.DefaultParamspace),
+		auth.FeeCollectorName,
+		mockApp.supplyKeeper,
+		mockApp.keyToken,
+		mockApp.keyLock,
+		mockApp.Cdc,
+		true)
+
+	mockApp.farmKeeper = NewKeeper(mockApp.supplyKeeper, mockApp.tokenKeeper, mockDistrKeeper{mockApp.supplyKeeper},
+		mockApp.Cdc, mockApp.keyFarm)
+
+	mockApp.Router().AddRoute(RouterKey, NewHandler(mockApp.farmKeeper))
+	mockApp.QueryRouter().AddRoute(QuerierRoute, NewQuerier(mockApp.farmKeeper))
+
+	mockApp.SetInitChainer(getInitChainer(mockApp.App, mockApp.supplyKeeper,
+		[]exported.ModuleAccountI{feeCollector}))
+
+	coins, err := sdk.ParseDecCoins("1000" + testStakedSymbol + ",1000" + testRewardSymbol)
+	require.Nil(t, err)
+
+	keysSlice, genAccs := createGenAccounts(numGenAccs, coins)
+	addrKeysSlice = keysSlice
+
+	mockApp.SetAnteHandler(nil)
+
+	app := mockApp
+	require.NoError(t, app.CompleteSetup(
+		app.keyFarm,
+		app.keyToken,
+		app.keyLock,
+		app.keySupply,
+	))
+	mock.SetGenesis(mockApp.App, genAccs)
+
+	for i := 0; i < numGenAccs; i++ {
+		mock.CheckBalance(t, app.App, keysSlice[i].Address, coins)
+		mockApp.TotalCoinsSupply = mockApp.TotalCoinsSupply.Add(coins)
+	}
+
+	return mockApp, addrKeysSlice
+}
+
+func getInitChainer(mapp *mock.App, supplyKeeper supply.Keeper,
+	blacklistedAddrs []exported.ModuleAccountI) sdk.InitChainer {
+	return func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		mapp.InitChainer(ctx, req)
+		// set module accounts
+		for _, macc := range blacklistedAddrs {
+			supplyKeeper.SetModuleAccount(ctx, macc)
+		}
+		return abci.ResponseInitChain{}
+	}
+}
+
+func createGenAccounts(numAccs int, genCoins sdk.Coins) (addrKeysSlice mock.AddrKeysSlice,
+	genAccs []auth.Account) {
+	for i := 0; i < numAccs; i++ {
+		privKey := secp256k1.GenPrivKey()
+		pubKey := privKey.PubKey()
+		addr := sdk.AccAddress(pubKey.Address())
+
+		addrKeys := mock.NewAddrKeys(addr, pubKey, privKey)
+		account := &auth.BaseAccount{
+			Address: addr,
+			Coins:   genCoins,
+		}
+		genAccs = append(genAccs, account)
+		addrKeysSlice = append(addrKeysSlice, addrKeys)
+	}
+	return
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgCreatePool{}, "okexchain/farm/MsgCreatePool", nil)
+	cdc.RegisterConcrete(MsgProvideRewards{}, "okexchain/farm/MsgProvideRewards", nil)
+	cdc.RegisterConcrete(MsgStake{}, "okexchain/farm/MsgStake", nil)
+	cdc.RegisterConcrete(MsgUnstake{}, "okexchain/farm/MsgUnstake", nil)
+	cdc.RegisterConcrete(MsgClaim{}, "okexchain/farm/MsgClaim", nil)
+	cdc.RegisterConcrete(CommunityPoolRewardsProposal{}, "okexchain/farm/CommunityPoolRewardsProposal", nil)
+}
+
+// ModuleCdc defines the module codec
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
@@ -0,0 +1,10 @@
+package types
+
+// farm module event types
+const (
+	AttributeValueCategory = ModuleName
+
+	AttributeKeyPoolName = "pool_name"
+	AttributeKeyAmount   = "amount"
+	AttributeKeyRewards  = "rewards"
+)
@@ -0,0 +1,24 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SupplyKeeper defines the expected supply interface
+type SupplyKeeper interface {
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string,
+		recipientAddr sdk.AccAddress, amt sdk.Coins) sdk.Error
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress,
+		recipientModule string, amt sdk.Coins) sdk.Error
+}
+
+// DistrKeeper defines the expected distribution interface
+type DistrKeeper interface {
+	SpendCommunityPoolToModule(ctx sdk.Context, amount sdk.Coins, recipientModule string) sdk.Error
+}
+
+// TokenKeeper defines the expected token interface
+type TokenKeeper interface {
+	TokenExist(ctx sdk.Context, symbol string) bool
+	CheckCoinsTransferable(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins) sdk.Error
+}
@@ -0,0 +1,135 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RewardPerShareScale scales up the accumulated rewards per share to keep the precision of the small rewards
+// shared by a large amount of staked tokens
+var RewardPerShareScale = sdk.NewDec(100000000)
+
+// FarmPool defines a pool rewarding the stakers of a pool token with a reward token every block
+type FarmPool struct {
+	Name              string         `json:"name"`
+	Owner             sdk.AccAddress `json:"owner"`
+	StakedSymbol      string         `json:"staked_symbol"`        // The pool token to stake
+	RewardSymbol      string         `json:"reward_symbol"`        // The token to reward
+	RewardPerBlock    sdk.Dec        `json:"reward_per_block"`     // The amount of reward token shared by the stakers every block
+	RemainingRewards  sdk.Dec        `json:"remaining_rewards"`    // The rewards provided but not yet distributed
+	UnclaimedRewards  sdk.Dec        `json:"unclaimed_rewards"`    // The rewards distributed but not yet claimed
+	TotalStaked       sdk.Dec        `json:"total_staked"`         // The amount of pool token staked
+	AccRewardPerShare sdk.Dec        `json:"acc_reward_per_share"` // The rewards per staked token since creation, scaled by RewardPerShareScale
+	LastRewardHeight  int64          `json:"last_reward_height"`   // The height until which the rewards have been distributed
+}
+
+// NewFarmPool is a constructor function for FarmPool
+func NewFarmPool(name string, owner sdk.AccAddress, stakedSymbol, rewardSymbol string, rewardPerBlock sdk.Dec,
+	height int64) FarmPool {
+	return FarmPool{
+		Name:              name,
+		Owner:             owner,
+		StakedSymbol:      stakedSymbol,
+		RewardSymbol:      rewardSymbol,
+		RewardPerBlock:    rewardPerBlock,
+		RemainingRewards:  sdk.ZeroDec(),
+		UnclaimedRewards:  sdk.ZeroDec(),
+		TotalStaked:       sdk.ZeroDec(),
+		AccRewardPerShare: sdk.ZeroDec(),
+		LastRewardHeight:  height,
+	}
+}
+
+// Update returns the pool with the rewards of the blocks until the height distributed to the stakers.
+// No rewards are distributed while nothing is staked, and at most the remaining rewards are distributed.
+func (p FarmPool) Update(height int64) FarmPool {
+	if height <= p.LastRewardHeight {
+		return p
+	}
+	if p.TotalStaked.IsPositive() && p.RemainingRewards.IsPositive() {
+		rewards := p.RewardPerBlock.MulInt64(height - p.LastRewardHeight)
+		if rewards.GT(p.RemainingRewards) {
+			rewards = p.RemainingRewards
+		}
+		p.AccRewardPerShare = p.AccRewardPerShare.Add(rewards.Mul(RewardPerShareScale).QuoTruncate(p.TotalStaked))
+		p.RemainingRewards = p.RemainingRewards.Sub(rewards)
+		p.UnclaimedRewards = p.UnclaimedRewards.Add(rewards)
+	}
+	p.LastRewardHeight = height
+	return p
+}
+
+// RewardsOf returns the rewards accumulated by the amount of staked token since the creation of the pool
+func (p FarmPool) RewardsOf(amount sdk.Dec) sdk.Dec {
+	return amount.MulTruncate(p.AccRewardPerShare).QuoTruncate(RewardPerShareScale)
+}
+
+// String implement fmt.Stringer
+func (p FarmPool) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Name: %s
+Owner: %s
+StakedSymbol: %s
+RewardSymbol: %s
+RewardPerBlock: %s
+RemainingRewards: %s
+UnclaimedRewards: %s
+TotalStaked: %s
+AccRewardPerShare: %s
+LastRewardHeight: %d`, p.Name, p.Owner, p.StakedSymbol, p.RewardSymbol, p.RewardPerBlock, p.RemainingRewards,
+		p.UnclaimedRewards, p.TotalStaked, p.AccRewardPerShare, p.LastRewardHeight))
+}
+
+// StakeInfo defines the pool token staked by an address in a farm pool
+type StakeInfo struct {
+	PoolName   string         `json:"pool_name"`
+	Owner      sdk.AccAddress `json:"owner"`
+	Amount     sdk.Dec        `json:"amount"`      // The amount of pool token staked
+	RewardDebt sdk.Dec        `json:"reward_debt"` // The rewards of the staked amount already claimed or not earned
+}
+
+// NewStakeInfo is a constructor function for StakeInfo
+func NewStakeInfo(poolName string, owner sdk.AccAddress) StakeInfo {
+	return StakeInfo{
+		PoolName:   poolName,
+		Owner:      owner,
+		Amount:     sdk.ZeroDec(),
+		RewardDebt: sdk.ZeroDec(),
+	}
+}
+
+// PendingRewards returns the rewards earned but not yet claimed in the updated pool
+func (s StakeInfo) PendingRewards(pool FarmPool) sdk.Dec {
+	pending := pool.RewardsOf(s.Amount).Sub(s.RewardDebt)
+	if pending.GT(pool.UnclaimedRewards) {
+		// never pay more than the rewards distributed to the pool because of the rounding
+		pending = pool.UnclaimedRewards
+	}
+	if pending.IsNegative() {
+		return sdk.ZeroDec()
+	}
+	return pending
+}
+
+// String implement fmt.Stringer
+func (s StakeInfo) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`PoolName: %s
+Owner: %s
+Amount: %s
+RewardDebt: %s`, s.PoolName, s.Owner, s.Amount, s.RewardDebt))
+}
+
+// Earnings defines the staked amount and the pending rewards of an address in a farm pool
+type Earnings struct {
+	PoolName       string      `json:"pool_name"`
+	StakedAmount   sdk.DecCoin `json:"staked_amount"`
+	PendingRewards sdk.DecCoin `json:"pending_rewards"`
+}
+
+// String implement fmt.Stringer
+func (e Earnings) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`PoolName: %s
+StakedAmount: %s
+PendingRewards: %s`, e.PoolName, e.StakedAmount, e.PendingRewards))
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFarmPoolUpdate(t *testing.T) {
+	pool := NewFarmPool("pool", sdk.AccAddress([]byte("owner")), "ammswap-xxb", "xxb", sdk.NewDec(10), 10)
+
+	// nothing is distributed without the stakers or the rewards
+	pool = pool.Update(12)
+	require.Equal(t, int64(12), pool.LastRewardHeight)
+	require.True(t, pool.AccRewardPerShare.IsZero())
+	pool.TotalStaked = sdk.NewDec(3)
+	pool = pool.Update(13)
+	require.True(t, pool.AccRewardPerShare.IsZero())
+
+	pool.RemainingRewards = sdk.NewDec(25)
+	pool = pool.Update(15)
+	require.Equal(t, sdk.NewDec(5), pool.RemainingRewards)
+	require.Equal(t, sdk.NewDec(20), pool.UnclaimedRewards)
+	require.Equal(t, sdk.MustNewDecFromStr("666666666.66666666"), pool.AccRewardPerShare)
+
+	// the truncated rewards of the stakers never exceed the distributed ones
+	info := NewStakeInfo(pool.Name, sdk.AccAddress([]byte("staker")))
+	info.Amount = sdk.NewDec(3)
+	require.Equal(t, sdk.MustNewDecFromStr("19.99999999"), info.PendingRewards(pool))
+
+	// at most the remaining rewards are distributed
+	pool = pool.Update(100)
+	require.True(t, pool.RemainingRewards.IsZero())
+	require.Equal(t, sdk.NewDec(25), pool.UnclaimedRewards)
+	require.Equal(t, pool, pool.Update(99))
+}
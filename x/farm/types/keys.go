@@ -0,0 +1,47 @@
+package types
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "farm"
+
+	// StoreKey to be used when creating the KVStore
+	StoreKey = ModuleName
+
+	// RouterKey to be used for routing msgs
+	RouterKey = ModuleName
+
+	// QuerierRoute to be used for querier msgs
+	QuerierRoute = ModuleName
+
+	// QueryPool query endpoints supported by the farm Querier
+	QueryPool = "pool"
+
+	QueryPools = "pools"
+
+	QueryStakeInfo = "stakeInfo"
+
+	QueryEarnings = "earnings"
+)
+
+var (
+	// FarmPoolPrefixKey to be used for KVStore
+	FarmPoolPrefixKey = []byte{0x01}
+	// StakeInfoPrefixKey to be used for the tokens staked by every address in every farm pool
+	StakeInfoPrefixKey = []byte{0x02}
+)
+
+// GetFarmPoolKey returns the store key of the farm pool
+func GetFarmPoolKey(poolName string) []byte {
+	return append(FarmPoolPrefixKey, []byte(poolName)...)
+}
+
+// GetStakeInfosPrefix returns the store key prefix of the stake infos of the farm pool.
+// The name is terminated by 0x00 which is not a valid pool name character.
+func GetStakeInfosPrefix(poolName string) []byte {
+	return append(append(StakeInfoPrefixKey, []byte(poolName)...), 0x00)
+}
+
+// GetStakeInfoKey returns the store key of the tokens staked by the address in the farm pool
+func GetStakeInfoKey(poolName string, addr []byte) []byte {
+	return append(GetStakeInfosPrefix(poolName), addr...)
+}
@@ -0,0 +1,248 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	swaptypes "github.com/okex/okexchain/x/ammswap/types"
+)
+
+// farm message types
+const (
+	TypeMsgCreatePool     = "create_pool"
+	TypeMsgProvideRewards = "provide_rewards"
+	TypeMsgStake          = "stake"
+	TypeMsgUnstake        = "unstake"
+	TypeMsgClaim          = "claim"
+)
+
+var poolNameRegExp = regexp.MustCompile(`^[a-z0-9][a-z0-9_\-]{0,31}$`)
+
+// ValidatePoolName validates the format of the farm pool name
+func ValidatePoolName(poolName string) sdk.Error {
+	if !poolNameRegExp.MatchString(poolName) {
+		return sdk.ErrUnknownRequest("invalid pool name " + poolName)
+	}
+	return nil
+}
+
+// MsgCreatePool creates a farm pool rewarding the stakers of the pool token
+type MsgCreatePool struct {
+	Owner          sdk.AccAddress `json:"owner"`
+	PoolName       string         `json:"pool_name"`
+	StakedSymbol   string         `json:"staked_symbol"`    // The ammswap pool token to stake
+	RewardSymbol   string         `json:"reward_symbol"`    // The token to reward
+	RewardPerBlock sdk.Dec        `json:"reward_per_block"` // The amount of reward token shared by the stakers every block
+}
+
+// NewMsgCreatePool is a constructor function for MsgCreatePool
+func NewMsgCreatePool(owner sdk.AccAddress, poolName, stakedSymbol, rewardSymbol string,
+	rewardPerBlock sdk.Dec) MsgCreatePool {
+	return MsgCreatePool{
+		Owner:          owner,
+		PoolName:       poolName,
+		StakedSymbol:   stakedSymbol,
+		RewardSymbol:   rewardSymbol,
+		RewardPerBlock: rewardPerBlock,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCreatePool) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCreatePool) Type() string { return TypeMsgCreatePool }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCreatePool) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidatePoolName(msg.PoolName); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(msg.StakedSymbol, swaptypes.PoolTokenPrefix) {
+		return sdk.ErrUnknownRequest("the staked token should be an ammswap pool token: " + msg.StakedSymbol)
+	}
+	if msg.RewardSymbol == "" {
+		return sdk.ErrUnknownRequest("empty reward token")
+	}
+	if msg.RewardPerBlock.IsNil() || !msg.RewardPerBlock.IsPositive() {
+		return sdk.ErrUnknownRequest("reward per block must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCreatePool) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCreatePool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgProvideRewards provides the reward token to a farm pool by a sponsor, the community pool funds it
+// by a CommunityPoolRewardsProposal
+type MsgProvideRewards struct {
+	PoolName string         `json:"pool_name"`
+	Amount   sdk.DecCoin    `json:"amount"`
+	Sender   sdk.AccAddress `json:"sender"`
+}
+
+// NewMsgProvideRewards is a constructor function for MsgProvideRewards
+func NewMsgProvideRewards(poolName string, amount sdk.DecCoin, sender sdk.AccAddress) MsgProvideRewards {
+	return MsgProvideRewards{
+		PoolName: poolName,
+		Amount:   amount,
+		Sender:   sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgProvideRewards) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgProvideRewards) Type() string { return TypeMsgProvideRewards }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgProvideRewards) ValidateBasic() sdk.Error {
+	return validatePoolAmountSender(msg.PoolName, msg.Amount, msg.Sender)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgProvideRewards) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgProvideRewards) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgStake stakes the pool token to a farm pool
+type MsgStake struct {
+	PoolName string         `json:"pool_name"`
+	Amount   sdk.DecCoin    `json:"amount"`
+	Sender   sdk.AccAddress `json:"sender"`
+}
+
+// NewMsgStake is a constructor function for MsgStake
+func NewMsgStake(poolName string, amount sdk.DecCoin, sender sdk.AccAddress) MsgStake {
+	return MsgStake{
+		PoolName: poolName,
+		Amount:   amount,
+		Sender:   sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgStake) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgStake) Type() string { return TypeMsgStake }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgStake) ValidateBasic() sdk.Error {
+	return validatePoolAmountSender(msg.PoolName, msg.Amount, msg.Sender)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgStake) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgStake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgUnstake withdraws the staked pool token from a farm pool
+type MsgUnstake struct {
+	PoolName string         `json:"pool_name"`
+	Amount   sdk.DecCoin    `json:"amount"`
+	Sender   sdk.AccAddress `json:"sender"`
+}
+
+// NewMsgUnstake is a constructor function for MsgUnstake
+func NewMsgUnstake(poolName string, amount sdk.DecCoin, sender sdk.AccAddress) MsgUnstake {
+	return MsgUnstake{
+		PoolName: poolName,
+		Amount:   amount,
+		Sender:   sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgUnstake) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgUnstake) Type() string { return TypeMsgUnstake }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgUnstake) ValidateBasic() sdk.Error {
+	return validatePoolAmountSender(msg.PoolName, msg.Amount, msg.Sender)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgUnstake) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgUnstake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgClaim claims the pending rewards of a farm pool
+type MsgClaim struct {
+	PoolName string         `json:"pool_name"`
+	Sender   sdk.AccAddress `json:"sender"`
+}
+
+// NewMsgClaim is a constructor function for MsgClaim
+func NewMsgClaim(poolName string, sender sdk.AccAddress) MsgClaim {
+	return MsgClaim{
+		PoolName: poolName,
+		Sender:   sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgClaim) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgClaim) Type() string { return TypeMsgClaim }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgClaim) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	return ValidatePoolName(msg.PoolName)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgClaim) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgClaim) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+func validatePoolAmountSender(poolName string, amount sdk.DecCoin, sender sdk.AccAddress) sdk.Error {
+	if sender.Empty() {
+		return sdk.ErrInvalidAddress(sender.String())
+	}
+	if err := ValidatePoolName(poolName); err != nil {
+		return err
+	}
+	if !amount.IsValid() || !amount.IsPositive() {
+		return sdk.ErrUnknownRequest("invalid amount " + amount.String())
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgCreatePool(t *testing.T) {
+	owner := sdk.AccAddress([]byte("owner"))
+	tests := []struct {
+		testCase string
+		msg      MsgCreatePool
+		isValid  bool
+	}{
+		{"valid", NewMsgCreatePool(owner, "xxb-pool", "ammswap-xxb", "xxb", sdk.NewDec(1)), true},
+		{"empty owner", NewMsgCreatePool(nil, "xxb-pool", "ammswap-xxb", "xxb", sdk.NewDec(1)), false},
+		{"invalid pool name", NewMsgCreatePool(owner, "Xxb Pool", "ammswap-xxb", "xxb", sdk.NewDec(1)), false},
+		{"staking a non-pool token", NewMsgCreatePool(owner, "xxb-pool", "xxb", "xxb", sdk.NewDec(1)), false},
+		{"empty reward token", NewMsgCreatePool(owner, "xxb-pool", "ammswap-xxb", "", sdk.NewDec(1)), false},
+		{"zero reward", NewMsgCreatePool(owner, "xxb-pool", "ammswap-xxb", "xxb", sdk.ZeroDec()), false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.isValid, test.msg.ValidateBasic() == nil, test.testCase)
+	}
+	msg := tests[0].msg
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgCreatePool, msg.Type())
+	require.NotNil(t, msg.GetSignBytes())
+	require.Equal(t, []sdk.AccAddress{owner}, msg.GetSigners())
+}
+
+func TestMsgStake(t *testing.T) {
+	sender := sdk.AccAddress([]byte("sender"))
+	amount := sdk.NewDecCoinFromDec("ammswap-xxb", sdk.NewDec(1))
+	tests := []struct {
+		testCase string
+		msg      sdk.Msg
+		isValid  bool
+	}{
+		{"valid stake", NewMsgStake("xxb-pool", amount, sender), true},
+		{"valid unstake", NewMsgUnstake("xxb-pool", amount, sender), true},
+		{"valid provide", NewMsgProvideRewards("xxb-pool", amount, sender), true},
+		{"valid claim", NewMsgClaim("xxb-pool", sender), true},
+		{"empty sender", NewMsgStake("xxb-pool", amount, nil), false},
+		{"empty pool name", NewMsgUnstake("", amount, sender), false},
+		{"zero amount", NewMsgProvideRewards("xxb-pool", sdk.NewDecCoinFromDec("xxb", sdk.ZeroDec()), sender), false},
+		{"claim with empty sender", NewMsgClaim("xxb-pool", nil), false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.isValid, test.msg.ValidateBasic() == nil, test.testCase)
+		require.Equal(t, RouterKey, test.msg.Route())
+	}
+}
+
+func TestCommunityPoolRewardsProposal(t *testing.T) {
+	amount := sdk.NewDecCoinFromDec("xxb", sdk.NewDec(1))
+	tests := []struct {
+		testCase string
+		proposal CommunityPoolRewardsProposal
+		isValid  bool
+	}{
+		{"valid", NewCommunityPoolRewardsProposal("title", "description", "xxb-pool", amount), true},
+		{"empty title", NewCommunityPoolRewardsProposal("", "description", "xxb-pool", amount), false},
+		{"empty description", NewCommunityPoolRewardsProposal("title", "", "xxb-pool", amount), false},
+		{"invalid pool name", NewCommunityPoolRewardsProposal("title", "description", "Xxb Pool", amount), false},
+		{"zero amount", NewCommunityPoolRewardsProposal("title", "description", "xxb-pool",
+			sdk.NewDecCoinFromDec("xxb", sdk.ZeroDec())), false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.isValid, test.proposal.ValidateBasic() == nil, test.testCase)
+	}
+	proposal := tests[0].proposal
+	require.Equal(t, RouterKey, proposal.ProposalRoute())
+	require.Equal(t, ProposalTypeCommunityPoolRewards, proposal.ProposalType())
+	require.NotEmpty(t, proposal.String())
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/okex/okexchain/x/gov/types"
+)
+
+const (
+	// ProposalTypeCommunityPoolRewards defines the type for a CommunityPoolRewardsProposal
+	ProposalTypeCommunityPoolRewards = "CommunityPoolRewards"
+)
+
+// Assert CommunityPoolRewardsProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = CommunityPoolRewardsProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeCommunityPoolRewards)
+	govtypes.RegisterProposalTypeCodec(CommunityPoolRewardsProposal{}, "okexchain/farm/CommunityPoolRewardsProposal")
+}
+
+// CommunityPoolRewardsProposal provides the reward token to a farm pool from the community pool
+type CommunityPoolRewardsProposal struct {
+	Title       string      `json:"title" yaml:"title"`
+	Description string      `json:"description" yaml:"description"`
+	PoolName    string      `json:"pool_name" yaml:"pool_name"`
+	Amount      sdk.DecCoin `json:"amount" yaml:"amount"`
+}
+
+// NewCommunityPoolRewardsProposal creates a new community pool rewards proposal
+func NewCommunityPoolRewardsProposal(title, description, poolName string,
+	amount sdk.DecCoin) CommunityPoolRewardsProposal {
+	return CommunityPoolRewardsProposal{title, description, poolName, amount}
+}
+
+// GetTitle returns the title of a community pool rewards proposal
+func (p CommunityPoolRewardsProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of a community pool rewards proposal
+func (p CommunityPoolRewardsProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of a community pool rewards proposal
+func (p CommunityPoolRewardsProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of a community pool rewards proposal
+func (p CommunityPoolRewardsProposal) ProposalType() string { return ProposalTypeCommunityPoolRewards }
+
+// ValidateBasic runs basic stateless validity checks
+func (p CommunityPoolRewardsProposal) ValidateBasic() sdk.Error {
+	if err := govtypes.ValidateAbstract(govtypes.DefaultCodespace, p); err != nil {
+		return err
+	}
+	if err := ValidatePoolName(p.PoolName); err != nil {
+		return err
+	}
+	if !p.Amount.IsValid() || !p.Amount.IsPositive() {
+		return sdk.ErrUnknownRequest("invalid amount " + p.Amount.String())
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p CommunityPoolRewardsProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Community Pool Rewards Proposal:
+  Title:       %s
+  Description: %s
+  Pool Name:   %s
+  Amount:      %s
+`, p.Title, p.Description, p.PoolName, p.Amount))
+	return b.String()
+}
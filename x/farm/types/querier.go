@@ -0,0 +1,19 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// QueryStakeInfoParams defines the params to query the tokens staked by the address in the farm pool
+type QueryStakeInfoParams struct {
+	PoolName string         `json:"pool_name"`
+	Address  sdk.AccAddress `json:"address"`
+}
+
+// NewQueryStakeInfoParams creates a new instance of QueryStakeInfoParams
+func NewQueryStakeInfoParams(poolName string, address sdk.AccAddress) QueryStakeInfoParams {
+	return QueryStakeInfoParams{
+		PoolName: poolName,
+		Address:  address,
+	}
+}
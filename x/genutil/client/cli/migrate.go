@@ -13,10 +13,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/version"
 	extypes "github.com/cosmos/cosmos-sdk/x/genutil"
 	v011 "github.com/okex/okexchain/x/genutil/legacy/v0_11"
+	v012 "github.com/okex/okexchain/x/genutil/legacy/v0_12"
 )
 
 var migrationMap = extypes.MigrationMap{
 	"v0.11": v011.Migrate,
+	"v0.12": v012.Migrate,
 }
 
 const (
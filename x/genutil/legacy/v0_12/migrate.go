@@ -0,0 +1,28 @@
+package v0_12
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	v011token "github.com/okex/okexchain/x/token/legacy/v0_11"
+	v012token "github.com/okex/okexchain/x/token/legacy/v0_12"
+)
+
+// Migrate migrates exported state from v0.11.x to a v0.12.0 genesis state
+func Migrate(appState genutil.AppMap) genutil.AppMap {
+	v011Codec := codec.New()
+	codec.RegisterCrypto(v011Codec)
+
+	v012Codec := codec.New()
+	codec.RegisterCrypto(v012Codec)
+
+	// migrate token state
+	if appState[v011token.ModuleName] != nil {
+		var tokenGenState v011token.GenesisState
+		v011Codec.MustUnmarshalJSON(appState[v011token.ModuleName], &tokenGenState)
+
+		delete(appState, v011token.ModuleName)
+		appState[v012token.ModuleName] = v012Codec.MustMarshalJSON(v012token.Migrate(tokenGenState))
+	}
+
+	return appState
+}
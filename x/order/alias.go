@@ -2,6 +2,7 @@ package order
 
 import (
 	"github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/match"
 	"github.com/okex/okexchain/x/order/types"
 )
 
@@ -29,17 +30,21 @@ type (
 	MsgCancelOrder   = types.MsgCancelOrder
 	MsgNewOrders     = types.MsgNewOrders
 	MsgCancelOrders  = types.MsgCancelOrders
+	MsgAmendOrders   = types.MsgAmendOrders
+	MsgRouteOrder    = types.MsgRouteOrder
 	BlockMatchResult = types.BlockMatchResult
 )
 
 // nolint
 // functions aliases
 var (
-	RegisterCodec     = types.RegisterCodec
-	DefaultParams     = types.DefaultParams
-	NewMsgNewOrder    = types.NewMsgNewOrder
-	NewMsgCancelOrder = types.NewMsgCancelOrder
-	NewKeeper         = keeper.NewKeeper
-	NewQuerier        = keeper.NewQuerier
-	FormatOrderIDsKey = types.FormatOrderIDsKey
+	GetMatchEngines        = match.GetEngineNames
+	RegisterCodec          = types.RegisterCodec
+	DefaultParams          = types.DefaultParams
+	NewMsgNewOrder         = types.NewMsgNewOrder
+	NewMsgCancelOrder      = types.NewMsgCancelOrder
+	NewKeeper              = keeper.NewKeeper
+	NewQuerier             = keeper.NewQuerier
+	FormatOrderIDsKey      = types.FormatOrderIDsKey
+	ValidateParamsSubspace = types.ValidateParamsSubspace
 )
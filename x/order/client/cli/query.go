@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -27,6 +28,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdDepthBook(queryRoute, cdc),
 		GetCmdQueryStore(queryRoute, cdc),
 		GetCmdQueryParams(queryRoute, cdc),
+		GetCmdQueryOrderRoute(queryRoute, cdc),
 	)...)
 
 	queryCmd.Flags().StringP(client.FlagNode, "n", "tcp://localhost:26657", "Node to connect to")
@@ -170,3 +172,37 @@ $ okexchaincli query order params
 		},
 	}
 }
+
+// GetCmdQueryOrderRoute queries the split of a trade between the depth book and the ammswap pool
+func GetCmdQueryOrderRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "route [product] [side] [quantity]",
+		Short: "Query the split of a trade between the depth book and the ammswap pool",
+		Long: strings.TrimSpace(`Query the split of a trade by the smart order router, the quantity is the amount of
+quote token to spend when buying, or the amount of base token to sell when selling:
+
+$ okexchaincli query order route xxb_okt SELL 100
+`),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			quantity, sdkErr := sdk.NewDecFromStr(args[2])
+			if sdkErr != nil {
+				return sdkErr
+			}
+			bz, err := cdc.MarshalJSON(keeper.NewQueryOrderRouteParams(args[0], strings.ToUpper(args[1]), quantity))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryOrderRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var route types.OrderRoute
+			cdc.MustUnmarshalJSON(res, &route)
+			return cliCtx.PrintOutput(route)
+		},
+	}
+}
@@ -25,6 +25,8 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(client.PostCommands(
 		getCmdNewOrder(cdc),
 		getCmdCancelOrder(cdc),
+		getCmdAmendOrder(cdc),
+		getCmdRouteOrder(cdc),
 	)...)
 
 	return txCmd
@@ -36,9 +38,36 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	var side string
 	var price string
 	var quantity string
+	var orderType string
+	var timeInForce string
+	var condition string
+	var triggerPrice string
+	var expireHeight int64
+	var expireTime int64
 	cmd := &cobra.Command{
 		Use:   "new",
 		Short: "place a new order",
+		Long: strings.TrimSpace(`place new orders, a market order has no price (use 0 as a placeholder)
+and the remaining of it is refunded at the end of the block:
+
+$ okexchaincli tx order new --product xxb_okt --side BUY,BUY --price 10.1,0 --quantity 1,20 --type LIMIT,MARKET
+
+The quantity of a market buy order is the amount of quote token to spend. The time in force of an order is one of
+GTC (good till expired), IOC (immediate or cancel), FOK (fill or kill) and POST_ONLY (rejected if it would be filled
+immediately):
+
+$ okexchaincli tx order new --product xxb_okt --side BUY --price 10.1 --quantity 1 --time-in-force IOC
+
+A conditional order is placed when the last price reaches its trigger price, a STOP_LOSS sell order or
+a TAKE_PROFIT buy order is triggered when the price falls to the trigger price, and the others are triggered
+when the price rises to it. Its id starts with "CID", and it can be cancelled before triggered:
+
+$ okexchaincli tx order new --product xxb_okt --side SELL --price 0 --quantity 1 --type MARKET \
+	--condition STOP_LOSS --trigger-price 9.5
+
+An order expires after OrderExpireBlocks by default, or at an earlier block height or unix timestamp:
+
+$ okexchaincli tx order new --product xxb_okt --side BUY --price 10.1 --quantity 1 --expire-height 100000`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(product) == 0 || len(side) == 0 || len(price) == 0 || len(quantity) == 0 {
 				return errors.New("invalid param format")
@@ -52,7 +81,8 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 				return errors.New("invalid param counts")
 			}
 
-			err := handleNewOrder(cdc, product, side, price, quantity)
+			err := handleNewOrder(cdc, product, side, price, quantity, orderType, timeInForce, condition,
+				triggerPrice, expireHeight, expireTime)
 			return err
 
 		},
@@ -62,15 +92,31 @@ func getCmdNewOrder(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().StringVarP(&side, "side", "s", "", "BUY or SELL (default \"SELL\")")
 	cmd.Flags().StringVarP(&price, "price", "p", "", "The price of the order")
 	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The quantity of the order")
+	cmd.Flags().StringVarP(&orderType, "type", "t", "", "LIMIT or MARKET (default \"LIMIT\")")
+	cmd.Flags().StringVarP(&timeInForce, "time-in-force", "", "", "GTC, IOC, FOK or POST_ONLY (default \"GTC\")")
+	cmd.Flags().StringVarP(&condition, "condition", "", "", "STOP_LOSS or TAKE_PROFIT for conditional orders")
+	cmd.Flags().StringVarP(&triggerPrice, "trigger-price", "", "", "The trigger price of conditional orders")
+	cmd.Flags().Int64VarP(&expireHeight, "expire-height", "", 0, "The block height at which the orders expire")
+	cmd.Flags().Int64VarP(&expireTime, "expire-time", "", 0, "The unix timestamp at which the orders expire")
 	return cmd
 }
 
-func handleNewOrder(cdc *codec.Codec, product string, side string, price string, quantity string) error {
+func handleNewOrder(cdc *codec.Codec, product string, side string, price string, quantity string,
+	orderType string, timeInForce string, condition string, triggerPrice string, expireHeight int64,
+	expireTime int64) error {
 	var items []types.OrderItem
 	productArr := strings.Split(product, ",")
 	sideArr := strings.Split(side, ",")
 	priceArr := strings.Split(price, ",")
 	quantityArr := strings.Split(quantity, ",")
+	typeArr := make([]string, len(productArr))
+	if len(orderType) > 0 {
+		typeArr = strings.Split(orderType, ",")
+	}
+	timeInForceArr := make([]string, len(productArr))
+	if len(timeInForce) > 0 {
+		timeInForceArr = strings.Split(timeInForce, ",")
+	}
 	if len(productArr) != len(sideArr) {
 		return errors.New("invalid param side counts")
 	}
@@ -83,6 +129,24 @@ func handleNewOrder(cdc *codec.Codec, product string, side string, price string,
 		return errors.New("invalid param quantity counts")
 	}
 
+	if len(productArr) != len(typeArr) {
+		return errors.New("invalid param type counts")
+	}
+
+	if len(productArr) != len(timeInForceArr) {
+		return errors.New("invalid param time-in-force counts")
+	}
+
+	conditionArr := make([]string, len(productArr))
+	triggerPriceArr := make([]string, len(productArr))
+	if len(condition) > 0 {
+		conditionArr = strings.Split(condition, ",")
+		triggerPriceArr = strings.Split(triggerPrice, ",")
+	}
+	if len(productArr) != len(conditionArr) || len(productArr) != len(triggerPriceArr) {
+		return errors.New("invalid param condition or trigger-price counts")
+	}
+
 	for i := 0; i < len(productArr); i++ {
 		product := productArr[i]
 		side := sideArr[i]
@@ -94,12 +158,26 @@ func handleNewOrder(cdc *codec.Codec, product string, side string, price string,
 		if err != nil {
 			return errors.New(err.Error())
 		}
-		items = append(items, types.OrderItem{
-			Product:  product,
-			Side:     side,
-			Price:    price,
-			Quantity: quantity,
-		})
+		item := types.OrderItem{
+			Product:      product,
+			Side:         side,
+			Price:        price,
+			Quantity:     quantity,
+			Type:         typeArr[i],
+			TimeInForce:  timeInForceArr[i],
+			ExpireHeight: expireHeight,
+			ExpireTime:   expireTime,
+		}
+		if typeArr[i] == types.MarketOrder {
+			item.Price = sdk.Dec{}
+		}
+		if conditionArr[i] != "" {
+			item.Condition = conditionArr[i]
+			if item.TriggerPrice, err = sdk.NewDecFromStr(triggerPriceArr[i]); err != nil {
+				return errors.New(err.Error())
+			}
+		}
+		items = append(items, item)
 	}
 
 	txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
@@ -130,3 +208,84 @@ func getCmdCancelOrder(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+func getCmdAmendOrder(cdc *codec.Codec) *cobra.Command {
+	var price string
+	var quantity string
+	cmd := &cobra.Command{
+		Use:   "amend [order-id]",
+		Short: "amend the price and quantity of orders",
+		Long: strings.TrimSpace(`amend open orders atomically, an order keeps its priority if only its quantity is
+reduced, otherwise it's cancelled and replaced by a new order:
+
+$ okexchaincli tx order amend ID0000000010-1,ID0000000010-2 --price 10.1,10.2 --quantity 1,2`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			orderIDs := strings.Split(args[0], ",")
+			priceArr := strings.Split(price, ",")
+			quantityArr := strings.Split(quantity, ",")
+			if len(orderIDs) != len(priceArr) || len(orderIDs) != len(quantityArr) {
+				return errors.New("invalid param price or quantity counts")
+			}
+
+			items := make([]types.AmendOrderItem, 0, len(orderIDs))
+			for i, orderID := range orderIDs {
+				price, err := sdk.NewDecFromStr(priceArr[i])
+				if err != nil {
+					return errors.New(err.Error())
+				}
+				quantity, err := sdk.NewDecFromStr(quantityArr[i])
+				if err != nil {
+					return errors.New(err.Error())
+				}
+				items = append(items, types.AmendOrderItem{OrderID: orderID, Price: price, Quantity: quantity})
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgAmendOrders(cliCtx.GetFromAddress(), items)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&price, "price", "p", "", "The new price of the orders")
+	cmd.Flags().StringVarP(&quantity, "quantity", "q", "", "The new quantity of the orders, including the filled quantity")
+	return cmd
+}
+
+func getCmdRouteOrder(cdc *codec.Codec) *cobra.Command {
+	var minOutput string
+	cmd := &cobra.Command{
+		Use:   "route [product] [side] [quantity]",
+		Short: "trade through the depth book and the ammswap pool with the smart order router",
+		Long: strings.TrimSpace(`trade on a product with the smart order router, which splits the trade between the
+depth book and the ammswap pool of the same tokens to get the most output. The pool part is swapped immediately,
+and the book part is placed as a FOK order bounded by the rest of the minimum output. The quantity is the amount of
+quote token to spend when buying, or the amount of base token to sell when selling. As the order may not be filled,
+the pool part must reach its share of the minimum output, or the trade goes to the best single venue:
+
+$ okexchaincli tx order route xxb_okt SELL 100 --min-output 990`),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			quantity, err := sdk.NewDecFromStr(args[2])
+			if err != nil {
+				return errors.New(err.Error())
+			}
+			minOutputDec, err := sdk.NewDecFromStr(minOutput)
+			if err != nil {
+				return errors.New(err.Error())
+			}
+
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			msg := types.NewMsgRouteOrder(cliCtx.GetFromAddress(), args[0], strings.ToUpper(args[1]), quantity,
+				minOutputDec)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&minOutput, "min-output", "", "0", "The minimum quantity of the token to buy")
+	return cmd
+}
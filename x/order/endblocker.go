@@ -2,6 +2,7 @@ package order
 
 import (
 	"fmt"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -12,14 +13,16 @@ import (
 )
 
 // EndBlocker called every block
-// 1. execute matching engine
-// 2. flush cache
+// 1. trigger conditional orders
+// 2. execute matching engines
+// 3. flush cache
 func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 
 	seq := perf.GetPerf().OnEndBlockEnter(ctx, types.ModuleName)
 	defer perf.GetPerf().OnEndBlockExit(ctx, types.ModuleName, seq)
 
-	match.GetEngine().Run(ctx, keeper)
+	triggerConditionalOrders(ctx, keeper)
+	match.Run(ctx, keeper)
 
 	// flush cache at the end
 	keeper.Cache2Disk(ctx)
@@ -38,3 +41,41 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 
 	perf.GetPerf().EnqueueMsg(msg)
 }
+
+// triggerConditionalOrders removes the expired conditional orders, and places the conditional orders whose trigger
+// prices are reached by the last prices, in the sequence of their placement, so that they are matched in this block
+func triggerConditionalOrders(ctx sdk.Context, k keeper.Keeper) {
+	logger := ctx.Logger().With("module", "order")
+	k.RemoveExpiredConditionalOrders(ctx)
+
+	var triggeredOrders []*types.ConditionalOrder
+	for _, tokenPair := range k.GetDexKeeper().GetTokenPairs(ctx) {
+		product := tokenPair.Name()
+		// orders of locked products are triggered after the products are unlocked
+		if k.IsProductLocked(ctx, product) {
+			continue
+		}
+		triggeredOrders = append(triggeredOrders, k.GetTriggeredConditionalOrders(ctx, product,
+			k.GetLastPrice(ctx, product))...)
+	}
+	sort.Slice(triggeredOrders, func(i, j int) bool {
+		return triggeredOrders[i].OrderID < triggeredOrders[j].OrderID
+	})
+
+	for _, conditionalOrder := range triggeredOrders {
+		k.RemoveConditionalOrder(ctx, conditionalOrder)
+		res, cacheItem, err := handleNewOrder(ctx, k, conditionalOrder.Sender, conditionalOrder.GetOrderItem(),
+			conditionalOrder.Ratio, logger)
+		if err != nil {
+			logger.Info(fmt.Sprintf("failed to place the triggered conditional order(%s): %v",
+				conditionalOrder.OrderID, err))
+			continue
+		}
+		cacheItem.Write()
+
+		order := k.GetOrder(ctx, res.OrderID)
+		order.TxHash = conditionalOrder.TxHash
+		order.RecordConditionalOrderID(conditionalOrder.OrderID)
+		k.SetOrder(ctx, order.OrderID, order)
+	}
+}
@@ -127,6 +127,266 @@ func TestEndBlockerPeriodicMatch(t *testing.T) {
 	require.EqualValues(t, "", collectedFees.String())
 }
 
+func TestEndBlockerContinuousMatchOfTokenPair(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	// the token pair chooses the continuous auction instead of the periodic auction of the chain
+	tokenPair := dex.GetBuiltInTokenPair()
+	tokenPair.MatchEngine = types.ContinuousAuction
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "9.5", "1.0"),
+	}
+	orders[0].Sender = addrKeysSlice[1].Address
+	orders[1].Sender = addrKeysSlice[0].Address
+	for _, order := range orders {
+		require.NoError(t, k.PlaceOrder(ctx, order))
+	}
+
+	EndBlocker(ctx, k)
+
+	// the taker is filled at the price of the maker
+	order0 := k.GetOrder(ctx, orders[0].OrderID)
+	order1 := k.GetOrder(ctx, orders[1].OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, order0.Status)
+	require.EqualValues(t, types.OrderStatusFilled, order1.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.0"), order1.FilledAvgPrice)
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.0"), k.GetLastPrice(ctx, types.TestTokenPair))
+}
+
+func TestEndBlockerRefundMarketOrder(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	maker := types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0")
+	maker.Sender = addrKeysSlice[1].Address
+	require.NoError(t, k.PlaceOrder(ctx, maker))
+
+	handler := NewOrderHandler(k)
+	msg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewMarketOrderItem(types.TestTokenPair, types.BuyOrder, "22"),
+	})
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	marketOrderID := types.FormatOrderID(startHeight, 2)
+
+	EndBlocker(ctx, k)
+
+	// the market order is partially filled, and the remaining is refunded instead of resting on the depth book
+	require.EqualValues(t, types.OrderStatusFilled, k.GetOrder(ctx, maker.OrderID).Status)
+	marketOrder := k.GetOrder(ctx, marketOrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledIOCCancelled, marketOrder.Status)
+	require.EqualValues(t, sdk.OneDec(), marketOrder.RemainQuantity)
+	require.True(t, marketOrder.RemainLocked.IsZero())
+	require.EqualValues(t, 0, len(k.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.EqualValues(t, 0, len(k.GetImmediateOrderIDs(ctx)))
+}
+
+func TestEndBlockerTimeInForce(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	makers := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+	}
+	for _, order := range makers {
+		order.Sender = addrKeysSlice[1].Address
+		require.NoError(t, k.PlaceOrder(ctx, order))
+	}
+	EndBlocker(ctx, k)
+
+	// the FOK order can't be fully filled, and the post-only buy order would be filled after the FOK order is killed
+	ctx = mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(11)
+	BeginBlocker(ctx, k)
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "3.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "11.0", "1.0"),
+	}
+	orders[0].TimeInForce = types.TimeInForceFOK
+	orders[1].TimeInForce = types.TimeInForcePostOnly
+	orders[2].TimeInForce = types.TimeInForcePostOnly
+	orders[0].Sender = addrKeysSlice[0].Address
+	orders[1].Sender = addrKeysSlice[0].Address
+	orders[2].Sender = addrKeysSlice[1].Address
+	for _, order := range orders {
+		require.NoError(t, k.PlaceOrder(ctx, order))
+	}
+	EndBlocker(ctx, k)
+
+	require.EqualValues(t, types.OrderStatusFOKKilled, k.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusPostOnlyRejected, k.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, orders[2].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, makers[0].OrderID).Status)
+	require.EqualValues(t, 0, len(k.GetPostOnlyOrderIDs(ctx)))
+	require.EqualValues(t, 0, len(k.GetImmediateOrderIDs(ctx)))
+	book := k.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 2, len(book.Items))
+	require.True(t, book.Items[1].BuyQuantity.IsZero())
+
+	// the FOK order is filled first by price priority, and the IOC order is partially filled then cancelled
+	ctx = mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(12)
+	BeginBlocker(ctx, k)
+	fokOrder := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "11.0", "1.0")
+	fokOrder.TimeInForce = types.TimeInForceFOK
+	fokOrder.Sender = addrKeysSlice[0].Address
+	require.NoError(t, k.PlaceOrder(ctx, fokOrder))
+	item := types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "3.0")
+	item.TimeInForce = types.TimeInForceIOC
+	handler := NewOrderHandler(k)
+	require.True(t, handler(ctx, types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{item})).Code.IsOK())
+	iocOrderID := types.FormatOrderID(12, 2)
+	EndBlocker(ctx, k)
+
+	require.EqualValues(t, types.OrderStatusFilled, k.GetOrder(ctx, fokOrder.OrderID).Status)
+	iocOrder := k.GetOrder(ctx, iocOrderID)
+	require.EqualValues(t, types.OrderStatusPartialFilledIOCCancelled, iocOrder.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), iocOrder.RemainQuantity)
+	require.True(t, iocOrder.RemainLocked.IsZero())
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, orders[2].OrderID).Status)
+	book = k.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("11.0"), book.Items[0].Price)
+}
+
+func TestEndBlockerTriggerConditionalOrder(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	maker := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "9.0", "1.0")
+	maker.Sender = addrKeysSlice[0].Address
+	require.NoError(t, k.PlaceOrder(ctx, maker))
+
+	stopLoss := types.NewMarketOrderItem(types.TestTokenPair, types.SellOrder, "1.0")
+	stopLoss.Condition = types.ConditionStopLoss
+	stopLoss.TriggerPrice = sdk.MustNewDecFromStr("9.5")
+	takeProfit := types.NewOrderItem(types.TestTokenPair, types.SellOrder, "11.0", "1.0")
+	takeProfit.Condition = types.ConditionTakeProfit
+	takeProfit.TriggerPrice = sdk.MustNewDecFromStr("11.0")
+	handler := NewOrderHandler(k)
+	msg := types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{stopLoss, takeProfit})
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	stopLossID, takeProfitID := types.FormatConditionalOrderID(1), types.FormatConditionalOrderID(2)
+
+	// last price is 10, nothing is triggered
+	EndBlocker(ctx, k)
+	require.NotNil(t, k.GetConditionalOrder(ctx, stopLossID))
+	require.EqualValues(t, 1, k.GetBlockOrderNum(ctx, 10))
+
+	// the stop-loss order is triggered when the price falls to 9.4, and is matched in the same block
+	ctx = mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(11)
+	BeginBlocker(ctx, k)
+	k.SetLastPrice(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("9.4"))
+	EndBlocker(ctx, k)
+
+	require.Nil(t, k.GetConditionalOrder(ctx, stopLossID))
+	require.NotNil(t, k.GetConditionalOrder(ctx, takeProfitID))
+	order := k.GetOrder(ctx, types.FormatOrderID(11, 1))
+	require.EqualValues(t, stopLossID, order.GetExtraInfoWithKey(types.OrderExtraInfoKeyConditionalOrderID))
+	require.EqualValues(t, types.MarketOrder, order.Type)
+	require.EqualValues(t, types.OrderStatusFilled, order.Status)
+	require.EqualValues(t, types.OrderStatusFilled, k.GetOrder(ctx, maker.OrderID).Status)
+}
+
+func TestEndBlockerCustomExpiry(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+
+	var startHeight int64 = 10
+	var startTime int64 = 1000
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(startHeight).
+		WithBlockTime(time.Unix(startTime, 0))
+	mapp.supplyKeeper.SetSupply(ctx, supply.NewSupply(mapp.TotalCoinsSupply))
+
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	EndBlocker(ctx, k)
+
+	heightItem := types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	heightItem.ExpireHeight = startHeight + 2
+	timeItem := types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	timeItem.ExpireTime = startTime + 100
+	handler := NewOrderHandler(k)
+	msg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{heightItem, timeItem})
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	heightOrderID := types.FormatOrderID(startHeight, 1)
+	timeOrderID := types.FormatOrderID(startHeight, 2)
+	EndBlocker(ctx, k)
+
+	ctx = ctx.WithBlockHeight(startHeight + 1).WithBlockTime(time.Unix(startTime+50, 0))
+	EndBlocker(ctx, k)
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, heightOrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, timeOrderID).Status)
+
+	// expired at its expiry height
+	ctx = ctx.WithBlockHeight(startHeight + 2).WithBlockTime(time.Unix(startTime+60, 0))
+	EndBlocker(ctx, k)
+	require.EqualValues(t, types.OrderStatusExpired, k.GetOrder(ctx, heightOrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, k.GetOrder(ctx, timeOrderID).Status)
+	// the expiry time is checked again at the next block as it's approaching
+	require.EqualValues(t, []int64{startHeight}, k.GetCustomExpireBlockHeight(ctx, startHeight+3))
+
+	// expired once the block time passes its expiry time
+	ctx = ctx.WithBlockHeight(startHeight + 3).WithBlockTime(time.Unix(startTime+120, 0))
+	EndBlocker(ctx, k)
+	require.EqualValues(t, types.OrderStatusExpired, k.GetOrder(ctx, timeOrderID).Status)
+	require.EqualValues(t, 0, len(k.GetDepthBookCopy(types.TestTokenPair).Items))
+	// the order num of the block is kept until the default expiry, as the custom expiry doesn't drop it
+	require.EqualValues(t, 2, k.GetBlockOrderNum(ctx, startHeight))
+	require.Empty(t, k.GetCustomExpireBlockHeight(ctx, startHeight+2))
+}
+
 func TestEndBlockerPeriodicMatchBusyProduct(t *testing.T) {
 	mapp, addrKeysSlice := getMockApp(t, 2)
 	k := mapp.orderKeeper
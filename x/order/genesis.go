@@ -2,6 +2,7 @@ package order
 
 import (
 	"fmt"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okexchain/x/order/keeper"
@@ -10,20 +11,40 @@ import (
 
 // GenesisState - all order state that must be provided at genesis
 type GenesisState struct {
-	Params     types.Params   `json:"params"`
-	OpenOrders []*types.Order `json:"open_orders"`
+	Params                  types.Params              `json:"params"`
+	OpenOrders              []*types.Order            `json:"open_orders"`
+	ConditionalOrders       []*types.ConditionalOrder `json:"conditional_orders"`
+	NextConditionalOrderSeq int64                     `json:"next_conditional_order_seq"`
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Params:     types.DefaultParams(),
-		OpenOrders: nil,
+		Params:                  types.DefaultParams(),
+		OpenOrders:              nil,
+		ConditionalOrders:       nil,
+		NextConditionalOrderSeq: 1,
 	}
 }
 
 // ValidateGenesis validates the slashing genesis parameters
 func ValidateGenesis(data GenesisState) error {
+	if err := types.ValidateParams(data.Params); err != nil {
+		return err
+	}
+	if data.NextConditionalOrderSeq < 0 {
+		return fmt.Errorf("invalid next conditional order seq %d", data.NextConditionalOrderSeq)
+	}
+	// the ids are formatted with the fixed width sequence, so they can be compared as strings
+	nextOrderID := types.FormatConditionalOrderID(data.NextConditionalOrderSeq)
+	for _, order := range data.ConditionalOrders {
+		if order == nil {
+			return fmt.Errorf("the nil conditional order is not expected")
+		}
+		if !types.IsConditionalOrderID(order.OrderID) || order.OrderID >= nextOrderID {
+			return fmt.Errorf("invalid conditional order id %s, the next one is %s", order.OrderID, nextOrderID)
+		}
+	}
 	return nil
 }
 
@@ -43,6 +64,11 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
 		futureExpireHeightList := keeper.GetExpireBlockHeight(ctx, futureHeight)
 		futureExpireHeightList = append(futureExpireHeightList, height)
 		keeper.SetExpireBlockHeight(ctx, futureHeight, futureExpireHeightList)
+		if order.ExpireHeight > 0 {
+			keeper.AddCustomExpireBlockHeight(ctx, order.ExpireHeight, height)
+		} else if order.ExpireTime > 0 {
+			keeper.AddCustomExpireBlockHeight(ctx, ctx.BlockHeight()+1, height)
+		}
 
 		orderNum := keeper.GetBlockOrderNum(ctx, height)
 		keeper.SetBlockOrderNum(ctx, height, orderNum+1)
@@ -54,6 +80,43 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
 	if len(data.OpenOrders) > 0 {
 		keeper.Cache2Disk(ctx)
 	}
+	initConditionalOrders(ctx, keeper, data)
+}
+
+// initConditionalOrders restores the conditional orders with their indexes, the coins locked by them
+// must have been restored by the token module
+func initConditionalOrders(ctx sdk.Context, keeper keeper.Keeper, data GenesisState) {
+	if data.NextConditionalOrderSeq > 0 {
+		keeper.SetConditionalOrderSeq(ctx, data.NextConditionalOrderSeq-1)
+	}
+
+	conditionalLocks := make(map[string]sdk.DecCoins)
+	for _, order := range data.ConditionalOrders {
+		if order == nil {
+			panic("the nil pointer is not expected")
+		}
+		keeper.SetConditionalOrder(ctx, order)
+		addr := order.Sender.String()
+		conditionalLocks[addr] = conditionalLocks[addr].Add(order.Locked)
+	}
+	if len(conditionalLocks) == 0 {
+		return
+	}
+
+	for _, lock := range keeper.GetTokenKeeper().GetAllLockedCoins(ctx) {
+		addr := lock.Acc.String()
+		if coins, ok := conditionalLocks[addr]; ok {
+			if _, hasNeg := lock.Coins.SafeSub(coins); hasNeg {
+				panic(fmt.Sprintf("the locked coins %s of %s are less than the coins %s locked by its conditional orders",
+					lock.Coins, addr, coins))
+			}
+			delete(conditionalLocks, addr)
+		}
+	}
+	for addr, coins := range conditionalLocks {
+		panic(fmt.Sprintf("the coins %s locked by the conditional orders of %s are not locked in the token module",
+			coins, addr))
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -109,8 +172,19 @@ func ExportGenesis(ctx sdk.Context, keeper keeper.Keeper) (data GenesisState) {
 		}
 	}
 
+	var conditionalOrders []*types.ConditionalOrder
+	keeper.IterateConditionalOrders(ctx, func(order *types.ConditionalOrder) bool {
+		conditionalOrders = append(conditionalOrders, order)
+		return false
+	})
+	sort.Slice(conditionalOrders, func(i, j int) bool {
+		return conditionalOrders[i].OrderID < conditionalOrders[j].OrderID
+	})
+
 	return GenesisState{
-		Params:     *params,
-		OpenOrders: openOrders,
+		Params:                  *params,
+		OpenOrders:              openOrders,
+		ConditionalOrders:       conditionalOrders,
+		NextConditionalOrderSeq: keeper.GetConditionalOrderSeq(ctx) + 1,
 	}
 }
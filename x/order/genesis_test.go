@@ -9,6 +9,7 @@ import (
 	"github.com/okex/okexchain/x/dex"
 	"github.com/okex/okexchain/x/order/keeper"
 	"github.com/okex/okexchain/x/order/types"
+	token "github.com/okex/okexchain/x/token/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/types/time"
 )
@@ -148,3 +149,62 @@ func TestExportGenesis(t *testing.T) {
 	// 0x20
 	require.Equal(t, int64(2), newOrderKeeper.GetStoreOrderNum(newCtx))
 }
+
+func TestExportGenesisConditionalOrders(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	orderKeeper := testInput.OrderKeeper
+	addr := testInput.TestAddrs[0]
+	params := types.DefaultParams()
+	orderKeeper.SetParams(ctx, &params)
+
+	var orders []*types.ConditionalOrder
+	for _, triggerPrice := range []string{"9.5", "10.5"} {
+		item := types.NewOrderItem(types.TestTokenPair, types.SellOrder, "9.0", "1.0")
+		item.Condition = types.ConditionStopLoss
+		item.TriggerPrice = sdk.MustNewDecFromStr(triggerPrice)
+		order := types.NewConditionalOrder("", addr, item, "1", 0)
+		order.ExpireHeight = 20
+		require.NoError(t, orderKeeper.PlaceConditionalOrder(ctx, order))
+		orders = append(orders, order)
+	}
+	orderKeeper.RemoveConditionalOrder(ctx, orders[0])
+
+	exportGenesis := ExportGenesis(ctx, orderKeeper)
+	require.Equal(t, []*types.ConditionalOrder{orders[1]}, exportGenesis.ConditionalOrders)
+	require.EqualValues(t, 3, exportGenesis.NextConditionalOrderSeq)
+	require.NoError(t, ValidateGenesis(exportGenesis))
+
+	// the coins of the conditional orders must be locked by the token module before the import
+	newTestInput := keeper.CreateTestInput(t)
+	newCtx := newTestInput.Ctx.WithBlockHeight(10)
+	newOrderKeeper := newTestInput.OrderKeeper
+	require.Panics(t, func() {
+		InitGenesis(newCtx, newOrderKeeper, exportGenesis)
+	})
+
+	newTestInput = keeper.CreateTestInput(t)
+	newCtx = newTestInput.Ctx.WithBlockHeight(10)
+	newOrderKeeper = newTestInput.OrderKeeper
+	addr = newTestInput.TestAddrs[0]
+	exportGenesis.ConditionalOrders[0].Sender = addr
+	require.NoError(t, newTestInput.TokenKeeper.LockCoins(newCtx, addr, orders[1].Locked, token.LockCoinsTypeQuantity))
+	InitGenesis(newCtx, newOrderKeeper, exportGenesis)
+	require.Equal(t, exportGenesis.ConditionalOrders[0], newOrderKeeper.GetConditionalOrder(newCtx, orders[1].OrderID))
+	require.Equal(t, exportGenesis.ConditionalOrders,
+		newOrderKeeper.GetTriggeredConditionalOrders(newCtx, types.TestTokenPair, sdk.MustNewDecFromStr("10.0")))
+
+	// the new conditional order continues the sequence
+	item := types.NewOrderItem(types.TestTokenPair, types.SellOrder, "9.0", "1.0")
+	item.Condition = types.ConditionStopLoss
+	item.TriggerPrice = sdk.MustNewDecFromStr("9.0")
+	order := types.NewConditionalOrder("", addr, item, "1", 0)
+	order.ExpireHeight = 20
+	require.NoError(t, newOrderKeeper.PlaceConditionalOrder(newCtx, order))
+	require.Equal(t, types.FormatConditionalOrderID(3), order.OrderID)
+
+	// the expire index is restored
+	newOrderKeeper.RemoveExpiredConditionalOrders(newCtx.WithBlockHeight(20))
+	require.Nil(t, newOrderKeeper.GetConditionalOrder(newCtx, orders[1].OrderID))
+	require.Nil(t, newOrderKeeper.GetConditionalOrder(newCtx, order.OrderID))
+}
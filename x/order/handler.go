@@ -24,6 +24,10 @@ func CalculateGas(msg sdk.Msg, params *types.Params) (gas uint64) {
 		gas = msg.CalculateGas(params.NewOrderMsgGasUnit)
 	case types.MsgCancelOrders:
 		gas = msg.CalculateGas(params.CancelOrderMsgGasUnit)
+	case types.MsgAmendOrders:
+		gas = msg.CalculateGas(params.NewOrderMsgGasUnit)
+	case types.MsgRouteOrder:
+		gas = msg.CalculateGas(params.NewOrderMsgGasUnit)
 	default:
 		gas = math.MaxUint64
 	}
@@ -63,6 +67,16 @@ func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 			handlerFun = func() sdk.Result {
 				return handleMsgCancelOrders(ctx, keeper, msg, logger)
 			}
+		case types.MsgAmendOrders:
+			name = "handleMsgAmendOrders"
+			handlerFun = func() sdk.Result {
+				return handleMsgAmendOrders(ctx, keeper, msg, logger)
+			}
+		case types.MsgRouteOrder:
+			name = "handleMsgRouteOrder"
+			handlerFun = func() sdk.Result {
+				return handleMsgRouteOrder(ctx, keeper, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("Invalid msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -103,14 +117,71 @@ func checkOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrd
 	if msg.Quantity.LT(tokenPair.MinQuantity) {
 		return fmt.Errorf("quantity should be greater than %s", tokenPair.MinQuantity)
 	}
+
+	// custom expiry can't be later than the default expiry
+	maxExpireHeight := ctx.BlockHeight() + keeper.GetParams(ctx).OrderExpireBlocks
+	if msg.ExpireHeight != 0 && (msg.ExpireHeight <= ctx.BlockHeight() || msg.ExpireHeight > maxExpireHeight) {
+		return fmt.Errorf("expire height(%d) should be in (%d, %d]", msg.ExpireHeight, ctx.BlockHeight(),
+			maxExpireHeight)
+	}
+	// the block interval is at least one second, so the custom expiry time is capped to OrderExpireBlocks seconds
+	blockTime := ctx.BlockHeader().Time.Unix()
+	maxExpireTime := blockTime + keeper.GetParams(ctx).OrderExpireBlocks
+	if msg.ExpireTime != 0 && (msg.ExpireTime <= blockTime || msg.ExpireTime > maxExpireTime) {
+		return fmt.Errorf("expire time(%d) should be in (%d, %d]", msg.ExpireTime, blockTime, maxExpireTime)
+	}
 	return nil
 }
 
+// convertMarketOrderMsg sets the price of a market order to the worst price it accepts, which is the last price
+// with the slippage of MarketSlippage param. A market buy order spends the quantity of quote token, its quantity
+// is converted into the quantity of base token that can be afforded at the worst price
+func convertMarketOrderMsg(ctx sdk.Context, keeper keeper.Keeper, msg *types.MsgNewOrder) error {
+	// the order is still built from the msg if the conversion fails
+	msg.Price = sdk.ZeroDec()
+	tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, msg.Product)
+	if tokenPair == nil {
+		return fmt.Errorf("trading pair '%s' does not exist", msg.Product)
+	}
+
+	lastPrice := keeper.GetLastPrice(ctx, msg.Product)
+	slippage := keeper.GetParams(ctx).MarketSlippage
+	if msg.Side == types.BuyOrder {
+		msg.Price = truncateDecimal(lastPrice.Mul(sdk.OneDec().Add(slippage)), tokenPair.MaxPriceDigit)
+		if msg.Price.IsPositive() {
+			msg.Quantity = truncateDecimal(msg.Quantity.QuoTruncate(msg.Price), tokenPair.MaxQuantityDigit)
+		}
+	} else {
+		msg.Price = ceilDecimal(lastPrice.Mul(sdk.OneDec().Sub(slippage)), tokenPair.MaxPriceDigit)
+	}
+
+	if !msg.Price.IsPositive() {
+		return fmt.Errorf("market order of trading pair '%s' is not available at last price(%s)",
+			msg.Product, lastPrice)
+	}
+	if !msg.Quantity.IsPositive() {
+		return fmt.Errorf("quantity of market order is too small to buy at price(%s)", msg.Price)
+	}
+	return nil
+}
+
+// truncateDecimal truncates the decimal to the specified precision
+func truncateDecimal(d sdk.Dec, precision int64) sdk.Dec {
+	precisionMul := sdk.NewIntWithDecimal(1, int(precision))
+	return d.MulInt(precisionMul).TruncateDec().QuoInt(precisionMul)
+}
+
+// ceilDecimal rounds the decimal up to the specified precision
+func ceilDecimal(d sdk.Dec, precision int64) sdk.Dec {
+	precisionMul := sdk.NewIntWithDecimal(1, int(precision))
+	return d.MulInt(precisionMul).Ceil().QuoInt(precisionMul)
+}
+
 func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ratio string) *types.Order {
 	feeParams := k.GetParams(ctx)
 	feePerBlockAmount := feeParams.FeePerBlock.Amount.Mul(sdk.MustNewDecFromStr(ratio))
 	feePerBlock := sdk.NewDecCoinFromDec(feeParams.FeePerBlock.Denom, feePerBlockAmount)
-	return types.NewOrder(
+	order := types.NewOrder(
 		fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes())),
 		msg.Sender,
 		msg.Product,
@@ -121,23 +192,90 @@ func getOrderFromMsg(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrder, ra
 		feeParams.OrderExpireBlocks,
 		feePerBlock,
 	)
+	order.Type = msg.Type
+	order.TimeInForce = msg.TimeInForce
+	order.ExpireHeight = msg.ExpireHeight
+	order.ExpireTime = msg.ExpireTime
+	return order
+}
+
+// getMsgFromItem builds the msg of a single order from the order item
+func getMsgFromItem(sender sdk.AccAddress, item types.OrderItem) MsgNewOrder {
+	return MsgNewOrder{
+		Sender:       sender,
+		Product:      item.Product,
+		Side:         item.Side,
+		Price:        item.Price,
+		Quantity:     item.Quantity,
+		Type:         item.Type,
+		TimeInForce:  item.TimeInForce,
+		ExpireHeight: item.ExpireHeight,
+		ExpireTime:   item.ExpireTime,
+	}
+}
+
+// checkConditionalOrderItem checks the product and the trigger price of a conditional order item. The price of a
+// conditional market order is unknown until it's triggered, so it's checked as a regular order when triggered
+func checkConditionalOrderItem(ctx sdk.Context, keeper keeper.Keeper, sender sdk.AccAddress,
+	item types.OrderItem) error {
+	tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, item.Product)
+	if tokenPair == nil {
+		return fmt.Errorf("trading pair '%s' does not exist", item.Product)
+	}
+	if !item.TriggerPrice.RoundDecimal(tokenPair.MaxPriceDigit).Equal(item.TriggerPrice) {
+		return fmt.Errorf("trigger price(%v) over accuracy(%d)", item.TriggerPrice, tokenPair.MaxPriceDigit)
+	}
+	if item.Type == types.MarketOrder {
+		return nil
+	}
+	return checkOrderNewMsg(ctx, keeper, getMsgFromItem(sender, item))
+}
+
+// handleNewConditionalOrder saves the conditional order item, which is placed as a regular order when triggered
+func handleNewConditionalOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
+	item types.OrderItem, ratio string, logger log.Logger) (types.OrderResult, sdk.CacheMultiStore, error) {
+
+	cacheItem := ctx.MultiStore().CacheMultiStore()
+	ctxItem := ctx.WithMultiStore(cacheItem)
+	if err := checkConditionalOrderItem(ctxItem, k, sender, item); err != nil {
+		return types.OrderResult{Code: sdk.CodeUnknownRequest, Message: err.Error()}, cacheItem, err
+	}
+
+	order := types.NewConditionalOrder(fmt.Sprintf("%X", tmhash.Sum(ctx.TxBytes())), sender, item, ratio,
+		ctx.BlockHeader().Time.Unix())
+	// the conditional order expires like a regular order if it isn't triggered
+	order.ExpireHeight = item.ExpireHeight
+	if order.ExpireHeight == 0 {
+		order.ExpireHeight = ctx.BlockHeight() + k.GetParams(ctx).OrderExpireBlocks
+	}
+	if err := k.PlaceConditionalOrder(ctxItem, order); err != nil {
+		return types.OrderResult{Code: sdk.CodeInsufficientCoins, Message: err.Error()}, cacheItem, err
+	}
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, conditional order<%s>",
+		ctx.BlockHeight(), "handleMsgNewOrder", order))
+
+	return types.OrderResult{Code: sdk.CodeOK, OrderID: order.OrderID}, cacheItem, nil
 }
 
 func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 	item types.OrderItem, ratio string, logger log.Logger) (types.OrderResult, sdk.CacheMultiStore, error) {
 
+	if item.Condition != "" {
+		return handleNewConditionalOrder(ctx, k, sender, item, ratio, logger)
+	}
+
 	cacheItem := ctx.MultiStore().CacheMultiStore()
 	ctxItem := ctx.WithMultiStore(cacheItem)
-	msg := MsgNewOrder{
-		Sender:   sender,
-		Product:  item.Product,
-		Side:     item.Side,
-		Price:    item.Price,
-		Quantity: item.Quantity,
+	msg := getMsgFromItem(sender, item)
+	var err error
+	if msg.Type == types.MarketOrder {
+		err = convertMarketOrderMsg(ctxItem, k, &msg)
 	}
 	order := getOrderFromMsg(ctxItem, k, msg, ratio)
 	code := sdk.CodeOK
-	err := checkOrderNewMsg(ctxItem, k, msg)
+	if err == nil {
+		err = checkOrderNewMsg(ctxItem, k, msg)
+	}
 
 	if err != nil {
 		code = sdk.CodeUnknownRequest
@@ -214,15 +352,36 @@ func ValidateMsgNewOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgNewOrde
 		ratio = "0.8"
 	}
 
+	// the coins of the conditional orders are only locked by the handler, the balance is checked here
+	var conditionalLocks sdk.DecCoins
 	for _, item := range msg.OrderItems {
-		msg := MsgNewOrder{
-			Sender:   msg.Sender,
-			Product:  item.Product,
-			Side:     item.Side,
-			Price:    item.Price,
-			Quantity: item.Quantity,
-		}
-		err := checkOrderNewMsg(ctx, k, msg)
+		if item.Condition != "" {
+			if err := checkConditionalOrderItem(ctx, k, msg.Sender, item); err != nil {
+				return sdk.Result{
+					Code: sdk.CodeUnknownRequest,
+					Log:  err.Error(),
+				}
+			}
+			order := types.NewConditionalOrder("", msg.Sender, item, ratio, 0)
+			conditionalLocks = conditionalLocks.Add(order.NeedLockCoins())
+			if _, hasNeg := k.GetCoins(ctx, msg.Sender).SafeSub(conditionalLocks); hasNeg {
+				return sdk.Result{
+					Code: sdk.CodeInsufficientCoins,
+					Log: fmt.Sprintf("insufficient coins of %s to lock %s for the conditional orders",
+						msg.Sender, conditionalLocks),
+				}
+			}
+			continue
+		}
+
+		msg := getMsgFromItem(msg.Sender, item)
+		var err error
+		if msg.Type == types.MarketOrder {
+			err = convertMarketOrderMsg(ctx, k, &msg)
+		}
+		if err == nil {
+			err = checkOrderNewMsg(ctx, k, msg)
+		}
 		if err != nil {
 			return sdk.Result{
 				Code: sdk.CodeUnknownRequest,
@@ -266,6 +425,9 @@ func handleCancelOrder(context sdk.Context, k Keeper, sender sdk.AccAddress, ord
 
 	if !validateResult.IsOK() {
 		message = validateResult.Log
+	} else if types.IsConditionalOrderID(orderID) {
+		// conditional order is not placed yet, its locked coins are unlocked and nothing is charged
+		k.RemoveConditionalOrder(ctx, k.GetConditionalOrder(ctx, orderID))
 	} else {
 		// cancel order
 		order := k.GetOrder(ctx, orderID)
@@ -320,7 +482,28 @@ func handleMsgCancelOrders(ctx sdk.Context, k Keeper, msg types.MsgCancelOrders,
 	}
 }
 
+// validateCancelConditionalOrder checks whether the conditional order exists and belongs to the sender
+func validateCancelConditionalOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelOrder) sdk.Result {
+	order := keeper.GetConditionalOrder(ctx, msg.OrderID)
+	if order == nil {
+		return sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log:  fmt.Sprintf("conditional order(%s) does not exist or already triggered", msg.OrderID),
+		}
+	}
+	if !order.Sender.Equals(msg.Sender) {
+		return sdk.Result{
+			Code: sdk.CodeUnauthorized,
+			Log:  fmt.Sprintf("not the owner of conditional order(%v)", msg.OrderID),
+		}
+	}
+	return sdk.Result{}
+}
+
 func validateCancelOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCancelOrder) sdk.Result {
+	if types.IsConditionalOrderID(msg.OrderID) {
+		return validateCancelConditionalOrder(ctx, keeper, msg)
+	}
 	order := keeper.GetOrder(ctx, msg.OrderID)
 
 	// Check order
@@ -366,3 +549,297 @@ func ValidateMsgCancelOrders(ctx sdk.Context, keeper keeper.Keeper, msg types.Ms
 
 	return sdk.Result{}
 }
+
+// getAmendedMsg builds the msg of the order after amended, it keeps the product, side, time in force and expiry of
+// the order. The new order only places the quantity which isn't filled by the order yet
+func getAmendedMsg(order *types.Order, item types.AmendOrderItem) MsgNewOrder {
+	msg := MsgNewOrder{
+		Sender:       order.Sender,
+		Product:      order.Product,
+		Side:         order.Side,
+		Price:        item.Price,
+		Quantity:     item.Quantity.Sub(order.Quantity.Sub(order.RemainQuantity)),
+		Type:         order.Type,
+		TimeInForce:  order.TimeInForce,
+		ExpireHeight: order.ExpireHeight,
+		ExpireTime:   order.ExpireTime,
+	}
+	if msg.ExpireHeight == 0 && msg.ExpireTime == 0 {
+		// the new order expires at the default expiry of the order
+		msg.ExpireHeight = types.GetBlockHeightFromOrderID(order.OrderID) + order.OrderExpireBlocks
+	}
+	return msg
+}
+
+// isAmendedInPlace returns true if the order can be reduced in place: the price is unchanged,
+// and the quantity is reduced but still more than the filled quantity
+func isAmendedInPlace(order *types.Order, item types.AmendOrderItem) bool {
+	filledQuantity := order.Quantity.Sub(order.RemainQuantity)
+	return item.Price.Equal(order.Price) && item.Quantity.LT(order.Quantity) && item.Quantity.GT(filledQuantity)
+}
+
+// validateAmendOrder checks whether the order can be amended to the price and quantity of the amend item
+func validateAmendOrder(ctx sdk.Context, k keeper.Keeper, sender sdk.AccAddress,
+	item types.AmendOrderItem) sdk.Result {
+	if types.IsConditionalOrderID(item.OrderID) {
+		return sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log:  fmt.Sprintf("conditional order(%s) can't be amended", item.OrderID),
+		}
+	}
+	if res := validateCancelOrder(ctx, k, types.MsgCancelOrder{Sender: sender, OrderID: item.OrderID}); !res.IsOK() {
+		return res
+	}
+
+	order := k.GetOrder(ctx, item.OrderID)
+	if order.IsImmediate() {
+		return sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log:  fmt.Sprintf("immediate order(%s) can't be amended", item.OrderID),
+		}
+	}
+
+	if isAmendedInPlace(order, item) {
+		return sdk.Result{}
+	}
+	msg := getAmendedMsg(order, item)
+	if !msg.Quantity.IsPositive() {
+		return sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log: fmt.Sprintf("amended quantity(%s) should be more than the filled quantity(%s) of order(%s)",
+				item.Quantity, order.Quantity.Sub(order.RemainQuantity), item.OrderID),
+		}
+	}
+	if err := checkOrderNewMsg(ctx, k, msg); err != nil {
+		return sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log:  err.Error(),
+		}
+	}
+	return sdk.Result{}
+}
+
+// handleAmendOrder reduces the order in place, or cancels the order and places a new one with the amended price
+// and quantity. The coins and fee of the order are unlocked, and the ones of the new order are locked in one step
+func handleAmendOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress, item types.AmendOrderItem,
+	ratio string, logger log.Logger) (types.AmendOrderResult, sdk.CacheMultiStore, error) {
+
+	cacheItem := ctx.MultiStore().CacheMultiStore()
+	ctxItem := ctx.WithMultiStore(cacheItem)
+	res := types.AmendOrderResult{OrderID: item.OrderID}
+	if validateResult := validateAmendOrder(ctxItem, k, sender, item); !validateResult.IsOK() {
+		res.Code, res.Message = validateResult.Code, validateResult.Log
+		return res, cacheItem, errors.New(validateResult.Log)
+	}
+
+	order := k.GetOrder(ctxItem, item.OrderID)
+	if isAmendedInPlace(order, item) {
+		k.ReduceOrder(ctxItem, order, order.Quantity.Sub(item.Quantity))
+		res.NewOrderID = order.OrderID
+		return res, cacheItem, nil
+	}
+
+	fee := k.CancelOrder(ctxItem, order, logger)
+	newOrder := getOrderFromMsg(ctxItem, k, getAmendedMsg(order, item), ratio)
+	if err := k.PlaceOrder(ctxItem, newOrder); err != nil {
+		res.Code, res.Message = sdk.CodeInsufficientCoins, err.Error()
+		return res, cacheItem, err
+	}
+	order.RecordAmendedOrderID(newOrder.OrderID)
+	k.SetOrder(ctxItem, order.OrderID, order)
+	res.NewOrderID, res.Message = newOrder.OrderID, fee.String()
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, order<%s> is replaced by order<%s>",
+		ctx.BlockHeight(), "handleMsgAmendOrder", order.OrderID, newOrder.OrderID))
+	return res, cacheItem, nil
+}
+
+func handleMsgAmendOrders(ctx sdk.Context, k Keeper, msg types.MsgAmendOrders, logger log.Logger) sdk.Result {
+	ratio := "1"
+	if len(msg.AmendItems) > 1 {
+		ratio = "0.8"
+	}
+
+	rs := make([]types.AmendOrderResult, 0, len(msg.AmendItems))
+	var handlerResult bitset.BitSet
+	for idx, item := range msg.AmendItems {
+		res, cacheItem, err := handleAmendOrder(ctx, k, msg.Sender, item, ratio, logger)
+		if err == nil {
+			cacheItem.Write()
+			handlerResult.Set(uint(idx))
+		}
+		rs = append(rs, res)
+	}
+	rss, err := json.Marshal(&rs)
+	if err != nil {
+		rss = []byte(fmt.Sprintf("failed to marshal result to JSON: %s", err))
+	}
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	event = event.AppendAttributes(sdk.NewAttribute("orders", string(rss)))
+	ctx.EventManager().EmitEvent(event)
+
+	if handlerResult.None() {
+		return sdk.Result{Code: sdk.CodeInternal}
+	}
+
+	k.AddTxHandlerMsgResult(handlerResult)
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// ValidateMsgAmendOrders validates whether the msg of amendOrders is valid.
+func ValidateMsgAmendOrders(ctx sdk.Context, k keeper.Keeper, msg types.MsgAmendOrders) sdk.Result {
+	for _, item := range msg.AmendItems {
+		if res := validateAmendOrder(ctx, k, msg.Sender, item); !res.IsOK() {
+			return res
+		}
+	}
+	return sdk.Result{}
+}
+
+// getRouteOrderItem builds the order of the book part of the route. It's a FOK limit order at the worst price of
+// the depth book reached, or at a better price if it's needed by the rest of the minimum output of the msg after the
+// swap part, so the total output is no less than the minimum once the order is filled
+func getRouteOrderItem(ctx sdk.Context, k keeper.Keeper, msg types.MsgRouteOrder,
+	route types.OrderRoute) (types.OrderItem, error) {
+	tokenPair := k.GetDexKeeper().GetTokenPair(ctx, msg.Product)
+	if tokenPair == nil {
+		return types.OrderItem{}, fmt.Errorf("trading pair '%s' does not exist", msg.Product)
+	}
+
+	price, quantity := route.BookPrice, route.BookInput.Amount
+	bookMinOutput := msg.MinOutput.Sub(route.SwapOutput.Amount)
+	if msg.Side == types.SellOrder {
+		if bookMinOutput.IsPositive() {
+			price = sdk.MaxDec(price, ceilDecimal(bookMinOutput.Quo(quantity), tokenPair.MaxPriceDigit))
+		}
+	} else {
+		if bookMinOutput.IsPositive() {
+			minQuantity := ceilDecimal(bookMinOutput, tokenPair.MaxQuantityDigit)
+			price = sdk.MinDec(price, truncateDecimal(quantity.QuoTruncate(minQuantity), tokenPair.MaxPriceDigit))
+		}
+		if !price.IsPositive() {
+			return types.OrderItem{}, fmt.Errorf("minimum output(%s) is not available on the depth book",
+				msg.MinOutput)
+		}
+		// the quote token not spent by the order is left to the sender
+		quantity = truncateDecimal(quantity.QuoTruncate(price), tokenPair.MaxQuantityDigit)
+	}
+
+	return types.OrderItem{
+		Product:     msg.Product,
+		Side:        msg.Side,
+		Price:       price,
+		Quantity:    quantity,
+		Type:        types.LimitOrder,
+		TimeInForce: types.TimeInForceFOK,
+	}, nil
+}
+
+// checkRouteOrder finds the best route of the msg and checks it against the minimum output of the msg.
+// The swap part of a split route is made immediately, while the FOK order of the book part, bounded by the rest of
+// the minimum output, is filled or canceled in the match. The swap part must reach its share of the minimum output
+// in proportion to its input, so that the part executed is no worse than the minimum output in either case.
+// Otherwise the msg falls back to the best single venue reaching the minimum output
+func checkRouteOrder(ctx sdk.Context, k keeper.Keeper, msg types.MsgRouteOrder) (types.OrderRoute, sdk.Result) {
+	route, err := k.GetBestOrderRoute(ctx, msg.Product, msg.Side, msg.Quantity)
+	if err != nil {
+		return route, sdk.Result{
+			Code: sdk.CodeUnknownRequest,
+			Log:  err.Error(),
+		}
+	}
+	if route.Output().LT(msg.MinOutput) {
+		return route, sdk.Result{
+			Code: sdk.CodeInternal,
+			Log: fmt.Sprintf("Failed: expected minimum output is %s but got %s", msg.MinOutput,
+				route.Output()),
+		}
+	}
+	if route.SwapInput.IsPositive() && route.BookInput.IsPositive() &&
+		route.SwapOutput.Amount.LT(msg.MinOutput.Mul(route.SwapInput.Amount).Quo(msg.Quantity)) {
+		route, err = k.GetBestSingleVenueOrderRoute(ctx, msg.Product, msg.Side, msg.Quantity)
+		if err != nil {
+			return route, sdk.Result{
+				Code: sdk.CodeInternal,
+				Log: fmt.Sprintf("Failed: expected minimum output is %s but the split route can't guarantee it "+
+					"and %s", msg.MinOutput, err),
+			}
+		}
+		if route.Output().LT(msg.MinOutput) {
+			return route, sdk.Result{
+				Code: sdk.CodeInternal,
+				Log: fmt.Sprintf("Failed: expected minimum output is %s but the split route can't guarantee it "+
+					"and a single venue gets %s", msg.MinOutput, route.Output()),
+			}
+		}
+	}
+	if route.BookInput.IsPositive() && k.IsProductLocked(ctx, msg.Product) {
+		return route, sdk.Result{
+			Code: sdk.CodeInternal,
+			Log:  fmt.Sprintf("the trading pair (%s) is locked, please retry later", msg.Product),
+		}
+	}
+	return route, sdk.Result{}
+}
+
+// handleMsgRouteOrder executes the best route of the msg, the pool part is swapped immediately and the book part is
+// placed as an order
+func handleMsgRouteOrder(ctx sdk.Context, k Keeper, msg types.MsgRouteOrder, logger log.Logger) sdk.Result {
+	route, res := checkRouteOrder(ctx, k, msg)
+	if !res.IsOK() {
+		return res
+	}
+
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+	if route.SwapInput.IsPositive() {
+		swapTokenPair, err := k.GetSwapKeeper().GetSwapTokenPair(ctx, route.SwapTokenPair)
+		if err != nil {
+			return sdk.Result{
+				Code: sdk.CodeInternal,
+				Log:  err.Error(),
+			}
+		}
+		if err := k.GetSwapKeeper().SwapTokens(ctx, swapTokenPair, route.SwapInput, route.SwapOutput, msg.Sender,
+			msg.Sender); err != nil {
+			return err.Result()
+		}
+		event = event.AppendAttributes(
+			sdk.NewAttribute("swap_input", route.SwapInput.String()),
+			sdk.NewAttribute("swap_output", route.SwapOutput.String()),
+		)
+	}
+
+	if route.BookInput.IsPositive() {
+		item, err := getRouteOrderItem(ctx, k, msg, route)
+		if err != nil {
+			return sdk.Result{
+				Code: sdk.CodeUnknownRequest,
+				Log:  err.Error(),
+			}
+		}
+		orderRes, cacheItem, err := handleNewOrder(ctx, k, msg.Sender, item, "1", logger)
+		if err != nil {
+			return sdk.Result{
+				Code: orderRes.Code,
+				Log:  orderRes.Message,
+			}
+		}
+		cacheItem.Write()
+		event = event.AppendAttributes(sdk.NewAttribute("orderid", orderRes.OrderID))
+	}
+
+	logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>, route<%s>", ctx.BlockHeight(), "handleMsgRouteOrder",
+		route))
+	ctx.EventManager().EmitEvent(event)
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// ValidateMsgRouteOrder validates whether the msg of routeOrder is valid.
+func ValidateMsgRouteOrder(ctx sdk.Context, k keeper.Keeper, msg types.MsgRouteOrder) sdk.Result {
+	_, res := checkRouteOrder(ctx, k, msg)
+	return res
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/x/supply"
 
@@ -15,6 +16,7 @@ import (
 
 	"github.com/okex/okexchain/x/common"
 	"github.com/okex/okexchain/x/dex"
+	"github.com/okex/okexchain/x/order/keeper"
 	"github.com/okex/okexchain/x/order/types"
 	"github.com/okex/okexchain/x/token"
 	tokentypes "github.com/okex/okexchain/x/token/types"
@@ -137,6 +139,360 @@ func TestHandleMsgNewOrderInvalid(t *testing.T) {
 	require.Equal(t, 0, len(depthBook.Items))
 }
 
+func TestHandleMsgNewMarketOrder(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	feeParams := types.DefaultTestParams()
+	mapp.orderKeeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(mapp.orderKeeper)
+	orderItems := []types.OrderItem{
+		types.NewMarketOrderItem(types.TestTokenPair, types.BuyOrder, "22"),
+		types.NewMarketOrderItem(types.TestTokenPair, types.SellOrder, "1.5"),
+	}
+	msg := types.NewMsgNewOrders(addrKeysSlice[0].Address, orderItems)
+	result := handler(ctx, msg)
+	require.True(t, result.Code.IsOK())
+
+	// last price is 10, the market buy order spends 22okt at the price of 11 at most
+	buyOrder := mapp.orderKeeper.GetOrder(ctx, types.FormatOrderID(10, 1))
+	require.EqualValues(t, types.MarketOrder, buyOrder.Type)
+	require.EqualValues(t, sdk.MustNewDecFromStr("11"), buyOrder.Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), buyOrder.Quantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("22"), buyOrder.RemainLocked)
+
+	// the market sell order sells at the price of 9 at least
+	sellOrder := mapp.orderKeeper.GetOrder(ctx, types.FormatOrderID(10, 2))
+	require.EqualValues(t, sdk.MustNewDecFromStr("9"), sellOrder.Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.5"), sellOrder.Quantity)
+
+	require.EqualValues(t, []string{buyOrder.OrderID, sellOrder.OrderID}, mapp.orderKeeper.GetImmediateOrderIDs(ctx))
+
+	// the quote amount can't afford the min unit of base token
+	msg = types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{
+		types.NewMarketOrderItem(types.TestTokenPair, types.BuyOrder, "0.00000001"),
+	})
+	result = handler(ctx, msg)
+	require.False(t, result.Code.IsOK())
+}
+
+func TestHandleMsgNewConditionalOrder(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	keeper := mapp.orderKeeper
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	tokenPair.MaxPriceDigit = 2
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	item := types.NewMarketOrderItem(types.TestTokenPair, types.SellOrder, "1.5")
+	item.Condition = types.ConditionStopLoss
+	item.TriggerPrice = sdk.MustNewDecFromStr("9.5")
+	msg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{item})
+	// the ante validation checks the balance without locking any coins
+	require.True(t, ValidateMsgNewOrders(ctx, keeper, msg).IsOK())
+	acc := mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address)
+	require.EqualValues(t, sdk.MustNewDecFromStr("100"), acc.GetCoins().AmountOf(common.TestToken))
+	hugeItem := types.NewMarketOrderItem(types.TestTokenPair, types.SellOrder, "60")
+	hugeItem.Condition = types.ConditionStopLoss
+	hugeItem.TriggerPrice = sdk.MustNewDecFromStr("9.5")
+	hugeMsg := types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{hugeItem, hugeItem})
+	require.Equal(t, sdk.CodeInsufficientCoins, ValidateMsgNewOrders(ctx, keeper, hugeMsg).Code)
+	require.True(t, handler(ctx, msg).Code.IsOK())
+
+	// the coins are locked until the conditional order is triggered, canceled or expired
+	orderID := types.FormatConditionalOrderID(1)
+	require.NotNil(t, keeper.GetConditionalOrder(ctx, orderID))
+	require.EqualValues(t, 0, keeper.GetBlockOrderNum(ctx, 10))
+	acc = mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address)
+	require.EqualValues(t, sdk.MustNewDecFromStr("98.5"), acc.GetCoins().AmountOf(common.TestToken))
+
+	// trigger price over accuracy
+	item.TriggerPrice = sdk.MustNewDecFromStr("9.123")
+	msg = types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{item})
+	require.False(t, ValidateMsgNewOrders(ctx, keeper, msg).IsOK())
+	require.False(t, handler(ctx, msg).Code.IsOK())
+
+	// only the owner can cancel the conditional order
+	require.False(t, handler(ctx, types.NewMsgCancelOrders(addrKeysSlice[1].Address, []string{orderID})).Code.IsOK())
+	cancelMsg := types.NewMsgCancelOrders(addrKeysSlice[0].Address, []string{orderID})
+	require.True(t, ValidateMsgCancelOrders(ctx, keeper, cancelMsg).IsOK())
+	require.True(t, handler(ctx, cancelMsg).Code.IsOK())
+	require.Nil(t, keeper.GetConditionalOrder(ctx, orderID))
+	acc = mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address)
+	require.EqualValues(t, sdk.MustNewDecFromStr("100"), acc.GetCoins().AmountOf(common.TestToken))
+	require.False(t, handler(ctx, cancelMsg).Code.IsOK())
+}
+
+func TestHandleMsgNewOrderExpiry(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	keeper := mapp.orderKeeper
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	newMsg := func(expireHeight, expireTime int64) types.MsgNewOrders {
+		item := types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+		item.ExpireHeight = expireHeight
+		item.ExpireTime = expireTime
+		return types.NewMsgNewOrders(addrKeysSlice[0].Address, []types.OrderItem{item})
+	}
+
+	// the expiry height should be in (current height, current height + OrderExpireBlocks]
+	require.False(t, handler(ctx, newMsg(10, 0)).Code.IsOK())
+	require.False(t, handler(ctx, newMsg(10+feeParams.OrderExpireBlocks+1, 0)).Code.IsOK())
+	require.True(t, handler(ctx, newMsg(10+feeParams.OrderExpireBlocks, 0)).Code.IsOK())
+	order := keeper.GetOrder(ctx, types.FormatOrderID(10, 1))
+	require.EqualValues(t, 10+feeParams.OrderExpireBlocks, order.ExpireHeight)
+	require.EqualValues(t, []int64{10}, keeper.GetCustomExpireBlockHeight(ctx, order.ExpireHeight))
+
+	// the expiry time should be in (block time, block time + OrderExpireBlocks]
+	require.False(t, handler(ctx, newMsg(0, 1000)).Code.IsOK())
+	require.False(t, handler(ctx, newMsg(0, 1000+feeParams.OrderExpireBlocks+1)).Code.IsOK())
+	require.True(t, handler(ctx, newMsg(0, 1001)).Code.IsOK())
+	order = keeper.GetOrder(ctx, types.FormatOrderID(10, 2))
+	require.EqualValues(t, 1001, order.ExpireTime)
+	require.EqualValues(t, []int64{10}, keeper.GetCustomExpireBlockHeight(ctx, 11))
+}
+
+func TestHandleMsgAmendOrders(t *testing.T) {
+	mapp, addrKeysSlice := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	keeper := mapp.orderKeeper
+	feeParams := types.DefaultTestParams()
+	keeper.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	tokenPair.MaxPriceDigit = 2
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(keeper)
+	msg := types.NewMsgNewOrder(addrKeysSlice[0].Address, types.TestTokenPair, types.BuyOrder, "10.0", "2.0")
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	orderID := types.FormatOrderID(10, 1)
+	getBalance := func() sdk.Dec {
+		return mapp.AccountKeeper.GetAccount(ctx, addrKeysSlice[0].Address).GetCoins().AmountOf(common.NativeToken)
+	}
+	balance := getBalance()
+
+	// only the owner can amend the order
+	amendMsg := types.NewMsgAmendOrders(addrKeysSlice[1].Address,
+		[]types.AmendOrderItem{types.NewAmendOrderItem(orderID, "10.0", "1.0")})
+	require.False(t, ValidateMsgAmendOrders(ctx, keeper, amendMsg).IsOK())
+	require.False(t, handler(ctx, amendMsg).Code.IsOK())
+
+	// the quantity is reduced in place
+	amendMsg = types.NewMsgAmendOrders(addrKeysSlice[0].Address,
+		[]types.AmendOrderItem{types.NewAmendOrderItem(orderID, "10.0", "1.0")})
+	require.True(t, ValidateMsgAmendOrders(ctx, keeper, amendMsg).IsOK())
+	require.True(t, handler(ctx, amendMsg).Code.IsOK())
+	order := keeper.GetOrder(ctx, orderID)
+	require.EqualValues(t, types.OrderStatusOpen, order.Status)
+	require.EqualValues(t, sdk.OneDec(), order.RemainQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10"), order.RemainLocked)
+	require.EqualValues(t, balance.Add(sdk.MustNewDecFromStr("10")), getBalance())
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.OneDec(), book.Items[0].BuyQuantity)
+
+	// the order is replaced by a new order with the new price
+	amendMsg = types.NewMsgAmendOrders(addrKeysSlice[0].Address,
+		[]types.AmendOrderItem{types.NewAmendOrderItem(orderID, "9.0", "1.0")})
+	require.True(t, handler(ctx, amendMsg).Code.IsOK())
+	order = keeper.GetOrder(ctx, orderID)
+	newOrderID := types.FormatOrderID(10, 2)
+	require.EqualValues(t, types.OrderStatusCancelled, order.Status)
+	require.EqualValues(t, newOrderID, order.GetExtraInfoWithKey(types.OrderExtraInfoKeyAmendedOrderID))
+	newOrder := keeper.GetOrder(ctx, newOrderID)
+	require.EqualValues(t, types.OrderStatusOpen, newOrder.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("9"), newOrder.Price)
+	require.EqualValues(t, balance.Add(sdk.MustNewDecFromStr("11")), getBalance())
+	book = keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("9"), book.Items[0].Price)
+
+	// the cancelled order and the price over accuracy can't be amended
+	require.False(t, handler(ctx, amendMsg).Code.IsOK())
+	amendMsg = types.NewMsgAmendOrders(addrKeysSlice[0].Address,
+		[]types.AmendOrderItem{types.NewAmendOrderItem(newOrderID, "9.123", "1.0")})
+	require.False(t, handler(ctx, amendMsg).Code.IsOK())
+
+	// the filled quantity is deducted from the new order, and the expiry is kept
+	newOrder.Fill(newOrder.Price, sdk.MustNewDecFromStr("0.4"))
+	keeper.SetOrder(ctx, newOrderID, newOrder)
+	amendMsg = types.NewMsgAmendOrders(addrKeysSlice[0].Address,
+		[]types.AmendOrderItem{types.NewAmendOrderItem(newOrderID, "8.0", "0.4")})
+	require.False(t, ValidateMsgAmendOrders(ctx, keeper, amendMsg).IsOK())
+	require.False(t, handler(ctx, amendMsg).Code.IsOK())
+	amendMsg = types.NewMsgAmendOrders(addrKeysSlice[0].Address,
+		[]types.AmendOrderItem{types.NewAmendOrderItem(newOrderID, "8.0", "1.0")})
+	require.True(t, ValidateMsgAmendOrders(ctx, keeper, amendMsg).IsOK())
+	require.True(t, handler(ctx, amendMsg).Code.IsOK())
+	newOrder = keeper.GetOrder(ctx, types.FormatOrderID(10, 3))
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.6"), newOrder.Quantity)
+	require.EqualValues(t, 10+feeParams.OrderExpireBlocks, newOrder.ExpireHeight)
+}
+
+func TestHandleMsgRouteOrder(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 3, 10000)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	k := mapp.orderKeeper
+	feeParams := types.DefaultTestParams()
+	k.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	tokenPair.MaxPriceDigit = 2
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// bids of 10 at 10.0 and 20 at 9.0, and the pool of 1000xxb and 10000okt
+	handler := NewOrderHandler(k)
+	msg := types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "10"),
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.0", "20"),
+	})
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	swapKeeper := keeper.NewMockSwapKeeper(mapp.tokenKeeper, addrKeysSlice[2].Address)
+	swapKeeper.SetPool(sdk.NewDecCoin(common.TestToken, sdk.NewInt(1000)),
+		sdk.NewDecCoin(common.NativeToken, sdk.NewInt(10000)))
+	k.SetSwapKeeper(swapKeeper)
+	handler = NewOrderHandler(k)
+
+	// the min output is more than the estimated output
+	sender := addrKeysSlice[0].Address
+	routeMsg := types.NewMsgRouteOrder(sender, types.TestTokenPair, types.SellOrder, sdk.NewDec(30), sdk.NewDec(300))
+	require.False(t, ValidateMsgRouteOrder(ctx, k, routeMsg).IsOK())
+	require.False(t, handler(ctx, routeMsg).Code.IsOK())
+
+	// the swap part doesn't reach its share of the min output, and neither does a single venue reach it
+	route, err := k.GetBestOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(30))
+	require.Nil(t, err)
+	require.True(t, route.SwapInput.IsPositive() && route.BookInput.IsPositive())
+	require.True(t, route.SwapOutput.Amount.LT(route.Output().Mul(route.SwapInput.Amount).QuoInt64(30)))
+	routeMsg = types.NewMsgRouteOrder(sender, types.TestTokenPair, types.SellOrder, sdk.NewDec(30), route.Output())
+	require.False(t, ValidateMsgRouteOrder(ctx, k, routeMsg).IsOK())
+	require.False(t, handler(ctx, routeMsg).Code.IsOK())
+
+	// the pool part is swapped immediately, and the book part is placed as a FOK order at the first level, bounded
+	// by the rest of the min output
+	minOutput := sdk.NewDec(290)
+	require.True(t, route.SwapOutput.Amount.GTE(minOutput.Mul(route.SwapInput.Amount).QuoInt64(30)))
+	routeMsg = types.NewMsgRouteOrder(sender, types.TestTokenPair, types.SellOrder, sdk.NewDec(30), minOutput)
+	require.True(t, ValidateMsgRouteOrder(ctx, k, routeMsg).IsOK())
+	require.True(t, handler(ctx, routeMsg).Code.IsOK())
+	order := k.GetOrder(ctx, types.FormatOrderID(10, 3))
+	require.NotNil(t, order)
+	require.EqualValues(t, types.TimeInForceFOK, order.TimeInForce)
+	require.EqualValues(t, sdk.NewDec(10), order.Price)
+	require.True(t, order.Price.Mul(order.Quantity).Add(route.SwapOutput.Amount).GTE(minOutput))
+	require.EqualValues(t, route.BookInput.Amount, order.Quantity)
+	pool, err := swapKeeper.GetSwapTokenPair(ctx, types.TestTokenPair)
+	require.Nil(t, err)
+	require.EqualValues(t, sdk.NewDec(1000).Add(route.SwapInput.Amount), pool.BasePooledCoin.Amount)
+
+	// the part executed is no worse than the min output even if the order isn't filled
+	cancelMsg := types.NewMsgCancelOrders(addrKeysSlice[1].Address,
+		[]string{types.FormatOrderID(10, 1), types.FormatOrderID(10, 2)})
+	require.True(t, handler(ctx, cancelMsg).Code.IsOK())
+	EndBlocker(ctx, k)
+	order = k.GetOrder(ctx, types.FormatOrderID(10, 3))
+	require.EqualValues(t, types.OrderStatusFOKKilled, order.Status)
+	balance := mapp.AccountKeeper.GetAccount(ctx, sender).GetCoins()
+	received := balance.AmountOf(common.NativeToken).Sub(sdk.NewDec(10000))
+	require.True(t, received.GTE(minOutput.Mul(route.SwapInput.Amount).QuoInt64(30)), received.String())
+	require.EqualValues(t, sdk.NewDec(10000).Sub(route.SwapInput.Amount), balance.AmountOf(common.TestToken))
+}
+
+func TestHandleMsgRouteOrderWithMinOutput(t *testing.T) {
+	mapp, addrKeysSlice := getMockAppWithBalance(t, 3, 10000)
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	k := mapp.orderKeeper
+	feeParams := types.DefaultTestParams()
+	k.SetParams(ctx, &feeParams)
+
+	tokenPair := dex.GetBuiltInTokenPair()
+	tokenPair.MaxPriceDigit = 2
+	err := mapp.dexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// bids of 10 at 11.0 and 100 at 9.7, and the pool of 1000xxb and 10000okt
+	handler := NewOrderHandler(k)
+	msg := types.NewMsgNewOrders(addrKeysSlice[1].Address, []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "11.0", "10"),
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "9.7", "100"),
+	})
+	require.True(t, handler(ctx, msg).Code.IsOK())
+	swapKeeper := keeper.NewMockSwapKeeper(mapp.tokenKeeper, addrKeysSlice[2].Address)
+	swapKeeper.SetPool(sdk.NewDecCoin(common.TestToken, sdk.NewInt(1000)),
+		sdk.NewDecCoin(common.NativeToken, sdk.NewInt(10000)))
+	k.SetSwapKeeper(swapKeeper)
+	handler = NewOrderHandler(k)
+	sender := addrKeysSlice[0].Address
+
+	// the swap part of the split doesn't reach its share of the min output, the depth book alone reaches it
+	route, err := k.GetBestOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(30))
+	require.Nil(t, err)
+	require.True(t, route.SwapInput.IsPositive() && route.BookInput.IsPositive())
+	minOutput := sdk.NewDec(303)
+	require.True(t, route.SwapOutput.Amount.LT(minOutput.Mul(route.SwapInput.Amount).QuoInt64(30)))
+	bookRoute, err := k.GetBestSingleVenueOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(30))
+	require.Nil(t, err)
+	require.True(t, bookRoute.SwapInput.IsZero())
+	require.True(t, bookRoute.Output().GTE(minOutput))
+
+	// the msg falls back to the depth book
+	routeMsg := types.NewMsgRouteOrder(sender, types.TestTokenPair, types.SellOrder, sdk.NewDec(30), minOutput)
+	require.True(t, ValidateMsgRouteOrder(ctx, k, routeMsg).IsOK())
+	require.True(t, handler(ctx, routeMsg).Code.IsOK())
+	order := k.GetOrder(ctx, types.FormatOrderID(10, 3))
+	require.NotNil(t, order)
+	require.EqualValues(t, types.TimeInForceFOK, order.TimeInForce)
+	require.EqualValues(t, sdk.NewDec(30), order.Quantity)
+	pool, err := swapKeeper.GetSwapTokenPair(ctx, types.TestTokenPair)
+	require.Nil(t, err)
+	require.EqualValues(t, sdk.NewDec(1000), pool.BasePooledCoin.Amount)
+
+	EndBlocker(ctx, k)
+	ctx = ctx.WithBlockHeight(11)
+	BeginBlocker(ctx, k)
+
+	// the split route reaches the min output once the order of the book part is filled
+	route, err = k.GetBestOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(20))
+	require.Nil(t, err)
+	require.True(t, route.SwapInput.IsPositive() && route.BookInput.IsPositive())
+	minOutput = sdk.NewDec(196)
+	require.True(t, route.SwapOutput.Amount.GTE(minOutput.Mul(route.SwapInput.Amount).QuoInt64(20)))
+	balanceBefore := mapp.AccountKeeper.GetAccount(ctx, sender).GetCoins().AmountOf(common.NativeToken)
+	routeMsg = types.NewMsgRouteOrder(sender, types.TestTokenPair, types.SellOrder, sdk.NewDec(20), minOutput)
+	require.True(t, handler(ctx, routeMsg).Code.IsOK())
+	EndBlocker(ctx, k)
+	order = k.GetOrder(ctx, types.FormatOrderID(11, 1))
+	require.NotNil(t, order)
+	require.EqualValues(t, types.OrderStatusFilled, order.Status)
+	received := mapp.AccountKeeper.GetAccount(ctx, sender).GetCoins().AmountOf(common.NativeToken).Sub(balanceBefore)
+	require.True(t, received.GTE(minOutput), received.String())
+}
+
 func TestValidateMsgNewOrder(t *testing.T) {
 	mapp, addrKeysSlice := getMockApp(t, 1)
 	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
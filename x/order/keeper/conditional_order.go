@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/order/types"
+	token "github.com/okex/okexchain/x/token/types"
+)
+
+// PlaceConditionalOrder locks the coins of the conditional order, assigns a new order id to it,
+// and saves it with its trigger index and expire index
+func (k Keeper) PlaceConditionalOrder(ctx sdk.Context, order *types.ConditionalOrder) error {
+	order.Locked = order.NeedLockCoins()
+	if err := k.LockCoins(ctx, order.Sender, order.Locked, token.LockCoinsTypeQuantity); err != nil {
+		return err
+	}
+
+	seq := k.GetConditionalOrderSeq(ctx) + 1
+	k.SetConditionalOrderSeq(ctx, seq)
+	order.OrderID = types.FormatConditionalOrderID(seq)
+	k.SetConditionalOrder(ctx, order)
+	return nil
+}
+
+// SetConditionalOrder saves the conditional order with its trigger index and expire index
+func (k Keeper) SetConditionalOrder(ctx sdk.Context, order *types.ConditionalOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Set(types.GetConditionalOrderKey(order.OrderID), k.cdc.MustMarshalBinaryBare(order))
+	store.Set(types.GetTriggerIndexKey(order.Item.Product, order.TriggerDirection(), order.Item.TriggerPrice,
+		order.OrderID), []byte(order.OrderID))
+	store.Set(types.GetConditionalOrderExpireKey(order.ExpireHeight, order.OrderID), []byte(order.OrderID))
+}
+
+// GetConditionalOrderSeq gets the sequence of the last conditional order
+func (k Keeper) GetConditionalOrderSeq(ctx sdk.Context) int64 {
+	bz := ctx.KVStore(k.orderStoreKey).Get(types.ConditionalOrderSeqKey)
+	if bz == nil {
+		return 0
+	}
+	return common.BytesToInt64(bz)
+}
+
+// SetConditionalOrderSeq sets the sequence of the last conditional order
+func (k Keeper) SetConditionalOrderSeq(ctx sdk.Context, seq int64) {
+	ctx.KVStore(k.orderStoreKey).Set(types.ConditionalOrderSeqKey, common.Int64ToBytes(seq))
+}
+
+// GetConditionalOrder gets the conditional order which hasn't been triggered or cancelled
+func (k Keeper) GetConditionalOrder(ctx sdk.Context, orderID string) *types.ConditionalOrder {
+	bz := ctx.KVStore(k.orderStoreKey).Get(types.GetConditionalOrderKey(orderID))
+	if bz == nil {
+		return nil
+	}
+	order := &types.ConditionalOrder{}
+	k.cdc.MustUnmarshalBinaryBare(bz, order)
+	return order
+}
+
+// RemoveConditionalOrder removes the conditional order and its indexes, and unlocks its coins
+func (k Keeper) RemoveConditionalOrder(ctx sdk.Context, order *types.ConditionalOrder) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetConditionalOrderKey(order.OrderID))
+	store.Delete(types.GetTriggerIndexKey(order.Item.Product, order.TriggerDirection(), order.Item.TriggerPrice,
+		order.OrderID))
+	store.Delete(types.GetConditionalOrderExpireKey(order.ExpireHeight, order.OrderID))
+	k.UnlockCoins(ctx, order.Sender, order.Locked, token.LockCoinsTypeQuantity)
+}
+
+// GetTriggeredConditionalOrders returns the conditional orders of the product triggered at the last price,
+// in the sequence of their placement. Only the crossed prices of the trigger index are scanned
+func (k Keeper) GetTriggeredConditionalOrders(ctx sdk.Context, product string, lastPrice sdk.Dec) []*types.ConditionalOrder {
+	if !lastPrice.IsPositive() {
+		return nil
+	}
+	store := ctx.KVStore(k.orderStoreKey)
+	fallPrefix := types.GetTriggerIndexPrefix(product, types.TriggerDirectionFall)
+	risePrefix := types.GetTriggerIndexPrefix(product, types.TriggerDirectionRise)
+	iterators := []sdk.Iterator{
+		// trigger prices not lower than the last price
+		store.Iterator(types.GetTriggerPriceKey(product, types.TriggerDirectionFall, lastPrice),
+			sdk.PrefixEndBytes(fallPrefix)),
+		// trigger prices not higher than the last price
+		store.Iterator(risePrefix,
+			sdk.PrefixEndBytes(types.GetTriggerPriceKey(product, types.TriggerDirectionRise, lastPrice))),
+	}
+
+	var orders []*types.ConditionalOrder
+	for _, iter := range iterators {
+		for ; iter.Valid(); iter.Next() {
+			if order := k.GetConditionalOrder(ctx, string(iter.Value())); order != nil {
+				orders = append(orders, order)
+			}
+		}
+		iter.Close()
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].OrderID < orders[j].OrderID
+	})
+	return orders
+}
+
+// RemoveExpiredConditionalOrders removes the conditional orders expired until the block height,
+// and unlocks their coins
+func (k Keeper) RemoveExpiredConditionalOrders(ctx sdk.Context) {
+	store := ctx.KVStore(k.orderStoreKey)
+	iter := store.Iterator(types.ConditionalOrderExpireKey,
+		sdk.PrefixEndBytes(types.GetConditionalOrderExpirePrefix(ctx.BlockHeight())))
+	var orderIDs []string
+	for ; iter.Valid(); iter.Next() {
+		orderIDs = append(orderIDs, string(iter.Value()))
+	}
+	iter.Close()
+
+	for _, orderID := range orderIDs {
+		if order := k.GetConditionalOrder(ctx, orderID); order != nil {
+			k.RemoveConditionalOrder(ctx, order)
+			ctx.Logger().With("module", "order").Debug(fmt.Sprintf("conditional order(%s) expired", orderID))
+		}
+	}
+}
+
+// IterateConditionalOrders iterates the conditional orders by the trigger index, ordered by product,
+// direction and trigger price, stops when the handler returns true
+func (k Keeper) IterateConditionalOrders(ctx sdk.Context, handler func(order *types.ConditionalOrder) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.orderStoreKey), types.TriggerIndexKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		order := k.GetConditionalOrder(ctx, string(iter.Value()))
+		if order != nil && handler(order) {
+			return
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/order/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestKeeper_ConditionalOrder(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	addr := testInput.TestAddrs[0]
+	balance := keeper.tokenKeeper.GetCoins(ctx, addr)
+
+	newItem := func(side, condition, triggerPrice string) types.OrderItem {
+		item := types.NewOrderItem(types.TestTokenPair, side, "9.0", "1.0")
+		item.Condition = condition
+		item.TriggerPrice = sdk.MustNewDecFromStr(triggerPrice)
+		return item
+	}
+	items := []types.OrderItem{
+		newItem(types.SellOrder, types.ConditionStopLoss, "9.5"),    // falls to 9.5
+		newItem(types.SellOrder, types.ConditionStopLoss, "10.5"),   // falls to 10.5
+		newItem(types.BuyOrder, types.ConditionStopLoss, "11.0"),    // rises to 11.0
+		newItem(types.SellOrder, types.ConditionTakeProfit, "12.0"), // rises to 12.0
+	}
+	var orders []*types.ConditionalOrder
+	for _, item := range items {
+		order := types.NewConditionalOrder("", addr, item, "1", 0)
+		order.ExpireHeight = 20
+		require.NoError(t, keeper.PlaceConditionalOrder(ctx, order))
+		orders = append(orders, order)
+	}
+	require.EqualValues(t, types.FormatConditionalOrderID(1), orders[0].OrderID)
+	require.EqualValues(t, types.FormatConditionalOrderID(4), orders[3].OrderID)
+	require.EqualValues(t, orders[1], keeper.GetConditionalOrder(ctx, orders[1].OrderID))
+
+	// the coins of the orders are locked
+	locked := sdk.NewDecCoinsFromDec(common.TestToken, sdk.NewDec(3)).Add(
+		sdk.NewDecCoinsFromDec(common.NativeToken, sdk.NewDec(9)))
+	require.EqualValues(t, balance.Sub(locked), keeper.tokenKeeper.GetCoins(ctx, addr))
+
+	// query the conditional order as an order detail
+	querier := NewQuerier(keeper)
+	bz, err := querier(ctx, []string{types.QueryOrderDetail, orders[1].OrderID}, abci.RequestQuery{})
+	require.Nil(t, err)
+	require.Contains(t, string(bz), types.ConditionStopLoss)
+
+	// only the crossed trigger prices are triggered
+	tests := []struct {
+		lastPrice string
+		triggered []*types.ConditionalOrder
+	}{
+		{"10.0", []*types.ConditionalOrder{orders[1]}},
+		{"9.5", []*types.ConditionalOrder{orders[0], orders[1]}},
+		{"11.0", []*types.ConditionalOrder{orders[2]}},
+		{"12.5", []*types.ConditionalOrder{orders[2], orders[3]}},
+		{"0", nil},
+	}
+	for _, test := range tests {
+		require.EqualValues(t, test.triggered,
+			keeper.GetTriggeredConditionalOrders(ctx, types.TestTokenPair, sdk.MustNewDecFromStr(test.lastPrice)))
+	}
+
+	// removed with its coins unlocked
+	keeper.RemoveConditionalOrder(ctx, orders[1])
+	require.Nil(t, keeper.GetConditionalOrder(ctx, orders[1].OrderID))
+	var orderIDs []string
+	keeper.IterateConditionalOrders(ctx, func(order *types.ConditionalOrder) bool {
+		orderIDs = append(orderIDs, order.OrderID)
+		return false
+	})
+	require.EqualValues(t, []string{orders[0].OrderID, orders[2].OrderID, orders[3].OrderID}, orderIDs)
+	require.Empty(t, keeper.GetTriggeredConditionalOrders(ctx, types.TestTokenPair, sdk.MustNewDecFromStr("10.0")))
+
+	// expired
+	keeper.RemoveExpiredConditionalOrders(ctx.WithBlockHeight(19))
+	require.NotNil(t, keeper.GetConditionalOrder(ctx, orders[0].OrderID))
+	keeper.RemoveExpiredConditionalOrders(ctx.WithBlockHeight(20))
+	for _, order := range orders {
+		require.Nil(t, keeper.GetConditionalOrder(ctx, order.OrderID))
+	}
+	require.EqualValues(t, balance, keeper.tokenKeeper.GetCoins(ctx, addr))
+}
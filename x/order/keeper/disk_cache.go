@@ -234,3 +234,19 @@ func (c *DiskCache) removeOrder(order *types.Order) {
 
 	c.closeOrder(order.OrderID)
 }
+
+// reduceOrder reduces the quantity of an order in depth book, the order keeps its position in orderIDsMap
+func (c *DiskCache) reduceOrder(order *types.Order, quantity sdk.Dec) {
+	depthBook := c.getDepthBook(order.Product)
+	if depthBook == nil {
+		return
+	}
+	bookLen := len(depthBook.Items)
+	index := sort.Search(bookLen, func(i int) bool {
+		return order.Price.GTE(depthBook.Items[i].Price)
+	})
+	if index < bookLen && depthBook.Items[index].Price.Equal(order.Price) {
+		depthBook.Sub(index, quantity, order.Side)
+		c.setDepthBook(order.Product, depthBook)
+	}
+}
@@ -40,6 +40,25 @@ func (k Keeper) DropExpireBlockHeight(ctx sdk.Context, blockHeight int64) {
 	store.Delete(key)
 }
 
+// AddCustomExpireBlockHeight adds the block height of the orders to the custom expiry index of the expire height,
+// the orders of the block height will be checked for their custom expiry at the expire height. Unlike
+// ExpireBlockHeight, the index doesn't drop the OrderNum of the block height, as its other orders are still open
+func (k Keeper) AddCustomExpireBlockHeight(ctx sdk.Context, expireHeight int64, blockHeight int64) {
+	blockHeights := k.GetCustomExpireBlockHeight(ctx, expireHeight)
+	for _, height := range blockHeights {
+		if height == blockHeight {
+			return
+		}
+	}
+	ctx.KVStore(k.orderStoreKey).Set(types.GetCustomExpireBlockHeightKey(expireHeight),
+		k.cdc.MustMarshalBinaryBare(append(blockHeights, blockHeight)))
+}
+
+// DropCustomExpireBlockHeight deletes the custom expiry index of the expire height
+func (k Keeper) DropCustomExpireBlockHeight(ctx sdk.Context, expireHeight int64) {
+	ctx.KVStore(k.orderStoreKey).Delete(types.GetCustomExpireBlockHeightKey(expireHeight))
+}
+
 // ===============================================
 // nolint
 func (k Keeper) SetOrder(ctx sdk.Context, orderID string, order *types.Order) {
@@ -131,6 +150,35 @@ func (k Keeper) SetLastClosedOrderIDs(ctx sdk.Context, orderIDs []string) {
 	store.Set(types.RecentlyClosedOrderIDsKey, k.cdc.MustMarshalJSON(orderIDs)) //recentlyClosedOrderIDs
 }
 
+// SetContinuousAuctionCursor sets ContinuousAuctionCursor to keeper, deletes it if orderID is empty
+func (k Keeper) SetContinuousAuctionCursor(ctx sdk.Context, orderID string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	if orderID == "" {
+		store.Delete(types.ContinuousAuctionCursorKey)
+		return
+	}
+	store.Set(types.ContinuousAuctionCursorKey, []byte(orderID))
+}
+
+// SetImmediateOrderIDs sets the ids of the market, IOC and FOK orders which haven't been closed
+func (k Keeper) SetImmediateOrderIDs(ctx sdk.Context, orderIDs []string) {
+	k.setOrderIDList(ctx, types.ImmediateOrderIDsKey, orderIDs)
+}
+
+// SetPostOnlyOrderIDs sets the ids of the post-only orders placed in current block
+func (k Keeper) SetPostOnlyOrderIDs(ctx sdk.Context, orderIDs []string) {
+	k.setOrderIDList(ctx, types.PostOnlyOrderIDsKey, orderIDs)
+}
+
+func (k Keeper) setOrderIDList(ctx sdk.Context, key []byte, orderIDs []string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	if len(orderIDs) == 0 {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, k.cdc.MustMarshalJSON(orderIDs))
+}
+
 // SetOrderIDs sets OrderIDs to diskCache
 func (k Keeper) SetOrderIDs(key string, orderIDs []string) {
 	k.diskCache.setOrderIDs(key, orderIDs)
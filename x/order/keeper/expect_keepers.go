@@ -4,6 +4,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/supply/exported"
 
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
 	dex "github.com/okex/okexchain/x/dex/types"
 	"github.com/okex/okexchain/x/order/types"
 	token "github.com/okex/okexchain/x/token/types"
@@ -17,10 +18,14 @@ type TokenKeeper interface {
 	UnlockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins, lockCoinsType int) error
 	BalanceAccount(ctx sdk.Context, addr sdk.AccAddress, outputCoins sdk.DecCoins, inputCoins sdk.DecCoins) error
 	SendCoinsFromAccountToAccount(ctx sdk.Context, from, to sdk.AccAddress, amt sdk.DecCoins) error
+	CheckCoinsTransferable(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins) sdk.Error
+	GetTokenInfo(ctx sdk.Context, symbol string) token.Token
 	// Fee detail
 	AddFeeDetail(ctx sdk.Context, from string, fee sdk.DecCoins, feeType string, receiver string)
 	GetAllLockedCoins(ctx sdk.Context) (locks []token.AccCoins)
 	IterateLockedFees(ctx sdk.Context, cb func(acc sdk.AccAddress, coins sdk.DecCoins) (stop bool))
+	IterateVestingCoins(ctx sdk.Context, cb func(acc sdk.AccAddress, coins sdk.DecCoins) (stop bool))
+	IterateHTLCs(ctx sdk.Context, cb func(htlc *token.HTLC) (stop bool))
 }
 
 // SupplyKeeper : expected supply keeper
@@ -50,3 +55,11 @@ type DexKeeper interface {
 	IsAnyProductLocked(ctx sdk.Context) bool
 	GetOperator(ctx sdk.Context, addr sdk.AccAddress) (operator dex.DEXOperator, isExist bool)
 }
+
+// SwapKeeper : expected ammswap keeper
+type SwapKeeper interface {
+	GetSwapTokenPair(ctx sdk.Context, tokenPairName string) (ammswap.SwapTokenPair, error)
+	GetParams(ctx sdk.Context) ammswap.Params
+	SwapTokens(ctx sdk.Context, swapTokenPair ammswap.SwapTokenPair, soldToken, boughtToken sdk.DecCoin,
+		sender, recipient sdk.AccAddress) sdk.Error
+}
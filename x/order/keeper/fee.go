@@ -15,6 +15,7 @@ const minFee = "0.00000001"
 // GetFeeKeeper is an interface for calculating handling fees
 type GetFeeKeeper interface {
 	GetLastPrice(ctx sdk.Context, product string) sdk.Dec
+	GetTradeVolume(ctx sdk.Context, addr sdk.AccAddress, days int64) sdk.Dec
 }
 
 // GetOrderNewFee is used to calculate the handling fee that needs to be locked when placing an order
@@ -46,9 +47,10 @@ func GetZeroFee() sdk.DecCoins {
 	return sdk.DecCoins{sdk.ZeroFee()}
 }
 
-// GetDealFee is used to calculate the handling fee when matching an order
+// GetDealFee is used to calculate the handling fee when matching an order, at the maker or taker fee rate
+// in the fee schedule
 func GetDealFee(order *types.Order, fillAmt sdk.Dec, ctx sdk.Context, keeper GetFeeKeeper,
-	feeParams *types.Params) sdk.DecCoins {
+	feeParams *types.Params, isMaker bool) sdk.DecCoins {
 	symbols := strings.Split(order.Product, "_")
 	symbol := symbols[0]
 	quantity := fillAmt
@@ -57,7 +59,8 @@ func GetDealFee(order *types.Order, fillAmt sdk.Dec, ctx sdk.Context, keeper Get
 		quantity = fillAmt.Mul(keeper.GetLastPrice(ctx, order.Product))
 	}
 
-	feeAmt := quantity.Mul(feeParams.TradeFeeRate)
+	volume := keeper.GetTradeVolume(ctx, order.Sender, feeParams.FeeVolumeDays)
+	feeAmt := quantity.Mul(feeParams.GetFeeRate(order.Product, isMaker, volume))
 	if feeAmt.IsPositive() {
 		return sdk.DecCoins{sdk.NewDecCoinFromDec(symbol, feeAmt)}
 	}
@@ -13,12 +13,13 @@ import (
 )
 
 type MockGetFeeKeeper struct {
-	coins    sdk.Coins
-	priceMap map[string]sdk.Dec
+	coins     sdk.Coins
+	priceMap  map[string]sdk.Dec
+	volumeMap map[string]sdk.Dec
 }
 
 func NewMockGetFeeKeeper() MockGetFeeKeeper {
-	return MockGetFeeKeeper{sdk.NewCoins(), make(map[string]sdk.Dec)}
+	return MockGetFeeKeeper{sdk.NewCoins(), make(map[string]sdk.Dec), make(map[string]sdk.Dec)}
 }
 
 func (k MockGetFeeKeeper) GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
@@ -32,6 +33,13 @@ func (k MockGetFeeKeeper) GetLastPrice(ctx sdk.Context, product string) sdk.Dec
 	return sdk.ZeroDec()
 }
 
+func (k MockGetFeeKeeper) GetTradeVolume(ctx sdk.Context, addr sdk.AccAddress, days int64) sdk.Dec {
+	if volume, ok := k.volumeMap[addr.String()]; ok {
+		return volume
+	}
+	return sdk.ZeroDec()
+}
+
 func TestGetOrderNewFee(t *testing.T) {
 	order := mockOrder("ID0000001970-1", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
 	orderExpireBlocks := sdk.NewDec(order.OrderExpireBlocks)
@@ -80,7 +88,7 @@ func TestOrderDealFee(t *testing.T) {
 		Quantity: sdk.MustNewDecFromStr("100.0"),
 	}
 	keeper.priceMap[types.TestTokenPair] = sdk.MustNewDecFromStr("10.0")
-	feeOther := GetDealFee(order, sdk.MustNewDecFromStr("10.0"), ctx, keeper, &feeParams)
+	feeOther := GetDealFee(order, sdk.MustNewDecFromStr("10.0"), ctx, keeper, &feeParams, false)
 	// 10 * 0.001
 	expectFee := sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("0.01"))}
 	require.EqualValues(t, expectFee, feeOther)
@@ -95,7 +103,7 @@ func TestOrderDealFee(t *testing.T) {
 	keeper.priceMap["xxb_yyb"] = sdk.MustNewDecFromStr("20.0")
 	keeper.priceMap["yyb_"+common.NativeToken] = sdk.MustNewDecFromStr("0.6")
 
-	feeOther = GetDealFee(order, sdk.MustNewDecFromStr("100.0"), ctx, keeper, &feeParams)
+	feeOther = GetDealFee(order, sdk.MustNewDecFromStr("100.0"), ctx, keeper, &feeParams, false)
 	// 100 * 0.001
 	expectFee = sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("0.1"))}
 	require.EqualValues(t, expectFee, feeOther)
@@ -107,7 +115,7 @@ func TestOrderDealFee(t *testing.T) {
 		Price:    sdk.MustNewDecFromStr("11.0"),
 		Quantity: sdk.MustNewDecFromStr("100.0"),
 	}
-	feeOther = GetDealFee(order, sdk.MustNewDecFromStr("100.0"), ctx, keeper, &feeParams)
+	feeOther = GetDealFee(order, sdk.MustNewDecFromStr("100.0"), ctx, keeper, &feeParams, false)
 	// 100 * 20 * 0.001
 	expectFee = sdk.DecCoins{sdk.NewDecCoinFromDec("yyb", sdk.MustNewDecFromStr("2.0"))}
 	require.EqualValues(t, expectFee, feeOther)
@@ -119,7 +127,49 @@ func TestOrderDealFee(t *testing.T) {
 		Price:    sdk.MustNewDecFromStr("1.0"),
 		Quantity: sdk.MustNewDecFromStr("0.00000001"),
 	}
-	feeOther = GetDealFee(order, sdk.MustNewDecFromStr("0.00000001"), ctx, keeper, &feeParams)
+	feeOther = GetDealFee(order, sdk.MustNewDecFromStr("0.00000001"), ctx, keeper, &feeParams, false)
 	expectFee = sdk.DecCoins{sdk.NewDecCoinFromDec("xxb", sdk.MustNewDecFromStr("0.00000001"))}
 	require.EqualValues(t, expectFee, feeOther)
 }
+
+func TestOrderDealFeeSchedule(t *testing.T) {
+	ctx := sdk.Context{}
+	keeper := NewMockGetFeeKeeper()
+	feeParams := types.DefaultTestParams()
+	feeParams.MakerFeeRate = sdk.MustNewDecFromStr("0.0005")
+	feeParams.FeeTiers = types.FeeTiers{
+		types.NewFeeTier(sdk.NewDec(10000), sdk.MustNewDecFromStr("0.2")),
+		types.NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("0.1")),
+	}
+	feeParams.PairFeeRates = []types.PairFee{
+		types.NewPairFee("xxb_yyb", sdk.ZeroDec(), sdk.MustNewDecFromStr("0.002")),
+	}
+
+	order := &types.Order{
+		Product: types.TestTokenPair,
+		Side:    types.BuyOrder,
+		Sender:  sdk.AccAddress([]byte("fee-schedule-address")),
+	}
+	fillAmt := sdk.NewDec(100)
+
+	// maker and taker rates without fee tier
+	require.EqualValues(t, sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("0.05"))},
+		GetDealFee(order, fillAmt, ctx, keeper, &feeParams, true))
+	require.EqualValues(t, sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("0.1"))},
+		GetDealFee(order, fillAmt, ctx, keeper, &feeParams, false))
+
+	// the highest tier reached is used
+	keeper.volumeMap[order.Sender.String()] = sdk.NewDec(20000)
+	require.EqualValues(t, sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("0.08"))},
+		GetDealFee(order, fillAmt, ctx, keeper, &feeParams, false))
+	keeper.volumeMap[order.Sender.String()] = sdk.NewDec(5000)
+	require.EqualValues(t, sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("0.09"))},
+		GetDealFee(order, fillAmt, ctx, keeper, &feeParams, false))
+
+	// the rates overridden by product, the min fee is charged at the zero rate
+	order.Product = "xxb_yyb"
+	require.EqualValues(t, sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr("0.18"))},
+		GetDealFee(order, fillAmt, ctx, keeper, &feeParams, false))
+	require.EqualValues(t, sdk.DecCoins{sdk.NewDecCoinFromDec(common.TestToken, sdk.MustNewDecFromStr(minFee))},
+		GetDealFee(order, fillAmt, ctx, keeper, &feeParams, true))
+}
@@ -6,6 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okexchain/x/order/types"
 	"github.com/okex/okexchain/x/token"
+	tokentypes "github.com/okex/okexchain/x/token/types"
 )
 
 // RegisterInvariants registers all order invariants
@@ -14,10 +15,10 @@ func RegisterInvariants(ir sdk.InvariantRegistry, keeper Keeper) {
 }
 
 // ModuleAccountInvariant checks that the module account coins reflects the sum of
-// locks amounts held on store
+// locks amounts held on store, including the coins locked by the vesting schedules and the open htlcs
 func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
 	return func(ctx sdk.Context) (string, bool) {
-		var lockedCoins, lockedFees, orderLockedFees sdk.DecCoins
+		var lockedCoins, lockedFees, orderLockedFees, vestingCoins, htlcCoins sdk.DecCoins
 
 		for _, accCoins := range keeper.tokenKeeper.GetAllLockedCoins(ctx) {
 			lockedCoins = lockedCoins.Add(accCoins.Coins)
@@ -29,6 +30,20 @@ func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
 			return false
 		})
 
+		// lock vesting
+		keeper.tokenKeeper.IterateVestingCoins(ctx, func(acc sdk.AccAddress, coins sdk.DecCoins) bool {
+			vestingCoins = vestingCoins.Add(coins)
+			return false
+		})
+
+		// htlc escrow, the settled contracts hold nothing
+		keeper.tokenKeeper.IterateHTLCs(ctx, func(htlc *tokentypes.HTLC) bool {
+			if htlc.State == tokentypes.HTLCStateOpen {
+				htlcCoins = htlcCoins.Add(htlc.Amount)
+			}
+			return false
+		})
+
 		// get open orders lock fee
 		products := keeper.GetProductsFromDepthBookMap()
 		for _, product := range products {
@@ -52,9 +67,10 @@ func ModuleAccountInvariant(keeper Keeper) sdk.Invariant {
 		}
 
 		macc := keeper.supplyKeeper.GetModuleAccount(ctx, token.ModuleName)
-		broken := !macc.GetCoins().IsEqual(lockedCoins.Add(lockedFees))
+		locks := lockedCoins.Add(lockedFees).Add(vestingCoins).Add(htlcCoins)
+		broken := !macc.GetCoins().IsEqual(locks)
 		return sdk.FormatInvariant(types.ModuleName, "locks",
 			fmt.Sprintf("\ttoken ModuleAccount coins: %s\n\tsum of locks amounts:  %s\n",
-				macc.GetCoins(), lockedCoins.Add(lockedFees))), broken
+				macc.GetCoins(), locks)), broken
 	}
 }
@@ -3,6 +3,7 @@ package keeper
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	token "github.com/okex/okexchain/x/token/types"
 
@@ -89,6 +90,54 @@ func TestModuleAccountInvariant(t *testing.T) {
 	require.True(t, broken)
 }
 
+func TestModuleAccountInvariantWithVesting(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10).WithBlockTime(time.Unix(1600000000, 0))
+	invariant := ModuleAccountInvariant(keeper)
+
+	// the vesting coins are held by the token module account
+	vestingCoins := sdk.MustParseCoins(sdk.DefaultBondDenom, "10")
+	_, err := testInput.TokenKeeper.VestingSend(ctx, testInput.TestAddrs[0], testInput.TestAddrs[1], vestingCoins,
+		token.VestingTypeLinear, ctx.BlockTime(), ctx.BlockTime().Add(10*time.Hour))
+	require.NoError(t, err)
+	msg, broken := invariant(ctx)
+	require.False(t, broken)
+	require.Equal(t, invariantMsg(vestingCoins), msg)
+
+	// the released coins are no longer held
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(5 * time.Hour))
+	testInput.TokenKeeper.ReleaseVestedCoins(ctx)
+	msg, broken = invariant(ctx)
+	require.False(t, broken)
+	require.Equal(t, invariantMsg(sdk.MustParseCoins(sdk.DefaultBondDenom, "5")), msg)
+}
+
+func TestModuleAccountInvariantWithHTLC(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	invariant := ModuleAccountInvariant(keeper)
+
+	// the coins of the open htlc are held by the token module account
+	secret := make([]byte, token.SecretLength)
+	hashLock := token.GetHashLock(secret)
+	htlcCoins := sdk.MustParseCoins(sdk.DefaultBondDenom, "10")
+	_, err := testInput.TokenKeeper.CreateHTLC(ctx, testInput.TestAddrs[0], testInput.TestAddrs[1], htlcCoins,
+		hashLock, token.MinHTLCTimeLock)
+	require.NoError(t, err)
+	msg, broken := invariant(ctx)
+	require.False(t, broken)
+	require.Equal(t, invariantMsg(htlcCoins), msg)
+
+	// the claimed htlc no longer holds the coins
+	_, err = testInput.TokenKeeper.ClaimHTLC(ctx, hashLock, secret)
+	require.NoError(t, err)
+	msg, broken = invariant(ctx)
+	require.False(t, broken)
+	require.Equal(t, invariantMsg(nil), msg)
+}
+
 func invariantMsg(lockCoins sdk.DecCoins) string {
 	return sdk.FormatInvariant(types.ModuleName, "locks",
 		fmt.Sprintf("\ttoken ModuleAccount coins: %s\n\tsum of locks amounts:  %s\n",
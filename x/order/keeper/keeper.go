@@ -28,6 +28,8 @@ type Keeper struct {
 	paramSpace params.Subspace
 
 	dexKeeper DexKeeper
+	// optional, the smart order router only trades on the depth book without it
+	swapKeeper SwapKeeper
 
 	supplyKeeper     SupplyKeeper
 	feeCollectorName string
@@ -169,6 +171,16 @@ func (k Keeper) GetDexKeeper() DexKeeper {
 	return k.dexKeeper
 }
 
+// SetSwapKeeper sets the ammswap keeper for the smart order router
+func (k *Keeper) SetSwapKeeper(sk SwapKeeper) {
+	k.swapKeeper = sk
+}
+
+// GetSwapKeeper returns the ammswap keeper, it's nil if not set
+func (k Keeper) GetSwapKeeper() SwapKeeper {
+	return k.swapKeeper
+}
+
 // GetExpireBlockHeight gets a slice of ExpireBlockHeight from KVStore
 func (k Keeper) GetExpireBlockHeight(ctx sdk.Context, blockHeight int64) []int64 {
 	store := ctx.KVStore(k.orderStoreKey)
@@ -181,6 +193,17 @@ func (k Keeper) GetExpireBlockHeight(ctx sdk.Context, blockHeight int64) []int64
 	return expireBlockNumbers
 }
 
+// GetCustomExpireBlockHeight gets the block heights whose orders are checked for the custom expiry at the block height
+func (k Keeper) GetCustomExpireBlockHeight(ctx sdk.Context, blockHeight int64) []int64 {
+	bz := ctx.KVStore(k.orderStoreKey).Get(types.GetCustomExpireBlockHeightKey(blockHeight))
+	if bz == nil {
+		return []int64{}
+	}
+	var blockHeights []int64
+	k.cdc.MustUnmarshalBinaryBare(bz, &blockHeights)
+	return blockHeights
+}
+
 // GetOrder gets order from KVStore
 func (k Keeper) GetOrder(ctx sdk.Context, orderID string) *types.Order {
 	store := ctx.KVStore(k.orderStoreKey)
@@ -315,6 +338,18 @@ func (k Keeper) GetStoreOrderNum(ctx sdk.Context) int64 {
 	return common.BytesToInt64(numBytes)
 }
 
+// GetContinuousAuctionCursor gets ContinuousAuctionCursor from KVStore
+// ContinuousAuctionCursor is the id of the first order which hasn't been matched by the continuous auction
+// because of the deals limit per block, empty means all the orders have been matched
+func (k Keeper) GetContinuousAuctionCursor(ctx sdk.Context) string {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(types.ContinuousAuctionCursorKey)
+	if bz == nil {
+		return ""
+	}
+	return string(bz)
+}
+
 // GetUpdatedDepthbookKeys gets UpdatedDepthbookKeys from diskCache
 func (k Keeper) GetUpdatedDepthbookKeys() []string {
 	return k.diskCache.GetUpdatedDepthbookKeys()
@@ -352,6 +387,27 @@ func (k Keeper) GetLastClosedOrderIDs(ctx sdk.Context) []string {
 	return orderIDs
 }
 
+// GetImmediateOrderIDs gets the ids of the market, IOC and FOK orders which haven't been closed
+func (k Keeper) GetImmediateOrderIDs(ctx sdk.Context) []string {
+	return k.getOrderIDList(ctx, types.ImmediateOrderIDsKey)
+}
+
+// GetPostOnlyOrderIDs gets the ids of the post-only orders placed in current block
+func (k Keeper) GetPostOnlyOrderIDs(ctx sdk.Context) []string {
+	return k.getOrderIDList(ctx, types.PostOnlyOrderIDsKey)
+}
+
+func (k Keeper) getOrderIDList(ctx sdk.Context, key []byte) []string {
+	store := ctx.KVStore(k.orderStoreKey)
+	bz := store.Get(key)
+	orderIDs := []string{}
+	if bz == nil {
+		return orderIDs
+	}
+	k.cdc.MustUnmarshalJSON(bz, &orderIDs)
+	return orderIDs
+}
+
 // nolint
 func (k Keeper) GetBlockMatchResult() *types.BlockMatchResult {
 	return k.cache.getBlockMatchResult()
@@ -364,6 +420,13 @@ func (k Keeper) SetBlockMatchResult(result *types.BlockMatchResult) {
 	}
 }
 
+// AddBlockMatchResult adds the match results of a match engine into the match results of current block
+func (k Keeper) AddBlockMatchResult(result *types.BlockMatchResult) {
+	if k.enableBackend {
+		k.cache.addBlockMatchResult(result)
+	}
+}
+
 // LockCoins locks coins from the specified address,
 func (k Keeper) LockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins, lockCoinsType int) error {
 	if coins.IsZero() {
@@ -471,6 +534,14 @@ func (k Keeper) SetParams(ctx sdk.Context, params *types.Params) {
 	k.cache.SetParams(params)
 }
 
+// SetParamIfMissing sets the param if it doesn't exist in the store, the params added by a software upgrade
+// are set to their default values in this way at the upgrade height
+func (k Keeper) SetParamIfMissing(ctx sdk.Context, key []byte, value interface{}) {
+	if !k.paramSpace.Has(ctx, key) {
+		k.paramSpace.Set(ctx, key, value)
+	}
+}
+
 // nolint
 func (k Keeper) GetMetric() *monitor.OrderMetric {
 	return k.metric
@@ -53,6 +53,7 @@ func (k Keeper) DumpStore(ctx sdk.Context) {
 
 	var expireBlockNumbers []int64
 	dumpKvs(orderStore, types.ExpireBlockHeightKey, "ExpireBlockHeightKey", &expireBlockNumbers, unmarshalHandler, dumpIntHandler)
+	dumpKvs(orderStore, types.CustomExpireBlockHeightKey, "CustomExpireBlockHeightKey", &expireBlockNumbers, unmarshalHandler, dumpIntHandler)
 
 	dumpKv(orderStore, logger, types.LastExpiredBlockHeightKey, "LastExpiredBlockHeightKey")
 	dumpKv(orderStore, logger, types.OpenOrderNumKey, "OpenOrderNumKey")
@@ -51,6 +51,18 @@ func (c *Cache) setBlockMatchResult(result *types.BlockMatchResult) {
 	c.blockMatchResult = result
 }
 
+// addBlockMatchResult merges the match results of a match engine into the match results of current block
+func (c *Cache) addBlockMatchResult(result *types.BlockMatchResult) {
+	if c.blockMatchResult == nil || c.blockMatchResult.ResultMap == nil {
+		c.blockMatchResult = result
+		return
+	}
+	for product, matchResult := range result.ResultMap {
+		c.blockMatchResult.ResultMap[product] = matchResult
+	}
+	c.blockMatchResult.SelfTradeOrders = append(c.blockMatchResult.SelfTradeOrders, result.SelfTradeOrders...)
+}
+
 func (c *Cache) addTxHandlerMsgResult(resultSet bitset.BitSet) {
 	c.handlerTxMsgResult = append(c.handlerTxMsgResult, resultSet)
 }
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"fmt"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -13,6 +14,10 @@ import (
 // TryPlaceOrder tries to charge fee & lock coins for a new order
 func (k Keeper) TryPlaceOrder(ctx sdk.Context, order *types.Order) (fee sdk.DecCoins, err error) {
 	logger := ctx.Logger().With("module", "order")
+	if err = k.CheckOrderTransferable(ctx, order); err != nil {
+		logger.Info(fmt.Sprintf("place order failed: %v, %v", err, order))
+		return fee, err
+	}
 	// Trying to lock coins
 	needLockCoins := order.NeedLockCoins()
 	err = k.LockCoins(ctx, order.Sender, needLockCoins, token.LockCoinsTypeQuantity)
@@ -48,12 +53,52 @@ func (k Keeper) PlaceOrder(ctx sdk.Context, order *types.Order) error {
 
 	k.SetBlockOrderNum(ctx, blockHeight, orderNum+1)
 	k.SetOrder(ctx, order.OrderID, order)
+	if order.IsImmediate() {
+		k.SetImmediateOrderIDs(ctx, append(k.GetImmediateOrderIDs(ctx), order.OrderID))
+	} else if order.TimeInForce == types.TimeInForcePostOnly {
+		k.SetPostOnlyOrderIDs(ctx, append(k.GetPostOnlyOrderIDs(ctx), order.OrderID))
+	}
+	if order.ExpireHeight > 0 {
+		k.AddCustomExpireBlockHeight(ctx, order.ExpireHeight, blockHeight)
+	} else if order.ExpireTime > 0 {
+		// the expiry time is checked from the next block on, see DropExpiredOrdersByBlockHeight
+		k.AddCustomExpireBlockHeight(ctx, blockHeight+1, blockHeight)
+	}
 
 	// update depth book and orderIDsMap in cache
 	k.InsertOrderIntoDepthBook(order)
 	return nil
 }
 
+// CheckOrderTransferable returns an error if the sender of the order can't transfer the base or quote token of
+// its product, because the account is frozen or the transfers of the token are paused
+func (k Keeper) CheckOrderTransferable(ctx sdk.Context, order *types.Order) sdk.Error {
+	symbols := strings.Split(order.Product, "_")
+	coins := sdk.DecCoins{{Denom: symbols[0], Amount: sdk.ZeroDec()}, {Denom: symbols[1], Amount: sdk.ZeroDec()}}
+	return k.tokenKeeper.CheckCoinsTransferable(ctx, order.Sender, coins)
+}
+
+// IsProductFreezable returns true if the base or quote token of the product is freezable,
+// only the orders of such products may become unable to be filled after they are placed
+func (k Keeper) IsProductFreezable(ctx sdk.Context, product string) bool {
+	for _, symbol := range strings.Split(product, "_") {
+		if k.tokenKeeper.GetTokenInfo(ctx, symbol).Freezable {
+			return true
+		}
+	}
+	return false
+}
+
+// ReduceOrder reduces the quantity of the open order in place without losing its priority in the depth book,
+// and unlocks the coins of the reduced quantity
+func (k Keeper) ReduceOrder(ctx sdk.Context, order *types.Order, quantity sdk.Dec) {
+	unlockCoins := order.Reduce(quantity)
+	k.UnlockCoins(ctx, order.Sender, unlockCoins, token.LockCoinsTypeQuantity)
+	k.SetOrder(ctx, order.OrderID, order)
+	k.addUpdatedOrderID(order.OrderID)
+	k.diskCache.reduceOrder(order, quantity)
+}
+
 // ExpireOrder quits the specified order with the expired state
 func (k Keeper) ExpireOrder(ctx sdk.Context, order *types.Order, logger log.Logger) {
 	k.quitOrder(ctx, order, types.FeeTypeOrderExpire, logger)
@@ -64,6 +109,18 @@ func (k Keeper) CancelOrder(ctx sdk.Context, order *types.Order, logger log.Logg
 	return k.quitOrder(ctx, order, types.FeeTypeOrderCancel, logger)
 }
 
+// CloseOrderByTimeInForce quits the specified order with the closed state of its time in force
+func (k Keeper) CloseOrderByTimeInForce(ctx sdk.Context, order *types.Order, logger log.Logger) {
+	order.CloseByTimeInForce()
+	k.closeOrder(ctx, order, types.FeeTypeOrderCancel, logger)
+}
+
+// CloseOrderBySelfTrade quits the specified order with the cancelled state of the self-trade prevention
+func (k Keeper) CloseOrderBySelfTrade(ctx sdk.Context, order *types.Order, logger log.Logger) {
+	order.CancelBySelfTrade()
+	k.closeOrder(ctx, order, types.FeeTypeOrderCancel, logger)
+}
+
 // quitOrder unlocks & charges fee, unlocks coins, updates order, and updates DepthBook
 func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, logger log.Logger) (fee sdk.DecCoins) {
 	switch feeType {
@@ -74,7 +131,11 @@ func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, l
 	default:
 		return
 	}
+	return k.closeOrder(ctx, order, feeType, logger)
+}
 
+// closeOrder does the work of quitting an order whose status has been updated
+func (k Keeper) closeOrder(ctx sdk.Context, order *types.Order, feeType string, logger log.Logger) (fee sdk.DecCoins) {
 	// unlock coins in this order & charge fee
 	needUnlockCoins := order.NeedUnlockCoins()
 	k.UnlockCoins(ctx, order.Sender, needUnlockCoins, token.LockCoinsTypeQuantity)
@@ -101,17 +162,36 @@ func (k Keeper) quitOrder(ctx sdk.Context, order *types.Order, feeType string, l
 	return fee
 }
 
+// DropExpiredOrdersByBlockHeight expires the open orders placed at the block height which reach their custom expiry
+// or the default expiry. The block height is added to the custom expiry index of a later height again if any of its
+// orders has a custom expiry time which isn't reached yet
 func (k Keeper) DropExpiredOrdersByBlockHeight(ctx sdk.Context, expiredBlockHeight int64) {
 	logger := ctx.Logger().With("module", "order")
 	store := ctx.KVStore(k.orderStoreKey)
+	blockHeight, blockTime := ctx.BlockHeight(), ctx.BlockHeader().Time.Unix()
+	orderExpireBlocks := k.GetParams(ctx).OrderExpireBlocks
+	var nextCheckHeight int64
 	iter := sdk.KVStorePrefixIterator(store, types.GetOrderKey(types.FormatOrderIDPrefix(expiredBlockHeight)))
 	defer iter.Close()
 	for ; iter.Valid(); iter.Next() {
 		var order types.Order
 		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &order)
-		if order.Status == types.OrderStatusOpen && !k.IsProductLocked(ctx, order.Product) {
-			k.ExpireOrder(ctx, &order, logger)
-			logger.Info(fmt.Sprintf("order (%s) expired", order.OrderID))
+		if order.Status != types.OrderStatusOpen || k.IsProductLocked(ctx, order.Product) {
+			continue
 		}
+		if !order.IsExpired(blockHeight, blockTime, orderExpireBlocks) {
+			if order.ExpireTime > 0 {
+				height := order.NextExpireTimeCheckHeight(blockHeight, blockTime)
+				if nextCheckHeight == 0 || height < nextCheckHeight {
+					nextCheckHeight = height
+				}
+			}
+			continue
+		}
+		k.ExpireOrder(ctx, &order, logger)
+		logger.Info(fmt.Sprintf("order (%s) expired", order.OrderID))
+	}
+	if nextCheckHeight > 0 {
+		k.AddCustomExpireBlockHeight(ctx, nextCheckHeight, expiredBlockHeight)
 	}
 }
@@ -0,0 +1,47 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// HaltProduct halts the auction of the product until the resume height
+func (k Keeper) HaltProduct(ctx sdk.Context, product string, resumeHeight int64) {
+	ctx.KVStore(k.orderStoreKey).Set(types.GetProductHaltKey(product), common.Int64ToBytes(resumeHeight))
+}
+
+// ResumeProduct resumes the auction of the halted product, and its next match is a reopening one
+func (k Keeper) ResumeProduct(ctx sdk.Context, product string) {
+	store := ctx.KVStore(k.orderStoreKey)
+	store.Delete(types.GetProductHaltKey(product))
+	store.Set(types.GetProductReopenKey(product), []byte{1})
+}
+
+// IsProductReopening returns true if the product is resumed but not matched yet. The price band isn't checked by
+// the reopening match, so that the last price is re-anchored to its match price
+func (k Keeper) IsProductReopening(ctx sdk.Context, product string) bool {
+	return ctx.KVStore(k.orderStoreKey).Has(types.GetProductReopenKey(product))
+}
+
+// FinishReopening marks the reopening match of the product is done
+func (k Keeper) FinishReopening(ctx sdk.Context, product string) {
+	ctx.KVStore(k.orderStoreKey).Delete(types.GetProductReopenKey(product))
+}
+
+// IsProductHalted returns true if the auction of the product is halted
+func (k Keeper) IsProductHalted(ctx sdk.Context, product string) bool {
+	return ctx.KVStore(k.orderStoreKey).Has(types.GetProductHaltKey(product))
+}
+
+// GetHaltedProducts returns the halted products with the heights they resume at
+func (k Keeper) GetHaltedProducts(ctx sdk.Context) map[string]int64 {
+	haltedProducts := make(map[string]int64)
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.orderStoreKey), types.ProductHaltKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		haltedProducts[types.GetKey(iter)] = common.BytesToInt64(iter.Value())
+	}
+	return haltedProducts
+}
@@ -31,6 +31,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 
 		case types.QueryDepthBookV2:
 			return queryDepthBookV2(ctx, path[1:], req, keeper)
+		case types.QueryOrderRoute:
+			return queryOrderRoute(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown order query endpoint")
 		}
@@ -40,6 +42,13 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 // nolint: unparam
 func queryOrder(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
 	err sdk.Error) {
+	if types.IsConditionalOrderID(path[0]) {
+		conditionalOrder := keeper.GetConditionalOrder(ctx, path[0])
+		if conditionalOrder == nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("conditional order(%v) does not exist", path[0]))
+		}
+		return keeper.cdc.MustMarshalJSON(conditionalOrder), nil
+	}
 	order := keeper.GetOrder(ctx, path[0])
 	if order == nil {
 		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("order(%v) does not exist", path[0]))
@@ -222,3 +231,42 @@ func queryDepthBookV2(ctx sdk.Context, path []string, req abci.RequestQuery, kee
 	}
 	return res, nil
 }
+
+// QueryOrderRouteParams as input parameters when querying the route of a trade
+type QueryOrderRouteParams struct {
+	Product  string  `json:"product"`
+	Side     string  `json:"side"`
+	Quantity sdk.Dec `json:"quantity"`
+}
+
+// NewQueryOrderRouteParams creates a new instance of QueryOrderRouteParams
+func NewQueryOrderRouteParams(product, side string, quantity sdk.Dec) QueryOrderRouteParams {
+	return QueryOrderRouteParams{
+		Product:  product,
+		Side:     side,
+		Quantity: quantity,
+	}
+}
+
+// queryOrderRoute returns the split of a trade between the depth book and the ammswap pool by the smart order router
+func queryOrderRoute(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params QueryOrderRouteParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	if params.Side != types.BuyOrder && params.Side != types.SellOrder {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid param: side= %s", params.Side))
+	}
+	if params.Quantity.IsNil() || !params.Quantity.IsPositive() {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid param: quantity= %s", params.Quantity))
+	}
+	route, err := keeper.GetBestOrderRoute(ctx, params.Product, params.Side, params.Quantity)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(err.Error())
+	}
+	res, errRes := codec.MarshalJSONIndent(keeper.cdc, route)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
@@ -229,6 +229,8 @@ func TestQueryParameters(t *testing.T) {
 		MaxDealsPerBlock:  10000,
 		FeePerBlock:       sdk.NewDecCoinFromDec(types.DefaultFeeDenomPerBlock, sdk.NewDec(1)),
 		TradeFeeRate:      sdk.MustNewDecFromStr("0.001"),
+		MarketSlippage:    sdk.MustNewDecFromStr("0.1"),
+		MakerFeeRate:      sdk.MustNewDecFromStr("0.0005"),
 	}
 	keeper.SetParams(ctx, params)
 	path := []string{types.QueryParameters}
@@ -0,0 +1,275 @@
+package keeper
+
+import (
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ammswapkeeper "github.com/okex/okexchain/x/ammswap/keeper"
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
+	dex "github.com/okex/okexchain/x/dex/types"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// bookLevel is a price level on one side of the depth book
+type bookLevel struct {
+	price    sdk.Dec
+	quantity sdk.Dec
+}
+
+// getBookLevels returns the levels of the depth book that a trade of the side takes, the best price first
+func (k Keeper) getBookLevels(product, side string) []bookLevel {
+	// items of the depth book are sorted by price in descending order
+	items := k.GetDepthBookCopy(product).Items
+	var levels []bookLevel
+	if side == types.SellOrder {
+		for _, item := range items {
+			if item.BuyQuantity.IsPositive() {
+				levels = append(levels, bookLevel{item.Price, item.BuyQuantity})
+			}
+		}
+	} else {
+		for i := len(items) - 1; i >= 0; i-- {
+			if items[i].SellQuantity.IsPositive() {
+				levels = append(levels, bookLevel{items[i].Price, items[i].SellQuantity})
+			}
+		}
+	}
+	return levels
+}
+
+// estimateBookTrade returns the output of trading the input through the levels of the depth book, and the worst
+// price reached. A sell trade sells the input of base token, a buy trade spends the input of quote token.
+// ok is false if the depth book is not deep enough for the input
+func estimateBookTrade(levels []bookLevel, side string, input sdk.Dec) (output, price sdk.Dec, ok bool) {
+	output, price = sdk.ZeroDec(), sdk.ZeroDec()
+	remaining := input
+	for _, level := range levels {
+		if !remaining.IsPositive() {
+			break
+		}
+		price = level.price
+		if side == types.SellOrder {
+			quantity := sdk.MinDec(remaining, level.quantity)
+			output = output.Add(quantity.MulTruncate(level.price))
+			remaining = remaining.Sub(quantity)
+		} else {
+			spent := sdk.MinDec(remaining, level.quantity.Mul(level.price))
+			output = output.Add(spent.QuoTruncate(level.price))
+			remaining = remaining.Sub(spent)
+		}
+	}
+	return output, price, !remaining.IsPositive()
+}
+
+// getBookCapacity returns the most input that the levels of the depth book can take
+func getBookCapacity(levels []bookLevel, side string) sdk.Dec {
+	capacity := sdk.ZeroDec()
+	for _, level := range levels {
+		if side == types.SellOrder {
+			capacity = capacity.Add(level.quantity)
+		} else {
+			capacity = capacity.Add(level.quantity.Mul(level.price))
+		}
+	}
+	return capacity
+}
+
+// routeVenues are the depth book and the ammswap pool of the same tokens that a trade on the product can be routed to
+type routeVenues struct {
+	tokenPair         *dex.TokenPair
+	product           string
+	side              string
+	inputDenom        string
+	outputDenom       string
+	levels            []bookLevel
+	swapTokenPairName string
+	swapTokenPair     ammswap.SwapTokenPair
+	swapParams        ammswap.Params
+	hasPool           bool
+}
+
+// getRouteVenues gets the venues of a trade of the side on the product
+func (k Keeper) getRouteVenues(ctx sdk.Context, product, side string) (routeVenues, error) {
+	tokenPair := k.dexKeeper.GetTokenPair(ctx, product)
+	if tokenPair == nil {
+		return routeVenues{}, fmt.Errorf("trading pair '%s' does not exist", product)
+	}
+	v := routeVenues{
+		tokenPair:   tokenPair,
+		product:     product,
+		side:        side,
+		inputDenom:  tokenPair.QuoteAssetSymbol,
+		outputDenom: tokenPair.BaseAssetSymbol,
+	}
+	if side == types.SellOrder {
+		v.inputDenom, v.outputDenom = v.outputDenom, v.inputDenom
+	}
+	v.levels = k.getBookLevels(product, side)
+
+	v.swapTokenPairName = ammswap.GetSwapTokenPairName(v.inputDenom, v.outputDenom)
+	if k.swapKeeper != nil {
+		var err error
+		v.swapTokenPair, err = k.swapKeeper.GetSwapTokenPair(ctx, v.swapTokenPairName)
+		v.hasPool = err == nil && v.swapTokenPair.BasePooledCoin.IsPositive() &&
+			v.swapTokenPair.QuotePooledCoin.IsPositive()
+	}
+	if v.hasPool {
+		v.swapParams = k.swapKeeper.GetParams(ctx)
+	}
+	return v, nil
+}
+
+// swapOutput returns the output of swapping the input in the pool, ok is false if the pool can't take the input
+func (v routeVenues) swapOutput(input sdk.Dec) (output sdk.Dec, ok bool) {
+	if !input.IsPositive() {
+		return sdk.ZeroDec(), true
+	}
+	if !v.hasPool {
+		return sdk.ZeroDec(), false
+	}
+	bought, err := ammswapkeeper.CalculateTokenToBuy(v.swapTokenPair, sdk.NewDecCoinFromDec(v.inputDenom, input),
+		v.outputDenom, v.swapParams)
+	if err != nil {
+		return sdk.ZeroDec(), false
+	}
+	return bought.Amount, true
+}
+
+// newOrderRoute builds the route sending the swap input to the pool and the rest of the quantity to the depth book.
+// The book part is placed as an order, the part that can't be an order is moved to the pool
+func (v routeVenues) newOrderRoute(quantity, swapInput sdk.Dec) (types.OrderRoute, error) {
+	bookInput := quantity.Sub(swapInput)
+	if v.side == types.SellOrder {
+		bookInput = truncateDecimal(bookInput, v.tokenPair.MaxQuantityDigit)
+	}
+	bookOutput, bookPrice, ok := estimateBookTrade(v.levels, v.side, bookInput)
+	if !ok {
+		return types.OrderRoute{}, fmt.Errorf("insufficient liquidity of the depth book for %s%s", bookInput,
+			v.inputDenom)
+	}
+	orderQuantity := bookInput
+	if v.side == types.BuyOrder && bookPrice.IsPositive() {
+		orderQuantity = truncateDecimal(bookInput.QuoTruncate(bookPrice), v.tokenPair.MaxQuantityDigit)
+	}
+	if bookInput.IsPositive() && orderQuantity.LT(v.tokenPair.MinQuantity) {
+		bookInput, bookOutput, bookPrice = sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()
+	}
+	swapInput = quantity.Sub(bookInput)
+	poolOutput, ok := v.swapOutput(swapInput)
+	if !ok {
+		return types.OrderRoute{}, fmt.Errorf("insufficient liquidity of the pool %s for %s%s",
+			v.swapTokenPairName, swapInput, v.inputDenom)
+	}
+
+	return types.OrderRoute{
+		Product:       v.product,
+		Side:          v.side,
+		BookInput:     sdk.NewDecCoinFromDec(v.inputDenom, bookInput),
+		BookOutput:    sdk.NewDecCoinFromDec(v.outputDenom, bookOutput),
+		BookPrice:     bookPrice,
+		SwapTokenPair: v.swapTokenPairName,
+		SwapInput:     sdk.NewDecCoinFromDec(v.inputDenom, swapInput),
+		SwapOutput:    sdk.NewDecCoinFromDec(v.outputDenom, poolOutput),
+	}, nil
+}
+
+// GetBestOrderRoute finds the split of a trade on the product between the depth book and the ammswap pool of the
+// same tokens with the most output. The output of either venue is concave in its input, so is the total output in
+// the input sent to the pool, and the split is found by the ternary search on it
+func (k Keeper) GetBestOrderRoute(ctx sdk.Context, product, side string, quantity sdk.Dec) (types.OrderRoute, error) {
+	v, err := k.getRouteVenues(ctx, product, side)
+	if err != nil {
+		return types.OrderRoute{}, err
+	}
+	toDec := func(units *big.Int) sdk.Dec {
+		return sdk.NewDecFromBigIntWithPrec(units, sdk.Precision)
+	}
+
+	// the input sent to the pool is searched in the units of the decimal, from the least that leaves the depth book
+	// enough to take the rest, to the most that the pool can take
+	low := big.NewInt(0)
+	if capacity := getBookCapacity(v.levels, side); capacity.LT(quantity) {
+		low.Sub(quantity.Int, capacity.Int)
+	}
+	high := new(big.Int).Set(quantity.Int)
+	if _, ok := v.swapOutput(toDec(high)); !ok {
+		// the pool takes a range of input from zero, find its end by the binary search
+		maxInput := big.NewInt(0)
+		for maxInput.Cmp(high) < 0 {
+			mid := new(big.Int).Add(maxInput, high)
+			mid.Add(mid, big.NewInt(1)).Rsh(mid, 1)
+			if _, ok := v.swapOutput(toDec(mid)); ok {
+				maxInput = mid
+			} else {
+				high = mid.Sub(mid, big.NewInt(1))
+			}
+		}
+		high = maxInput
+	}
+	if low.Cmp(high) > 0 {
+		return types.OrderRoute{}, fmt.Errorf("insufficient liquidity of the depth book and the pool for %s%s",
+			quantity, v.inputDenom)
+	}
+
+	totalOutput := func(units *big.Int) sdk.Dec {
+		swapInput := toDec(units)
+		bookOutput, _, _ := estimateBookTrade(v.levels, side, quantity.Sub(swapInput))
+		poolOutput, _ := v.swapOutput(swapInput)
+		return bookOutput.Add(poolOutput)
+	}
+	// the pool is preferred on a tie, as it's traded immediately
+	two := big.NewInt(2)
+	for new(big.Int).Sub(high, low).Cmp(two) > 0 {
+		third := new(big.Int).Sub(high, low)
+		third.Quo(third, big.NewInt(3))
+		mid1 := new(big.Int).Add(low, third)
+		mid2 := new(big.Int).Sub(high, third)
+		if totalOutput(mid1).LTE(totalOutput(mid2)) {
+			low = mid1.Add(mid1, big.NewInt(1))
+		} else {
+			high = mid2.Sub(mid2, big.NewInt(1))
+		}
+	}
+	best, bestOutput := new(big.Int).Set(low), totalOutput(low)
+	for units := new(big.Int).Add(low, big.NewInt(1)); units.Cmp(high) <= 0; units.Add(units, big.NewInt(1)) {
+		if output := totalOutput(units); output.GTE(bestOutput) {
+			best, bestOutput = new(big.Int).Set(units), output
+		}
+	}
+
+	return v.newOrderRoute(quantity, toDec(best))
+}
+
+// GetBestSingleVenueOrderRoute finds the route of a trade on the product with the most output that trades all the
+// quantity on either the depth book or the ammswap pool of the same tokens
+func (k Keeper) GetBestSingleVenueOrderRoute(ctx sdk.Context, product, side string,
+	quantity sdk.Dec) (types.OrderRoute, error) {
+	v, err := k.getRouteVenues(ctx, product, side)
+	if err != nil {
+		return types.OrderRoute{}, err
+	}
+	var best *types.OrderRoute
+	for _, swapInput := range []sdk.Dec{sdk.ZeroDec(), quantity} {
+		route, err := v.newOrderRoute(quantity, swapInput)
+		// the book part less than the min quantity is moved to the pool, which doesn't make a single venue
+		if err != nil || (route.BookInput.IsPositive() && route.SwapInput.IsPositive()) {
+			continue
+		}
+		if best == nil || route.Output().GT(best.Output()) {
+			best = &route
+		}
+	}
+	if best == nil {
+		return types.OrderRoute{}, fmt.Errorf("neither the depth book nor the pool %s can take %s%s",
+			v.swapTokenPairName, quantity, v.inputDenom)
+	}
+	return *best, nil
+}
+
+// truncateDecimal truncates the decimal to the specified precision
+func truncateDecimal(d sdk.Dec, precision int64) sdk.Dec {
+	precisionMul := sdk.NewIntWithDecimal(1, int(precision))
+	return d.MulInt(precisionMul).TruncateDec().QuoInt(precisionMul)
+}
@@ -0,0 +1,117 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/dex"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+func TestGetBestOrderRoute(t *testing.T) {
+	testInput := CreateTestInputWithBalance(t, 2, 100000)
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	keeper := testInput.OrderKeeper
+	tokenPair := dex.GetBuiltInTokenPair()
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, tokenPair))
+
+	// bids of 10 at 10.0 and 20 at 9.0
+	for _, bid := range [][2]string{{"10.0", "10"}, {"9.0", "20"}} {
+		order := mockOrder("", types.TestTokenPair, types.BuyOrder, bid[0], bid[1])
+		order.Sender = testInput.TestAddrs[0]
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+	}
+
+	// only the depth book without the pool
+	route, err := keeper.GetBestOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(30))
+	require.Nil(t, err)
+	require.EqualValues(t, sdk.NewDec(30), route.BookInput.Amount)
+	require.EqualValues(t, sdk.NewDec(280), route.BookOutput.Amount)
+	require.EqualValues(t, sdk.NewDec(9), route.BookPrice)
+	require.True(t, route.SwapInput.IsZero())
+	_, err = keeper.GetBestOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(31))
+	require.NotNil(t, err)
+	_, err = keeper.GetBestOrderRoute(ctx, types.TestTokenPair, types.BuyOrder, sdk.NewDec(10))
+	require.NotNil(t, err)
+	_, err = keeper.GetBestOrderRoute(ctx, "nil_okt", types.SellOrder, sdk.NewDec(10))
+	require.NotNil(t, err)
+
+	// the first level of the book is better than the pool, then the pool is better than the second level
+	swapKeeper := NewMockSwapKeeper(keeper.GetTokenKeeper(), testInput.TestAddrs[1])
+	swapKeeper.SetPool(sdk.NewDecCoin(common.TestToken, sdk.NewInt(1000)),
+		sdk.NewDecCoin(common.NativeToken, sdk.NewInt(10000)))
+	keeper.SetSwapKeeper(swapKeeper)
+	route, err = keeper.GetBestOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(30))
+	require.Nil(t, err)
+	require.EqualValues(t, types.TestTokenPair, route.SwapTokenPair)
+	// the split is at the end of the first level, up to the truncation of the outputs
+	tolerance := sdk.MustNewDecFromStr("0.000001")
+	require.True(t, route.BookInput.Amount.Sub(sdk.NewDec(10)).Abs().LTE(tolerance))
+	require.True(t, route.BookOutput.Amount.Sub(sdk.NewDec(100)).Abs().LTE(tolerance))
+	require.EqualValues(t, sdk.NewDec(30), route.BookInput.Amount.Add(route.SwapInput.Amount))
+	require.EqualValues(t, common.TestToken, route.SwapInput.Denom)
+	require.True(t, route.Output().GT(sdk.NewDec(295)))
+	require.EqualValues(t, common.NativeToken, route.SwapOutput.Denom)
+
+	// more than the depth book can take is sent to the pool
+	route, err = keeper.GetBestOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(100))
+	require.Nil(t, err)
+	require.True(t, route.SwapInput.Amount.GTE(sdk.NewDec(70)))
+	require.EqualValues(t, sdk.NewDec(100), route.BookInput.Amount.Add(route.SwapInput.Amount))
+
+	// buying with no asks on the depth book goes to the pool
+	route, err = keeper.GetBestOrderRoute(ctx, types.TestTokenPair, types.BuyOrder, sdk.NewDec(100))
+	require.Nil(t, err)
+	require.True(t, route.BookInput.IsZero())
+	require.EqualValues(t, sdk.NewDecCoin(common.NativeToken, sdk.NewInt(100)), route.SwapInput)
+	require.EqualValues(t, common.TestToken, route.SwapOutput.Denom)
+	require.True(t, route.SwapOutput.Amount.GT(sdk.MustNewDecFromStr("9.8")))
+
+	// the book part less than the min quantity is sent to the pool
+	tokenPair.MinQuantity = sdk.NewDec(15)
+	testInput.DexKeeper.UpdateTokenPair(ctx, types.TestTokenPair, tokenPair)
+	route, err = keeper.GetBestOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(30))
+	require.Nil(t, err)
+	require.True(t, route.BookInput.IsZero())
+	require.EqualValues(t, sdk.NewDec(30), route.SwapInput.Amount)
+}
+
+func TestGetBestSingleVenueOrderRoute(t *testing.T) {
+	testInput := CreateTestInputWithBalance(t, 2, 100000)
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	keeper := testInput.OrderKeeper
+	tokenPair := dex.GetBuiltInTokenPair()
+	require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, tokenPair))
+
+	// bids of 10 at 10.0 and 20 at 9.0
+	for _, bid := range [][2]string{{"10.0", "10"}, {"9.0", "20"}} {
+		order := mockOrder("", types.TestTokenPair, types.BuyOrder, bid[0], bid[1])
+		order.Sender = testInput.TestAddrs[0]
+		require.Nil(t, keeper.PlaceOrder(ctx, order))
+	}
+
+	// only the depth book without the pool
+	route, err := keeper.GetBestSingleVenueOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(30))
+	require.Nil(t, err)
+	require.EqualValues(t, sdk.NewDec(280), route.BookOutput.Amount)
+	require.True(t, route.SwapInput.IsZero())
+	_, err = keeper.GetBestSingleVenueOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(31))
+	require.NotNil(t, err)
+
+	// the pool gets more than the depth book for all the quantity
+	swapKeeper := NewMockSwapKeeper(keeper.GetTokenKeeper(), testInput.TestAddrs[1])
+	swapKeeper.SetPool(sdk.NewDecCoin(common.TestToken, sdk.NewInt(1000)),
+		sdk.NewDecCoin(common.NativeToken, sdk.NewInt(10000)))
+	keeper.SetSwapKeeper(swapKeeper)
+	route, err = keeper.GetBestSingleVenueOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(30))
+	require.Nil(t, err)
+	require.True(t, route.BookInput.IsZero())
+	require.EqualValues(t, sdk.NewDec(30), route.SwapInput.Amount)
+	require.True(t, route.Output().GT(sdk.NewDec(280)))
+	bestRoute, err := keeper.GetBestOrderRoute(ctx, types.TestTokenPair, types.SellOrder, sdk.NewDec(30))
+	require.Nil(t, err)
+	require.True(t, bestRoute.Output().GTE(route.Output()))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
 
+	ammswap "github.com/okex/okexchain/x/ammswap/types"
 	"github.com/okex/okexchain/x/dex"
 	"github.com/okex/okexchain/x/order/types"
 	"github.com/okex/okexchain/x/token"
@@ -161,3 +162,68 @@ func CreateTestInputWithBalance(t *testing.T, numAddrs, initQuantity int64) Test
 func CreateTestInput(t *testing.T) TestInput {
 	return CreateTestInputWithBalance(t, 2, 100)
 }
+
+// MockSwapKeeper is an ammswap keeper with the token pairs in memory, the pooled coins are held by PoolAddr
+type MockSwapKeeper struct {
+	TokenKeeper TokenKeeper
+	PoolAddr    sdk.AccAddress
+	Pairs       map[string]ammswap.SwapTokenPair
+	Params      ammswap.Params
+}
+
+// NewMockSwapKeeper creates a MockSwapKeeper with the default params
+func NewMockSwapKeeper(tokenKeeper TokenKeeper, poolAddr sdk.AccAddress) *MockSwapKeeper {
+	return &MockSwapKeeper{
+		TokenKeeper: tokenKeeper,
+		PoolAddr:    poolAddr,
+		Pairs:       make(map[string]ammswap.SwapTokenPair),
+		Params:      ammswap.DefaultParams(),
+	}
+}
+
+// SetPool sets the pooled coins of the constant product pool of two tokens
+func (k *MockSwapKeeper) SetPool(baseAmount, quoteAmount sdk.DecCoin) {
+	name := ammswap.GetSwapTokenPairName(baseAmount.Denom, quoteAmount.Denom)
+	k.Pairs[name] = ammswap.SwapTokenPair{
+		BasePooledCoin:  baseAmount,
+		QuotePooledCoin: quoteAmount,
+		PoolTokenName:   ammswap.PoolTokenPrefix + name,
+		Curve:           ammswap.DefaultPoolCurve(),
+	}
+}
+
+// nolint
+func (k *MockSwapKeeper) GetSwapTokenPair(ctx sdk.Context, tokenPairName string) (ammswap.SwapTokenPair, error) {
+	pair, ok := k.Pairs[tokenPairName]
+	if !ok {
+		return pair, fmt.Errorf("non-exist swap token pair: %s", tokenPairName)
+	}
+	return pair, nil
+}
+
+// nolint
+func (k *MockSwapKeeper) GetParams(ctx sdk.Context) ammswap.Params {
+	return k.Params
+}
+
+// nolint
+func (k *MockSwapKeeper) SwapTokens(ctx sdk.Context, swapTokenPair ammswap.SwapTokenPair,
+	soldToken, boughtToken sdk.DecCoin, sender, recipient sdk.AccAddress) sdk.Error {
+	if err := k.TokenKeeper.SendCoinsFromAccountToAccount(ctx, sender, k.PoolAddr,
+		sdk.DecCoins{soldToken}); err != nil {
+		return sdk.ErrInsufficientCoins(err.Error())
+	}
+	if err := k.TokenKeeper.SendCoinsFromAccountToAccount(ctx, k.PoolAddr, recipient,
+		sdk.DecCoins{boughtToken}); err != nil {
+		return sdk.ErrInsufficientCoins(err.Error())
+	}
+	if soldToken.Denom == swapTokenPair.QuotePooledCoin.Denom {
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Add(soldToken)
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Sub(boughtToken)
+	} else {
+		swapTokenPair.BasePooledCoin = swapTokenPair.BasePooledCoin.Add(soldToken)
+		swapTokenPair.QuotePooledCoin = swapTokenPair.QuotePooledCoin.Sub(boughtToken)
+	}
+	k.Pairs[swapTokenPair.TokenPairName()] = swapTokenPair
+	return nil
+}
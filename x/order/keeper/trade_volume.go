@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// dailyVolume is the trade volume of an address in a day
+type dailyVolume struct {
+	Day    int64   `json:"day"`
+	Volume sdk.Dec `json:"volume"`
+}
+
+func getDay(ctx sdk.Context) int64 {
+	return ctx.BlockTime().Unix() / secondsPerDay
+}
+
+func (k Keeper) getDailyVolumes(ctx sdk.Context, addr sdk.AccAddress) (volumes []dailyVolume) {
+	if bz := ctx.KVStore(k.orderStoreKey).Get(types.GetTradeVolumeKey(addr)); bz != nil {
+		k.cdc.MustUnmarshalBinaryBare(bz, &volumes)
+	}
+	return volumes
+}
+
+// AddTradeVolume adds the volume to the trade volume of the address in the current day,
+// and drops its daily volumes out of the rolling window of days
+func (k Keeper) AddTradeVolume(ctx sdk.Context, addr sdk.AccAddress, volume sdk.Dec, days int64) {
+	day := getDay(ctx)
+	var volumes []dailyVolume
+	for _, dv := range k.getDailyVolumes(ctx, addr) {
+		if dv.Day == day {
+			volume = volume.Add(dv.Volume)
+		} else if dv.Day > day-days {
+			volumes = append(volumes, dv)
+		}
+	}
+	volumes = append(volumes, dailyVolume{Day: day, Volume: volume})
+	ctx.KVStore(k.orderStoreKey).Set(types.GetTradeVolumeKey(addr), k.cdc.MustMarshalBinaryBare(volumes))
+}
+
+// GetTradeVolume returns the trade volume of the address in the rolling window of days, including the current day
+func (k Keeper) GetTradeVolume(ctx sdk.Context, addr sdk.AccAddress, days int64) sdk.Dec {
+	day := getDay(ctx)
+	totalVolume := sdk.ZeroDec()
+	for _, dv := range k.getDailyVolumes(ctx, addr) {
+		if dv.Day > day-days {
+			totalVolume = totalVolume.Add(dv.Volume)
+		}
+	}
+	return totalVolume
+}
+
+// GetNativeVolume converts the trade volume in the quote token into the native token by the last price of the token
+// pair between them, so that the trade volumes of all the products are counted in the same unit.
+// Zero is returned if there isn't such a token pair
+func (k Keeper) GetNativeVolume(ctx sdk.Context, quoteToken string, volume sdk.Dec) sdk.Dec {
+	if quoteToken == common.NativeToken {
+		return volume
+	}
+	product := fmt.Sprintf("%s_%s", quoteToken, common.NativeToken)
+	if k.dexKeeper.GetTokenPair(ctx, product) != nil {
+		return volume.Mul(k.GetLastPrice(ctx, product))
+	}
+	product = fmt.Sprintf("%s_%s", common.NativeToken, quoteToken)
+	if k.dexKeeper.GetTokenPair(ctx, product) != nil {
+		if price := k.GetLastPrice(ctx, product); price.IsPositive() {
+			return volume.Quo(price)
+		}
+	}
+	return sdk.ZeroDec()
+}
@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/dex"
+)
+
+func TestTradeVolume(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	addr := testInput.TestAddrs[0]
+	start := time.Unix(100*secondsPerDay, 0)
+	ctx := testInput.Ctx.WithBlockTime(start)
+
+	keeper.AddTradeVolume(ctx, addr, sdk.NewDec(10), 3)
+	keeper.AddTradeVolume(ctx, addr, sdk.NewDec(5), 3)
+	require.EqualValues(t, sdk.NewDec(15), keeper.GetTradeVolume(ctx, addr, 3))
+	require.EqualValues(t, sdk.ZeroDec(), keeper.GetTradeVolume(ctx, testInput.TestAddrs[1], 3))
+
+	ctx = ctx.WithBlockTime(start.Add(2 * secondsPerDay * time.Second))
+	keeper.AddTradeVolume(ctx, addr, sdk.NewDec(20), 3)
+	require.EqualValues(t, sdk.NewDec(35), keeper.GetTradeVolume(ctx, addr, 3))
+
+	// the volume of the first day is out of the rolling window
+	ctx = ctx.WithBlockTime(start.Add(3 * secondsPerDay * time.Second))
+	require.EqualValues(t, sdk.NewDec(20), keeper.GetTradeVolume(ctx, addr, 3))
+	keeper.AddTradeVolume(ctx, addr, sdk.NewDec(1), 3)
+	require.EqualValues(t, sdk.NewDec(21), keeper.GetTradeVolume(ctx, addr, 3))
+	require.EqualValues(t, sdk.NewDec(21), keeper.GetTradeVolume(ctx, addr, 10))
+}
+
+func TestGetNativeVolume(t *testing.T) {
+	testInput := CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx
+	for _, tokenPair := range []*dex.TokenPair{
+		{BaseAssetSymbol: "yyb", QuoteAssetSymbol: common.NativeToken, InitPrice: sdk.NewDec(2)},
+		{BaseAssetSymbol: common.NativeToken, QuoteAssetSymbol: "zzb", InitPrice: sdk.NewDec(4)},
+	} {
+		require.Nil(t, testInput.DexKeeper.SaveTokenPair(ctx, tokenPair))
+	}
+
+	require.EqualValues(t, sdk.NewDec(8), keeper.GetNativeVolume(ctx, common.NativeToken, sdk.NewDec(8)))
+	require.EqualValues(t, sdk.NewDec(16), keeper.GetNativeVolume(ctx, "yyb", sdk.NewDec(8)))
+	require.EqualValues(t, sdk.NewDec(2), keeper.GetNativeVolume(ctx, "zzb", sdk.NewDec(8)))
+	// the volume of the quote token without a token pair to the native token isn't counted
+	require.EqualValues(t, sdk.ZeroDec(), keeper.GetNativeVolume(ctx, "xxb", sdk.NewDec(8)))
+}
@@ -6,11 +6,19 @@ import (
 	"github.com/okex/okexchain/x/order/keeper"
 )
 
-// nolint
+// CaEngine is the continuous auction match engine
 type CaEngine struct {
 }
 
 // nolint
-func (e *CaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
-	// TODO
+func (e *CaEngine) Run(ctx sdk.Context, keeper keeper.Keeper, isDispatched func(product string) bool,
+	blockRemainDeals int64) int64 {
+	return matchOrders(ctx, keeper, isDispatched, blockRemainDeals)
+}
+
+// IsPending returns true if the order hasn't been matched as a taker yet,
+// because the deals limit per block has been reached
+func IsPending(ctx sdk.Context, keeper keeper.Keeper, orderID string) bool {
+	cursor := keeper.GetContinuousAuctionCursor(ctx)
+	return cursor != "" && !parseOrderSeq(orderID).before(parseOrderSeq(cursor))
 }
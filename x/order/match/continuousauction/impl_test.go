@@ -0,0 +1,297 @@
+package continuousauction
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/common"
+	"github.com/okex/okexchain/x/dex"
+	dextypes "github.com/okex/okexchain/x/dex/types"
+	orderkeeper "github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaEngine_Run(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	engine := &CaEngine{}
+
+	// resting orders
+	keeper.ResetCache(ctx)
+	makers := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "2.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.2", "1.0"),
+	}
+	for _, order := range makers {
+		order.Sender = testInput.TestAddrs[1]
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	keeper.Cache2Disk(ctx)
+	for _, order := range makers {
+		require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, order.OrderID).Status)
+	}
+
+	// the taker crosses two price levels and is filled at the prices of makers
+	ctx = ctx.WithBlockHeight(11)
+	keeper.ResetCache(ctx)
+	taker := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "2.0")
+	taker.Sender = testInput.TestAddrs[0]
+	require.NoError(t, keeper.PlaceOrder(ctx, taker))
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	keeper.Cache2Disk(ctx)
+
+	taker = keeper.GetOrder(ctx, taker.OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, taker.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.05"), taker.FilledAvgPrice)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, makers[0].OrderID).Status)
+	maker1 := keeper.GetOrder(ctx, makers[1].OrderID)
+	require.EqualValues(t, types.OrderStatusOpen, maker1.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1"), maker1.RemainQuantity)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, makers[2].OrderID).Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.1"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+
+	result := keeper.GetBlockMatchResult().ResultMap[types.TestTokenPair]
+	require.EqualValues(t, 4, len(result.Deals))
+	require.EqualValues(t, sdk.MustNewDecFromStr("2"), result.Quantity)
+
+	// the depth book is not crossed after matching
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 2, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.2"), book.Items[0].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.1"), book.Items[1].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1"), book.Items[1].SellQuantity)
+	require.True(t, book.Items[1].BuyQuantity.IsZero())
+}
+
+func TestCaEngine_RunWithDealsLimit(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	params := types.DefaultTestParams()
+	params.MaxDealsPerBlock = 2
+	keeper.SetParams(ctx, &params)
+
+	engine := &CaEngine{}
+
+	keeper.ResetCache(ctx)
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "2.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[1]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[0]
+	for _, order := range orders {
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	keeper.Cache2Disk(ctx)
+
+	// deals limit reached, the taker is partially filled and matched again in the next block
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, sdk.OneDec(), keeper.GetOrder(ctx, orders[2].OrderID).RemainQuantity)
+	require.EqualValues(t, orders[2].OrderID, keeper.GetContinuousAuctionCursor(ctx))
+	require.True(t, IsPending(ctx, keeper, orders[2].OrderID))
+	require.False(t, IsPending(ctx, keeper, orders[1].OrderID))
+
+	ctx = ctx.WithBlockHeight(11)
+	keeper.ResetCache(ctx)
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	keeper.Cache2Disk(ctx)
+
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[2].OrderID).Status)
+	require.EqualValues(t, "", keeper.GetContinuousAuctionCursor(ctx))
+	require.False(t, IsPending(ctx, keeper, orders[2].OrderID))
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+}
+
+func TestCaEngine_RunWithTimeInForce(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+	params := types.DefaultTestParams()
+	params.MaxDealsPerBlock = 2
+	keeper.SetParams(ctx, &params)
+
+	engine := &CaEngine{}
+
+	keeper.ResetCache(ctx)
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		// crosses a maker
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		// only 2 can be filled
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "3.0"),
+		// consumes 2 deals, then the FOK order below has to wait for the next block
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+	}
+	orders[2].TimeInForce = types.TimeInForcePostOnly
+	orders[3].TimeInForce = types.TimeInForceFOK
+	orders[5].TimeInForce = types.TimeInForceFOK
+	for i, order := range orders {
+		order.Sender = testInput.TestAddrs[0]
+		if order.Side == types.SellOrder {
+			order.Sender = testInput.TestAddrs[1]
+		}
+		require.NoError(t, keeper.PlaceOrder(ctx, order), i)
+	}
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	keeper.Cache2Disk(ctx)
+
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusPostOnlyRejected, keeper.GetOrder(ctx, orders[2].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFOKKilled, keeper.GetOrder(ctx, orders[3].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[4].OrderID).Status)
+	fokOrder := keeper.GetOrder(ctx, orders[5].OrderID)
+	require.EqualValues(t, types.OrderStatusOpen, fokOrder.Status)
+	require.EqualValues(t, sdk.OneDec(), fokOrder.RemainQuantity)
+	require.EqualValues(t, orders[5].OrderID, keeper.GetContinuousAuctionCursor(ctx))
+
+	ctx = ctx.WithBlockHeight(11)
+	keeper.ResetCache(ctx)
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	keeper.Cache2Disk(ctx)
+
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[5].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, "", keeper.GetContinuousAuctionCursor(ctx))
+}
+
+func dispatchAll(product string) bool {
+	return true
+}
+
+func TestCaEngine_RunHaltedByPriceBand(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	priceBand := dextypes.NewPriceBand(dextypes.DefaultPressureRate, sdk.NewDecWithPrec(1, 1), 3)
+	tokenPair.PriceBand = &priceBand
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	engine := &CaEngine{}
+	placeOrder := func(side, price string, sender int) *types.Order {
+		order := types.MockOrder("", types.TestTokenPair, side, price, "1.0")
+		order.Sender = testInput.TestAddrs[sender]
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+		return order
+	}
+	runBlock := func(height int64, orders func()) {
+		ctx = ctx.WithBlockHeight(height)
+		keeper.ResetCache(ctx)
+		orders()
+		engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+		keeper.Cache2Disk(ctx)
+	}
+
+	// the deal price 12 moves more than 10% from the last price 10, the taker is cancelled
+	var maker, taker *types.Order
+	runBlock(10, func() { maker = placeOrder(types.SellOrder, "12", 1) })
+	runBlock(11, func() { taker = placeOrder(types.BuyOrder, "12", 0) })
+	require.True(t, keeper.IsProductHalted(ctx, types.TestTokenPair))
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, taker.OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, maker.OrderID).Status)
+	require.EqualValues(t, sdk.NewDec(10), keeper.GetLastPrice(ctx, types.TestTokenPair))
+
+	// the takers crossing the depth book of the halted product are cancelled, and the others rest in the book
+	var crossed, resting *types.Order
+	runBlock(12, func() {
+		crossed = placeOrder(types.BuyOrder, "12", 0)
+		resting = placeOrder(types.BuyOrder, "11", 0)
+	})
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, crossed.OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, resting.OrderID).Status)
+	require.EqualValues(t, 2, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+
+	// the product is resumed after the cool-down, and the reopening deal re-anchors the last price
+	runBlock(14, func() { taker = placeOrder(types.BuyOrder, "12", 0) })
+	require.False(t, keeper.IsProductHalted(ctx, types.TestTokenPair))
+	require.False(t, keeper.IsProductReopening(ctx, types.TestTokenPair))
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, taker.OrderID).Status)
+	require.EqualValues(t, sdk.NewDec(12), keeper.GetLastPrice(ctx, types.TestTokenPair))
+}
+
+func TestCaEngine_RunWithFrozenMaker(t *testing.T) {
+	testInput := orderkeeper.CreateTestInputWithBalance(t, 3, 100)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	tokenInfo := testInput.TokenKeeper.GetTokenInfo(ctx, common.TestToken)
+	tokenInfo.Symbol = common.TestToken
+	tokenInfo.Freezable = true
+	testInput.TokenKeeper.UpdateToken(ctx, tokenInfo)
+
+	engine := &CaEngine{}
+
+	// resting orders, the seller of the best price is frozen after placing it
+	keeper.ResetCache(ctx)
+	makers := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "1.0"),
+	}
+	makers[0].Sender = testInput.TestAddrs[1]
+	makers[1].Sender = testInput.TestAddrs[2]
+	for _, order := range makers {
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	keeper.Cache2Disk(ctx)
+	testInput.TokenKeeper.SetFrozen(ctx, common.TestToken, testInput.TestAddrs[1], true)
+
+	// the frozen maker is cancelled and the taker is filled with the next one
+	ctx = ctx.WithBlockHeight(11)
+	keeper.ResetCache(ctx)
+	taker := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "1.0")
+	taker.Sender = testInput.TestAddrs[0]
+	require.NoError(t, keeper.PlaceOrder(ctx, taker))
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	keeper.Cache2Disk(ctx)
+
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, makers[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, makers[1].OrderID).Status)
+	taker = keeper.GetOrder(ctx, taker.OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, taker.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.1"), taker.FilledAvgPrice)
+	require.EqualValues(t, 0, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+
+	// the taker of a frozen account is cancelled before matching
+	ctx = ctx.WithBlockHeight(12)
+	keeper.ResetCache(ctx)
+	maker := types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0")
+	maker.Sender = testInput.TestAddrs[2]
+	require.NoError(t, keeper.PlaceOrder(ctx, maker))
+	taker = types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	taker.Sender = testInput.TestAddrs[0]
+	require.NoError(t, keeper.PlaceOrder(ctx, taker))
+	testInput.TokenKeeper.SetFrozen(ctx, common.TestToken, testInput.TestAddrs[0], true)
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	keeper.Cache2Disk(ctx)
+
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, taker.OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, maker.OrderID).Status)
+}
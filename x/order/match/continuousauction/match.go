@@ -0,0 +1,349 @@
+package continuousauction
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/match/periodicauction"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// orderSeq is the position of an order in the global order sequence,
+// orders are matched as takers following this sequence
+type orderSeq struct {
+	blockHeight int64
+	orderNum    int64
+}
+
+func parseOrderSeq(orderID string) orderSeq {
+	blockHeight, orderNum, _ := types.ParseOrderID(orderID)
+	return orderSeq{blockHeight: blockHeight, orderNum: orderNum}
+}
+
+// before returns true if the order with seq s has been placed before the one with seq other
+func (s orderSeq) before(other orderSeq) bool {
+	if s.blockHeight != other.blockHeight {
+		return s.blockHeight < other.blockHeight
+	}
+	return s.orderNum < other.orderNum
+}
+
+// matchOrders matches the new orders one by one in the sequence of their arrival.
+// Every new order is a taker, it is filled with the resting orders on the opposite side of the
+// depth book following price-time priority, at the price of the maker.
+// If the deals limit per block is reached, the rest of the new orders will be matched in the next block.
+// It returns the deals remaining in the block after matching.
+// The product is halted if a deal price moves out of its price band, the takers crossing the depth book of the
+// halted product are cancelled, so that the depth book stays uncrossed until the product resumes.
+func matchOrders(ctx sdk.Context, k keeper.Keeper, isDispatched func(product string) bool,
+	blockRemainDeals int64) int64 {
+	logger := ctx.Logger().With("module", "order")
+	blockHeight := ctx.BlockHeight()
+	feeParams := k.GetParams(ctx)
+	periodicauction.ResumeHaltedProducts(ctx, k, isDispatched)
+
+	// orders before the cursor have been matched in the previous blocks
+	cursor := orderSeq{blockHeight: blockHeight, orderNum: 1}
+	if cursorOrderID := k.GetContinuousAuctionCursor(ctx); cursorOrderID != "" {
+		cursor = parseOrderSeq(cursorOrderID)
+	}
+
+	resultMap := make(map[string]types.MatchResult)
+	for height := cursor.blockHeight; height <= blockHeight; height++ {
+		orderNum := k.GetBlockOrderNum(ctx, height)
+		var num int64 = 1
+		if height == cursor.blockHeight {
+			num = cursor.orderNum
+		}
+		for ; num <= orderNum; num++ {
+			taker := k.GetOrder(ctx, types.FormatOrderID(height, num))
+			if taker == nil || taker.Status != types.OrderStatusOpen || !isDispatched(taker.Product) ||
+				k.GetDexKeeper().GetTokenPair(ctx, taker.Product) == nil {
+				continue
+			}
+
+			if k.IsProductFreezable(ctx, taker.Product) {
+				if k.CheckOrderTransferable(ctx, taker) != nil {
+					k.CancelOrder(ctx, taker, logger)
+					continue
+				}
+				cancelNonTransferableMakers(ctx, k, taker, orderSeq{height, num}, logger)
+			}
+
+			if k.IsProductHalted(ctx, taker.Product) {
+				if len(crossedPrices(k.GetDepthBookCopy(taker.Product), taker)) > 0 {
+					k.CancelOrder(ctx, taker, logger)
+				}
+				continue
+			}
+
+			if rejected, exceeded := checkTimeInForce(ctx, k, taker, orderSeq{height, num}, blockRemainDeals,
+				feeParams); rejected {
+				k.CloseOrderByTimeInForce(ctx, taker, logger)
+				continue
+			} else if exceeded {
+				// the FOK taker can't be fully filled in this block, match it in the next block
+				k.SetContinuousAuctionCursor(ctx, taker.OrderID)
+				saveMatchResult(ctx, k, resultMap, logger)
+				return blockRemainDeals
+			}
+
+			var deals []types.Deal
+			deals, blockRemainDeals = matchTaker(ctx, k, taker, orderSeq{height, num}, blockRemainDeals, feeParams)
+			if len(deals) > 0 {
+				recordDeals(resultMap, blockHeight, taker, deals)
+			}
+			if taker.Status == types.OrderStatusOpen && k.IsProductHalted(ctx, taker.Product) {
+				// the product is halted by the taker
+				k.CancelOrder(ctx, taker, logger)
+				continue
+			}
+
+			if taker.Status == types.OrderStatusOpen && blockRemainDeals < 2 {
+				// the taker is still able to match, continue with it in the next block
+				k.SetContinuousAuctionCursor(ctx, taker.OrderID)
+				saveMatchResult(ctx, k, resultMap, logger)
+				return blockRemainDeals
+			}
+		}
+	}
+
+	k.SetContinuousAuctionCursor(ctx, "")
+	saveMatchResult(ctx, k, resultMap, logger)
+	return blockRemainDeals
+}
+
+// matchTaker fills the taker with the makers which cross its price, each match makes two deals,
+// one for the maker and one for the taker. The product is halted before a deal whose price is out of the price band
+// of the last price in the previous block, unless it's in the reopening match
+func matchTaker(ctx sdk.Context, k keeper.Keeper, taker *types.Order, takerSeq orderSeq,
+	blockRemainDeals int64, feeParams *types.Params) ([]types.Deal, int64) {
+
+	var deals []types.Deal
+	makerSide := types.SellOrder
+	if taker.Side == types.SellOrder {
+		makerSide = types.BuyOrder
+	}
+
+	priceBand := k.GetDexKeeper().GetTokenPair(ctx, taker.Product).GetPriceBand()
+	lastPrice := k.GetLastPrice(ctx, taker.Product)
+	isReopening := k.IsProductReopening(ctx, taker.Product)
+	book := k.GetDepthBookCopy(taker.Product)
+	for _, price := range crossedPrices(book, taker) {
+		if !isReopening && priceBand.IsOutOfBand(lastPrice, price) {
+			periodicauction.HaltProduct(ctx, k, taker.Product, priceBand.HaltBlocks, lastPrice, price)
+			break
+		}
+		key := types.FormatOrderIDsKey(taker.Product, price, makerSide)
+		orderIDs := k.GetProductPriceOrderIDs(key)
+
+		index := 0
+		for ; index < len(orderIDs); index++ {
+			if blockRemainDeals < 2 || !taker.RemainQuantity.IsPositive() {
+				break
+			}
+			// orders placed after the taker are not resting in the depth book yet
+			if !parseOrderSeq(orderIDs[index]).before(takerSeq) {
+				break
+			}
+			maker := k.GetOrder(ctx, orderIDs[index])
+			if maker == nil {
+				ctx.Logger().Error("[Order] Not exist orderID: ", orderIDs[index])
+				continue
+			}
+
+			fillQuantity := sdk.MinDec(maker.RemainQuantity, taker.RemainQuantity)
+			book.RemoveOrder(maker)
+			book.RemoveOrder(taker)
+			if deal := periodicauction.FillOrder(maker, ctx, k, price, fillQuantity, feeParams, true); deal != nil {
+				deals = append(deals, *deal)
+			}
+			if deal := periodicauction.FillOrder(taker, ctx, k, price, fillQuantity, feeParams, false); deal != nil {
+				deals = append(deals, *deal)
+			}
+			blockRemainDeals -= 2
+
+			if taker.Status == types.OrderStatusOpen {
+				book.InsertOrder(taker)
+			}
+			if maker.Status == types.OrderStatusOpen {
+				book.InsertOrder(maker)
+				break
+			}
+		}
+
+		// makers before index are fully filled
+		k.SetOrderIDs(key, append([]string{}, orderIDs[index:]...))
+
+		if blockRemainDeals < 2 || !taker.RemainQuantity.IsPositive() {
+			break
+		}
+	}
+
+	if taker.Status == types.OrderStatusFilled {
+		removeOrderID(k, taker)
+	}
+	k.SetDepthBook(taker.Product, book)
+
+	return deals, blockRemainDeals
+}
+
+// checkTimeInForce checks whether the taker should be rejected because of its time in force: a post-only taker is
+// rejected if it crosses any maker, and a FOK taker is killed if it can't be fully filled at once.
+// exceeded is true if the FOK taker can be fully filled, but not within the remaining deals of this block.
+func checkTimeInForce(ctx sdk.Context, k keeper.Keeper, taker *types.Order, takerSeq orderSeq,
+	blockRemainDeals int64, feeParams *types.Params) (rejected, exceeded bool) {
+
+	if taker.TimeInForce != types.TimeInForcePostOnly && taker.TimeInForce != types.TimeInForceFOK {
+		return false, false
+	}
+
+	makersNum, quantity := crossedMakers(ctx, k, taker, takerSeq)
+	if taker.TimeInForce == types.TimeInForcePostOnly {
+		return makersNum > 0, false
+	}
+	if quantity.LT(taker.RemainQuantity) || 2*makersNum > feeParams.MaxDealsPerBlock {
+		return true, false
+	}
+	return false, 2*makersNum > blockRemainDeals
+}
+
+// cancelNonTransferableMakers cancels the makers crossing the taker whose senders are frozen or whose token
+// transfers are paused, until the remaining makers are enough to fill the taker
+func cancelNonTransferableMakers(ctx sdk.Context, k keeper.Keeper, taker *types.Order, takerSeq orderSeq,
+	logger log.Logger) {
+
+	makerSide := types.SellOrder
+	if taker.Side == types.SellOrder {
+		makerSide = types.BuyOrder
+	}
+
+	var makers []*types.Order
+	quantity := sdk.ZeroDec()
+	for _, price := range crossedPrices(k.GetDepthBookCopy(taker.Product), taker) {
+		for _, orderID := range k.GetProductPriceOrderIDs(types.FormatOrderIDsKey(taker.Product, price, makerSide)) {
+			if quantity.GTE(taker.RemainQuantity) || !parseOrderSeq(orderID).before(takerSeq) {
+				break
+			}
+			maker := k.GetOrder(ctx, orderID)
+			if maker == nil {
+				continue
+			}
+			if k.CheckOrderTransferable(ctx, maker) != nil {
+				makers = append(makers, maker)
+			} else {
+				quantity = quantity.Add(maker.RemainQuantity)
+			}
+		}
+	}
+
+	for _, maker := range makers {
+		k.CancelOrder(ctx, maker, logger)
+	}
+}
+
+// crossedMakers returns the number of makers needed to fill the taker, and the quantity they can fill
+func crossedMakers(ctx sdk.Context, k keeper.Keeper, taker *types.Order, takerSeq orderSeq) (int64, sdk.Dec) {
+	makerSide := types.SellOrder
+	if taker.Side == types.SellOrder {
+		makerSide = types.BuyOrder
+	}
+
+	var makersNum int64
+	quantity := sdk.ZeroDec()
+	for _, price := range crossedPrices(k.GetDepthBookCopy(taker.Product), taker) {
+		for _, orderID := range k.GetProductPriceOrderIDs(types.FormatOrderIDsKey(taker.Product, price, makerSide)) {
+			if quantity.GTE(taker.RemainQuantity) || !parseOrderSeq(orderID).before(takerSeq) {
+				break
+			}
+			if maker := k.GetOrder(ctx, orderID); maker != nil {
+				makersNum++
+				quantity = quantity.Add(maker.RemainQuantity)
+			}
+		}
+	}
+	return makersNum, quantity
+}
+
+// crossedPrices returns the prices on the opposite side of the depth book which the taker can trade with,
+// the best price comes first
+func crossedPrices(book *types.DepthBook, taker *types.Order) []sdk.Dec {
+	var prices []sdk.Dec
+	if taker.Side == types.BuyOrder {
+		// items in depth book are sorted by price desc, sell prices from low to high
+		for i := len(book.Items) - 1; i >= 0; i-- {
+			if book.Items[i].Price.GT(taker.Price) {
+				break
+			}
+			if book.Items[i].SellQuantity.IsPositive() {
+				prices = append(prices, book.Items[i].Price)
+			}
+		}
+	} else {
+		// buy prices from high to low
+		for i := 0; i < len(book.Items); i++ {
+			if book.Items[i].Price.LT(taker.Price) {
+				break
+			}
+			if book.Items[i].BuyQuantity.IsPositive() {
+				prices = append(prices, book.Items[i].Price)
+			}
+		}
+	}
+	return prices
+}
+
+// removeOrderID removes the fully filled taker from orderIDsMap
+func removeOrderID(k keeper.Keeper, order *types.Order) {
+	key := types.FormatOrderIDsKey(order.Product, order.Price, order.Side)
+	orderIDs := k.GetProductPriceOrderIDs(key)
+	for i, orderID := range orderIDs {
+		if orderID == order.OrderID {
+			remainOrderIDs := make([]string, 0, len(orderIDs)-1)
+			remainOrderIDs = append(remainOrderIDs, orderIDs[:i]...)
+			remainOrderIDs = append(remainOrderIDs, orderIDs[i+1:]...)
+			k.SetOrderIDs(key, remainOrderIDs)
+			return
+		}
+	}
+}
+
+// recordDeals adds the deals of a taker into the match result of its product,
+// price of the match result is the latest deal price, and quantity is the total filled quantity of takers
+func recordDeals(resultMap map[string]types.MatchResult, blockHeight int64, taker *types.Order, deals []types.Deal) {
+	matchResult, ok := resultMap[taker.Product]
+	if !ok {
+		matchResult = types.MatchResult{BlockHeight: blockHeight, Quantity: sdk.ZeroDec(), Deals: []types.Deal{}}
+	}
+	for _, deal := range deals {
+		if deal.OrderID == taker.OrderID {
+			matchResult.Quantity = matchResult.Quantity.Add(deal.Quantity)
+		}
+		matchResult.Price = deal.Price
+	}
+	matchResult.Deals = append(matchResult.Deals, deals...)
+	resultMap[taker.Product] = matchResult
+}
+
+// saveMatchResult saves the latest price of the matched products and the match results for querying
+func saveMatchResult(ctx sdk.Context, k keeper.Keeper, resultMap map[string]types.MatchResult, logger log.Logger) {
+	if len(resultMap) == 0 {
+		return
+	}
+
+	for product, matchResult := range resultMap {
+		k.SetLastPrice(ctx, product, matchResult.Price)
+		k.FinishReopening(ctx, product)
+		logger.Info(fmt.Sprintf("matchResult(%d-%s): price: %v, quantity: %v, dealsNum: %d",
+			matchResult.BlockHeight, product, matchResult.Price, matchResult.Quantity, len(matchResult.Deals)))
+	}
+
+	k.AddBlockMatchResult(&types.BlockMatchResult{
+		BlockHeight: ctx.BlockHeight(),
+		ResultMap:   resultMap,
+		TimeStamp:   ctx.BlockHeader().Time.Unix(),
+	})
+}
@@ -1,38 +1,116 @@
 package match
 
 import (
-	"sync"
+	"fmt"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/okex/okexchain/x/order/keeper"
 	"github.com/okex/okexchain/x/order/match/continuousauction"
 	"github.com/okex/okexchain/x/order/match/periodicauction"
+	"github.com/okex/okexchain/x/order/types"
 )
 
 // nolint
-const DefaultAuctionType = "periodicauction"
+const DefaultAuctionType = types.PeriodicAuction
 
 // nolint
-var (
-	once        sync.Once
-	engine      Engine
-	auctionType = DefaultAuctionType
-)
+var engines = make(map[string]Engine)
+
+func init() {
+	RegisterEngine(types.PeriodicAuction, &periodicauction.PaEngine{})
+	RegisterEngine(types.ContinuousAuction, &continuousauction.CaEngine{})
+}
+
+// RegisterEngine registers a match engine, so that it can be chosen by the token pairs
+func RegisterEngine(auctionType string, engine Engine) {
+	engines[auctionType] = engine
+}
+
+// GetEngineNames returns the sorted auction types of all the registered match engines
+func GetEngineNames() []string {
+	names := make([]string, 0, len(engines))
+	for auctionType := range engines {
+		names = append(names, auctionType)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetEngine returns the match engine with the specified auction type,
+// the periodic auction engine is returned if the auction type is unknown
+func GetEngine(auctionType string) Engine {
+	if engine, ok := engines[auctionType]; ok {
+		return engine
+	}
+	return engines[DefaultAuctionType]
+}
 
-// GetEngine : periodic auction only today
-func GetEngine() Engine {
-	once.Do(func() {
-		if auctionType == DefaultAuctionType {
-			engine = &periodicauction.PaEngine{}
-		} else {
-			engine = &continuousauction.CaEngine{}
+// GetProductAuctionType returns the auction type of the product, which is chosen by its token pair,
+// or the MatchEngine param if the token pair doesn't choose one
+func GetProductAuctionType(ctx sdk.Context, k keeper.Keeper, product string) string {
+	auctionType := k.GetParams(ctx).MatchEngine
+	if tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product); tokenPair != nil && tokenPair.MatchEngine != "" {
+		auctionType = tokenPair.MatchEngine
+	}
+	if _, ok := engines[auctionType]; !ok {
+		ctx.Logger().Error(fmt.Sprintf("unknown match engine(%s) of product(%s), use %s instead",
+			auctionType, product, DefaultAuctionType))
+		return DefaultAuctionType
+	}
+	return auctionType
+}
+
+// Run cleans up the expired orders and the orders of delisted products,
+// then runs every match engine with the products dispatched to it, and closes the unfilled immediate orders
+func Run(ctx sdk.Context, k keeper.Keeper) {
+	periodicauction.CleanupExpiredOrders(ctx, k)
+	periodicauction.CleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, k)
+
+	auctionTypes := make([]string, 0, len(engines))
+	for auctionType := range engines {
+		auctionTypes = append(auctionTypes, auctionType)
+	}
+	sort.Strings(auctionTypes)
+
+	// the deals limit per block is shared by all the match engines
+	blockRemainDeals := k.GetParams(ctx).MaxDealsPerBlock
+	for _, auctionType := range auctionTypes {
+		auctionType := auctionType
+		blockRemainDeals = engines[auctionType].Run(ctx, k, func(product string) bool {
+			return GetProductAuctionType(ctx, k, product) == auctionType
+		}, blockRemainDeals)
+	}
+
+	closeImmediateOrders(ctx, k)
+	// post-only orders which have survived the match of their block are resting orders now
+	k.SetPostOnlyOrderIDs(ctx, nil)
+}
+
+// closeImmediateOrders refunds the remaining of market, IOC and FOK orders after matching, except the ones which are
+// still waiting for the match engine: the orders of locked products, and the orders after the cursor of continuous auction
+func closeImmediateOrders(ctx sdk.Context, k keeper.Keeper) {
+	logger := ctx.Logger().With("module", "order")
+	var pendingOrderIDs []string
+	for _, orderID := range k.GetImmediateOrderIDs(ctx) {
+		order := k.GetOrder(ctx, orderID)
+		if order == nil || order.Status != types.OrderStatusOpen {
+			continue
 		}
-	})
-	return engine
+		if k.IsProductLocked(ctx, order.Product) ||
+			(GetProductAuctionType(ctx, k, order.Product) == types.ContinuousAuction &&
+				continuousauction.IsPending(ctx, k, orderID)) {
+			pendingOrderIDs = append(pendingOrderIDs, orderID)
+			continue
+		}
+		k.CloseOrderByTimeInForce(ctx, order, logger)
+	}
+	k.SetImmediateOrderIDs(ctx, pendingOrderIDs)
 }
 
-// nolint
+// Engine matches the orders of the products which are dispatched to it with the deals remaining in the block,
+// and returns the deals remaining after its match
 type Engine interface {
-	Run(ctx sdk.Context, keeper keeper.Keeper)
+	Run(ctx sdk.Context, keeper keeper.Keeper, isDispatched func(product string) bool, blockRemainDeals int64) int64
 }
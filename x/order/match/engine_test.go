@@ -0,0 +1,47 @@
+package match
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// mockEngine makes the deals of its product, and records the deals remaining when it runs
+type mockEngine struct {
+	deals       int64
+	remainDeals int64
+}
+
+func (e *mockEngine) Run(ctx sdk.Context, keeper keeper.Keeper, isDispatched func(product string) bool,
+	blockRemainDeals int64) int64 {
+	e.remainDeals = blockRemainDeals
+	if e.deals > blockRemainDeals {
+		return 0
+	}
+	return blockRemainDeals - e.deals
+}
+
+func TestRunWithSharedDealsLimit(t *testing.T) {
+	testInput := keeper.CreateTestInput(t)
+	k := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	params := types.DefaultTestParams()
+	params.MaxDealsPerBlock = 10
+	k.SetParams(ctx, &params)
+
+	defaultEngines := engines
+	defer func() { engines = defaultEngines }()
+	engineA, engineB, engineC := &mockEngine{deals: 4}, &mockEngine{deals: 8}, &mockEngine{deals: 2}
+	engines = map[string]Engine{"a": engineA, "b": engineB, "c": engineC}
+
+	// the engines run in the order of their auction types, each with the deals left by the previous ones
+	k.ResetCache(ctx)
+	Run(ctx, k)
+	require.EqualValues(t, 10, engineA.remainDeals)
+	require.EqualValues(t, 6, engineB.remainDeals)
+	require.EqualValues(t, 0, engineC.remainDeals)
+}
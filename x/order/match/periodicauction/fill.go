@@ -156,14 +156,16 @@ func fillOrderByKey(ctx sdk.Context, keeper orderkeeper.Keeper, key string,
 		}
 		if filledAmount.Add(order.RemainQuantity).LTE(needFillAmount) {
 			filledAmount = filledAmount.Add(order.RemainQuantity)
-			if deal := fillOrder(order, ctx, keeper, fillPrice, order.RemainQuantity, feeParams); deal != nil {
+			if deal := FillOrder(order, ctx, keeper, fillPrice, order.RemainQuantity, feeParams,
+				isRestingOrder(ctx, order)); deal != nil {
 				deals = append(deals, *deal)
 			}
 
 			filledDealsCnt++
 			index++
 		} else {
-			if deal := fillOrder(order, ctx, keeper, fillPrice, needFillAmount.Sub(filledAmount), feeParams); deal != nil {
+			if deal := FillOrder(order, ctx, keeper, fillPrice, needFillAmount.Sub(filledAmount), feeParams,
+				isRestingOrder(ctx, order)); deal != nil {
 				deals = append(deals, *deal)
 			}
 			filledAmount = needFillAmount
@@ -183,6 +185,12 @@ func fillOrderByKey(ctx sdk.Context, keeper orderkeeper.Keeper, key string,
 	return deals, filledAmount, filledDealsCnt
 }
 
+// isRestingOrder returns true if the order has been resting in the depth book before the current block,
+// it's the maker of the periodic auction
+func isRestingOrder(ctx sdk.Context, order *types.Order) bool {
+	return types.GetBlockHeightFromOrderID(order.OrderID) < ctx.BlockHeight()
+}
+
 func balanceAccount(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
 	fillPrice, fillQuantity sdk.Dec) {
 
@@ -200,7 +208,7 @@ func balanceAccount(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keep
 }
 
 func chargeFee(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper, fillQuantity sdk.Dec,
-	feeParams *types.Params) (dealFee sdk.DecCoins, feeReceiver string) {
+	feeParams *types.Params, isMaker bool) (dealFee sdk.DecCoins, feeReceiver string) {
 	// charge fee
 	fee := orderkeeper.GetZeroFee()
 	if order.Status == types.OrderStatusFilled {
@@ -217,7 +225,7 @@ func chargeFee(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper, f
 			ctx.Logger().Error(fmt.Sprintf("Send fee failed:%s\n", err.Error()))
 		}
 	}
-	dealFee = orderkeeper.GetDealFee(order, fillQuantity, ctx, keeper, feeParams)
+	dealFee = orderkeeper.GetDealFee(order, fillQuantity, ctx, keeper, feeParams, isMaker)
 	feeReceiver, err := keeper.SendFeesToProductOwner(ctx, dealFee, order.Sender, types.FeeTypeOrderDeal, order.Product)
 	if err == nil {
 		order.RecordOrderDealFee(fee)
@@ -225,10 +233,12 @@ func chargeFee(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper, f
 	return
 }
 
-// Fill an order. Update order, charge fee and transfer tokens. Return a deal.
+// FillOrder fills an order. Update order, charge fee and transfer tokens. Return a deal.
 // If an order is fully filled but still lock some coins, unlock it.
-func fillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
-	fillPrice, fillQuantity sdk.Dec, feeParams *types.Params) *types.Deal {
+// The fee is charged at the maker rate if the order is resting in the depth book, otherwise at the taker rate,
+// and the quote amount of the deal in the native token is added to the rolling trade volume of the order sender.
+func FillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
+	fillPrice, fillQuantity sdk.Dec, feeParams *types.Params, isMaker bool) *types.Deal {
 
 	// update order
 	order.Fill(fillPrice, fillQuantity)
@@ -241,7 +251,10 @@ func fillOrder(order *types.Order, ctx sdk.Context, keeper orderkeeper.Keeper,
 		order.Unlock()
 	}
 
-	dealFee, feeReceiver := chargeFee(order, ctx, keeper, fillQuantity, feeParams)
+	dealFee, feeReceiver := chargeFee(order, ctx, keeper, fillQuantity, feeParams, isMaker)
+	volume := keeper.GetNativeVolume(ctx, strings.Split(order.Product, "_")[1], fillPrice.Mul(fillQuantity))
+	keeper.AddTradeVolume(ctx, order.Sender, volume, feeParams.FeeVolumeDays)
 	keeper.UpdateOrder(order, ctx) // update order info on filled
-	return &types.Deal{OrderID: order.OrderID, Side: order.Side, Quantity: fillQuantity, Fee: dealFee.String(), FeeReceiver: feeReceiver}
+	return &types.Deal{OrderID: order.OrderID, Side: order.Side, Price: fillPrice, Quantity: fillQuantity,
+		Fee: dealFee.String(), FeeReceiver: feeReceiver}
 }
@@ -11,8 +11,7 @@ type PaEngine struct {
 }
 
 // nolint
-func (e *PaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
-	cleanupExpiredOrders(ctx, keeper)
-	cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
-	matchOrders(ctx, keeper)
+func (e *PaEngine) Run(ctx sdk.Context, keeper keeper.Keeper, isDispatched func(product string) bool,
+	blockRemainDeals int64) int64 {
+	return matchOrders(ctx, keeper, isDispatched, blockRemainDeals)
 }
@@ -35,7 +35,7 @@ func TestPaEngine_Run(t *testing.T) {
 	}
 
 	engine := &PaEngine{}
-	engine.Run(ctx, keeper)
+	engine.Run(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
 
 	// check order status
 	order0 := keeper.GetOrder(ctx, orders[0].OrderID)
@@ -46,3 +46,7 @@ func TestPaEngine_Run(t *testing.T) {
 	require.EqualValues(t, types.OrderStatusOpen, order2.Status)
 	require.EqualValues(t, sdk.MustNewDecFromStr("2"), order2.RemainQuantity)
 }
+
+func dispatchAll(product string) bool {
+	return true
+}
@@ -75,18 +75,18 @@ func execRule2(buyAmountSum, sellAmountSum []sdk.Dec, indexesRule1 []int) (index
 	return
 }
 
-func execRule3(book *types.DepthBook, offset int, refPrice sdk.Dec, pricePrecision int64,
+func execRule3(book *types.DepthBook, offset int, refPrice sdk.Dec, pricePrecision int64, pressureRate sdk.Dec,
 	indexesRule2 []int, imbalance []sdk.Dec) (bestPrice sdk.Dec) {
 	indexLen2 := len(indexesRule2)
 	if imbalance[indexesRule2[0]-offset].GT(sdk.ZeroDec()) {
 		// rule3a: all imbalances are positive, buy side pressure
-		newRefPrice := refPrice.Mul(sdk.MustNewDecFromStr("1.05"))
+		newRefPrice := refPrice.Mul(sdk.OneDec().Add(pressureRate))
 		newRefPrice = newRefPrice.RoundDecimal(pricePrecision)
 		bestPrice = bestPriceFromRefPrice(book.Items[indexesRule2[0]].Price,
 			book.Items[indexesRule2[indexLen2-1]].Price, newRefPrice)
 	} else if imbalance[indexesRule2[indexLen2-1]-offset].LT(sdk.ZeroDec()) {
 		// rule3b: all imbalances are negative, sell side pressure
-		newRefPrice := refPrice.Mul(sdk.MustNewDecFromStr("0.95"))
+		newRefPrice := refPrice.Mul(sdk.OneDec().Sub(pressureRate))
 		newRefPrice = newRefPrice.RoundDecimal(pricePrecision)
 		bestPrice = bestPriceFromRefPrice(book.Items[indexesRule2[0]].Price,
 			book.Items[indexesRule2[indexLen2-1]].Price, newRefPrice)
@@ -109,15 +109,15 @@ func execRule3(book *types.DepthBook, offset int, refPrice sdk.Dec, pricePrecisi
 //        If more than one price satisfy rule2, following rule3
 // rule3: Market Pressure. There are 3 cases:
 // rule3a: All imbalances are positive. It indicates buy side pressure. Set reference price with
-//         last execute price plus a upper limit percentage(e.g. 5%). Then choose the price
+//         last execute price plus the pressure rate of the token pair(5% by default). Then choose the price
 //         which is closest to reference price.
 // rule3b: All imbalances are negative. It indicates sell side pressure. Set reference price with
-//         last execute price minus a lower limit percentage(e.g. 5%). Then choose the price
+//         last execute price minus the pressure rate of the token pair(5% by default). Then choose the price
 //         which is closest to reference price.
 // rule3c: Otherwise, it indicates no one side pressure. Set reference price with last execute
 //         price. Then choose the price which is closest to reference price.
 func periodicAuctionMatchPrice(book *types.DepthBook, pricePrecision int64,
-	refPrice sdk.Dec) (bestPrice sdk.Dec, maxExecution sdk.Dec) {
+	refPrice sdk.Dec, pressureRate sdk.Dec) (bestPrice sdk.Dec, maxExecution sdk.Dec) {
 
 	buyAmountSum, sellAmountSum := preMatchProcessing(book)
 	if len(buyAmountSum) == 0 {
@@ -141,7 +141,7 @@ func periodicAuctionMatchPrice(book *types.DepthBook, pricePrecision int64,
 		return
 	}
 
-	bestPrice = execRule3(book, indexesRule1[0], refPrice, pricePrecision, indexesRule2, imbalance)
+	bestPrice = execRule3(book, indexesRule1[0], refPrice, pricePrecision, pressureRate, indexesRule2, imbalance)
 
 	return
 }
@@ -196,7 +196,8 @@ func cacheExpiredBlockToCurrentHeight(ctx sdk.Context, keeper keeper.Keeper) {
 	// check orders in expired blocks, remove expired orders by order id
 	for height := lastExpiredBlockHeight + 1; height <= curBlockHeight; height++ {
 		var expiredHeight int64
-		expiredBlocks := keeper.GetExpireBlockHeight(ctx, height)
+		// the orders with a custom expiry are checked at their custom expire heights as well
+		expiredBlocks := append(keeper.GetExpireBlockHeight(ctx, height), keeper.GetCustomExpireBlockHeight(ctx, height)...)
 		for _, expiredHeight = range expiredBlocks {
 			keeper.DropExpiredOrdersByBlockHeight(ctx, expiredHeight)
 			logger.Info(fmt.Sprintf("currentHeight(%d), expire orders at blockHeight(%d)",
@@ -215,13 +216,15 @@ func cacheExpiredBlockToCurrentHeight(ctx sdk.Context, keeper keeper.Keeper) {
 						curBlockHeight, expiredHeight))
 				}
 				keeper.DropExpireBlockHeight(ctx, height)
+				keeper.DropCustomExpireBlockHeight(ctx, height)
 			}
 		}
 		keeper.SetLastExpiredBlockHeight(ctx, height)
 	}
 }
 
-func cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx sdk.Context, keeper keeper.Keeper) {
+// CleanupOrdersWhoseTokenPairHaveBeenDelisted cancels all the orders of the delisted products
+func CleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx sdk.Context, keeper keeper.Keeper) {
 	products := keeper.GetProductsFromDepthBookMap()
 	for _, product := range products {
 		tokenPair := keeper.GetDexKeeper().GetTokenPair(ctx, product)
@@ -250,7 +253,8 @@ func cleanOrdersByOrderIDList(ctx sdk.Context, keeper keeper.Keeper, orderIDList
 	}
 }
 
-func cleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
+// CleanupExpiredOrders expires the orders which reach their expire height and drops the closed orders
+func CleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
 
 	// Look forward to see what height will this block expired
 	markCurBlockToFutureExpireBlockList(ctx, keeper)
@@ -262,19 +266,75 @@ func cleanupExpiredOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	cacheExpiredBlockToCurrentHeight(ctx, keeper)
 }
 
-func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
+// filterDispatchedProducts returns the products which are dispatched to the periodic auction engine
+func filterDispatchedProducts(products []string, isDispatched func(product string) bool) []string {
+	var dispatchedProducts []string
+	for _, product := range products {
+		if isDispatched(product) {
+			dispatchedProducts = append(dispatchedProducts, product)
+		}
+	}
+	return dispatchedProducts
+}
+
+// cancelNonTransferableOrders cancels the open orders of the products with a freezable token, whose senders are
+// frozen or whose token transfers are paused after the orders were placed, so that they are never filled
+func cancelNonTransferableOrders(ctx sdk.Context, k keeper.Keeper, products []string) {
+	logger := ctx.Logger().With("module", "order")
+	for _, product := range products {
+		if !k.IsProductFreezable(ctx, product) {
+			continue
+		}
+
+		var orders []*types.Order
+		for _, item := range k.GetDepthBookCopy(product).Items {
+			for _, side := range []string{types.BuyOrder, types.SellOrder} {
+				for _, orderID := range k.GetProductPriceOrderIDs(types.FormatOrderIDsKey(product, item.Price, side)) {
+					order := k.GetOrder(ctx, orderID)
+					if order != nil && order.Status == types.OrderStatusOpen &&
+						k.CheckOrderTransferable(ctx, order) != nil {
+						orders = append(orders, order)
+					}
+				}
+			}
+		}
+
+		for _, order := range orders {
+			k.CancelOrder(ctx, order, logger)
+		}
+	}
+}
+
+func matchOrders(ctx sdk.Context, keeper keeper.Keeper, isDispatched func(product string) bool,
+	blockRemainDeals int64) int64 {
 	blockHeight := ctx.BlockHeight()
 	orderNum := keeper.GetBlockOrderNum(ctx, blockHeight)
-	// no new orders in this block & no product lock in previous blocks, skip match
-	if orderNum == 0 && !keeper.AnyProductLocked(ctx) {
-		return
+	resumedProducts := ResumeHaltedProducts(ctx, keeper, isDispatched)
+	// no new orders in this block & no product lock in previous blocks & no product resumed, skip match
+	if orderNum == 0 && !keeper.AnyProductLocked(ctx) && len(resumedProducts) == 0 {
+		return blockRemainDeals
 	}
 
-	// step0: get active products
+	// step0: get active products, the halted products are not matched until resumed
 	products := keeper.GetDiskCache().GetNewDepthbookKeys()
+	products = filterHaltedProducts(ctx, keeper, products, resumedProducts)
 	products = keeper.FilterDelistedProducts(ctx, products)
+	products = filterDispatchedProducts(products, isDispatched)
 	keeper.GetDexKeeper().SortProducts(ctx, products) // sort products
 
+	// step0.1: cancel the orders whose senders can't transfer the tokens of the product any more
+	cancelNonTransferableOrders(ctx, keeper, products)
+
+	// step0.2: halt the products whose match prices are out of their price bands, before any order of them is
+	// closed by the time in force or the self-trade prevention for an auction without deals
+	products = haltOutOfBandProducts(ctx, keeper, products)
+
+	// step0.3: reject the FOK and post-only orders which can't be matched as required
+	rejectOrdersByTimeInForce(ctx, keeper, products)
+
+	// step0.4: cancel or decrement the orders which would trade with the orders of the same address
+	selfTradeOrders := preventSelfTrades(ctx, keeper, products)
+
 	// step1: calc best price and max execution for every active product, save latest price
 	//updatedProductsBaseprice := make(map[string]types.MatchResult)
 	updatedProductsBasePrice := calcMatchPriceAndExecution(ctx, keeper, products)
@@ -282,22 +342,26 @@ func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	// step1.1: recover locked depth book
 	lockMap := keeper.GetDexKeeper().GetLockedProductsCopy(ctx)
 	for product := range lockMap.Data {
-		products = append(products, product)
+		if isDispatched(product) {
+			products = append(products, product)
+		}
 	}
 	keeper.GetDexKeeper().SortProducts(ctx, products) // sort products
 
 	// step2: execute match results, fill orders in match results, transfer tokens and collect fees
-	executeMatch(ctx, keeper, products, updatedProductsBasePrice, lockMap)
+	blockRemainDeals = executeMatch(ctx, keeper, products, updatedProductsBasePrice, lockMap, blockRemainDeals)
 
 	// step3: save match results for querying
-	if len(updatedProductsBasePrice) > 0 {
+	if len(updatedProductsBasePrice) > 0 || len(selfTradeOrders) > 0 {
 		blockMatchResult := &types.BlockMatchResult{
-			BlockHeight: blockHeight,
-			ResultMap:   updatedProductsBasePrice,
-			TimeStamp:   ctx.BlockHeader().Time.Unix(),
+			BlockHeight:     blockHeight,
+			ResultMap:       updatedProductsBasePrice,
+			TimeStamp:       ctx.BlockHeader().Time.Unix(),
+			SelfTradeOrders: selfTradeOrders,
 		}
-		keeper.SetBlockMatchResult(blockMatchResult)
+		keeper.AddBlockMatchResult(blockMatchResult)
 	}
+	return blockRemainDeals
 }
 
 func calcMatchPriceAndExecution(ctx sdk.Context, k keeper.Keeper, products []string) map[string]types.MatchResult {
@@ -309,9 +373,18 @@ func calcMatchPriceAndExecution(ctx sdk.Context, k keeper.Keeper, products []str
 			continue
 		}
 		book := k.GetDepthBookCopy(product)
-		bestPrice, maxExecution := periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit,
-			k.GetLastPrice(ctx, product))
+		priceBand := tokenPair.GetPriceBand()
+		lastPrice := k.GetLastPrice(ctx, product)
+		bestPrice, maxExecution := periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit, lastPrice,
+			priceBand.PressureRate)
 		if maxExecution.IsPositive() {
+			// the reopening auction after a halt isn't limited by the price band
+			if k.IsProductReopening(ctx, product) {
+				k.FinishReopening(ctx, product)
+			} else if priceBand.IsOutOfBand(lastPrice, bestPrice) {
+				HaltProduct(ctx, k, product, priceBand.HaltBlocks, lastPrice, bestPrice)
+				continue
+			}
 			k.SetLastPrice(ctx, product, bestPrice)
 			resultMap[product] = types.MatchResult{BlockHeight: ctx.BlockHeight(), Price: bestPrice,
 				Quantity: maxExecution, Deals: []types.Deal{}}
@@ -416,10 +489,10 @@ func executeLockedProduct(ctx sdk.Context, k keeper.Keeper,
 }
 
 func executeMatch(ctx sdk.Context, k keeper.Keeper, products []string,
-	updatedProductsBasePrice map[string]types.MatchResult, lockMap *types.ProductLockMap) {
+	updatedProductsBasePrice map[string]types.MatchResult, lockMap *types.ProductLockMap,
+	blockRemainDeals int64) int64 {
 	logger := ctx.Logger().With("module", "order")
 	feeParams := k.GetParams(ctx)
-	blockRemainDeals := feeParams.MaxDealsPerBlock
 
 	for _, product := range products {
 		if _, ok := updatedProductsBasePrice[product]; ok {
@@ -430,4 +503,5 @@ func executeMatch(ctx sdk.Context, k keeper.Keeper, products []string,
 				blockRemainDeals, product, logger)
 		}
 	}
+	return blockRemainDeals
 }
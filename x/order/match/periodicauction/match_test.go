@@ -5,7 +5,9 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/okex/okexchain/x/common"
 	"github.com/okex/okexchain/x/dex"
+	dextypes "github.com/okex/okexchain/x/dex/types"
 	orderkeeper "github.com/okex/okexchain/x/order/keeper"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -46,7 +48,7 @@ func runPeriodicAuctionMatchPriceTest(t *testing.T, testData *MatchTestData, che
 	require.Nil(t, err)
 	needres, err := sdk.NewDecFromStr(testData.output)
 	require.Nil(t, err)
-	bestPrice, _ := periodicAuctionMatchPrice(&book, testData.pricePrecision, refPrice)
+	bestPrice, _ := periodicAuctionMatchPrice(&book, testData.pricePrecision, refPrice, dextypes.DefaultPressureRate)
 	if check {
 		if !needres.Equal(bestPrice) {
 			t.Fatalf("need:%s calc:%s\n", needres.String(), bestPrice.String())
@@ -171,6 +173,18 @@ func TestPeriodicAuctionMatchPriceRule3A(t *testing.T) {
 	runPeriodicAuctionMatchPriceTest(t, &data, true)
 }
 
+func TestPeriodicAuctionMatchPriceRule3AWithPressureRate(t *testing.T) {
+	book := &types.DepthBook{Items: []types.DepthBookItem{
+		{Price: sdk.NewDec(102), BuyQuantity: sdk.NewDec(60), SellQuantity: sdk.ZeroDec()},
+		{Price: sdk.NewDec(100), BuyQuantity: sdk.ZeroDec(), SellQuantity: sdk.NewDec(20)},
+		{Price: sdk.NewDec(95), BuyQuantity: sdk.ZeroDec(), SellQuantity: sdk.NewDec(30)},
+	}}
+
+	// the reference price moves to 101 instead of 105 with the pressure rate of 1%
+	bestPrice, _ := periodicAuctionMatchPrice(book, 1, sdk.NewDec(100), sdk.NewDecWithPrec(1, 2))
+	require.EqualValues(t, sdk.NewDec(101), bestPrice)
+}
+
 func TestPeriodicAuctionMatchPriceRule3B(t *testing.T) {
 	data := MatchTestData{
 		items: []BookItemTestData{{
@@ -209,7 +223,8 @@ func TestPeriodicAuctionMatchPriceRule3C(t *testing.T) {
 
 func TestPeriodicAuctionMatchPriceByEmptyDepthBook(t *testing.T) {
 	depthBook := &types.DepthBook{}
-	bestPrice, maxExecution := periodicAuctionMatchPrice(depthBook, 10, sdk.MustNewDecFromStr("10.0"))
+	bestPrice, maxExecution := periodicAuctionMatchPrice(depthBook, 10, sdk.MustNewDecFromStr("10.0"),
+		dextypes.DefaultPressureRate)
 
 	require.EqualValues(t, sdk.ZeroDec(), bestPrice)
 	require.EqualValues(t, sdk.ZeroDec(), maxExecution)
@@ -299,7 +314,8 @@ func TestExecRule3(t *testing.T) {
 	indexesRule2 := []int{1, 2}
 	imbalance := []sdk.Dec{sdk.NewDec(0.0), sdk.NewDec(0.0)}
 
-	bestPrice := execRule3(depthBook, indexesRule1[0], refPrice, pricePrecision, indexesRule2, imbalance)
+	bestPrice := execRule3(depthBook, indexesRule1[0], refPrice, pricePrecision, dextypes.DefaultPressureRate,
+		indexesRule2, imbalance)
 	require.EqualValues(t, sdk.NewDec(10), bestPrice)
 }
 
@@ -339,7 +355,11 @@ func TestExpireOrdersInExpiredBlock(t *testing.T) {
 		require.EqualValues(t, nil, err)
 	}
 
-	keeper.DropExpiredOrdersByBlockHeight(ctx, ctx.BlockHeight())
+	// the orders are kept before they reach the expiry
+	expiredCtx := ctx.WithBlockHeight(ctx.BlockHeight() + keeper.GetParams(ctx).OrderExpireBlocks)
+	keeper.DropExpiredOrdersByBlockHeight(expiredCtx.WithBlockHeight(expiredCtx.BlockHeight()-1), ctx.BlockHeight())
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, "ID0000000000-1").Status)
+	keeper.DropExpiredOrdersByBlockHeight(expiredCtx, ctx.BlockHeight())
 
 	order := keeper.GetOrder(ctx, "ID0000000000-1")
 	require.NotEqual(t, nil, order)
@@ -407,10 +427,11 @@ func TestCacheExpiredBlockToCurrentHeight(t *testing.T) {
 		require.EqualValues(t, nil, err)
 	}
 
-	keeper.DropExpiredOrdersByBlockHeight(ctx, ctx.BlockHeight())
-	keeper.SetExpireBlockHeight(ctx, ctx.BlockHeight(), []int64{ctx.BlockHeight()})
+	expiredCtx := ctx.WithBlockHeight(ctx.BlockHeight() + keeper.GetParams(ctx).OrderExpireBlocks)
+	keeper.DropExpiredOrdersByBlockHeight(expiredCtx, ctx.BlockHeight())
+	keeper.SetExpireBlockHeight(ctx, expiredCtx.BlockHeight(), []int64{ctx.BlockHeight()})
 
-	cacheExpiredBlockToCurrentHeight(ctx, keeper)
+	cacheExpiredBlockToCurrentHeight(expiredCtx, keeper)
 
 	num := keeper.GetCache().GetExpireNum()
 	require.EqualValues(t, len(orders), int(num))
@@ -441,7 +462,7 @@ func TestCleanupExpiredOrders(t *testing.T) {
 	keeper.SetLastClosedOrderIDs(ctx, []string{orders[0].OrderID})
 	keeper.ExpireOrder(ctx, orders[1], ctx.Logger())
 
-	cleanupExpiredOrders(ctx, keeper)
+	CleanupExpiredOrders(ctx, keeper)
 
 	expiredBlocks := keeper.GetExpireBlockHeight(ctx, ctx.BlockHeight()+
 		feeParams.OrderExpireBlocks)
@@ -481,7 +502,7 @@ func TestMatchOrders(t *testing.T) {
 		depthBook.InsertOrder(orders[i])
 	}
 
-	matchOrders(ctx, keeper)
+	matchOrders(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
 
 	depthBook = keeper.GetDepthBookCopy(types.TestTokenPair)
 	require.EqualValues(t, 1, len(depthBook.Items))
@@ -509,7 +530,7 @@ func TestMatchOrders(t *testing.T) {
 	lockProduct(ctx, keeper, ctx.Logger(), types.TestTokenPair, updatedProductsBasePrice[types.TestTokenPair],
 		sdk.ZeroDec(), sdk.ZeroDec())
 
-	matchOrders(ctx, keeper)
+	matchOrders(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
 
 	depthBook = keeper.GetDepthBookCopy(types.TestTokenPair)
 	require.EqualValues(t, 1, len(depthBook.Items))
@@ -518,6 +539,105 @@ func TestMatchOrders(t *testing.T) {
 	require.EqualValues(t, sdk.MustNewDecFromStr("2"), depthBook.Items[0].SellQuantity)
 }
 
+func TestMatchOrdersHaltedByPriceBand(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	priceBand := dextypes.NewPriceBand(dextypes.DefaultPressureRate, sdk.NewDecWithPrec(1, 1), 3)
+	tokenPair.PriceBand = &priceBand
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// the match price 12 moves more than 10% from the last price 10
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "12", "2.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "12", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "12", "1.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[0]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[1]
+	orders[2].TimeInForce = types.TimeInForcePostOnly
+	for _, order := range orders {
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+
+	// the post-only order isn't rejected by the halted auction
+	matchOrders(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	require.True(t, keeper.IsProductHalted(ctx, types.TestTokenPair))
+	require.EqualValues(t, 13, keeper.GetHaltedProducts(ctx)[types.TestTokenPair])
+	require.EqualValues(t, sdk.NewDec(10), keeper.GetLastPrice(ctx, types.TestTokenPair))
+	require.EqualValues(t, 1, len(keeper.GetDepthBookCopy(types.TestTokenPair).Items))
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[2].OrderID).Status)
+	events := ctx.EventManager().Events()
+	require.EqualValues(t, types.EventTypeHaltProduct, events[len(events)-1].Type)
+
+	// the product is still halted before the cool-down passes
+	ctx = ctx.WithBlockHeight(12)
+	matchOrders(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	require.True(t, keeper.IsProductHalted(ctx, types.TestTokenPair))
+
+	// the product is resumed and matched without new orders after the cool-down,
+	// the reopening auction re-anchors the last price out of the price band
+	ctx = ctx.WithBlockHeight(13)
+	matchOrders(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	require.False(t, keeper.IsProductHalted(ctx, types.TestTokenPair))
+	require.False(t, keeper.IsProductReopening(ctx, types.TestTokenPair))
+	require.EqualValues(t, sdk.NewDec(12), keeper.GetLastPrice(ctx, types.TestTokenPair))
+	require.NotEqual(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[2].OrderID).Status)
+	depthBook := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(depthBook.Items))
+	require.EqualValues(t, sdk.OneDec(), depthBook.Items[0].BuyQuantity)
+}
+
+func TestMatchOrdersOfFrozenAccount(t *testing.T) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	tokenInfo := testInput.TokenKeeper.GetTokenInfo(ctx, common.TestToken)
+	tokenInfo.Symbol = common.TestToken
+	tokenInfo.Freezable = true
+	testInput.TokenKeeper.UpdateToken(ctx, tokenInfo)
+
+	// the seller is frozen after placing the open order
+	sellOrder := mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0")
+	sellOrder.Sender = testInput.TestAddrs[1]
+	sellerCoins := testInput.TokenKeeper.GetCoins(ctx, testInput.TestAddrs[1])
+	require.NoError(t, keeper.PlaceOrder(ctx, sellOrder))
+	testInput.TokenKeeper.SetFrozen(ctx, common.TestToken, testInput.TestAddrs[1], true)
+
+	buyOrder := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	buyOrder.Sender = testInput.TestAddrs[0]
+	require.NoError(t, keeper.PlaceOrder(ctx, buyOrder))
+
+	// the frozen seller can't place any new order of the product
+	newOrder := mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	newOrder.Sender = testInput.TestAddrs[1]
+	_, err = keeper.TryPlaceOrder(ctx, newOrder)
+	require.Error(t, err)
+
+	matchOrders(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+
+	// the order of the frozen seller is cancelled instead of filled, the buy order keeps open
+	require.EqualValues(t, types.OrderStatusCancelled, keeper.GetOrder(ctx, sellOrder.OrderID).Status)
+	buyOrder = keeper.GetOrder(ctx, buyOrder.OrderID)
+	require.EqualValues(t, types.OrderStatusOpen, buyOrder.Status)
+	require.EqualValues(t, sdk.OneDec(), buyOrder.RemainQuantity)
+	require.True(t, testInput.TokenKeeper.GetLockedCoins(ctx, testInput.TestAddrs[1]).IsZero())
+	require.EqualValues(t, sellerCoins.AmountOf(common.TestToken),
+		testInput.TokenKeeper.GetCoins(ctx, testInput.TestAddrs[1]).AmountOf(common.TestToken))
+
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.EqualValues(t, 1, len(book.Items))
+	require.True(t, book.Items[0].SellQuantity.IsZero())
+	require.EqualValues(t, sdk.OneDec(), book.Items[0].BuyQuantity)
+}
+
 func TestMatchOrdersByEmptyBlock(t *testing.T) {
 	testInput := orderkeeper.CreateTestInput(t)
 	keeper := testInput.OrderKeeper
@@ -526,7 +646,7 @@ func TestMatchOrdersByEmptyBlock(t *testing.T) {
 	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
 	require.Nil(t, err)
 
-	matchOrders(ctx, keeper)
+	matchOrders(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
 	require.EqualValues(t, int64(0), keeper.GetBlockOrderNum(ctx, ctx.BlockHeight()))
 	require.EqualValues(t, false, keeper.AnyProductLocked(ctx))
 }
@@ -835,7 +955,7 @@ func TestExecuteMatch(t *testing.T) {
 	}
 	keeper.GetDexKeeper().SortProducts(ctx, products) // sort products
 
-	executeMatch(ctx, keeper, products, updatedProductsBasePrice, lockMap)
+	executeMatch(ctx, keeper, products, updatedProductsBasePrice, lockMap, keeper.GetParams(ctx).MaxDealsPerBlock)
 
 	depthBook = keeper.GetDepthBookCopy(types.TestTokenPair)
 	require.EqualValues(t, 1, len(depthBook.Items))
@@ -868,7 +988,7 @@ func TestCleanupOrdersWhoseTokenPairHaveBeenDelisted(t *testing.T) {
 		depthBook.InsertOrder(orders[i])
 	}
 
-	cleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
+	CleanupOrdersWhoseTokenPairHaveBeenDelisted(ctx, keeper)
 
 	depthBook = keeper.GetDepthBookCopy(types.TestTokenPair)
 	require.EqualValues(t, 0, len(depthBook.Items))
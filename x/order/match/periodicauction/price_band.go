@@ -0,0 +1,87 @@
+package periodicauction
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// HaltProduct halts the auction of the product for the halt blocks of its price band, because its match price
+// moves too far from the last price
+func HaltProduct(ctx sdk.Context, k keeper.Keeper, product string, haltBlocks int64, lastPrice, matchPrice sdk.Dec) {
+	resumeHeight := ctx.BlockHeight() + haltBlocks
+	k.HaltProduct(ctx, product, resumeHeight)
+	ctx.Logger().With("module", "order").Info(fmt.Sprintf("BlockHeight<%d> halt product(%s) until %d, "+
+		"last price: %s, match price: %s", ctx.BlockHeight(), product, resumeHeight, lastPrice, matchPrice))
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeHaltProduct,
+		sdk.NewAttribute(types.AttributeKeyProduct, product),
+		sdk.NewAttribute(types.AttributeKeyLastPrice, lastPrice.String()),
+		sdk.NewAttribute(types.AttributeKeyMatchPrice, matchPrice.String()),
+		sdk.NewAttribute(types.AttributeKeyResumeHeight, strconv.FormatInt(resumeHeight, 10)),
+	))
+}
+
+// haltOutOfBandProducts halts the products whose match prices on the current depth books are out of their price
+// bands, and returns the other products. The reopening products aren't limited by the price band
+func haltOutOfBandProducts(ctx sdk.Context, k keeper.Keeper, products []string) []string {
+	var activeProducts []string
+	for _, product := range products {
+		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
+		if tokenPair != nil && !k.IsProductReopening(ctx, product) {
+			priceBand := tokenPair.GetPriceBand()
+			lastPrice := k.GetLastPrice(ctx, product)
+			matchPrice, maxExecution := periodicAuctionMatchPrice(k.GetDepthBookCopy(product), tokenPair.MaxPriceDigit,
+				lastPrice, priceBand.PressureRate)
+			if maxExecution.IsPositive() && priceBand.IsOutOfBand(lastPrice, matchPrice) {
+				HaltProduct(ctx, k, product, priceBand.HaltBlocks, lastPrice, matchPrice)
+				continue
+			}
+		}
+		activeProducts = append(activeProducts, product)
+	}
+	return activeProducts
+}
+
+// ResumeHaltedProducts resumes the halted products whose cool-down has passed, and returns them sorted,
+// so that their depth books are matched in the current block even without new orders
+func ResumeHaltedProducts(ctx sdk.Context, k keeper.Keeper, isDispatched func(product string) bool) []string {
+	var resumedProducts []string
+	for product, resumeHeight := range k.GetHaltedProducts(ctx) {
+		if resumeHeight > ctx.BlockHeight() || !isDispatched(product) {
+			continue
+		}
+		k.ResumeProduct(ctx, product)
+		resumedProducts = append(resumedProducts, product)
+	}
+	sort.Strings(resumedProducts)
+
+	for _, product := range resumedProducts {
+		ctx.Logger().With("module", "order").Info(fmt.Sprintf("BlockHeight<%d> resume product(%s)",
+			ctx.BlockHeight(), product))
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeResumeProduct,
+			sdk.NewAttribute(types.AttributeKeyProduct, product),
+		))
+	}
+	return resumedProducts
+}
+
+// filterHaltedProducts removes the halted products, and appends the resumed products which are not in the products
+func filterHaltedProducts(ctx sdk.Context, k keeper.Keeper, products []string, resumedProducts []string) []string {
+	productSet := make(map[string]bool, len(products))
+	var activeProducts []string
+	for _, product := range append(products, resumedProducts...) {
+		if productSet[product] || k.IsProductHalted(ctx, product) {
+			continue
+		}
+		productSet[product] = true
+		activeProducts = append(activeProducts, product)
+	}
+	return activeProducts
+}
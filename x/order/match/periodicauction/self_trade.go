@@ -0,0 +1,168 @@
+package periodicauction
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// maxSelfTradePasses is the max number of the dry runs to prevent the self-trades of a product in a block,
+// which bounds the cost of the self-trade prevention in the end blocker
+var maxSelfTradePasses = 10
+
+// selfTrade is the buy and sell orders of an address which would be filled in the same auction,
+// sorted from the oldest to the newest
+type selfTrade struct {
+	buyOrders  []*types.Order
+	sellOrders []*types.Order
+	buyFilled  sdk.Dec
+	sellFilled sdk.Dec
+}
+
+// placedBefore returns true if the order has been placed before the other one
+func placedBefore(order, other *types.Order) bool {
+	height, num, _ := types.ParseOrderID(order.OrderID)
+	otherHeight, otherNum, _ := types.ParseOrderID(other.OrderID)
+	if height != otherHeight {
+		return height < otherHeight
+	}
+	return num < otherNum
+}
+
+// findSelfTrades returns the self-trades of the addresses whose buy and sell orders would be both filled,
+// ordered by address
+func findSelfTrades(ctx sdk.Context, k keeper.Keeper, filledMap map[string]sdk.Dec) []*selfTrade {
+	tradesMap := make(map[string]*selfTrade)
+	for orderID, filled := range filledMap {
+		order := k.GetOrder(ctx, orderID)
+		if order == nil || !filled.IsPositive() {
+			continue
+		}
+		addr := order.Sender.String()
+		trade, ok := tradesMap[addr]
+		if !ok {
+			trade = &selfTrade{buyFilled: sdk.ZeroDec(), sellFilled: sdk.ZeroDec()}
+			tradesMap[addr] = trade
+		}
+		if order.Side == types.BuyOrder {
+			trade.buyOrders = append(trade.buyOrders, order)
+			trade.buyFilled = trade.buyFilled.Add(filled)
+		} else {
+			trade.sellOrders = append(trade.sellOrders, order)
+			trade.sellFilled = trade.sellFilled.Add(filled)
+		}
+	}
+
+	addrs := make([]string, 0, len(tradesMap))
+	for addr, trade := range tradesMap {
+		if len(trade.buyOrders) > 0 && len(trade.sellOrders) > 0 {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Strings(addrs)
+	trades := make([]*selfTrade, 0, len(addrs))
+	for _, addr := range addrs {
+		trade := tradesMap[addr]
+		for _, orders := range [][]*types.Order{trade.buyOrders, trade.sellOrders} {
+			orders := orders
+			sort.Slice(orders, func(i, j int) bool { return placedBefore(orders[i], orders[j]) })
+		}
+		trades = append(trades, trade)
+	}
+	return trades
+}
+
+// cancelSelfTradeOrder cancels the order by the self-trade prevention
+func cancelSelfTradeOrder(ctx sdk.Context, k keeper.Keeper, order *types.Order,
+	logger log.Logger) types.SelfTradeOrder {
+	quantity := order.RemainQuantity
+	k.CloseOrderBySelfTrade(ctx, order, logger)
+	return types.SelfTradeOrder{OrderID: order.OrderID, Product: order.Product, Quantity: quantity,
+		Status: order.Status}
+}
+
+// decrementSelfTradeOrders decrements the orders from the newest to the oldest by the quantity in total,
+// the order is cancelled if its remaining quantity isn't more than the quantity to be decremented
+func decrementSelfTradeOrders(ctx sdk.Context, k keeper.Keeper, orders []*types.Order, quantity sdk.Dec,
+	logger log.Logger) []types.SelfTradeOrder {
+	var selfTradeOrders []types.SelfTradeOrder
+	for i := len(orders) - 1; i >= 0 && quantity.IsPositive(); i-- {
+		order := orders[i]
+		if order.RemainQuantity.LTE(quantity) {
+			quantity = quantity.Sub(order.RemainQuantity)
+			selfTradeOrders = append(selfTradeOrders, cancelSelfTradeOrder(ctx, k, order, logger))
+			continue
+		}
+		k.ReduceOrder(ctx, order, quantity)
+		selfTradeOrders = append(selfTradeOrders, types.SelfTradeOrder{OrderID: order.OrderID,
+			Product: order.Product, Quantity: quantity, Status: order.Status})
+		quantity = sdk.ZeroDec()
+	}
+	return selfTradeOrders
+}
+
+// preventSelfTrade cancels or decrements the orders of the self-trade by the mode
+func preventSelfTrade(ctx sdk.Context, k keeper.Keeper, trade *selfTrade, mode string,
+	logger log.Logger) []types.SelfTradeOrder {
+	newestBuy, newestSell := trade.buyOrders[len(trade.buyOrders)-1], trade.sellOrders[len(trade.sellOrders)-1]
+	switch mode {
+	case types.SelfTradePreventionCancelNewest:
+		if placedBefore(newestBuy, newestSell) {
+			return []types.SelfTradeOrder{cancelSelfTradeOrder(ctx, k, newestSell, logger)}
+		}
+		return []types.SelfTradeOrder{cancelSelfTradeOrder(ctx, k, newestBuy, logger)}
+	case types.SelfTradePreventionCancelOldest:
+		if placedBefore(trade.buyOrders[0], trade.sellOrders[0]) {
+			return []types.SelfTradeOrder{cancelSelfTradeOrder(ctx, k, trade.buyOrders[0], logger)}
+		}
+		return []types.SelfTradeOrder{cancelSelfTradeOrder(ctx, k, trade.sellOrders[0], logger)}
+	case types.SelfTradePreventionCancelBoth:
+		var selfTradeOrders []types.SelfTradeOrder
+		for _, order := range append(trade.buyOrders, trade.sellOrders...) {
+			selfTradeOrders = append(selfTradeOrders, cancelSelfTradeOrder(ctx, k, order, logger))
+		}
+		return selfTradeOrders
+	default:
+		quantity := sdk.MinDec(trade.buyFilled, trade.sellFilled)
+		return append(decrementSelfTradeOrders(ctx, k, trade.buyOrders, quantity, logger),
+			decrementSelfTradeOrders(ctx, k, trade.sellOrders, quantity, logger)...)
+	}
+}
+
+// preventSelfTrades applies the self-trade prevention mode of the params before filling. An address trades with
+// itself if both its buy and sell orders would be filled at the match price. The self-trades of all the addresses
+// found by a dry run are prevented together. Cancelling or decrementing orders changes the match price, so the check
+// is repeated until no self-trade is found, at most maxSelfTradePasses times for a product.
+func preventSelfTrades(ctx sdk.Context, k keeper.Keeper, products []string) []types.SelfTradeOrder {
+	mode := k.GetParams(ctx).SelfTradePrevention
+	if !types.IsSelfTradePrevented(mode) {
+		return nil
+	}
+
+	logger := ctx.Logger().With("module", "order")
+	var selfTradeOrders []types.SelfTradeOrder
+	for _, product := range products {
+		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
+		if tokenPair == nil || k.IsProductLocked(ctx, product) {
+			continue
+		}
+
+		for pass := 0; pass < maxSelfTradePasses; pass++ {
+			book := k.GetDepthBookCopy(product)
+			bestPrice, maxExecution := periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit,
+				k.GetLastPrice(ctx, product), tokenPair.GetPriceBand().PressureRate)
+			trades := findSelfTrades(ctx, k, dryRunFill(ctx, k, product, book, bestPrice, maxExecution))
+			if len(trades) == 0 {
+				break
+			}
+			for _, trade := range trades {
+				selfTradeOrders = append(selfTradeOrders, preventSelfTrade(ctx, k, trade, mode, logger)...)
+			}
+		}
+	}
+	return selfTradeOrders
+}
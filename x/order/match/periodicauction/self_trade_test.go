@@ -0,0 +1,139 @@
+package periodicauction
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/okexchain/x/dex"
+	orderkeeper "github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// prepareSelfTradeTest places the orders with the senders indexed by senderIndexes
+func prepareSelfTradeTest(t *testing.T, mode string, orders []*types.Order,
+	senderIndexes []int) (sdk.Context, orderkeeper.Keeper) {
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	err := testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+
+	params := types.DefaultParams()
+	params.SelfTradePrevention = mode
+	keeper.SetParams(ctx, &params)
+
+	for i, order := range orders {
+		order.Sender = testInput.TestAddrs[senderIndexes[i]]
+		require.NoError(t, keeper.PlaceOrder(ctx, order))
+	}
+	return ctx, keeper
+}
+
+func TestPreventSelfTrades(t *testing.T) {
+	tests := []struct {
+		mode     string
+		statuses []int64
+	}{
+		{types.SelfTradePreventionNone, []int64{types.OrderStatusFilled, types.OrderStatusFilled}},
+		{types.SelfTradePreventionCancelNewest, []int64{types.OrderStatusOpen, types.OrderStatusSelfTradeCancelled}},
+		{types.SelfTradePreventionCancelOldest, []int64{types.OrderStatusSelfTradeCancelled, types.OrderStatusOpen}},
+		{types.SelfTradePreventionCancelBoth,
+			[]int64{types.OrderStatusSelfTradeCancelled, types.OrderStatusSelfTradeCancelled}},
+		{types.SelfTradePreventionDecrement,
+			[]int64{types.OrderStatusSelfTradeCancelled, types.OrderStatusSelfTradeCancelled}},
+	}
+
+	for _, test := range tests {
+		orders := []*types.Order{
+			mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+			mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		}
+		ctx, keeper := prepareSelfTradeTest(t, test.mode, orders, []int{0, 0})
+
+		matchOrders(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+		for i, order := range orders {
+			require.EqualValues(t, test.statuses[i], keeper.GetOrder(ctx, order.OrderID).Status, test.mode)
+		}
+		if test.mode == types.SelfTradePreventionNone {
+			require.Nil(t, keeper.GetBlockMatchResult().SelfTradeOrders)
+		} else {
+			require.EqualValues(t, 0, len(keeper.GetBlockMatchResult().ResultMap), test.mode)
+			require.NotEmpty(t, keeper.GetBlockMatchResult().SelfTradeOrders, test.mode)
+		}
+	}
+}
+
+func TestPreventSelfTradesByDecrement(t *testing.T) {
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.1", "2.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.1", "1.0"),
+	}
+	ctx, keeper := prepareSelfTradeTest(t, types.SelfTradePreventionDecrement, orders, []int{0, 0, 1})
+
+	// the buy order is decremented by 1, and the sell order of the same address is cancelled
+	matchOrders(ctx, keeper, dispatchAll, keeper.GetParams(ctx).MaxDealsPerBlock)
+	buyOrder := keeper.GetOrder(ctx, orders[0].OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, buyOrder.Status)
+	require.EqualValues(t, sdk.OneDec(), buyOrder.Quantity)
+	require.EqualValues(t, types.OrderStatusSelfTradeCancelled, keeper.GetOrder(ctx, orders[1].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusFilled, keeper.GetOrder(ctx, orders[2].OrderID).Status)
+
+	result := keeper.GetBlockMatchResult()
+	require.EqualValues(t, sdk.OneDec(), result.ResultMap[types.TestTokenPair].Quantity)
+	require.EqualValues(t, []types.SelfTradeOrder{
+		{OrderID: orders[0].OrderID, Product: types.TestTokenPair, Quantity: sdk.OneDec(),
+			Status: types.OrderStatusOpen},
+		{OrderID: orders[1].OrderID, Product: types.TestTokenPair, Quantity: sdk.OneDec(),
+			Status: types.OrderStatusSelfTradeCancelled},
+	}, result.SelfTradeOrders)
+}
+
+func TestPreventSelfTradesWithMaxPasses(t *testing.T) {
+	newOrders := func() []*types.Order {
+		return []*types.Order{
+			mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+			mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+			mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+			mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		}
+	}
+
+	// the newest order is cancelled in every pass until the address doesn't trade with itself
+	orders := newOrders()
+	ctx, keeper := prepareSelfTradeTest(t, types.SelfTradePreventionCancelNewest, orders, []int{0, 0, 0, 0})
+	selfTradeOrders := preventSelfTrades(ctx, keeper, []string{types.TestTokenPair})
+	require.EqualValues(t, 2, len(selfTradeOrders))
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[0].OrderID).Status)
+	require.EqualValues(t, types.OrderStatusOpen, keeper.GetOrder(ctx, orders[2].OrderID).Status)
+
+	// the passes are bounded
+	defaultMaxPasses := maxSelfTradePasses
+	defer func() { maxSelfTradePasses = defaultMaxPasses }()
+	maxSelfTradePasses = 1
+	orders = newOrders()
+	ctx, keeper = prepareSelfTradeTest(t, types.SelfTradePreventionCancelNewest, orders, []int{0, 0, 0, 0})
+	selfTradeOrders = preventSelfTrades(ctx, keeper, []string{types.TestTokenPair})
+	require.EqualValues(t, 1, len(selfTradeOrders))
+	require.EqualValues(t, orders[3].OrderID, selfTradeOrders[0].OrderID)
+}
+
+func TestPreventSelfTradesOfAddressesInOnePass(t *testing.T) {
+	orders := []*types.Order{
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0"),
+		mockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+	}
+	ctx, keeper := prepareSelfTradeTest(t, types.SelfTradePreventionCancelBoth, orders, []int{0, 0, 1, 1})
+
+	defaultMaxPasses := maxSelfTradePasses
+	defer func() { maxSelfTradePasses = defaultMaxPasses }()
+	maxSelfTradePasses = 1
+	require.EqualValues(t, 4, len(preventSelfTrades(ctx, keeper, []string{types.TestTokenPair})))
+	for _, order := range orders {
+		require.EqualValues(t, types.OrderStatusSelfTradeCancelled, keeper.GetOrder(ctx, order.OrderID).Status)
+	}
+}
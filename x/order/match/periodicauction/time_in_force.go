@@ -0,0 +1,110 @@
+package periodicauction
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// getTimeInForceOrders returns the open FOK and post-only orders of the products, grouped by product
+func getTimeInForceOrders(ctx sdk.Context, k keeper.Keeper, products []string) map[string][]*types.Order {
+	productSet := make(map[string]bool, len(products))
+	for _, product := range products {
+		productSet[product] = true
+	}
+
+	ordersMap := make(map[string][]*types.Order)
+	orderIDs := append(k.GetImmediateOrderIDs(ctx), k.GetPostOnlyOrderIDs(ctx)...)
+	for _, orderID := range orderIDs {
+		order := k.GetOrder(ctx, orderID)
+		if order == nil || order.Status != types.OrderStatusOpen || !productSet[order.Product] {
+			continue
+		}
+		if order.TimeInForce == types.TimeInForceFOK || order.TimeInForce == types.TimeInForcePostOnly {
+			ordersMap[order.Product] = append(ordersMap[order.Product], order)
+		}
+	}
+	return ordersMap
+}
+
+// rejectOrdersByTimeInForce removes the FOK orders which can't be fully filled and the post-only orders which
+// would be filled from the depth book before matching. Removing an order may change the match price,
+// so the check is repeated until no more order is rejected.
+func rejectOrdersByTimeInForce(ctx sdk.Context, k keeper.Keeper, products []string) {
+	logger := ctx.Logger().With("module", "order")
+	ordersMap := getTimeInForceOrders(ctx, k, products)
+
+	for _, product := range products {
+		orders := ordersMap[product]
+		tokenPair := k.GetDexKeeper().GetTokenPair(ctx, product)
+		if len(orders) == 0 || tokenPair == nil {
+			continue
+		}
+
+		for len(orders) > 0 {
+			book := k.GetDepthBookCopy(product)
+			bestPrice, maxExecution := periodicAuctionMatchPrice(book, tokenPair.MaxPriceDigit,
+				k.GetLastPrice(ctx, product), tokenPair.GetPriceBand().PressureRate)
+			filledMap := dryRunFill(ctx, k, product, book, bestPrice, maxExecution)
+
+			var remainOrders []*types.Order
+			for _, order := range orders {
+				filled, ok := filledMap[order.OrderID]
+				if !ok {
+					filled = sdk.ZeroDec()
+				}
+				if (order.TimeInForce == types.TimeInForceFOK && filled.LT(order.RemainQuantity)) ||
+					(order.TimeInForce == types.TimeInForcePostOnly && filled.IsPositive()) {
+					k.CloseOrderByTimeInForce(ctx, order, logger)
+					continue
+				}
+				remainOrders = append(remainOrders, order)
+			}
+
+			if len(remainOrders) == len(orders) {
+				break
+			}
+			orders = remainOrders
+		}
+	}
+}
+
+// dryRunFill returns the quantity of every order which would be filled at the best price, following the same
+// priority as fillDepthBook, without updating anything
+func dryRunFill(ctx sdk.Context, k keeper.Keeper, product string, book *types.DepthBook,
+	bestPrice, maxExecution sdk.Dec) map[string]sdk.Dec {
+
+	filledMap := make(map[string]sdk.Dec)
+	if !maxExecution.IsPositive() {
+		return filledMap
+	}
+
+	allocate := func(price sdk.Dec, side string, remain sdk.Dec) sdk.Dec {
+		key := types.FormatOrderIDsKey(product, price, side)
+		for _, orderID := range k.GetProductPriceOrderIDs(key) {
+			if !remain.IsPositive() {
+				break
+			}
+			order := k.GetOrder(ctx, orderID)
+			if order == nil {
+				continue
+			}
+			filled := sdk.MinDec(order.RemainQuantity, remain)
+			filledMap[orderID] = filled
+			remain = remain.Sub(filled)
+		}
+		return remain
+	}
+
+	// buy orders from high price to low, sell orders from low price to high
+	remain := maxExecution
+	for i := 0; i < len(book.Items) && book.Items[i].Price.GTE(bestPrice) && remain.IsPositive(); i++ {
+		remain = allocate(book.Items[i].Price, types.BuyOrder, remain)
+	}
+	remain = maxExecution
+	for i := len(book.Items) - 1; i >= 0 && book.Items[i].Price.LTE(bestPrice) && remain.IsPositive(); i-- {
+		remain = allocate(book.Items[i].Price, types.SellOrder, remain)
+	}
+	return filledMap
+}
@@ -8,6 +8,8 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgNewOrders{}, "okexchain/order/MsgNew", nil)
 	cdc.RegisterConcrete(MsgCancelOrders{}, "okexchain/order/MsgCancel", nil)
+	cdc.RegisterConcrete(MsgAmendOrders{}, "okexchain/order/MsgAmend", nil)
+	cdc.RegisterConcrete(MsgRouteOrder{}, "okexchain/order/MsgRoute", nil)
 }
 
 // ModuleCdc generic sealed codec to be used throughout this module
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const conditionalOrderIDPrefix = "CID"
+
+// ConditionalOrder is an order item waiting for the last price to reach its trigger price,
+// it's placed as a regular order when triggered. The coins of the order are locked until it's triggered,
+// cancelled or expired
+type ConditionalOrder struct {
+	OrderID      string         `json:"order_id"`
+	TxHash       string         `json:"txhash"`
+	Sender       sdk.AccAddress `json:"sender"`
+	Item         OrderItem      `json:"item"`
+	Ratio        string         `json:"ratio"` // ratio of fee per block of the order item in its msg
+	Timestamp    int64          `json:"timestamp"`
+	Locked       sdk.DecCoins   `json:"locked"`
+	ExpireHeight int64          `json:"expire_height"`
+}
+
+// NewConditionalOrder creates a new conditional order
+func NewConditionalOrder(txHash string, sender sdk.AccAddress, item OrderItem, ratio string,
+	timestamp int64) *ConditionalOrder {
+	return &ConditionalOrder{
+		TxHash:    txHash,
+		Sender:    sender,
+		Item:      item,
+		Ratio:     ratio,
+		Timestamp: timestamp,
+	}
+}
+
+func (order *ConditionalOrder) String() string {
+	if orderJSON, err := json.Marshal(order); err != nil {
+		panic(err)
+	} else {
+		return string(orderJSON)
+	}
+}
+
+// IsTriggered returns true if the conditional order is triggered at the last price.
+// A stop-loss sell order and a take-profit buy order are triggered when the price falls to the trigger price,
+// the others are triggered when the price rises to the trigger price
+func (order *ConditionalOrder) IsTriggered(lastPrice sdk.Dec) bool {
+	if !lastPrice.IsPositive() {
+		return false
+	}
+	if order.TriggerDirection() == TriggerDirectionFall {
+		return lastPrice.LTE(order.Item.TriggerPrice)
+	}
+	return lastPrice.GTE(order.Item.TriggerPrice)
+}
+
+// TriggerDirection returns the direction of the price movement that triggers the conditional order
+func (order *ConditionalOrder) TriggerDirection() byte {
+	if (order.Item.Condition == ConditionStopLoss) == (order.Item.Side == SellOrder) {
+		return TriggerDirectionFall
+	}
+	return TriggerDirectionRise
+}
+
+// NeedLockCoins returns the coins to lock for the conditional order. The quantity of a market buy order is
+// the quantity of quote token it spends
+func (order *ConditionalOrder) NeedLockCoins() sdk.DecCoins {
+	symbols := strings.Split(order.Item.Product, "_")
+	if order.Item.Side == SellOrder {
+		return sdk.DecCoins{sdk.NewDecCoinFromDec(symbols[0], order.Item.Quantity)}
+	}
+	amount := order.Item.Quantity
+	if order.Item.Type != MarketOrder {
+		amount = order.Item.Price.Mul(order.Item.Quantity)
+	}
+	return sdk.DecCoins{sdk.NewDecCoinFromDec(symbols[1], amount)}
+}
+
+// GetOrderItem returns the order item to be placed when the conditional order is triggered
+func (order *ConditionalOrder) GetOrderItem() OrderItem {
+	item := order.Item
+	item.Condition = ""
+	item.TriggerPrice = sdk.Dec{}
+	return item
+}
+
+// FormatConditionalOrderID formats the id of the conditional order with its sequence
+func FormatConditionalOrderID(seq int64) string {
+	return fmt.Sprintf("%s%010d", conditionalOrderIDPrefix, seq)
+}
+
+// IsConditionalOrderID returns true if the order id belongs to a conditional order
+func IsConditionalOrderID(orderID string) bool {
+	return strings.HasPrefix(orderID, conditionalOrderIDPrefix)
+}
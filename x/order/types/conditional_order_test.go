@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalOrderIsTriggered(t *testing.T) {
+	newConditionalOrder := func(side, condition string) *ConditionalOrder {
+		item := NewOrderItem(TestTokenPair, side, "10.0", "1.0")
+		item.Condition = condition
+		item.TriggerPrice = sdk.MustNewDecFromStr("10.0")
+		return NewConditionalOrder("", nil, item, "1", 0)
+	}
+	lower, trigger, higher := sdk.MustNewDecFromStr("9.9"), sdk.MustNewDecFromStr("10.0"), sdk.MustNewDecFromStr("10.1")
+
+	// triggered when the price falls
+	for _, order := range []*ConditionalOrder{
+		newConditionalOrder(SellOrder, ConditionStopLoss),
+		newConditionalOrder(BuyOrder, ConditionTakeProfit),
+	} {
+		require.True(t, order.IsTriggered(lower))
+		require.True(t, order.IsTriggered(trigger))
+		require.False(t, order.IsTriggered(higher))
+	}
+
+	// triggered when the price rises
+	for _, order := range []*ConditionalOrder{
+		newConditionalOrder(BuyOrder, ConditionStopLoss),
+		newConditionalOrder(SellOrder, ConditionTakeProfit),
+	} {
+		require.False(t, order.IsTriggered(lower))
+		require.True(t, order.IsTriggered(trigger))
+		require.True(t, order.IsTriggered(higher))
+	}
+
+	// no last price
+	require.False(t, newConditionalOrder(SellOrder, ConditionStopLoss).IsTriggered(sdk.ZeroDec()))
+
+	item := newConditionalOrder(SellOrder, ConditionStopLoss).GetOrderItem()
+	require.EqualValues(t, "", item.Condition)
+	require.True(t, item.TriggerPrice.IsNil())
+}
+
+func TestConditionalOrderID(t *testing.T) {
+	orderID := FormatConditionalOrderID(12)
+	require.EqualValues(t, "CID0000000012", orderID)
+	require.True(t, IsConditionalOrderID(orderID))
+	require.False(t, IsConditionalOrderID(FormatOrderID(10, 1)))
+}
@@ -16,3 +16,29 @@ const (
 	BuyOrder            = "BUY"
 	SellOrder           = "SELL"
 )
+
+// nolint : names of the match engines
+const (
+	PeriodicAuction   = "periodicauction"
+	ContinuousAuction = "continuousauction"
+)
+
+// nolint : types of orders
+const (
+	LimitOrder  = "LIMIT"
+	MarketOrder = "MARKET"
+)
+
+// nolint : time in force of orders
+const (
+	TimeInForceGTC      = "GTC"       // good till cancelled, rests on the depth book until it's expired
+	TimeInForceIOC      = "IOC"       // immediate or cancel, the unfilled part is cancelled after its first match
+	TimeInForceFOK      = "FOK"       // fill or kill, killed if it can't be filled entirely in its first match
+	TimeInForcePostOnly = "POST_ONLY" // rejected if it would be filled in its first match, so it's always a maker
+)
+
+// nolint : conditions of the conditional orders
+const (
+	ConditionStopLoss   = "STOP_LOSS"   // triggered when the price moves against the position
+	ConditionTakeProfit = "TAKE_PROFIT" // triggered when the price moves in favor of the position
+)
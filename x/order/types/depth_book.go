@@ -10,6 +10,7 @@ import (
 type Deal struct {
 	OrderID     string  `json:"order_id"`
 	Side        string  `json:"side"`
+	Price       sdk.Dec `json:"price"`
 	Quantity    sdk.Dec `json:"quantity"`
 	Fee         string  `json:"fee"`
 	FeeReceiver string  `json:"fee_receiver"`
@@ -25,9 +26,10 @@ type MatchResult struct {
 
 // nolint
 type BlockMatchResult struct {
-	BlockHeight int64                  `json:"block_height"`
-	ResultMap   map[string]MatchResult `json:"result_map"`
-	TimeStamp   int64                  `json:"timestamp"`
+	BlockHeight     int64                  `json:"block_height"`
+	ResultMap       map[string]MatchResult `json:"result_map"`
+	TimeStamp       int64                  `json:"timestamp"`
+	SelfTradeOrders []SelfTradeOrder       `json:"self_trade_orders,omitempty"`
 }
 
 // nolint
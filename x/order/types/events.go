@@ -0,0 +1,12 @@
+package types
+
+// order module event types
+const (
+	EventTypeHaltProduct   = "halt_product"
+	EventTypeResumeProduct = "resume_product"
+
+	AttributeKeyProduct      = "product"
+	AttributeKeyLastPrice    = "last_price"
+	AttributeKeyMatchPrice   = "match_price"
+	AttributeKeyResumeHeight = "resume_height"
+)
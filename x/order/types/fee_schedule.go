@@ -0,0 +1,112 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeTier discounts the trade fee of the addresses whose rolling trade volume reaches MinVolume
+type FeeTier struct {
+	MinVolume sdk.Dec `json:"min_volume"` // trade volume counted in the native token
+	Discount  sdk.Dec `json:"discount"`   // 0.2 means paying 80% of the fee
+}
+
+// NewFeeTier creates a new fee tier
+func NewFeeTier(minVolume, discount sdk.Dec) FeeTier {
+	return FeeTier{MinVolume: minVolume, Discount: discount}
+}
+
+func (tier FeeTier) String() string {
+	return fmt.Sprintf("{MinVolume: %s, Discount: %s}", tier.MinVolume, tier.Discount)
+}
+
+// FeeTiers is the volume based fee tiers
+type FeeTiers []FeeTier
+
+// Validate checks that the min volumes of the tiers are ascending, and the discounts are in [0, 1]
+func (tiers FeeTiers) Validate() error {
+	minVolume := sdk.ZeroDec()
+	for i, tier := range tiers {
+		if tier.MinVolume.IsNil() || tier.Discount.IsNil() {
+			return fmt.Errorf("min volume and discount of fee tier %d can't be empty", i)
+		}
+		if tier.MinVolume.IsNegative() || (i > 0 && tier.MinVolume.LTE(minVolume)) {
+			return fmt.Errorf("min volume(%s) of fee tier %d should be more than the previous one", tier.MinVolume, i)
+		}
+		if tier.Discount.IsNegative() || tier.Discount.GT(sdk.OneDec()) {
+			return fmt.Errorf("discount(%s) of fee tier %d should be in [0, 1]", tier.Discount, i)
+		}
+		minVolume = tier.MinVolume
+	}
+	return nil
+}
+
+// GetDiscount returns the discount of the highest tier which the trade volume reaches,
+// zero is returned if the volume doesn't reach any tier
+func (tiers FeeTiers) GetDiscount(volume sdk.Dec) sdk.Dec {
+	discount, minVolume := sdk.ZeroDec(), sdk.ZeroDec()
+	for _, tier := range tiers {
+		if volume.GTE(tier.MinVolume) && tier.MinVolume.GTE(minVolume) {
+			discount, minVolume = tier.Discount, tier.MinVolume
+		}
+	}
+	return discount
+}
+
+// PairFee overrides the maker and taker fee rates of a product
+type PairFee struct {
+	Product      string  `json:"product"`
+	MakerFeeRate sdk.Dec `json:"maker_fee_rate"`
+	TakerFeeRate sdk.Dec `json:"taker_fee_rate"`
+}
+
+// NewPairFee creates a new fee rate override of the product
+func NewPairFee(product string, makerFeeRate, takerFeeRate sdk.Dec) PairFee {
+	return PairFee{Product: product, MakerFeeRate: makerFeeRate, TakerFeeRate: takerFeeRate}
+}
+
+func (pf PairFee) String() string {
+	return fmt.Sprintf("{Product: %s, MakerFeeRate: %s, TakerFeeRate: %s}", pf.Product, pf.MakerFeeRate,
+		pf.TakerFeeRate)
+}
+
+// validatePairFeeRates checks that the products are unique, and the fee rates are in [0, 1]
+func validatePairFeeRates(pairFeeRates []PairFee) error {
+	products := make(map[string]bool, len(pairFeeRates))
+	for _, pf := range pairFeeRates {
+		if pf.Product == "" || products[pf.Product] {
+			return fmt.Errorf("product(%s) of pair fee rates should be unique and not empty", pf.Product)
+		}
+		products[pf.Product] = true
+		for _, feeRate := range []sdk.Dec{pf.MakerFeeRate, pf.TakerFeeRate} {
+			if feeRate.IsNil() || feeRate.IsNegative() || feeRate.GT(sdk.OneDec()) {
+				return fmt.Errorf("fee rates of product(%s) should be in [0, 1]", pf.Product)
+			}
+		}
+	}
+	return nil
+}
+
+// GetFeeRate returns the fee rate of a maker or taker order of the product, overridden by PairFeeRates,
+// and discounted by the fee tier which the rolling trade volume of the order sender reaches
+func (p Params) GetFeeRate(product string, isMaker bool, volume sdk.Dec) sdk.Dec {
+	feeRate := p.TradeFeeRate
+	if isMaker {
+		feeRate = p.MakerFeeRate
+	}
+	for _, pf := range p.PairFeeRates {
+		if pf.Product == product {
+			feeRate = pf.TakerFeeRate
+			if isMaker {
+				feeRate = pf.MakerFeeRate
+			}
+			break
+		}
+	}
+	feeRate = feeRate.Mul(sdk.OneDec().Sub(p.FeeTiers.GetDiscount(volume)))
+	if feeRate.IsNegative() {
+		return sdk.ZeroDec()
+	}
+	return feeRate
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeTiers_GetDiscount(t *testing.T) {
+	tiers := FeeTiers{
+		NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("0.1")),
+		NewFeeTier(sdk.NewDec(10000), sdk.MustNewDecFromStr("0.2")),
+	}
+	require.EqualValues(t, sdk.ZeroDec(), FeeTiers{}.GetDiscount(sdk.NewDec(10000)))
+	require.EqualValues(t, sdk.ZeroDec(), tiers.GetDiscount(sdk.NewDec(999)))
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.1"), tiers.GetDiscount(sdk.NewDec(1000)))
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.2"), tiers.GetDiscount(sdk.NewDec(20000)))
+}
+
+func TestParams_GetFeeRate(t *testing.T) {
+	params := DefaultParams()
+	require.EqualValues(t, params.MakerFeeRate, params.GetFeeRate(TestTokenPair, true, sdk.ZeroDec()))
+	require.EqualValues(t, params.TradeFeeRate, params.GetFeeRate(TestTokenPair, false, sdk.ZeroDec()))
+
+	params.MakerFeeRate = sdk.MustNewDecFromStr("0.0005")
+	params.FeeTiers = FeeTiers{NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("0.5"))}
+	params.PairFeeRates = []PairFee{NewPairFee("xxb_yyb", sdk.ZeroDec(), sdk.MustNewDecFromStr("0.002"))}
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.00025"), params.GetFeeRate(TestTokenPair, true, sdk.NewDec(1000)))
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.001"), params.GetFeeRate("xxb_yyb", false, sdk.NewDec(1000)))
+	require.EqualValues(t, sdk.ZeroDec(), params.GetFeeRate("xxb_yyb", true, sdk.NewDec(1000)))
+
+	// the fee rate can't be negative with a discount more than 100%
+	params.FeeTiers = FeeTiers{NewFeeTier(sdk.ZeroDec(), sdk.NewDec(2))}
+	require.EqualValues(t, sdk.ZeroDec(), params.GetFeeRate(TestTokenPair, false, sdk.ZeroDec()))
+}
+
+func TestValidateParams(t *testing.T) {
+	params := DefaultParams()
+	require.NoError(t, ValidateParams(params))
+
+	params.FeeTiers = FeeTiers{
+		NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("0.1")),
+		NewFeeTier(sdk.NewDec(10000), sdk.MustNewDecFromStr("0.2")),
+	}
+	params.PairFeeRates = []PairFee{NewPairFee(TestTokenPair, sdk.ZeroDec(), sdk.MustNewDecFromStr("0.002"))}
+	require.NoError(t, ValidateParams(params))
+
+	invalidTiers := []FeeTiers{
+		{NewFeeTier(sdk.NewDec(1000), sdk.Dec{})},
+		{NewFeeTier(sdk.NewDec(-1), sdk.MustNewDecFromStr("0.1"))},
+		{NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("0.1")), NewFeeTier(sdk.NewDec(1000), sdk.OneDec())},
+		{NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("1.1"))},
+		{NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("-0.1"))},
+	}
+	for _, tiers := range invalidTiers {
+		params.FeeTiers = tiers
+		require.Error(t, ValidateParams(params))
+	}
+	params.FeeTiers = nil
+
+	invalidPairFeeRates := [][]PairFee{
+		{NewPairFee("", sdk.ZeroDec(), sdk.ZeroDec())},
+		{NewPairFee(TestTokenPair, sdk.ZeroDec(), sdk.ZeroDec()), NewPairFee(TestTokenPair, sdk.ZeroDec(), sdk.ZeroDec())},
+		{NewPairFee(TestTokenPair, sdk.Dec{}, sdk.ZeroDec())},
+		{NewPairFee(TestTokenPair, sdk.ZeroDec(), sdk.NewDec(2))},
+	}
+	for _, pairFeeRates := range invalidPairFeeRates {
+		params.PairFeeRates = pairFeeRates
+		require.Error(t, ValidateParams(params))
+	}
+}
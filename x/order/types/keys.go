@@ -25,6 +25,7 @@ const (
 	QueryParameters  = "params"
 	QueryStore       = "store"
 	QueryDepthBookV2 = "depthbookV2"
+	QueryOrderRoute  = "route"
 
 	OrderStoreKey = ModuleName
 )
@@ -42,10 +43,21 @@ var (
 	OrderNumPerBlockKey  = []byte{0x16}
 
 	// none iterator keys
-	RecentlyClosedOrderIDsKey = []byte{0x17}
-	LastExpiredBlockHeightKey = []byte{0x18}
-	OpenOrderNumKey           = []byte{0x19}
-	StoreOrderNumKey          = []byte{0x20}
+	RecentlyClosedOrderIDsKey  = []byte{0x17}
+	LastExpiredBlockHeightKey  = []byte{0x18}
+	OpenOrderNumKey            = []byte{0x19}
+	StoreOrderNumKey           = []byte{0x20}
+	ContinuousAuctionCursorKey = []byte{0x21}
+	ImmediateOrderIDsKey       = []byte{0x22}
+	PostOnlyOrderIDsKey        = []byte{0x23}
+	ConditionalOrderKey        = []byte{0x24}
+	TriggerIndexKey            = []byte{0x25}
+	ConditionalOrderSeqKey     = []byte{0x26}
+	ConditionalOrderExpireKey  = []byte{0x27}
+	ProductHaltKey             = []byte{0x28}
+	TradeVolumeKey             = []byte{0x29}
+	ProductReopenKey           = []byte{0x2A}
+	CustomExpireBlockHeightKey = []byte{0x2B}
 )
 
 // nolint
@@ -53,6 +65,50 @@ func GetOrderKey(key string) []byte {
 	return append(OrderKey, []byte(key)...)
 }
 
+// GetConditionalOrderKey returns the key of the conditional order
+func GetConditionalOrderKey(orderID string) []byte {
+	return append(ConditionalOrderKey, []byte(orderID)...)
+}
+
+// the directions of the conditional orders in the trigger index
+const (
+	TriggerDirectionFall byte = 0x00 // triggered when the last price falls to the trigger price
+	TriggerDirectionRise byte = 0x01 // triggered when the last price rises to the trigger price
+)
+
+// triggerPriceLength is the length of the big-endian trigger price in the trigger index
+const triggerPriceLength = 32
+
+// GetTriggerIndexPrefix returns the prefix of the conditional orders of the product in the direction
+func GetTriggerIndexPrefix(product string, direction byte) []byte {
+	key := append(append([]byte{}, TriggerIndexKey...), []byte(product)...)
+	return append(key, 0x00, direction)
+}
+
+// GetTriggerPriceKey returns the prefix of the conditional orders of the product in the direction at the trigger
+// price. The price is encoded in big-endian with a fixed length, so that the conditional orders are ordered by price
+func GetTriggerPriceKey(product string, direction byte, price sdk.Dec) []byte {
+	priceBytes := make([]byte, triggerPriceLength)
+	bz := price.Int.Bytes()
+	copy(priceBytes[triggerPriceLength-len(bz):], bz)
+	return append(GetTriggerIndexPrefix(product, direction), priceBytes...)
+}
+
+// GetTriggerIndexKey returns the key of the conditional order in the trigger index
+func GetTriggerIndexKey(product string, direction byte, price sdk.Dec, orderID string) []byte {
+	return append(GetTriggerPriceKey(product, direction, price), []byte(orderID)...)
+}
+
+// GetConditionalOrderExpirePrefix returns the prefix of the conditional orders which expire at the block height
+func GetConditionalOrderExpirePrefix(blockHeight int64) []byte {
+	return append(ConditionalOrderExpireKey, sdk.Uint64ToBigEndian(uint64(blockHeight))...)
+}
+
+// GetConditionalOrderExpireKey returns the key of the conditional order in the expire index
+func GetConditionalOrderExpireKey(blockHeight int64, orderID string) []byte {
+	return append(GetConditionalOrderExpirePrefix(blockHeight), []byte(orderID)...)
+}
+
 // nolint
 func GetDepthBookKey(key string) []byte {
 	return append(DepthBookKey, []byte(key)...)
@@ -78,6 +134,27 @@ func GetExpireBlockHeightKey(blockHeight int64) []byte {
 	return append(ExpireBlockHeightKey, sdk.Uint64ToBigEndian(uint64(blockHeight))...)
 }
 
+// GetCustomExpireBlockHeightKey returns the key of the block heights whose orders are checked for the custom expiry
+// at the block height
+func GetCustomExpireBlockHeightKey(blockHeight int64) []byte {
+	return append(CustomExpireBlockHeightKey, sdk.Uint64ToBigEndian(uint64(blockHeight))...)
+}
+
+// GetProductHaltKey returns the key of the height that the halted product resumes at
+func GetProductHaltKey(product string) []byte {
+	return append(ProductHaltKey, []byte(product)...)
+}
+
+// GetProductReopenKey returns the key of the resumed product whose next match is a reopening one
+func GetProductReopenKey(product string) []byte {
+	return append(ProductReopenKey, []byte(product)...)
+}
+
+// GetTradeVolumeKey returns the key of the daily trade volumes of the address
+func GetTradeVolumeKey(addr sdk.AccAddress) []byte {
+	return append(TradeVolumeKey, addr.Bytes()...)
+}
+
 // nolint
 func FormatOrderIDsKey(product string, price sdk.Dec, side string) string {
 	return fmt.Sprintf("%v:%v:%v", product, price.String(), side)
@@ -17,11 +17,16 @@ const (
 
 // nolint
 type MsgNewOrder struct {
-	Sender   sdk.AccAddress `json:"sender"`   // order maker address
-	Product  string         `json:"product"`  // product for trading pair in full name of the tokens
-	Side     string         `json:"side"`     // BUY/SELL
-	Price    sdk.Dec        `json:"price"`    // price of the order
-	Quantity sdk.Dec        `json:"quantity"` // quantity of the order
+	Sender      sdk.AccAddress `json:"sender"`        // order maker address
+	Product     string         `json:"product"`       // product for trading pair in full name of the tokens
+	Side        string         `json:"side"`          // BUY/SELL
+	Price       sdk.Dec        `json:"price"`         // price of the order
+	Quantity    sdk.Dec        `json:"quantity"`      // quantity of the order
+	Type        string         `json:"type"`          // LIMIT/MARKET
+	TimeInForce string         `json:"time_in_force"` // GTC/IOC/FOK/POST_ONLY
+	// the order expires at the earlier one of the custom expiry and the default expiry
+	ExpireHeight int64 `json:"expire_height"`
+	ExpireTime   int64 `json:"expire_time"`
 }
 
 // NewMsgNewOrder is a constructor function for MsgNewOrder
@@ -63,12 +68,23 @@ type MsgNewOrders struct {
 	OrderItems []OrderItem    `json:"order_items"`
 }
 
-// nolint
+// OrderItem is a limit order by default. A market order has no price, it buys with the quantity of quote token,
+// or sells the quantity of base token
 type OrderItem struct {
-	Product  string  `json:"product"`  // product for trading pair in full name of the tokens
-	Side     string  `json:"side"`     // BUY/SELL
-	Price    sdk.Dec `json:"price"`    // price of the order
-	Quantity sdk.Dec `json:"quantity"` // quantity of the order
+	Product     string  `json:"product"`                 // product for trading pair in full name of the tokens
+	Side        string  `json:"side"`                    // BUY/SELL
+	Price       sdk.Dec `json:"price"`                   // price of the order
+	Quantity    sdk.Dec `json:"quantity"`                // quantity of the order
+	Type        string  `json:"type,omitempty"`          // LIMIT/MARKET, empty means LIMIT
+	TimeInForce string  `json:"time_in_force,omitempty"` // GTC/IOC/FOK/POST_ONLY, empty means GTC
+
+	// the order item is placed when the last price reaches the trigger price, if the condition is set
+	Condition    string  `json:"condition,omitempty"` // STOP_LOSS/TAKE_PROFIT
+	TriggerPrice sdk.Dec `json:"trigger_price,omitempty"`
+
+	// custom expiry of the order, it can't be later than the default expiry of OrderExpireBlocks
+	ExpireHeight int64 `json:"expire_height,omitempty"` // good till the block height
+	ExpireTime   int64 `json:"expire_time,omitempty"`   // good till the unix timestamp
 }
 
 // nolint
@@ -82,6 +98,17 @@ func NewOrderItem(product string, side string, price string,
 	}
 }
 
+// NewMarketOrderItem creates a market order item, quantity is the amount of quote token to spend when buying,
+// or the amount of base token to sell when selling
+func NewMarketOrderItem(product string, side string, quantity string) OrderItem {
+	return OrderItem{
+		Product:  product,
+		Side:     side,
+		Quantity: sdk.MustNewDecFromStr(quantity),
+		Type:     MarketOrder,
+	}
+}
+
 // NewMsgNewOrders is a constructor function for MsgNewOrder
 func NewMsgNewOrders(sender sdk.AccAddress, orderItems []OrderItem) MsgNewOrders {
 	return MsgNewOrders{
@@ -122,14 +149,73 @@ func (msg MsgNewOrders) ValidateBasic() sdk.Error {
 			return sdk.ErrUnknownRequest(
 				fmt.Sprintf("Side is expected to be \"BUY\" or \"SELL\", but got \"%s\"", item.Side))
 		}
-		if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
-			return sdk.ErrUnknownRequest("Price/Quantity must be positive")
+		switch item.Type {
+		case "", LimitOrder:
+			if !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
+				return sdk.ErrUnknownRequest("Price/Quantity must be positive")
+			}
+		case MarketOrder:
+			if !item.Price.IsNil() && !item.Price.IsZero() {
+				return sdk.ErrUnknownRequest("Price of market order must be empty")
+			}
+			if !item.Quantity.IsPositive() {
+				return sdk.ErrUnknownRequest("Quantity must be positive")
+			}
+		default:
+			return sdk.ErrUnknownRequest(
+				fmt.Sprintf("Type is expected to be \"LIMIT\" or \"MARKET\", but got \"%s\"", item.Type))
+		}
+		if err := validateTimeInForce(item); err != nil {
+			return err
+		}
+		if err := validateCondition(item); err != nil {
+			return err
+		}
+		if item.ExpireHeight < 0 || item.ExpireTime < 0 {
+			return sdk.ErrUnknownRequest("ExpireHeight and ExpireTime can't be negative")
+		}
+		if item.ExpireHeight > 0 && item.ExpireTime > 0 {
+			return sdk.ErrUnknownRequest("only one of ExpireHeight and ExpireTime can be set")
 		}
 	}
 
 	return nil
 }
 
+// validateTimeInForce checks the time in force of the order item, a market order is always immediate
+func validateTimeInForce(item OrderItem) sdk.Error {
+	switch item.TimeInForce {
+	case "", TimeInForceIOC, TimeInForceFOK:
+		return nil
+	case TimeInForceGTC, TimeInForcePostOnly:
+		if item.Type == MarketOrder {
+			return sdk.ErrUnknownRequest(
+				fmt.Sprintf("TimeInForce of market order can't be \"%s\"", item.TimeInForce))
+		}
+		return nil
+	default:
+		return sdk.ErrUnknownRequest(fmt.Sprintf(
+			"TimeInForce is expected to be \"GTC\", \"IOC\", \"FOK\" or \"POST_ONLY\", but got \"%s\"",
+			item.TimeInForce))
+	}
+}
+
+// validateCondition checks the condition and the trigger price of a conditional order item
+func validateCondition(item OrderItem) sdk.Error {
+	switch item.Condition {
+	case "":
+		return nil
+	case ConditionStopLoss, ConditionTakeProfit:
+		if item.TriggerPrice.IsNil() || !item.TriggerPrice.IsPositive() {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("TriggerPrice(%s) must be positive", item.TriggerPrice))
+		}
+		return nil
+	default:
+		return sdk.ErrUnknownRequest(fmt.Sprintf(
+			"Condition is expected to be \"STOP_LOSS\" or \"TAKE_PROFIT\", but got \"%s\"", item.Condition))
+	}
+}
+
 // GetSignBytes : encodes the message for signing
 func (msg MsgNewOrders) GetSignBytes() []byte {
 	bz := ModuleCdc.MustMarshalJSON(msg)
@@ -218,9 +304,171 @@ func (msg MsgCancelOrders) CalculateGas(gasUnit uint64) uint64 {
 	return uint64(len(msg.OrderIDs)) * gasUnit
 }
 
+//********************MsgAmendOrders*************
+// MsgAmendOrders amends the price and quantity of open orders atomically. An order is reduced in place
+// when its price is unchanged and its quantity is reduced, otherwise it's cancelled and replaced by a new order
+type MsgAmendOrders struct {
+	Sender     sdk.AccAddress   `json:"sender"` // order maker address
+	AmendItems []AmendOrderItem `json:"amend_items"`
+}
+
+// AmendOrderItem is the new price and quantity of an order
+type AmendOrderItem struct {
+	OrderID  string  `json:"order_id"` // order to be amended
+	Price    sdk.Dec `json:"price"`    // new price of the order
+	Quantity sdk.Dec `json:"quantity"` // new quantity of the order, including the filled quantity
+}
+
+// NewAmendOrderItem creates an amend order item
+func NewAmendOrderItem(orderID string, price string, quantity string) AmendOrderItem {
+	return AmendOrderItem{
+		OrderID:  orderID,
+		Price:    sdk.MustNewDecFromStr(price),
+		Quantity: sdk.MustNewDecFromStr(quantity),
+	}
+}
+
+// NewMsgAmendOrders is a constructor function for MsgAmendOrders
+func NewMsgAmendOrders(sender sdk.AccAddress, amendItems []AmendOrderItem) MsgAmendOrders {
+	return MsgAmendOrders{
+		Sender:     sender,
+		AmendItems: amendItems,
+	}
+}
+
+// nolint
+func (msg MsgAmendOrders) Route() string { return "order" }
+
+// nolint
+func (msg MsgAmendOrders) Type() string { return "amend" }
+
+// ValidateBasic : Implements Msg.
+func (msg MsgAmendOrders) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if len(msg.AmendItems) == 0 {
+		return sdk.ErrUnknownRequest("invalid AmendItems")
+	}
+	if len(msg.AmendItems) > OrderItemLimit {
+		return sdk.ErrUnknownRequest("Numbers of AmendOrderItem should not be more than " + strconv.Itoa(OrderItemLimit))
+	}
+	orderIDs := make([]string, 0, len(msg.AmendItems))
+	for _, item := range msg.AmendItems {
+		if item.OrderID == "" {
+			return sdk.ErrUnknownRequest("orderID cannot be empty")
+		}
+		if item.Price.IsNil() || item.Quantity.IsNil() || !(item.Price.IsPositive() && item.Quantity.IsPositive()) {
+			return sdk.ErrUnknownRequest("Price/Quantity must be positive")
+		}
+		orderIDs = append(orderIDs, item.OrderID)
+	}
+	if hasDuplicatedID(orderIDs) {
+		return sdk.ErrUnknownRequest("Duplicated order ids detected")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgAmendOrders) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgAmendOrders) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// Calculate customize gas
+func (msg MsgAmendOrders) CalculateGas(gasUnit uint64) uint64 {
+	return uint64(len(msg.AmendItems)) * gasUnit
+}
+
+// AmendOrderResult is the result of amending an order, NewOrderID is the id of the order which replaces it,
+// or the same as OrderID if the order is reduced in place
+type AmendOrderResult struct {
+	Code       sdk.CodeType `json:"code"`         // order return code
+	Message    string       `json:"msg"`          // order return error message
+	OrderID    string       `json:"orderid"`      // id of the amended order
+	NewOrderID string       `json:"new_order_id"` // id of the order after amended
+}
+
 // nolint
 type OrderResult struct {
 	Code    sdk.CodeType `json:"code"`    // order return code
 	Message string       `json:"msg"`     // order return error message
 	OrderID string       `json:"orderid"` // order return orderid
 }
+
+//********************MsgRouteOrder*************
+// MsgRouteOrder trades on a product with the smart order router, which splits the trade between the depth book
+// and the ammswap pool of the same tokens to get the most output. Like a market order, the quantity is the amount of
+// quote token to spend when buying, or the amount of base token to sell when selling. The minimum output is reached
+// by both parts once the FOK order of the book part is filled, and the pool part reaches its share of it in case the
+// order isn't filled
+type MsgRouteOrder struct {
+	Sender    sdk.AccAddress `json:"sender"`
+	Product   string         `json:"product"`    // product for trading pair in full name of the tokens
+	Side      string         `json:"side"`       // BUY/SELL
+	Quantity  sdk.Dec        `json:"quantity"`   // quantity of the token to sell
+	MinOutput sdk.Dec        `json:"min_output"` // minimum quantity of the token to buy from the book and the pool
+}
+
+// NewMsgRouteOrder is a constructor function for MsgRouteOrder
+func NewMsgRouteOrder(sender sdk.AccAddress, product, side string, quantity, minOutput sdk.Dec) MsgRouteOrder {
+	return MsgRouteOrder{
+		Sender:    sender,
+		Product:   product,
+		Side:      side,
+		Quantity:  quantity,
+		MinOutput: minOutput,
+	}
+}
+
+// nolint
+func (msg MsgRouteOrder) Route() string { return "order" }
+
+// nolint
+func (msg MsgRouteOrder) Type() string { return "route" }
+
+// ValidateBasic : Implements Msg.
+func (msg MsgRouteOrder) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	symbols := strings.Split(msg.Product, "_")
+	if len(symbols) != 2 || symbols[0] == "" || symbols[1] == "" {
+		return sdk.ErrUnknownRequest("Product should be in the format of \"base_quote\"")
+	}
+	if symbols[0] == symbols[1] {
+		return sdk.ErrUnknownRequest("invalid product")
+	}
+	if msg.Side != BuyOrder && msg.Side != SellOrder {
+		return sdk.ErrUnknownRequest(
+			fmt.Sprintf("Side is expected to be \"BUY\" or \"SELL\", but got \"%s\"", msg.Side))
+	}
+	if msg.Quantity.IsNil() || !msg.Quantity.IsPositive() {
+		return sdk.ErrUnknownRequest("Quantity must be positive")
+	}
+	if msg.MinOutput.IsNil() || msg.MinOutput.IsNegative() {
+		return sdk.ErrUnknownRequest("MinOutput can't be negative")
+	}
+	return nil
+}
+
+// GetSignBytes : encodes the message for signing
+func (msg MsgRouteOrder) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRouteOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// CalculateGas charges the gas of placing an order, the swap part is executed in the same step
+func (msg MsgRouteOrder) CalculateGas(gasUnit uint64) uint64 {
+	return gasUnit
+}
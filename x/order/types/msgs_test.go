@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/okexchain/x/common"
 
 	"github.com/stretchr/testify/require"
@@ -93,6 +94,117 @@ func TestMsgNewOrderInvalid(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestMsgNewMarketOrder(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	product := "btc_" + common.NativeToken
+
+	orderMsg := NewMsgNewOrders(addr, []OrderItem{NewMarketOrderItem(product, BuyOrder, testQuantity)})
+	require.Nil(t, orderMsg.ValidateBasic())
+
+	// the type of limit order is omitted in sign bytes
+	require.NotContains(t, string(NewMsgNewOrder(addr, product, BuyOrder, testPrice, testQuantity).GetSignBytes()),
+		`"type":""`)
+
+	// market order with price
+	item := NewMarketOrderItem(product, SellOrder, testQuantity)
+	item.Price = sdk.MustNewDecFromStr(testPrice)
+	orderMsg = NewMsgNewOrders(addr, []OrderItem{item})
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// market order with zero quantity
+	orderMsg = NewMsgNewOrders(addr, []OrderItem{NewMarketOrderItem(product, SellOrder, "0")})
+	require.NotNil(t, orderMsg.ValidateBasic())
+
+	// invalid type
+	item = NewOrderItem(product, SellOrder, testPrice, testQuantity)
+	item.Type = "STOP"
+	orderMsg = NewMsgNewOrders(addr, []OrderItem{item})
+	require.NotNil(t, orderMsg.ValidateBasic())
+}
+
+func TestMsgNewOrderTimeInForce(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	product := "btc_" + common.NativeToken
+
+	for _, timeInForce := range []string{"", TimeInForceGTC, TimeInForceIOC, TimeInForceFOK, TimeInForcePostOnly} {
+		item := NewOrderItem(product, BuyOrder, testPrice, testQuantity)
+		item.TimeInForce = timeInForce
+		require.Nil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+	}
+
+	// market order is always immediate
+	for _, timeInForce := range []string{TimeInForceGTC, TimeInForcePostOnly} {
+		item := NewMarketOrderItem(product, BuyOrder, testQuantity)
+		item.TimeInForce = timeInForce
+		require.NotNil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+	}
+	item := NewMarketOrderItem(product, BuyOrder, testQuantity)
+	item.TimeInForce = TimeInForceFOK
+	require.Nil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+
+	// invalid time in force
+	item = NewOrderItem(product, BuyOrder, testPrice, testQuantity)
+	item.TimeInForce = "GTD"
+	require.NotNil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+}
+
+func TestMsgNewOrderExpiry(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	product := "btc_" + common.NativeToken
+
+	item := NewOrderItem(product, BuyOrder, testPrice, testQuantity)
+	item.ExpireHeight = 100
+	require.Nil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+
+	item = NewOrderItem(product, BuyOrder, testPrice, testQuantity)
+	item.ExpireTime = 1600000000
+	require.Nil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+
+	// negative expiry
+	item = NewOrderItem(product, BuyOrder, testPrice, testQuantity)
+	item.ExpireHeight = -1
+	require.NotNil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+
+	// both expiry height and time
+	item = NewOrderItem(product, BuyOrder, testPrice, testQuantity)
+	item.ExpireHeight = 100
+	item.ExpireTime = 1600000000
+	require.NotNil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+
+	// the expiry is omitted in sign bytes of the orders without custom expiry
+	require.NotContains(t, string(NewMsgNewOrder(addr, product, BuyOrder, testPrice, testQuantity).GetSignBytes()),
+		"expire_")
+}
+
+func TestMsgNewConditionalOrder(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	product := "btc_" + common.NativeToken
+
+	item := NewMarketOrderItem(product, SellOrder, testQuantity)
+	item.Condition = ConditionStopLoss
+	item.TriggerPrice = sdk.MustNewDecFromStr(testPrice)
+	require.Nil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+
+	// the trigger price is omitted in sign bytes of regular orders
+	require.NotContains(t, string(NewMsgNewOrder(addr, product, BuyOrder, testPrice, testQuantity).GetSignBytes()),
+		"trigger_price")
+
+	// invalid condition
+	item.Condition = "STOP"
+	require.NotNil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+
+	// no trigger price
+	item.Condition = ConditionTakeProfit
+	item.TriggerPrice = sdk.Dec{}
+	require.NotNil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+	item.TriggerPrice = sdk.ZeroDec()
+	require.NotNil(t, NewMsgNewOrders(addr, []OrderItem{item}).ValidateBasic())
+}
+
 func TestMsgCancelOrder(t *testing.T) {
 	orderID := testOrderID
 	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
@@ -129,6 +241,60 @@ func TestMsgCancelOrderInvalid(t *testing.T) {
 	require.NotNil(t, sdkErr)
 }
 
+func TestMsgAmendOrders(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	msg := NewMsgAmendOrders(addr, []AmendOrderItem{NewAmendOrderItem(testOrderID, testPrice, testQuantity)})
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, "order", msg.Route())
+	require.Equal(t, "amend", msg.Type())
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+	require.EqualValues(t, 2, msg.CalculateGas(2))
+
+	// empty sender
+	require.NotNil(t, NewMsgAmendOrders(nil, msg.AmendItems).ValidateBasic())
+	// empty items
+	require.NotNil(t, NewMsgAmendOrders(addr, nil).ValidateBasic())
+	// empty order id
+	require.NotNil(t, NewMsgAmendOrders(addr, []AmendOrderItem{NewAmendOrderItem("", testPrice, testQuantity)}).
+		ValidateBasic())
+	// non-positive price or quantity
+	require.NotNil(t, NewMsgAmendOrders(addr, []AmendOrderItem{NewAmendOrderItem(testOrderID, "0", testQuantity)}).
+		ValidateBasic())
+	require.NotNil(t, NewMsgAmendOrders(addr, []AmendOrderItem{{OrderID: testOrderID}}).ValidateBasic())
+	// duplicated order ids
+	item := NewAmendOrderItem(testOrderID, testPrice, testQuantity)
+	require.NotNil(t, NewMsgAmendOrders(addr, []AmendOrderItem{item, item}).ValidateBasic())
+}
+
+func TestMsgRouteOrder(t *testing.T) {
+	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
+	require.Nil(t, err)
+	product := "btc_" + common.NativeToken
+	msg := NewMsgRouteOrder(addr, product, SellOrder, sdk.OneDec(), sdk.ZeroDec())
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, "order", msg.Route())
+	require.Equal(t, "route", msg.Type())
+	require.EqualValues(t, addr, msg.GetSigners()[0])
+	require.EqualValues(t, 2, msg.CalculateGas(2))
+
+	tests := []struct {
+		name string
+		msg  MsgRouteOrder
+	}{
+		{"empty sender", NewMsgRouteOrder(nil, product, SellOrder, sdk.OneDec(), sdk.ZeroDec())},
+		{"invalid product", NewMsgRouteOrder(addr, "btc", SellOrder, sdk.OneDec(), sdk.ZeroDec())},
+		{"same tokens", NewMsgRouteOrder(addr, "btc_btc", SellOrder, sdk.OneDec(), sdk.ZeroDec())},
+		{"invalid side", NewMsgRouteOrder(addr, product, "SWAP", sdk.OneDec(), sdk.ZeroDec())},
+		{"zero quantity", NewMsgRouteOrder(addr, product, BuyOrder, sdk.ZeroDec(), sdk.ZeroDec())},
+		{"negative min output", NewMsgRouteOrder(addr, product, BuyOrder, sdk.OneDec(), sdk.NewDec(-1))},
+		{"nil min output", MsgRouteOrder{Sender: addr, Product: product, Side: BuyOrder, Quantity: sdk.OneDec()}},
+	}
+	for _, test := range tests {
+		require.NotNil(t, test.msg.ValidateBasic(), test.name)
+	}
+}
+
 func TestMsgMultiNewOrder(t *testing.T) {
 	addr, err := hex.DecodeString("1212121212121212123412121212121212121234")
 	require.Nil(t, err)
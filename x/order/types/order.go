@@ -20,6 +20,13 @@ const (
 	Expired
 	PartialFilledCancelled
 	PartialFilledExpired
+	_ // reserved for partial filled
+	IOCCancelled
+	PartialFilledIOCCancelled
+	FOKKilled
+	PostOnlyRejected
+	SelfTradeCancelled
+	PartialFilledSelfTradeCancelled
 )
 
 func (p OrderStatus) String() string {
@@ -36,6 +43,18 @@ func (p OrderStatus) String() string {
 		return "PartialFilledCancelled"
 	case PartialFilledExpired:
 		return "PartialFilledExpired"
+	case IOCCancelled:
+		return "IOCCancelled"
+	case PartialFilledIOCCancelled:
+		return "PartialFilledIOCCancelled"
+	case FOKKilled:
+		return "FOKKilled"
+	case PostOnlyRejected:
+		return "PostOnlyRejected"
+	case SelfTradeCancelled:
+		return "SelfTradeCancelled"
+	case PartialFilledSelfTradeCancelled:
+		return "PartialFilledSelfTradeCancelled"
 	default:
 		return "Unknown"
 	}
@@ -50,6 +69,12 @@ const (
 	OrderStatusPartialFilledCancelled = 4
 	OrderStatusPartialFilledExpired   = 5
 	//OrderStatusPartialFilled          = 6
+	OrderStatusIOCCancelled                    = 7
+	OrderStatusPartialFilledIOCCancelled       = 8
+	OrderStatusFOKKilled                       = 9
+	OrderStatusPostOnlyRejected                = 10
+	OrderStatusSelfTradeCancelled              = 11
+	OrderStatusPartialFilledSelfTradeCancelled = 12
 )
 
 // nolint
@@ -59,6 +84,9 @@ const (
 	OrderExtraInfoKeyExpireFee  = "expireFee"
 	OrderExtraInfoKeyDealFee    = "dealFee"
 	OrderExtraInfoKeyReceiveFee = "receiveFee"
+
+	OrderExtraInfoKeyConditionalOrderID = "conditionalOrderID"
+	OrderExtraInfoKeyAmendedOrderID     = "amendedOrderID"
 )
 
 // nolint
@@ -77,7 +105,11 @@ type Order struct {
 	Timestamp         int64          `json:"timestamp"`        // created timestamp
 	OrderExpireBlocks int64          `json:"order_expire_blocks"`
 	FeePerBlock       sdk.DecCoin    `json:"fee_per_block"`
-	ExtraInfo         string         `json:"extra_info"` // extra info of order in json format
+	ExtraInfo         string         `json:"extra_info"`              // extra info of order in json format
+	Type              string         `json:"type,omitempty"`          // LIMIT/MARKET, empty means LIMIT
+	TimeInForce       string         `json:"time_in_force,omitempty"` // GTC/IOC/FOK/POST_ONLY, empty means GTC
+	ExpireHeight      int64          `json:"expire_height,omitempty"` // custom expiry block height
+	ExpireTime        int64          `json:"expire_time,omitempty"`   // custom expiry unix timestamp
 }
 
 // nolint
@@ -166,6 +198,16 @@ func (order *Order) RecordOrderReceiveFee(fee sdk.DecCoins) {
 	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyReceiveFee, fee.String())
 }
 
+// RecordConditionalOrderID records the id of the conditional order which the order is triggered from
+func (order *Order) RecordConditionalOrderID(conditionalOrderID string) {
+	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyConditionalOrderID, conditionalOrderID)
+}
+
+// RecordAmendedOrderID records the id of the order which replaces the amended order
+func (order *Order) RecordAmendedOrderID(amendedOrderID string) {
+	order.setExtraInfoWithKeyValue(OrderExtraInfoKeyAmendedOrderID, amendedOrderID)
+}
+
 // RecordOrderDealFee : An order may have several deals
 func (order *Order) RecordOrderDealFee(fee sdk.DecCoins) {
 	oldValue := order.GetExtraInfoWithKey(OrderExtraInfoKeyDealFee)
@@ -198,6 +240,21 @@ func (order *Order) Fill(price, fillAmount sdk.Dec) {
 	}
 }
 
+// Reduce reduces the quantity of the order in place, and returns the coins which are no longer locked.
+// The reduced quantity must be less than the remaining quantity
+func (order *Order) Reduce(quantity sdk.Dec) sdk.DecCoins {
+	order.Quantity = order.Quantity.Sub(quantity)
+	order.RemainQuantity = order.RemainQuantity.Sub(quantity)
+	token := strings.Split(order.Product, "_")[0]
+	amount := quantity
+	if order.Side == BuyOrder {
+		token = strings.Split(order.Product, "_")[1]
+		amount = order.Price.Mul(quantity)
+	}
+	order.RemainLocked = order.RemainLocked.Sub(amount)
+	return sdk.DecCoins{{Denom: token, Amount: amount}}
+}
+
 // nolint
 func (order *Order) Cancel() {
 	if order.RemainQuantity.Equal(order.Quantity) {
@@ -216,6 +273,37 @@ func (order *Order) Expire() {
 	}
 }
 
+// IsExpired returns whether the order reaches its custom expiry height or time, or the default expiry of
+// orderExpireBlocks after the block it's placed at
+func (order *Order) IsExpired(blockHeight, blockTime, orderExpireBlocks int64) bool {
+	if order.ExpireHeight > 0 && blockHeight >= order.ExpireHeight {
+		return true
+	}
+	if order.ExpireTime > 0 && blockTime >= order.ExpireTime {
+		return true
+	}
+	// the param can be changed after the order is placed, the earlier expiry works
+	if order.OrderExpireBlocks < orderExpireBlocks {
+		orderExpireBlocks = order.OrderExpireBlocks
+	}
+	return blockHeight-GetBlockHeightFromOrderID(order.OrderID) >= orderExpireBlocks
+}
+
+// NextExpireTimeCheckHeight estimates the block height to check the expiry time of the order again, which is after
+// half of the remaining blocks by the average block interval since the order is placed. So the order is checked at
+// every block when its expiry time is approaching
+func (order *Order) NextExpireTimeCheckHeight(blockHeight, blockTime int64) int64 {
+	var step int64
+	passedBlocks := blockHeight - GetBlockHeightFromOrderID(order.OrderID)
+	if passedTime := blockTime - order.Timestamp; passedTime > 0 && passedBlocks > 0 {
+		step = (order.ExpireTime - blockTime) * passedBlocks / passedTime / 2
+	}
+	if step < 1 {
+		step = 1
+	}
+	return blockHeight + step
+}
+
 // NeedLockCoins : when place a new order, we should lock the coins of sender
 func (order *Order) NeedLockCoins() sdk.DecCoins {
 	if order.Side == BuyOrder {
@@ -240,6 +328,35 @@ func (order *Order) NeedUnlockCoins() sdk.DecCoins {
 
 }
 
+// IsImmediate returns true if the order never rests on the depth book after its first match,
+// market orders are immediate or cancel
+func (order *Order) IsImmediate() bool {
+	return order.Type == MarketOrder || order.TimeInForce == TimeInForceIOC || order.TimeInForce == TimeInForceFOK
+}
+
+// CloseByTimeInForce closes the order which isn't allowed to rest on the depth book by its time in force
+func (order *Order) CloseByTimeInForce() {
+	switch {
+	case order.TimeInForce == TimeInForceFOK:
+		order.Status = OrderStatusFOKKilled
+	case order.TimeInForce == TimeInForcePostOnly:
+		order.Status = OrderStatusPostOnlyRejected
+	case order.RemainQuantity.Equal(order.Quantity):
+		order.Status = OrderStatusIOCCancelled
+	default:
+		order.Status = OrderStatusPartialFilledIOCCancelled
+	}
+}
+
+// CancelBySelfTrade cancels the order by the self-trade prevention
+func (order *Order) CancelBySelfTrade() {
+	if order.RemainQuantity.Equal(order.Quantity) {
+		order.Status = OrderStatusSelfTradeCancelled
+	} else {
+		order.Status = OrderStatusPartialFilledSelfTradeCancelled
+	}
+}
+
 // nolint
 func (order *Order) Unlock() {
 	order.RemainLocked = sdk.ZeroDec()
@@ -263,16 +380,20 @@ func FormatOrderIDPrefix(blockHeight int64) string {
 	return fmt.Sprintf(format, blockHeight)
 }
 
+// ParseOrderID returns the block height of the order and its order num in the block, which decide the sequence
+// of the orders. Zeros are returned with the error if the order id is invalid
+func ParseOrderID(orderID string) (blockHeight, orderNum int64, err error) {
+	if _, err = fmt.Sscanf(orderID, "ID%d-%d", &blockHeight, &orderNum); err != nil {
+		return 0, 0, err
+	}
+	return blockHeight, orderNum, nil
+}
+
 // nolint
 func GetBlockHeightFromOrderID(orderID string) int64 {
-	var blockHeight int64
-	var id int64
-	format := "ID%d-%d"
-	_, err := fmt.Sscanf(orderID, format, &blockHeight, &id)
+	blockHeight, _, err := ParseOrderID(orderID)
 	if err != nil {
 		log.Println(err)
-		return 0
 	}
-
 	return blockHeight
 }
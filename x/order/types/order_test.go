@@ -30,7 +30,7 @@ func TestNewOrder(t *testing.T) {
 
 	require.Equal(t, expected, order1.String())
 
-	order1.Status = 10
+	order1.Status = 13
 	require.Equal(t, "Unknown", OrderStatus(order1.Status).String())
 }
 
@@ -113,6 +113,21 @@ func TestOrderCancel(t *testing.T) {
 	require.EqualValues(t, "PartialFilledCancelled", OrderStatus(order2.Status).String())
 }
 
+func TestOrderReduce(t *testing.T) {
+	order := MockOrder("", TestTokenPair, BuyOrder, "0.1", "10.0")
+	order.Fill(sdk.MustNewDecFromStr("0.1"), sdk.MustNewDecFromStr("2"))
+	unlockCoins := order.Reduce(sdk.MustNewDecFromStr("3"))
+	require.EqualValues(t, sdk.MustNewDecFromStr("7"), order.Quantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("5"), order.RemainQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), order.RemainLocked)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.3"), unlockCoins.AmountOf(common.NativeToken))
+
+	order2 := MockOrder("", TestTokenPair, SellOrder, "0.1", "10.0")
+	unlockCoins = order2.Reduce(sdk.MustNewDecFromStr("4"))
+	require.EqualValues(t, sdk.MustNewDecFromStr("6"), order2.RemainLocked)
+	require.EqualValues(t, sdk.MustNewDecFromStr("4"), unlockCoins.AmountOf(common.TestToken))
+}
+
 func TestOrderExpire(t *testing.T) {
 	// Full expire
 	order := MockOrder("", TestTokenPair, SellOrder, "0.1", "10.0")
@@ -128,6 +143,35 @@ func TestOrderExpire(t *testing.T) {
 	require.EqualValues(t, "PartialFilledExpired", OrderStatus(order2.Status).String())
 }
 
+func TestOrderCloseByTimeInForce(t *testing.T) {
+	order := MockOrder("", TestTokenPair, SellOrder, "0.1", "10.0")
+	order.TimeInForce = TimeInForceIOC
+	require.True(t, order.IsImmediate())
+	order.CloseByTimeInForce()
+	require.EqualValues(t, OrderStatusIOCCancelled, order.Status)
+	require.EqualValues(t, "IOCCancelled", OrderStatus(order.Status).String())
+
+	order = MockOrder("", TestTokenPair, SellOrder, "0.1", "10.0")
+	order.Type = MarketOrder
+	require.True(t, order.IsImmediate())
+	order.Fill(sdk.MustNewDecFromStr("0.2"), sdk.MustNewDecFromStr("5"))
+	order.CloseByTimeInForce()
+	require.EqualValues(t, OrderStatusPartialFilledIOCCancelled, order.Status)
+
+	order = MockOrder("", TestTokenPair, SellOrder, "0.1", "10.0")
+	order.TimeInForce = TimeInForceFOK
+	order.CloseByTimeInForce()
+	require.EqualValues(t, OrderStatusFOKKilled, order.Status)
+	require.EqualValues(t, "FOKKilled", OrderStatus(order.Status).String())
+
+	order = MockOrder("", TestTokenPair, SellOrder, "0.1", "10.0")
+	order.TimeInForce = TimeInForcePostOnly
+	require.False(t, order.IsImmediate())
+	order.CloseByTimeInForce()
+	require.EqualValues(t, OrderStatusPostOnlyRejected, order.Status)
+	require.EqualValues(t, "PostOnlyRejected", OrderStatus(order.Status).String())
+}
+
 func TestOrderNeedLockCoins(t *testing.T) {
 	order := MockOrder("", TestTokenPair, BuyOrder, "0.1", "10.0")
 	decCoins := order.NeedLockCoins()
@@ -166,3 +210,36 @@ func TestGetBlockHeightFromOrderID(t *testing.T) {
 	num = GetBlockHeightFromOrderID(orderID)
 	require.Equal(t, blockHeight, num)
 }
+
+func TestParseOrderID(t *testing.T) {
+	blockHeight, orderNum, err := ParseOrderID(FormatOrderID(100, 2))
+	require.NoError(t, err)
+	require.EqualValues(t, 100, blockHeight)
+	require.EqualValues(t, 2, orderNum)
+
+	blockHeight, orderNum, err = ParseOrderID(FormatConditionalOrderID(1))
+	require.Error(t, err)
+	require.EqualValues(t, 0, blockHeight)
+	require.EqualValues(t, 0, orderNum)
+}
+
+func TestOrderCustomExpiry(t *testing.T) {
+	order := &Order{OrderID: FormatOrderID(10, 1), Timestamp: 1000, OrderExpireBlocks: 100}
+	require.False(t, order.IsExpired(109, 2000, 100))
+	require.True(t, order.IsExpired(110, 2000, 100))
+	// the default expiry is shortened by the param
+	require.True(t, order.IsExpired(60, 2000, 50))
+
+	order.ExpireHeight = 20
+	require.False(t, order.IsExpired(19, 2000, 100))
+	require.True(t, order.IsExpired(20, 2000, 100))
+
+	order.ExpireHeight, order.ExpireTime = 0, 2000
+	require.False(t, order.IsExpired(50, 1999, 100))
+	require.True(t, order.IsExpired(50, 2000, 100))
+
+	// 10 seconds per block, 90 blocks to expire, check it again after 45 blocks
+	require.EqualValues(t, 65, order.NextExpireTimeCheckHeight(20, 1100))
+	require.EqualValues(t, 199, order.NextExpireTimeCheckHeight(198, 1980))
+	require.EqualValues(t, 11, order.NextExpireTimeCheckHeight(10, 1000))
+}
@@ -11,13 +11,18 @@ import (
 // nolint
 const (
 	// System param
-	DefaultOrderExpireBlocks = 259200 // order will be expired after 86400 blocks.
-	DefaultMaxDealsPerBlock  = 1000   // deals limit per block
+	DefaultOrderExpireBlocks   = 259200 // order will be expired after 86400 blocks.
+	DefaultMaxDealsPerBlock    = 1000   // deals limit per block
+	DefaultMatchEngine         = PeriodicAuction
+	DefaultMarketSlippage      = "0.1" // market orders can be filled at most 10% away from the last price
+	DefaultSelfTradePrevention = SelfTradePreventionNone
 
 	// Fee param
 	DefaultFeeAmountPerBlock     = "0" // okt
 	DefaultFeeDenomPerBlock      = common.NativeToken
 	DefaultFeeRateTrade          = "0.001" // percentage
+	DefaultFeeRateMaker          = "0.001" // percentage
+	DefaultFeeVolumeDays         = 30      // days of the rolling trade volume to decide the fee tier
 	DefaultNewOrderMsgGasUnit    = 40000
 	DefaultCancelOrderMsgGasUnit = 30000
 )
@@ -30,6 +35,13 @@ var (
 	KeyTradeFeeRate          = []byte("TradeFeeRate")
 	KeyNewOrderMsgGasUnit    = []byte("NewOrderMsgGasUnit")
 	KeyCancelOrderMsgGasUnit = []byte("CancelOrderMsgGasUnit")
+	KeyMatchEngine           = []byte("MatchEngine")
+	KeyMarketSlippage        = []byte("MarketSlippage")
+	KeyMakerFeeRate          = []byte("MakerFeeRate")
+	KeyFeeTiers              = []byte("FeeTiers")
+	KeyPairFeeRates          = []byte("PairFeeRates")
+	KeyFeeVolumeDays         = []byte("FeeVolumeDays")
+	KeySelfTradePrevention   = []byte("SelfTradePrevention")
 	DefaultFeePerBlock       = sdk.NewDecCoinFromDec(DefaultFeeDenomPerBlock, sdk.MustNewDecFromStr(DefaultFeeAmountPerBlock))
 )
 
@@ -41,9 +53,16 @@ type Params struct {
 	OrderExpireBlocks     int64       `json:"order_expire_blocks"`
 	MaxDealsPerBlock      int64       `json:"max_deals_per_block"`
 	FeePerBlock           sdk.DecCoin `json:"fee_per_block"`
-	TradeFeeRate          sdk.Dec     `json:"trade_fee_rate"`
+	TradeFeeRate          sdk.Dec     `json:"trade_fee_rate"` // fee rate of the takers
 	NewOrderMsgGasUnit    uint64      `json:"new_order_msg_gas_unit"`
 	CancelOrderMsgGasUnit uint64      `json:"cancel_order_msg_gas_unit"`
+	MatchEngine           string      `json:"match_engine"` // name of the match engine used by the chain
+	MarketSlippage        sdk.Dec     `json:"market_slippage"`
+	MakerFeeRate          sdk.Dec     `json:"maker_fee_rate"`
+	FeeTiers              FeeTiers    `json:"fee_tiers"`             // discounts by the rolling trade volume
+	PairFeeRates          []PairFee   `json:"pair_fee_rates"`        // fee rates overridden by product
+	FeeVolumeDays         int64       `json:"fee_volume_days"`       // days of the rolling trade volume
+	SelfTradePrevention   string      `json:"self_trade_prevention"` // mode of the self-trade prevention
 }
 
 // ParamKeyTable for auth module
@@ -62,6 +81,13 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		{KeyTradeFeeRate, &p.TradeFeeRate},
 		{KeyNewOrderMsgGasUnit, &p.NewOrderMsgGasUnit},
 		{KeyCancelOrderMsgGasUnit, &p.CancelOrderMsgGasUnit},
+		{KeyMatchEngine, &p.MatchEngine},
+		{KeyMarketSlippage, &p.MarketSlippage},
+		{KeyMakerFeeRate, &p.MakerFeeRate},
+		{KeyFeeTiers, &p.FeeTiers},
+		{KeyPairFeeRates, &p.PairFeeRates},
+		{KeyFeeVolumeDays, &p.FeeVolumeDays},
+		{KeySelfTradePrevention, &p.SelfTradePrevention},
 	}
 }
 
@@ -74,6 +100,11 @@ func DefaultParams() Params {
 		TradeFeeRate:          sdk.MustNewDecFromStr(DefaultFeeRateTrade),
 		NewOrderMsgGasUnit:    DefaultNewOrderMsgGasUnit,
 		CancelOrderMsgGasUnit: DefaultCancelOrderMsgGasUnit,
+		MatchEngine:           DefaultMatchEngine,
+		MarketSlippage:        sdk.MustNewDecFromStr(DefaultMarketSlippage),
+		MakerFeeRate:          sdk.MustNewDecFromStr(DefaultFeeRateMaker),
+		FeeVolumeDays:         DefaultFeeVolumeDays,
+		SelfTradePrevention:   DefaultSelfTradePrevention,
 	}
 }
 
@@ -85,7 +116,37 @@ func (p Params) String() string {
   FeePerBlock: %s
   TradeFeeRate: %s
   NewOrderMsgGasUnit: %d
-  CancelOrderMsgGasUnit: %d`, p.OrderExpireBlocks,
+  CancelOrderMsgGasUnit: %d
+  MatchEngine: %s
+  MarketSlippage: %s
+  MakerFeeRate: %s
+  FeeTiers: %v
+  PairFeeRates: %v
+  FeeVolumeDays: %d
+  SelfTradePrevention: %s`, p.OrderExpireBlocks,
 		p.MaxDealsPerBlock, p.FeePerBlock,
-		p.TradeFeeRate, p.NewOrderMsgGasUnit, p.CancelOrderMsgGasUnit)
+		p.TradeFeeRate, p.NewOrderMsgGasUnit, p.CancelOrderMsgGasUnit, p.MatchEngine, p.MarketSlippage,
+		p.MakerFeeRate, p.FeeTiers, p.PairFeeRates, p.FeeVolumeDays,
+		p.SelfTradePrevention)
+}
+
+// ValidateParams validates the fee tiers, the fee rates overridden by product and the self-trade prevention mode
+func ValidateParams(p Params) error {
+	if err := p.FeeTiers.Validate(); err != nil {
+		return err
+	}
+	if err := validatePairFeeRates(p.PairFeeRates); err != nil {
+		return err
+	}
+	return ValidateSelfTradePrevention(p.SelfTradePrevention)
+}
+
+// ValidateParamsSubspace validates the params in the subspace of the order module,
+// it checks the params changed by the param change proposals
+func ValidateParamsSubspace(ctx sdk.Context, subspace params.Subspace) error {
+	p := Params{SelfTradePrevention: DefaultSelfTradePrevention}
+	subspace.GetIfExists(ctx, KeyFeeTiers, &p.FeeTiers)
+	subspace.GetIfExists(ctx, KeyPairFeeRates, &p.PairFeeRates)
+	subspace.GetIfExists(ctx, KeySelfTradePrevention, &p.SelfTradePrevention)
+	return ValidateParams(p)
 }
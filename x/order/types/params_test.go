@@ -18,6 +18,13 @@ func TestParamSetPairs(t *testing.T) {
 			TradeFeeRate:          sdk.MustNewDecFromStr("0.001"),
 			NewOrderMsgGasUnit:    123,
 			CancelOrderMsgGasUnit: 456,
+			MatchEngine:           ContinuousAuction,
+			MarketSlippage:        sdk.MustNewDecFromStr("0.05"),
+			MakerFeeRate:          sdk.MustNewDecFromStr("0.0005"),
+			FeeTiers:              FeeTiers{NewFeeTier(sdk.NewDec(1000), sdk.MustNewDecFromStr("0.1"))},
+			PairFeeRates:          []PairFee{NewPairFee(TestTokenPair, sdk.ZeroDec(), sdk.MustNewDecFromStr("0.002"))},
+			FeeVolumeDays:         7,
+			SelfTradePrevention:   SelfTradePreventionDecrement,
 		},
 	}
 
@@ -41,6 +48,24 @@ func TestParamSetPairs(t *testing.T) {
 				require.EqualValues(t, test.NewOrderMsgGasUnit, *(v.Value.(*uint64)))
 			case string(KeyCancelOrderMsgGasUnit):
 				require.EqualValues(t, test.CancelOrderMsgGasUnit, *(v.Value.(*uint64)))
+			case string(KeyMatchEngine):
+				require.EqualValues(t, test.MatchEngine, *(v.Value.(*string)))
+			case string(KeyMarketSlippage):
+				if !v.Value.(*sdk.Dec).Equal(test.MarketSlippage) {
+					t.Errorf("key(%s) -> %x, want %x", v.Key, test.MarketSlippage, v.Value)
+				}
+			case string(KeyMakerFeeRate):
+				if !v.Value.(*sdk.Dec).Equal(test.MakerFeeRate) {
+					t.Errorf("key(%s) -> %x, want %x", v.Key, test.MakerFeeRate, v.Value)
+				}
+			case string(KeyFeeTiers):
+				require.EqualValues(t, test.FeeTiers, *(v.Value.(*FeeTiers)))
+			case string(KeyPairFeeRates):
+				require.EqualValues(t, test.PairFeeRates, *(v.Value.(*[]PairFee)))
+			case string(KeyFeeVolumeDays):
+				require.EqualValues(t, test.FeeVolumeDays, *(v.Value.(*int64)))
+			case string(KeySelfTradePrevention):
+				require.EqualValues(t, test.SelfTradePrevention, *(v.Value.(*string)))
 			}
 		}
 	}
@@ -54,6 +79,26 @@ func TestParamsString(t *testing.T) {
   FeePerBlock: 0.00000000` + common.NativeToken + `
   TradeFeeRate: 0.00100000
   NewOrderMsgGasUnit: 40000
-  CancelOrderMsgGasUnit: 30000`
+  CancelOrderMsgGasUnit: 30000
+  MatchEngine: periodicauction
+  MarketSlippage: 0.10000000
+  MakerFeeRate: 0.00100000
+  FeeTiers: []
+  PairFeeRates: []
+  FeeVolumeDays: 30
+  SelfTradePrevention: none`
 	require.EqualValues(t, expectString, param.String())
 }
+
+func TestValidateSelfTradePrevention(t *testing.T) {
+	params := DefaultParams()
+	for _, mode := range []string{SelfTradePreventionNone, SelfTradePreventionCancelNewest,
+		SelfTradePreventionCancelOldest, SelfTradePreventionCancelBoth, SelfTradePreventionDecrement} {
+		params.SelfTradePrevention = mode
+		require.NoError(t, ValidateParams(params))
+	}
+	for _, mode := range []string{"", "cancel", "DECREMENT"} {
+		params.SelfTradePrevention = mode
+		require.Error(t, ValidateParams(params))
+	}
+}
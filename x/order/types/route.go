@@ -0,0 +1,40 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// OrderRoute is the split of a trade between the depth book and the ammswap pool found by the smart order router.
+// The outputs are estimated with the current depth book and pool, the book part is filled in the match at the end
+// of the block
+type OrderRoute struct {
+	Product       string      `json:"product"`
+	Side          string      `json:"side"`
+	BookInput     sdk.DecCoin `json:"book_input"`
+	BookOutput    sdk.DecCoin `json:"book_output"`
+	BookPrice     sdk.Dec     `json:"book_price"` // the worst price of the depth book reached by the book part
+	SwapTokenPair string      `json:"swap_token_pair"`
+	SwapInput     sdk.DecCoin `json:"swap_input"`
+	SwapOutput    sdk.DecCoin `json:"swap_output"`
+}
+
+// Output returns the total output estimated of the route
+func (r OrderRoute) Output() sdk.Dec {
+	return r.BookOutput.Amount.Add(r.SwapOutput.Amount)
+}
+
+// String implements the Stringer interface
+func (r OrderRoute) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Product:          %s
+Side:             %s
+Book Input:       %s
+Book Output:      %s
+Book Price:       %s
+Swap Token Pair:  %s
+Swap Input:       %s
+Swap Output:      %s`, r.Product, r.Side, r.BookInput, r.BookOutput, r.BookPrice, r.SwapTokenPair, r.SwapInput,
+		r.SwapOutput))
+}
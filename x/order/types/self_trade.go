@@ -0,0 +1,47 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// modes of the self-trade prevention, which decide the orders to be cancelled or decremented
+// when the buy and sell orders of an address would be filled with each other
+const (
+	SelfTradePreventionNone         = "none"
+	SelfTradePreventionCancelNewest = "cancel_newest"
+	SelfTradePreventionCancelOldest = "cancel_oldest"
+	SelfTradePreventionCancelBoth   = "cancel_both"
+	SelfTradePreventionDecrement    = "decrement"
+)
+
+// ValidateSelfTradePrevention checks that the mode is one of the self-trade prevention modes
+func ValidateSelfTradePrevention(mode string) error {
+	switch mode {
+	case SelfTradePreventionNone, SelfTradePreventionCancelNewest, SelfTradePreventionCancelOldest,
+		SelfTradePreventionCancelBoth, SelfTradePreventionDecrement:
+		return nil
+	default:
+		return fmt.Errorf("unknown self-trade prevention mode: %s", mode)
+	}
+}
+
+// IsSelfTradePrevented returns true if the mode prevents self-trades, unknown modes are treated as none
+func IsSelfTradePrevented(mode string) bool {
+	switch mode {
+	case SelfTradePreventionCancelNewest, SelfTradePreventionCancelOldest, SelfTradePreventionCancelBoth,
+		SelfTradePreventionDecrement:
+		return true
+	default:
+		return false
+	}
+}
+
+// SelfTradeOrder is an order cancelled or decremented by the self-trade prevention
+type SelfTradeOrder struct {
+	OrderID  string  `json:"order_id"`
+	Product  string  `json:"product"`
+	Quantity sdk.Dec `json:"quantity"` // quantity cancelled or decremented
+	Status   int64   `json:"status"`   // status of the order after the prevention
+}
@@ -12,6 +12,10 @@ func DefaultTestParams() Params {
 		MaxDealsPerBlock:  DefaultMaxDealsPerBlock,
 		FeePerBlock:       DefaultTestFeePerBlock,
 		TradeFeeRate:      sdk.MustNewDecFromStr(DefaultFeeRateTrade),
+		MatchEngine:       DefaultMatchEngine,
+		MarketSlippage:    sdk.MustNewDecFromStr(DefaultMarketSlippage),
+		MakerFeeRate:      sdk.MustNewDecFromStr(DefaultFeeRateMaker),
+		FeeVolumeDays:     DefaultFeeVolumeDays,
 	}
 }
 
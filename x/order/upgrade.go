@@ -0,0 +1,21 @@
+package order
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/okexchain/x/order/keeper"
+	"github.com/okex/okexchain/x/order/types"
+)
+
+// UpgradeParams sets the params added by the software upgrade to their default values, it's run by the upgrade
+// module at the upgrade height, as the params don't exist in the store of the running chain
+func UpgradeParams(ctx sdk.Context, k keeper.Keeper) {
+	defaultParams := types.DefaultParams()
+	k.SetParamIfMissing(ctx, types.KeyMatchEngine, defaultParams.MatchEngine)
+	k.SetParamIfMissing(ctx, types.KeyMarketSlippage, defaultParams.MarketSlippage)
+	k.SetParamIfMissing(ctx, types.KeyMakerFeeRate, defaultParams.MakerFeeRate)
+	k.SetParamIfMissing(ctx, types.KeyFeeTiers, defaultParams.FeeTiers)
+	k.SetParamIfMissing(ctx, types.KeyPairFeeRates, defaultParams.PairFeeRates)
+	k.SetParamIfMissing(ctx, types.KeyFeeVolumeDays, defaultParams.FeeVolumeDays)
+	k.SetParamIfMissing(ctx, types.KeySelfTradePrevention, defaultParams.SelfTradePrevention)
+}
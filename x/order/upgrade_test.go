@@ -0,0 +1,48 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/okex/okexchain/x/order/types"
+)
+
+func TestUpgradeParams(t *testing.T) {
+	mapp, _ := getMockApp(t, 1)
+	k := mapp.orderKeeper
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+
+	params := types.DefaultTestParams()
+	params.MatchEngine = types.ContinuousAuction
+	k.SetParams(ctx, &params)
+
+	// the params existing before the upgrade are kept
+	UpgradeParams(ctx, k)
+	k.ResetCache(ctx)
+	require.Equal(t, params, *k.GetParams(ctx))
+
+	// the params added by the upgrade don't exist in the store of the running chain
+	paramsStore := ctx.KVStore(mapp.KeyParams)
+	for _, key := range [][]byte{types.KeyMatchEngine, types.KeyMarketSlippage, types.KeyMakerFeeRate,
+		types.KeyFeeTiers, types.KeyPairFeeRates, types.KeyFeeVolumeDays, types.KeySelfTradePrevention} {
+		paramsStore.Delete(append([]byte(DefaultParamspace+"/"), key...))
+	}
+	k.ResetCache(ctx)
+	require.Panics(t, func() {
+		k.GetParams(ctx)
+	})
+
+	UpgradeParams(ctx, k)
+	k.ResetCache(ctx)
+	defaultParams := types.DefaultParams()
+	require.Equal(t, defaultParams.MatchEngine, k.GetParams(ctx).MatchEngine)
+	require.Equal(t, defaultParams.MarketSlippage, k.GetParams(ctx).MarketSlippage)
+	require.Equal(t, defaultParams.MakerFeeRate, k.GetParams(ctx).MakerFeeRate)
+	require.Equal(t, defaultParams.FeeTiers, k.GetParams(ctx).FeeTiers)
+	require.Equal(t, defaultParams.PairFeeRates, k.GetParams(ctx).PairFeeRates)
+	require.Equal(t, defaultParams.FeeVolumeDays, k.GetParams(ctx).FeeVolumeDays)
+	require.Equal(t, defaultParams.SelfTradePrevention, k.GetParams(ctx).SelfTradePrevention)
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/okex/okexchain/x/params/types"
 )
 
+// ParamsValidator validates the params in the subspace after they are changed by a param change proposal
+type ParamsValidator func(ctx sdk.Context, subspace sdkparams.Subspace) error
+
 // Keeper is the struct of params keeper
 type Keeper struct {
 	cdc *codec.Codec
@@ -20,13 +23,16 @@ type Keeper struct {
 	ck BankKeeper
 	// the reference to the GovKeeper to insert waiting queue
 	gk GovKeeper
+	// the validators of the params by subspace
+	validators map[string]ParamsValidator
 }
 
 // NewKeeper creates a new instance of params keeper
 func NewKeeper(cdc *codec.Codec, key *sdk.KVStoreKey, tkey *sdk.TransientStoreKey, codespace sdk.CodespaceType) (
 	k Keeper) {
 	k = Keeper{
-		Keeper: sdkparams.NewKeeper(cdc, key, tkey, codespace),
+		Keeper:     sdkparams.NewKeeper(cdc, key, tkey, codespace),
+		validators: make(map[string]ParamsValidator),
 	}
 	k.cdc = cdc
 	k.paramSpace = k.Subspace(DefaultParamspace).WithKeyTable(types.ParamKeyTable())
@@ -48,6 +54,11 @@ func (keeper *Keeper) SetGovKeeper(gk GovKeeper) {
 	keeper.gk = gk
 }
 
+// RegisterParamsValidator registers the validator of the params in the subspace
+func (keeper *Keeper) RegisterParamsValidator(subspace string, validator ParamsValidator) {
+	keeper.validators[subspace] = validator
+}
+
 // SetParams sets the params into the store
 func (keeper *Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	keeper.paramSpace.SetParamSet(ctx, &params)
@@ -65,6 +65,11 @@ func changeParams(ctx sdk.Context, k *Keeper, paramProposal types.ParameterChang
 		if err != nil {
 			return sdkparams.ErrSettingParameter(k.Codespace(), c.Key, c.Subkey, c.Value, err.Error())
 		}
+		if validate, ok := k.validators[c.Subspace]; ok {
+			if err := validate(ctx, ss); err != nil {
+				return sdkparams.ErrSettingParameter(k.Codespace(), c.Key, c.Subkey, c.Value, err.Error())
+			}
+		}
 	}
 	return nil
 }
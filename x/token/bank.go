@@ -0,0 +1,47 @@
+package token
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// TransferCheckedBankKeeper is the bank keeper of the bank msgs, which rejects the transfers of the paused tokens
+// and of the frozen coins
+type TransferCheckedBankKeeper struct {
+	bank.Keeper
+	tokenKeeper Keeper
+}
+
+// NewTransferCheckedBankKeeper creates a new instance of TransferCheckedBankKeeper
+func NewTransferCheckedBankKeeper(bankKeeper bank.Keeper, tokenKeeper Keeper) TransferCheckedBankKeeper {
+	return TransferCheckedBankKeeper{
+		Keeper:      bankKeeper,
+		tokenKeeper: tokenKeeper,
+	}
+}
+
+// SendCoins checks the coins of both accounts before sending them
+func (k TransferCheckedBankKeeper) SendCoins(ctx sdk.Context, from, to sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	if err := k.tokenKeeper.CheckCoinsTransferable(ctx, from, amt); err != nil {
+		return err
+	}
+	if err := k.tokenKeeper.CheckCoinsTransferable(ctx, to, amt); err != nil {
+		return err
+	}
+	return k.Keeper.SendCoins(ctx, from, to, amt)
+}
+
+// InputOutputCoins checks the coins of every input and output before sending them
+func (k TransferCheckedBankKeeper) InputOutputCoins(ctx sdk.Context, inputs []bank.Input, outputs []bank.Output) sdk.Error {
+	for _, in := range inputs {
+		if err := k.tokenKeeper.CheckCoinsTransferable(ctx, in.Address, in.Coins); err != nil {
+			return err
+		}
+	}
+	for _, out := range outputs {
+		if err := k.tokenKeeper.CheckCoinsTransferable(ctx, out.Address, out.Coins); err != nil {
+			return err
+		}
+	}
+	return k.Keeper.InputOutputCoins(ctx, inputs, outputs)
+}
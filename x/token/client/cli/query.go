@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/okex/okexchain/x/token/types"
 	"github.com/spf13/cobra"
@@ -25,6 +26,10 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	queryCmd.AddCommand(client.GetCommands(
 		getCmdQueryParams(queryRoute, cdc),
 		getCmdTokenInfo(queryRoute, cdc),
+		getCmdFrozenAccounts(queryRoute, cdc),
+		getCmdVestingSchedules(queryRoute, cdc),
+		getCmdHTLC(queryRoute, cdc),
+		getCmdHTLCs(queryRoute, cdc),
 		//getAccountCmd(queryRoute, cdc),
 	)...)
 
@@ -78,6 +83,98 @@ func getCmdTokenInfo(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// getCmdFrozenAccounts queries the accounts whose token is frozen by the token owner
+func getCmdFrozenAccounts(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "frozen [symbol]",
+		Short: "query the accounts whose token is frozen",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryFrozen, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var addrs []sdk.AccAddress
+			cdc.MustUnmarshalJSON(bz, &addrs)
+			var frozen Strings
+			for _, addr := range addrs {
+				frozen = append(frozen, addr.String())
+			}
+			return cliCtx.PrintOutput(frozen)
+		},
+	}
+}
+
+// getCmdVestingSchedules queries the vesting schedules of the recipient
+func getCmdVestingSchedules(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vesting [address]",
+		Short: "query the vesting schedules of the recipient",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryVesting, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var schedules types.VestingSchedules
+			cdc.MustUnmarshalJSON(bz, &schedules)
+			return cliCtx.PrintOutput(schedules)
+		},
+	}
+}
+
+// getCmdHTLC queries the hash time-locked contract of the hash lock
+func getCmdHTLC(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "htlc [hash-lock]",
+		Short: "query the hash time-locked contract of the hash lock with its state",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryHTLC, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var htlc types.HTLC
+			cdc.MustUnmarshalJSON(bz, &htlc)
+			return cliCtx.PrintOutput(htlc)
+		},
+	}
+}
+
+// getCmdHTLCs queries the hash time-locked contracts sent or received by the address
+func getCmdHTLCs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "htlcs [address]",
+		Short: "query the open hash time-locked contracts sent or received by the address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryHTLCs, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var htlcs types.HTLCs
+			cdc.MustUnmarshalJSON(bz, &htlcs)
+			return cliCtx.PrintOutput(htlcs)
+		},
+	}
+}
+
 // getCmdQueryParams implements the query params command.
 func getCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -3,7 +3,9 @@ package cli
 import (
 	"fmt"
 	"io/ioutil"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -23,10 +25,19 @@ const (
 	Sign          = "sign"
 	Amount        = "amount"
 	TotalSupply   = "total-supply"
+	MaxSupply     = "max-supply"
+	VestingType   = "vesting-type"
+	StartTime     = "start-time"
+	EndTime       = "end-time"
 	Symbol        = "symbol"
 	WholeName     = "whole-name"
 	TokenDesc     = "desc"
+	Decimals      = "decimals"
+	Website       = "website"
+	Logo          = "logo"
+	Attributes    = "attributes"
 	Mintable      = "mintable"
+	Freezable     = "freezable"
 	Transfers     = "transfers"
 	TransfersFile = "transfers-file"
 )
@@ -38,15 +49,18 @@ const (
 var (
 	errSymbolNotValid         = errors.New("symbol not valid")
 	errTotalSupplyNotValid    = errors.New("total-supply not valid")
+	errMaxSupplyNotValid      = errors.New("max-supply not valid")
 	errFromNotValid           = errors.New("from not valid")
 	errAmountNotValid         = errors.New("amount not valid")
 	errTokenDescNotValid      = errors.New("token-desc not valid")
 	errTokenWholeNameNotValid = errors.New("token whole name not valid")
 	errMintableNotValid       = errors.New("mintable not valid")
+	errFreezableNotValid      = errors.New("freezable not valid")
 	errTransfersNotValid      = errors.New("transfers not valid")
 	errTransfersFileNotValid  = errors.New("transfers file not valid")
+	errAttributesNotValid     = errors.New("attributes not valid")
 	errSign                   = errors.New("sign not succeed")
-	errParam                  = errors.New("can't get token desc or whole name")
+	errParam                  = errors.New("can't get token desc, whole name or metadata")
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -67,6 +81,14 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		getCmdTransferOwnership(cdc),
 		getCmdConfirmOwnership(cdc),
 		getCmdTokenEdit(cdc),
+		getCmdTokenFreeze(cdc, true),
+		getCmdTokenFreeze(cdc, false),
+		getCmdTokenPause(cdc, true),
+		getCmdTokenPause(cdc, false),
+		getCmdTokenSetMaxSupply(cdc),
+		getCmdVestingSend(cdc),
+		getCmdCreateHTLC(cdc),
+		getCmdClaimHTLC(cdc),
 	)...)
 
 	return distTxCmd
@@ -103,6 +125,10 @@ func getCmdTokenIssue(cdc *codec.Codec) *cobra.Command {
 			if err != nil {
 				return errTotalSupplyNotValid
 			}
+			maxSupply, err := flags.GetString(MaxSupply)
+			if err != nil {
+				return errMaxSupplyNotValid
+			}
 			tokenDesc, err := flags.GetString(TokenDesc)
 			if err != nil || len(tokenDesc) > TokenDescLenLimit {
 				return errTokenDescNotValid
@@ -124,10 +150,15 @@ func getCmdTokenIssue(cdc *codec.Codec) *cobra.Command {
 				return errMintableNotValid
 			}
 
+			freezable, err := flags.GetBool(Freezable)
+			if err != nil {
+				return errFreezableNotValid
+			}
+
 			var symbol string
 
 			// totalSupply int64 ,coins bigint
-			msg := types.NewMsgTokenIssue(tokenDesc, symbol, originalSymbol, wholeName, totalSupply, cliCtx.FromAddress, mintable)
+			msg := types.NewMsgTokenIssue(tokenDesc, symbol, originalSymbol, wholeName, totalSupply, maxSupply, cliCtx.FromAddress, mintable, freezable)
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
@@ -137,7 +168,9 @@ func getCmdTokenIssue(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().StringP(WholeName, "w", "", "whole name of the new token")
 	cmd.Flags().String(TokenDesc, "", "describe of the token")
 	cmd.Flags().StringP(TotalSupply, "n", "0", "total supply of the new token")
+	cmd.Flags().String(MaxSupply, "", "max supply of the new token, which can only be lowered later, no cap if empty")
 	cmd.Flags().Bool(Mintable, false, "whether the token can be minted")
+	cmd.Flags().Bool(Freezable, false, "whether the owner can freeze accounts and pause transfers of the token")
 
 	return cmd
 }
@@ -356,7 +389,7 @@ func SendTxCmd(cdc *codec.Codec) *cobra.Command {
 func getCmdTokenEdit(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "edit",
-		Short: "edit a token's whole name and desc",
+		Short: "edit a token's whole name, desc and metadata",
 		//Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -403,21 +436,74 @@ func getCmdTokenEdit(cdc *codec.Codec) *cobra.Command {
 					return errTokenWholeNameNotValid
 				}
 			}
-			if !isWholeNameEdit && !isDescEdit {
+
+			msg := types.NewMsgTokenModify(symbol, tokenDesc, wholeName, isDescEdit, isWholeNameEdit, cliCtx.FromAddress)
+			if flags.Changed(Decimals) {
+				msg.IsDecimalsModified = true
+				if msg.Decimals, err = flags.GetInt32(Decimals); err != nil {
+					return err
+				}
+			}
+			if flags.Changed(Website) {
+				msg.IsWebsiteModified = true
+				if msg.Website, err = flags.GetString(Website); err != nil {
+					return err
+				}
+			}
+			if flags.Changed(Logo) {
+				msg.IsLogoModified = true
+				if msg.Logo, err = flags.GetString(Logo); err != nil {
+					return err
+				}
+			}
+			if flags.Changed(Attributes) {
+				msg.IsAttributesModified = true
+				attributesStr, err := flags.GetString(Attributes)
+				if err != nil {
+					return err
+				}
+				if msg.Attributes, err = parseAttributes(attributesStr); err != nil {
+					return err
+				}
+			}
+			if !msg.IsWholeNameModified && !msg.IsDescriptionModified && !msg.IsDecimalsModified &&
+				!msg.IsWebsiteModified && !msg.IsLogoModified && !msg.IsAttributesModified {
 				return errParam
 			}
 
-			msg := types.NewMsgTokenModify(symbol, tokenDesc, wholeName, isDescEdit, isWholeNameEdit, cliCtx.FromAddress)
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
 	}
 	cmd.Flags().StringP(Symbol, "s", "", "symbol of the token")
 	cmd.Flags().StringP(WholeName, "w", "", "whole name of the token")
 	cmd.Flags().String(TokenDesc, "", "description of the token")
+	cmd.Flags().Int32(Decimals, 0, "decimals for wallets to display the token")
+	cmd.Flags().String(Website, "", "website url of the token, empty to clear it")
+	cmd.Flags().String(Logo, "", "logo url of the token, empty to clear it")
+	cmd.Flags().String(Attributes, "", "key/value attributes of the token replacing the current ones, e.g. key1=value1,key2=value2")
 
 	return cmd
 }
 
+// parseAttributes parses the attributes in the format of key1=value1,key2=value2
+func parseAttributes(str string) ([]types.TokenAttribute, error) {
+	var attributes []types.TokenAttribute
+	if len(strings.TrimSpace(str)) == 0 {
+		return attributes, nil
+	}
+	for _, pair := range strings.Split(str, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, errAttributesNotValid
+		}
+		attributes = append(attributes, types.TokenAttribute{
+			Key:   strings.TrimSpace(kv[0]),
+			Value: strings.TrimSpace(kv[1]),
+		})
+	}
+	return attributes, nil
+}
+
 // getCmdConfirmOwnership is the CLI command for sending a ConfirmOwnership transaction
 func getCmdConfirmOwnership(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -450,3 +536,202 @@ func getCmdConfirmOwnership(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().StringP("symbol", "s", "", "symbol of the token to be transferred")
 	return cmd
 }
+
+// getCmdTokenFreeze is the CLI command for sending a TokenFreeze transaction to freeze or unfreeze an account
+func getCmdTokenFreeze(cdc *codec.Codec, freeze bool) *cobra.Command {
+	use, short := "freeze", "freeze the token of an account"
+	if !freeze {
+		use, short = "unfreeze", "unfreeze the token of an account"
+	}
+	cmd := &cobra.Command{
+		Use:   use + " [symbol] [address]",
+		Short: short,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := authTypes.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			addr, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgTokenFreeze(cliCtx.GetFromAddress(), args[0], addr, freeze)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+// getCmdTokenPause is the CLI command for sending a TokenPause transaction to pause or resume the transfers
+func getCmdTokenPause(cdc *codec.Codec, pause bool) *cobra.Command {
+	use, short := "pause", "pause all the transfers of the token"
+	if !pause {
+		use, short = "unpause", "resume the transfers of the token"
+	}
+	cmd := &cobra.Command{
+		Use:   use + " [symbol]",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := authTypes.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			msg := types.NewMsgTokenPause(cliCtx.GetFromAddress(), args[0], pause)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+// getCmdTokenSetMaxSupply is the CLI command for sending a TokenSetMaxSupply transaction
+func getCmdTokenSetMaxSupply(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-max-supply [symbol] [max-supply]",
+		Short: "cap the total supply of the token, or lower the cap",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := authTypes.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			maxSupply, err := sdk.NewDecFromStr(args[1])
+			if err != nil {
+				return errMaxSupplyNotValid
+			}
+
+			msg := types.NewMsgTokenSetMaxSupply(cliCtx.GetFromAddress(), args[0], maxSupply)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+// getCmdVestingSend is the CLI command for sending a VestingSend transaction
+func getCmdVestingSend(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vesting-send [to] [amount]",
+		Short: "send coins released to the recipient over time",
+		Long: strings.TrimSpace(`Send coins released to the recipient over time. The coins are released linearly
+between the start time and the end time once an hour, or all at once at the end time with the cliff vesting type.
+An account can receive at most 100 vesting schedules being released:
+
+$ okexchaincli tx token vesting-send okexchain10q0rk5qnyag7wfvvt7rtphlw589m7frsku8qc9 1000okt \
+  --vesting-type=linear --start-time=2021-01-01T00:00:00Z --end-time=2022-01-01T00:00:00Z --from mykey
+`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := authTypes.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+			flags := cmd.Flags()
+
+			to, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				return errAmountNotValid
+			}
+
+			vestingType, err := flags.GetString(VestingType)
+			if err != nil {
+				return err
+			}
+			var startTime, endTime time.Time
+			if startTimeStr, _ := flags.GetString(StartTime); startTimeStr != "" {
+				if startTime, err = time.Parse(time.RFC3339, startTimeStr); err != nil {
+					return err
+				}
+			}
+			endTimeStr, err := flags.GetString(EndTime)
+			if err != nil {
+				return err
+			}
+			if endTime, err = time.Parse(time.RFC3339, endTimeStr); err != nil {
+				return err
+			}
+
+			msg := types.NewMsgVestingSend(cliCtx.GetFromAddress(), to, amount, vestingType, startTime, endTime)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(VestingType, types.VestingTypeLinear, "vesting type of the schedule, linear or cliff")
+	cmd.Flags().String(StartTime, "", "time to start releasing the coins linearly, in RFC3339 format")
+	cmd.Flags().String(EndTime, "", "time to release all the coins, in RFC3339 format")
+	return cmd
+}
+
+// getCmdCreateHTLC is the CLI command for sending a CreateHTLC transaction
+func getCmdCreateHTLC(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create-htlc [to] [amount] [hash-lock] [time-lock]",
+		Short: "lock coins for the recipient until the secret of the hash lock is revealed",
+		Long: strings.TrimSpace(`Lock coins for the recipient with a hex encoded sha256 hash lock of a 32 bytes secret.
+The recipient claims the coins with the secret before the time lock in blocks expires, or they are refunded:
+
+$ okexchaincli tx token create-htlc okexchain10q0rk5qnyag7wfvvt7rtphlw589m7frsku8qc9 1000okt \
+  e8d4a51000e8d4a51000e8d4a51000e8d4a51000e8d4a51000e8d4a51000e8d4 1000 --from mykey
+`),
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := authTypes.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			to, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseDecCoins(args[1])
+			if err != nil {
+				return errAmountNotValid
+			}
+			timeLock, err := strconv.ParseInt(args[3], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgCreateHTLC(cliCtx.GetFromAddress(), to, amount, args[2], timeLock)
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+// getCmdClaimHTLC is the CLI command for sending a ClaimHTLC transaction
+func getCmdClaimHTLC(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "claim-htlc [hash-lock] [secret]",
+		Short: "claim the coins of the hash time-locked contract for its recipient with the hex encoded secret",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := authTypes.NewAccountRetriever(cliCtx).EnsureExists(cliCtx.FromAddress); err != nil {
+				return err
+			}
+
+			msg := types.NewMsgClaimHTLC(cliCtx.GetFromAddress(), args[0], args[1])
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,15 @@
+package token
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/common/perf"
+	"github.com/okex/okexchain/x/token/types"
+)
+
+// EndBlocker is called when dapp handles with abci::EndBlock
+func endBlocker(ctx sdk.Context, keeper Keeper) {
+	seq := perf.GetPerf().OnEndBlockEnter(ctx, types.ModuleName)
+	defer perf.GetPerf().OnEndBlockExit(ctx, types.ModuleName, seq)
+
+	keeper.RefundExpiredHTLCs(ctx)
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 
@@ -18,6 +19,10 @@ type GenesisState struct {
 	Tokens       []types.Token    `json:"tokens"`
 	LockedAssets []types.AccCoins `json:"locked_assets"`
 	LockedFees   []types.AccCoins `json:"locked_fees"`
+
+	FrozenAccounts   []types.FrozenAccount   `json:"frozen_accounts"`
+	VestingSchedules []types.VestingSchedule `json:"vesting_schedules"`
+	HTLCs            []types.HTLC            `json:"htlcs"`
 }
 
 // default GenesisState used by Cosmos Hub
@@ -46,23 +51,73 @@ func defaultGenesisStateOKT() types.Token {
 		OriginalTotalSupply: totalSupply,
 		Owner:               addr,
 		Mintable:            true,
+		MaxSupply:           sdk.ZeroDec(),
 	}
 }
 
 func validateGenesis(data GenesisState) error {
 	for _, token := range data.Tokens {
+		var maxSupply string
+		if !token.MaxSupply.IsNil() {
+			maxSupply = token.MaxSupply.String()
+		}
 		msg := types.NewMsgTokenIssue(token.Description,
 			token.Symbol,
 			token.OriginalSymbol,
 			token.WholeName,
 			token.OriginalTotalSupply.String(),
+			maxSupply,
 			token.Owner,
-			token.Mintable)
+			token.Mintable,
+			token.Freezable)
 
 		err := msg.ValidateBasic()
 		if err != nil {
 			return errors.New(err.Error())
 		}
+
+		modifyMsg := types.NewMsgTokenModify(token.Symbol, token.Description, token.WholeName, false, false, token.Owner)
+		modifyMsg.Decimals, modifyMsg.IsDecimalsModified = token.Decimals, true
+		modifyMsg.Website, modifyMsg.IsWebsiteModified = token.Website, true
+		modifyMsg.Logo, modifyMsg.IsLogoModified = token.Logo, true
+		modifyMsg.Attributes, modifyMsg.IsAttributesModified = token.Attributes, true
+		if err := modifyMsg.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid metadata of token(%s): %s", token.Symbol, err.Error())
+		}
+	}
+	for _, frozen := range data.FrozenAccounts {
+		if frozen.Address.Empty() {
+			return fmt.Errorf("empty address frozen for token(%s)", frozen.Symbol)
+		}
+		if sdk.ValidateDenom(frozen.Symbol) != nil {
+			return fmt.Errorf("invalid symbol(%s) of the frozen account %s", frozen.Symbol, frozen.Address.String())
+		}
+	}
+	for _, schedule := range data.VestingSchedules {
+		msg := types.NewMsgVestingSend(schedule.From, schedule.To, schedule.Amount, schedule.VestingType,
+			schedule.StartTime, schedule.EndTime)
+		if err := msg.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid vesting schedule %d: %s", schedule.ID, err.Error())
+		}
+		if _, isNegative := schedule.Amount.SafeSub(schedule.Released); isNegative {
+			return fmt.Errorf("invalid vesting schedule %d: released more than the amount", schedule.ID)
+		}
+	}
+	for _, htlc := range data.HTLCs {
+		if err := types.NewMsgCreateHTLC(htlc.Sender, htlc.Recipient, htlc.Amount, htlc.HashLock,
+			types.MinHTLCTimeLock).ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid htlc %s: %s", htlc.HashLock, err.Error())
+		}
+		switch htlc.State {
+		case types.HTLCStateOpen, types.HTLCStateRefunded:
+		case types.HTLCStateCompleted:
+			if secret, err := hex.DecodeString(htlc.Secret); err != nil ||
+				hex.EncodeToString(types.GetHashLock(secret)) != htlc.HashLock {
+				return fmt.Errorf("invalid secret of htlc %s", htlc.HashLock)
+			}
+		default:
+			return fmt.Errorf("invalid state(%s) of htlc %s", htlc.State, htlc.HashLock)
+		}
 	}
 	return nil
 }
@@ -93,6 +148,35 @@ func initGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 			panic(err)
 		}
 	}
+	for _, frozen := range data.FrozenAccounts {
+		keeper.SetFrozen(ctx, frozen.Symbol, frozen.Address, true)
+	}
+	var lastVestingScheduleID uint64
+	for i := range data.VestingSchedules {
+		schedule := data.VestingSchedules[i]
+		keeper.SetVestingSchedule(ctx, &schedule)
+		keeper.insertVestingQueue(ctx, schedule.NextReleaseTime(ctx.BlockTime()), schedule.To, schedule.ID)
+		if err := keeper.updateLockedCoins(ctx, schedule.To, schedule.LockedCoins(), true, types.LockCoinsTypeVesting); err != nil {
+			panic(err)
+		}
+		if schedule.ID > lastVestingScheduleID {
+			lastVestingScheduleID = schedule.ID
+		}
+	}
+	if lastVestingScheduleID > 0 {
+		keeper.setLastVestingScheduleID(ctx, lastVestingScheduleID)
+	}
+	for i := range data.HTLCs {
+		htlc := data.HTLCs[i]
+		hashLock, err := hex.DecodeString(htlc.HashLock)
+		if err != nil {
+			panic(err)
+		}
+		keeper.SetHTLC(ctx, &htlc)
+		if htlc.State == types.HTLCStateOpen {
+			keeper.insertHTLCIndexes(ctx, &htlc, hashLock)
+		}
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -113,10 +197,25 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data GenesisState) {
 		return false
 	})
 
+	var vestingSchedules []types.VestingSchedule
+	keeper.IterateVestingSchedules(ctx, func(schedule *types.VestingSchedule) bool {
+		vestingSchedules = append(vestingSchedules, *schedule)
+		return false
+	})
+
+	var htlcs []types.HTLC
+	keeper.IterateHTLCs(ctx, func(htlc *types.HTLC) bool {
+		htlcs = append(htlcs, *htlc)
+		return false
+	})
+
 	return GenesisState{
-		Params:       params,
-		Tokens:       tokens,
-		LockedAssets: lockedAsset,
-		LockedFees:   lockedFees,
+		Params:           params,
+		Tokens:           tokens,
+		LockedAssets:     lockedAsset,
+		LockedFees:       lockedFees,
+		FrozenAccounts:   keeper.GetAllFrozenAccounts(ctx),
+		VestingSchedules: vestingSchedules,
+		HTLCs:            htlcs,
 	}
 }
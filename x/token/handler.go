@@ -1,6 +1,7 @@
 package token
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	"github.com/okex/okexchain/x/common"
@@ -68,6 +69,42 @@ func NewTokenHandler(keeper Keeper, protocolVersion version.ProtocolVersionType)
 			handlerFun = func() sdk.Result {
 				return handleMsgTokenModify(ctx, keeper, msg, logger)
 			}
+
+		case types.MsgTokenFreeze:
+			name = "handleMsgTokenFreeze"
+			handlerFun = func() sdk.Result {
+				return handleMsgTokenFreeze(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgTokenPause:
+			name = "handleMsgTokenPause"
+			handlerFun = func() sdk.Result {
+				return handleMsgTokenPause(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgVestingSend:
+			name = "handleMsgVestingSend"
+			handlerFun = func() sdk.Result {
+				return handleMsgVestingSend(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgTokenSetMaxSupply:
+			name = "handleMsgTokenSetMaxSupply"
+			handlerFun = func() sdk.Result {
+				return handleMsgTokenSetMaxSupply(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgCreateHTLC:
+			name = "handleMsgCreateHTLC"
+			handlerFun = func() sdk.Result {
+				return handleMsgCreateHTLC(ctx, keeper, msg, logger)
+			}
+
+		case types.MsgClaimHTLC:
+			name = "handleMsgClaimHTLC"
+			handlerFun = func() sdk.Result {
+				return handleMsgClaimHTLC(ctx, keeper, msg, logger)
+			}
 		default:
 			errMsg := fmt.Sprintf("Unrecognized token Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -89,6 +126,16 @@ func handleMsgTokenIssue(ctx sdk.Context, keeper Keeper, msg types.MsgTokenIssue
 		return sdk.ErrInternal(fmt.Sprintf("total-supply(%s) exceeds the upper limit(%d)",
 			msg.TotalSupply, types.TotalSupplyUpperbound)).Result()
 	}
+	maxSupply := sdk.ZeroDec()
+	if len(msg.MaxSupply) != 0 {
+		maxSupply, err = sdk.NewDecFromStr(msg.MaxSupply)
+		if err != nil {
+			return sdk.ErrInternal(fmt.Sprintf("invalid max supply(%s)", msg.MaxSupply)).Result()
+		}
+	}
+	if maxSupply.IsPositive() && totalSupply.GT(maxSupply) {
+		return types.ErrExceedsMaxSupply(DefaultCodespace, msg.OriginalSymbol, totalSupply, maxSupply).Result()
+	}
 
 	token := types.Token{
 		Description:         msg.Description,
@@ -97,6 +144,8 @@ func handleMsgTokenIssue(ctx sdk.Context, keeper Keeper, msg types.MsgTokenIssue
 		OriginalTotalSupply: totalSupply,
 		Owner:               msg.Owner,
 		Mintable:            msg.Mintable,
+		Freezable:           msg.Freezable,
+		MaxSupply:           maxSupply,
 	}
 
 	// generate a random symbol
@@ -216,6 +265,14 @@ func handleMsgTokenMint(ctx sdk.Context, keeper Keeper, msg types.MsgTokenMint,
 		return sdk.ErrUnauthorized(fmt.Sprintf("token(%s) is not mintable", token.Symbol)).Result()
 	}
 
+	// check max supply
+	if token.HasMaxSupply() {
+		totalSupply := keeper.GetTokenTotalSupply(ctx, token.Symbol).Add(msg.Amount.Amount)
+		if totalSupply.GT(token.MaxSupply) {
+			return types.ErrExceedsMaxSupply(DefaultCodespace, token.Symbol, totalSupply, token.MaxSupply).Result()
+		}
+	}
+
 	mintCoins := msg.Amount.ToCoins()
 	// set supply
 	err := keeper.supplyKeeper.MintCoins(ctx, types.ModuleName, mintCoins)
@@ -267,6 +324,9 @@ func handleMsgMultiSend(ctx sdk.Context, keeper Keeper, msg types.MsgMultiSend,
 	for _, transferUnit := range msg.Transfers {
 		coinNum += len(transferUnit.Coins)
 		err := keeper.SendCoinsFromAccountToAccount(ctx, msg.From, transferUnit.To, transferUnit.Coins)
+		if sdkErr, ok := err.(sdk.Error); ok && isTransferRestricted(sdkErr) {
+			return sdkErr.Result()
+		}
 		if err != nil {
 			return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins(need %s)",
 				transferUnit.Coins.String())).Result()
@@ -297,6 +357,9 @@ func handleMsgSend(ctx sdk.Context, keeper Keeper, msg types.MsgSend, logger log
 	}
 
 	err := keeper.SendCoinsFromAccountToAccount(ctx, msg.FromAddress, msg.ToAddress, msg.Amount)
+	if sdkErr, ok := err.(sdk.Error); ok && isTransferRestricted(sdkErr) {
+		return sdkErr.Result()
+	}
 	if err != nil {
 		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins(need %s)",
 			msg.Amount.String())).Result()
@@ -318,6 +381,122 @@ func handleMsgSend(ctx sdk.Context, keeper Keeper, msg types.MsgSend, logger log
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
+func handleMsgVestingSend(ctx sdk.Context, keeper Keeper, msg types.MsgVestingSend, logger log.Logger) sdk.Result {
+	if !keeper.bankKeeper.GetSendEnabled(ctx) {
+		return types.ErrSendDisabled(DefaultCodespace).Result()
+	}
+	if !msg.EndTime.After(ctx.BlockTime()) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("end time(%s) of the vesting schedule has passed",
+			msg.EndTime.String())).Result()
+	}
+
+	schedule, err := keeper.VestingSend(ctx, msg.FromAddress, msg.ToAddress, msg.Amount, msg.VestingType,
+		msg.StartTime, msg.EndTime)
+	if sdkErr, ok := err.(sdk.Error); ok && (isTransferRestricted(sdkErr) ||
+		sdkErr.Codespace() == DefaultCodespace && sdkErr.Code() == types.CodeTooManyVestingSchedules) {
+		return sdkErr.Result()
+	}
+	if err != nil {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient coins(need %s)",
+			msg.Amount.String())).Result()
+	}
+
+	var name = "handleMsgVestingSend"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<From:%s,To:%s,Amount:%s,VestingType:%s,StartTime:%s,EndTime:%s>\n"+
+			"                           result<vesting schedule %d>\n",
+			ctx.BlockHeight(), name,
+			msg.FromAddress, msg.ToAddress, msg.Amount, msg.VestingType, msg.StartTime, msg.EndTime,
+			schedule.ID))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("vesting_id", fmt.Sprintf("%d", schedule.ID)),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgCreateHTLC(ctx sdk.Context, keeper Keeper, msg types.MsgCreateHTLC, logger log.Logger) sdk.Result {
+	if !keeper.bankKeeper.GetSendEnabled(ctx) {
+		return types.ErrSendDisabled(DefaultCodespace).Result()
+	}
+	// the hash lock is checked by ValidateBasic
+	hashLock, _ := hex.DecodeString(msg.HashLock)
+
+	htlc, err := keeper.CreateHTLC(ctx, msg.Sender, msg.To, msg.Amount, hashLock, msg.TimeLock)
+	if err != nil {
+		return err.Result()
+	}
+
+	var name = "handleMsgCreateHTLC"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Sender:%s,To:%s,Amount:%s,HashLock:%s,TimeLock:%d>\n"+
+			"                           result<expire height %d>\n",
+			ctx.BlockHeight(), name,
+			msg.Sender, msg.To, msg.Amount, msg.HashLock, msg.TimeLock,
+			htlc.ExpireHeight))
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		),
+		sdk.NewEvent(
+			types.EventTypeCreateHTLC,
+			sdk.NewAttribute(types.AttributeKeyHashLock, htlc.HashLock),
+			sdk.NewAttribute(types.AttributeKeySender, htlc.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipient, htlc.Recipient.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, htlc.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyExpireHeight, fmt.Sprintf("%d", htlc.ExpireHeight)),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgClaimHTLC(ctx sdk.Context, keeper Keeper, msg types.MsgClaimHTLC, logger log.Logger) sdk.Result {
+	// the hash lock and the secret are checked by ValidateBasic
+	hashLock, _ := hex.DecodeString(msg.HashLock)
+	secret, _ := hex.DecodeString(msg.Secret)
+
+	htlc, err := keeper.ClaimHTLC(ctx, hashLock, secret)
+	if err != nil {
+		return err.Result()
+	}
+
+	var name = "handleMsgClaimHTLC"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Sender:%s,HashLock:%s,Secret:%s>\n"+
+			"                           result<claimed %s for %s>\n",
+			ctx.BlockHeight(), name,
+			msg.Sender, msg.HashLock, msg.Secret,
+			htlc.Amount, htlc.Recipient))
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		),
+		sdk.NewEvent(
+			types.EventTypeClaimHTLC,
+			sdk.NewAttribute(types.AttributeKeyHashLock, htlc.HashLock),
+			sdk.NewAttribute(types.AttributeKeySender, htlc.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeyRecipient, htlc.Recipient.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, htlc.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeySecret, htlc.Secret),
+		),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
 func handleMsgTransferOwnership(ctx sdk.Context, keeper Keeper, msg types.MsgTransferOwnership, logger log.Logger) sdk.Result {
 	tokenInfo := keeper.GetTokenInfo(ctx, msg.Symbol)
 
@@ -422,7 +601,8 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of token(%s)",
 			msg.Owner.String(), msg.Symbol)).Result()
 	}
-	if !msg.IsWholeNameModified && !msg.IsDescriptionModified {
+	if !msg.IsWholeNameModified && !msg.IsDescriptionModified && !msg.IsDecimalsModified &&
+		!msg.IsWebsiteModified && !msg.IsLogoModified && !msg.IsAttributesModified {
 		return sdk.ErrInternal("nothing modified").Result()
 	}
 	// modify
@@ -432,6 +612,18 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 	if msg.IsDescriptionModified {
 		token.Description = msg.Description
 	}
+	if msg.IsDecimalsModified {
+		token.Decimals = msg.Decimals
+	}
+	if msg.IsWebsiteModified {
+		token.Website = msg.Website
+	}
+	if msg.IsLogoModified {
+		token.Logo = msg.Logo
+	}
+	if msg.IsAttributesModified {
+		token.Attributes = msg.Attributes
+	}
 
 	keeper.UpdateToken(ctx, token)
 
@@ -446,10 +638,10 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 	name := "handleMsgTokenModify"
 	if logger != nil {
 		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
-			"                           msg<Owner:%s,Symbol:%s,WholeName:%s,Description:%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s,WholeName:%s,Description:%s,Decimals:%d,Website:%s,Logo:%s,Attributes:%v>\n"+
 			"                           result<Owner have enough okts to edit %s>\n",
 			ctx.BlockHeight(), name,
-			msg.Owner, msg.Symbol, msg.WholeName, msg.Description,
+			msg.Owner, msg.Symbol, msg.WholeName, msg.Description, msg.Decimals, msg.Website, msg.Logo, msg.Attributes,
 			msg.Symbol))
 	}
 
@@ -462,3 +654,124 @@ func handleMsgTokenModify(ctx sdk.Context, keeper Keeper, msg types.MsgTokenModi
 	)
 	return sdk.Result{Events: ctx.EventManager().Events()}
 }
+
+func handleMsgTokenFreeze(ctx sdk.Context, keeper Keeper, msg types.MsgTokenFreeze, logger log.Logger) sdk.Result {
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !token.Owner.Equals(msg.Owner) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of token(%s)",
+			msg.Owner.String(), msg.Symbol)).Result()
+	}
+	// check whether token is freezable
+	if !token.Freezable {
+		return sdk.ErrUnauthorized(fmt.Sprintf("token(%s) is not freezable", token.Symbol)).Result()
+	}
+	if keeper.IsFrozen(ctx, msg.Symbol, msg.Address) == msg.Freeze {
+		return sdk.ErrInternal(fmt.Sprintf("token(%s) of %s is already in the state(frozen:%v)",
+			msg.Symbol, msg.Address.String(), msg.Freeze)).Result()
+	}
+
+	keeper.SetFrozen(ctx, msg.Symbol, msg.Address, msg.Freeze)
+
+	name := "handleMsgTokenFreeze"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s,Address:%s,Freeze:%v>\n",
+			ctx.BlockHeight(), name,
+			msg.Owner, msg.Symbol, msg.Address, msg.Freeze))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+			sdk.NewAttribute("address", msg.Address.String()),
+			sdk.NewAttribute("freeze", fmt.Sprintf("%v", msg.Freeze)),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgTokenPause(ctx sdk.Context, keeper Keeper, msg types.MsgTokenPause, logger log.Logger) sdk.Result {
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !token.Owner.Equals(msg.Owner) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of token(%s)",
+			msg.Owner.String(), msg.Symbol)).Result()
+	}
+	// check whether token is freezable
+	if !token.Freezable {
+		return sdk.ErrUnauthorized(fmt.Sprintf("token(%s) is not freezable", token.Symbol)).Result()
+	}
+	if token.Paused == msg.Pause {
+		return sdk.ErrInternal(fmt.Sprintf("token(%s) is already in the state(paused:%v)",
+			msg.Symbol, msg.Pause)).Result()
+	}
+
+	token.Paused = msg.Pause
+	keeper.UpdateToken(ctx, token)
+
+	name := "handleMsgTokenPause"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s,Pause:%v>\n",
+			ctx.BlockHeight(), name,
+			msg.Owner, msg.Symbol, msg.Pause))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+			sdk.NewAttribute("pause", fmt.Sprintf("%v", msg.Pause)),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+func handleMsgTokenSetMaxSupply(ctx sdk.Context, keeper Keeper, msg types.MsgTokenSetMaxSupply, logger log.Logger) sdk.Result {
+	token := keeper.GetTokenInfo(ctx, msg.Symbol)
+	// check owner
+	if !token.Owner.Equals(msg.Owner) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the owner of token(%s)",
+			msg.Owner.String(), msg.Symbol)).Result()
+	}
+	// the max supply can only be lowered
+	if token.HasMaxSupply() && msg.MaxSupply.GTE(token.MaxSupply) {
+		return sdk.ErrInternal(fmt.Sprintf("max supply of token(%s) can only be lowered from %s",
+			msg.Symbol, token.MaxSupply.String())).Result()
+	}
+	totalSupply := keeper.GetTokenTotalSupply(ctx, msg.Symbol)
+	if totalSupply.GT(msg.MaxSupply) {
+		return types.ErrExceedsMaxSupply(DefaultCodespace, msg.Symbol, totalSupply, msg.MaxSupply).Result()
+	}
+
+	token.MaxSupply = msg.MaxSupply
+	keeper.UpdateToken(ctx, token)
+
+	name := "handleMsgTokenSetMaxSupply"
+	if logger != nil {
+		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
+			"                           msg<Owner:%s,Symbol:%s,MaxSupply:%s>\n",
+			ctx.BlockHeight(), name,
+			msg.Owner, msg.Symbol, msg.MaxSupply))
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute("symbol", msg.Symbol),
+			sdk.NewAttribute("max_supply", msg.MaxSupply.String()),
+		),
+	)
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// isTransferRestricted returns whether the transfer failed for the token frozen or paused by the token owner
+func isTransferRestricted(err sdk.Error) bool {
+	return err.Codespace() == DefaultCodespace &&
+		(err.Code() == types.CodeTokenFrozen || err.Code() == types.CodeTokenPaused)
+}
@@ -0,0 +1,177 @@
+package token
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/token/types"
+)
+
+// CreateHTLC locks the coins of the sender in the token module account for the recipient until the expire height
+func (k Keeper) CreateHTLC(ctx sdk.Context, sender, to sdk.AccAddress, amt sdk.DecCoins, hashLock []byte,
+	timeLock int64) (*types.HTLC, sdk.Error) {
+	if _, found := k.GetHTLC(ctx, hashLock); found {
+		return nil, types.ErrHTLCExists(DefaultCodespace, hex.EncodeToString(hashLock))
+	}
+	if k.bankKeeper.BlacklistedAddr(to) {
+		return nil, types.ErrBlockedRecipient(DefaultCodespace, to.String())
+	}
+	if err := k.CheckCoinsTransferable(ctx, sender, amt); err != nil {
+		return nil, err
+	}
+	if err := k.CheckCoinsTransferable(ctx, to, amt); err != nil {
+		return nil, err
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, amt); err != nil {
+		return nil, err
+	}
+
+	htlc := &types.HTLC{
+		HashLock:     hex.EncodeToString(hashLock),
+		Sender:       sender,
+		Recipient:    to,
+		Amount:       amt,
+		ExpireHeight: ctx.BlockHeight() + timeLock,
+		State:        types.HTLCStateOpen,
+	}
+	k.SetHTLC(ctx, htlc)
+	k.insertHTLCIndexes(ctx, htlc, hashLock)
+	return htlc, nil
+}
+
+// ClaimHTLC sends the coins of the contract to its recipient if the secret matches the hash lock,
+// and keeps the completed contract with the secret
+func (k Keeper) ClaimHTLC(ctx sdk.Context, hashLock, secret []byte) (*types.HTLC, sdk.Error) {
+	htlc, found := k.GetHTLC(ctx, hashLock)
+	if !found {
+		return nil, types.ErrUnknownHTLC(DefaultCodespace, hex.EncodeToString(hashLock))
+	}
+	if htlc.State != types.HTLCStateOpen {
+		return nil, types.ErrHTLCNotOpen(DefaultCodespace, htlc.HashLock, htlc.State)
+	}
+	if ctx.BlockHeight() >= htlc.ExpireHeight {
+		return nil, types.ErrHTLCExpired(DefaultCodespace, htlc.HashLock, htlc.ExpireHeight)
+	}
+	if !bytes.Equal(types.GetHashLock(secret), hashLock) {
+		return nil, types.ErrInvalidSecret(DefaultCodespace, htlc.HashLock)
+	}
+	if err := k.CheckCoinsTransferable(ctx, htlc.Recipient, htlc.Amount); err != nil {
+		return nil, err
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.Recipient, htlc.Amount); err != nil {
+		return nil, err
+	}
+
+	htlc.Secret = hex.EncodeToString(secret)
+	htlc.State = types.HTLCStateCompleted
+	k.settleHTLC(ctx, htlc, hashLock)
+	return htlc, nil
+}
+
+// RefundExpiredHTLCs refunds the coins of the open contracts expired until the current height to their senders,
+// and keeps the refunded contracts
+func (k Keeper) RefundExpiredHTLCs(ctx sdk.Context) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := store.Iterator(types.PrefixHTLCExpireQueueKey,
+		sdk.PrefixEndBytes(types.GetHTLCExpireQueuePrefix(ctx.BlockHeight())))
+	var hashLocks [][]byte
+	for ; iter.Valid(); iter.Next() {
+		hashLocks = append(hashLocks, iter.Value())
+	}
+	iter.Close()
+
+	for _, hashLock := range hashLocks {
+		htlc, found := k.GetHTLC(ctx, hashLock)
+		if !found || htlc.State != types.HTLCStateOpen {
+			continue
+		}
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.Sender, htlc.Amount); err != nil {
+			ctx.Logger().Error(fmt.Sprintf("failed to refund htlc %s: %s", htlc.HashLock, err.Error()))
+			continue
+		}
+		htlc.State = types.HTLCStateRefunded
+		k.settleHTLC(ctx, htlc, hashLock)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeRefundHTLC,
+				sdk.NewAttribute(types.AttributeKeyHashLock, htlc.HashLock),
+				sdk.NewAttribute(types.AttributeKeySender, htlc.Sender.String()),
+				sdk.NewAttribute(types.AttributeKeyRecipient, htlc.Recipient.String()),
+				sdk.NewAttribute(types.AttributeKeyAmount, htlc.Amount.String()),
+			),
+		)
+	}
+}
+
+// GetHTLC gets the hash time-locked contract of the hash lock
+func (k Keeper) GetHTLC(ctx sdk.Context, hashLock []byte) (*types.HTLC, bool) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	bz := store.Get(types.GetHTLCKey(hashLock))
+	if bz == nil {
+		return nil, false
+	}
+	var htlc types.HTLC
+	k.cdc.MustUnmarshalBinaryBare(bz, &htlc)
+	return &htlc, true
+}
+
+// SetHTLC sets the hash time-locked contract to db
+func (k Keeper) SetHTLC(ctx sdk.Context, htlc *types.HTLC) {
+	hashLock, err := hex.DecodeString(htlc.HashLock)
+	if err != nil {
+		panic(err)
+	}
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetHTLCKey(hashLock), k.cdc.MustMarshalBinaryBare(htlc))
+}
+
+// GetHTLCs returns the open hash time-locked contracts sent or received by the address
+func (k Keeper) GetHTLCs(ctx sdk.Context, addr sdk.AccAddress) (htlcs []types.HTLC) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	prefix := types.GetHTLCAddressPrefix(addr)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if htlc, found := k.GetHTLC(ctx, iter.Key()[len(prefix):]); found {
+			htlcs = append(htlcs, *htlc)
+		}
+	}
+	return htlcs
+}
+
+// IterateHTLCs iterates over all the hash time-locked contracts and performs a callback function
+func (k Keeper) IterateHTLCs(ctx sdk.Context, cb func(htlc *types.HTLC) (stop bool)) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.PrefixHTLCKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var htlc types.HTLC
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &htlc)
+		if cb(&htlc) {
+			break
+		}
+	}
+}
+
+// insertHTLCIndexes adds the open contract to the expire queue and the indexes of its sender and recipient
+func (k Keeper) insertHTLCIndexes(ctx sdk.Context, htlc *types.HTLC, hashLock []byte) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetHTLCExpireQueueKey(htlc.ExpireHeight, hashLock), hashLock)
+	store.Set(types.GetHTLCAddressKey(htlc.Sender, hashLock), []byte{})
+	store.Set(types.GetHTLCAddressKey(htlc.Recipient, hashLock), []byte{})
+}
+
+// settleHTLC saves the settled contract, and removes it from the expire queue and the indexes of its sender
+// and recipient. The contract is kept so its hash lock can't be reused
+func (k Keeper) settleHTLC(ctx sdk.Context, htlc *types.HTLC, hashLock []byte) {
+	k.SetHTLC(ctx, htlc)
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Delete(types.GetHTLCExpireQueueKey(htlc.ExpireHeight, hashLock))
+	store.Delete(types.GetHTLCAddressKey(htlc.Sender, hashLock))
+	store.Delete(types.GetHTLCAddressKey(htlc.Recipient, hashLock))
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"strings"
@@ -167,12 +168,21 @@ func (k Keeper) SendCoinsFromAccountToAccount(ctx sdk.Context, from, to sdk.AccA
 	if k.bankKeeper.BlacklistedAddr(to) {
 		return types.ErrBlockedRecipient(DefaultCodespace, to.String())
 	}
+	if err := k.CheckCoinsTransferable(ctx, from, amt); err != nil {
+		return err
+	}
+	if err := k.CheckCoinsTransferable(ctx, to, amt); err != nil {
+		return err
+	}
 
 	return k.bankKeeper.SendCoins(ctx, from, to, amt)
 }
 
 // nolint
 func (k Keeper) LockCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins, lockCoinsType int) error {
+	if err := k.CheckCoinsTransferable(ctx, addr, coins); err != nil {
+		return err
+	}
 	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, addr, types.ModuleName, coins); err != nil {
 		return err
 	}
@@ -188,6 +198,8 @@ func (k Keeper) updateLockedCoins(ctx sdk.Context, addr sdk.AccAddress, coins sd
 		key = types.GetLockAddress(addr.Bytes())
 	case types.LockCoinsTypeFee:
 		key = types.GetLockFeeAddress(addr.Bytes())
+	case types.LockCoinsTypeVesting:
+		key = types.GetLockVestingAddress(addr)
 	default:
 		return fmt.Errorf("unrecognized lock coins type: %d", lockCoinsType)
 	}
@@ -328,9 +340,10 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 func (k Keeper) GetCoinsInfo(ctx sdk.Context, addr sdk.AccAddress) (coinsInfo types.CoinsInfo) {
 	availableCoins := k.GetCoins(ctx, addr)
 	lockedCoins := k.GetLockedCoins(ctx, addr)
+	vestingCoins := k.GetVestingCoins(ctx, addr)
 
 	// merge coins
-	coinsInfo = types.MergeCoinInfo(availableCoins, lockedCoins)
+	coinsInfo = types.MergeCoinInfo(availableCoins, lockedCoins, vestingCoins)
 	return coinsInfo
 }
 
@@ -428,3 +441,70 @@ func (k Keeper) DeleteConfirmOwnership(ctx sdk.Context, symbol string) {
 	key := types.GetConfirmOwnershipKey(symbol)
 	store.Delete(key)
 }
+
+// IsFrozen returns whether the token of the account is frozen by the token owner
+func (k Keeper) IsFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.tokenStoreKey)
+	return store.Has(types.GetFrozenAccountKey(symbol, addr))
+}
+
+// SetFrozen freezes or unfreezes the token of the account
+func (k Keeper) SetFrozen(ctx sdk.Context, symbol string, addr sdk.AccAddress, frozen bool) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	key := types.GetFrozenAccountKey(symbol, addr)
+	if frozen {
+		store.Set(key, []byte{0x01})
+	} else {
+		store.Delete(key)
+	}
+}
+
+// GetFrozenAccounts returns the accounts whose token is frozen
+func (k Keeper) GetFrozenAccounts(ctx sdk.Context, symbol string) (addrs []sdk.AccAddress) {
+	prefix := types.GetFrozenAccountPrefix(symbol)
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		addrs = append(addrs, sdk.AccAddress(iter.Key()[len(prefix):]))
+	}
+	return addrs
+}
+
+// GetAllFrozenAccounts returns the frozen accounts of all the tokens
+func (k Keeper) GetAllFrozenAccounts(ctx sdk.Context) (frozenAccounts []types.FrozenAccount) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.PrefixFrozenAccountKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()[len(types.PrefixFrozenAccountKey):]
+		// symbols never contain 0x00, the separator between the symbol and the address
+		i := bytes.IndexByte(key, 0x00)
+		if i == -1 {
+			continue
+		}
+		frozenAccounts = append(frozenAccounts, types.FrozenAccount{
+			Symbol:  string(key[:i]),
+			Address: sdk.AccAddress(key[i+1:]),
+		})
+	}
+	return frozenAccounts
+}
+
+// CheckCoinsTransferable returns an error if the transfers of any of the coins are paused,
+// or any of the coins of the account is frozen
+func (k Keeper) CheckCoinsTransferable(ctx sdk.Context, addr sdk.AccAddress, coins sdk.DecCoins) sdk.Error {
+	for _, coin := range coins {
+		token := k.GetTokenInfo(ctx, coin.Denom)
+		if !token.Freezable {
+			continue
+		}
+		if token.Paused {
+			return types.ErrTokenPaused(DefaultCodespace, coin.Denom)
+		}
+		if k.IsFrozen(ctx, coin.Denom, addr) {
+			return types.ErrTokenFrozen(DefaultCodespace, coin.Denom, addr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package v0_12
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/token/legacy/v0_11"
+)
+
+// Migrate migrates the token genesis state from v0.11, the existing tokens are left uncapped
+func Migrate(oldGenState v0_11.GenesisState) GenesisState {
+	tokens := make([]Token, len(oldGenState.Tokens))
+	for i, token := range oldGenState.Tokens {
+		tokens[i] = Token{
+			Description:         token.Description,
+			Symbol:              token.Symbol,
+			OriginalSymbol:      token.OriginalSymbol,
+			WholeName:           token.WholeName,
+			OriginalTotalSupply: token.OriginalTotalSupply,
+			Owner:               token.Owner,
+			Mintable:            token.Mintable,
+			MaxSupply:           sdk.ZeroDec(),
+		}
+	}
+
+	return GenesisState{
+		Params:       oldGenState.Params,
+		Tokens:       tokens,
+		LockedAssets: oldGenState.LockedAssets,
+		LockedFees:   oldGenState.LockedFees,
+	}
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	"github.com/okex/okexchain/x/token/types"
@@ -26,6 +27,14 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryAccount(ctx, path[1:], req, keeper)
 		case types.QueryKeysNum:
 			return queryKeysNum(ctx, keeper)
+		case types.QueryFrozen:
+			return queryFrozenAccounts(ctx, path[1:], keeper)
+		case types.QueryVesting:
+			return queryVestingSchedules(ctx, path[1:], keeper)
+		case types.QueryHTLC:
+			return queryHTLC(ctx, path[1:], keeper)
+		case types.QueryHTLCs:
+			return queryHTLCs(ctx, path[1:], keeper)
 		case types.QueryAccountV2:
 			return queryAccountV2(ctx, path[1:], req, keeper)
 		case types.QueryTokensV2:
@@ -56,6 +65,89 @@ func queryInfo(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Kee
 	return bz, nil
 }
 
+// queryFrozenAccounts returns the accounts whose token is frozen by the token owner
+func queryFrozenAccounts(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 || path[0] == "" {
+		return nil, sdk.ErrUnknownRequest("missing the token symbol")
+	}
+	if !keeper.TokenExist(ctx, path[0]) {
+		return nil, sdk.ErrInvalidCoins("unknown token")
+	}
+
+	addrs := keeper.GetFrozenAccounts(ctx, path[0])
+	if addrs == nil {
+		addrs = []sdk.AccAddress{}
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, addrs)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
+
+// queryVestingSchedules returns the vesting schedules of the recipient
+func queryVestingSchedules(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("missing the recipient address")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", path[0]))
+	}
+
+	schedules := keeper.GetVestingSchedules(ctx, addr)
+	if schedules == nil {
+		schedules = []types.VestingSchedule{}
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, schedules)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
+
+// queryHTLC returns the hash time-locked contract of the hash lock
+func queryHTLC(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("missing the hash lock")
+	}
+	hashLock, err := hex.DecodeString(path[0])
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid hash lock：%s", path[0]))
+	}
+
+	htlc, found := keeper.GetHTLC(ctx, hashLock)
+	if !found {
+		return nil, types.ErrUnknownHTLC(types.DefaultCodespace, path[0])
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, htlc)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
+
+// queryHTLCs returns the open hash time-locked contracts sent or received by the address
+func queryHTLCs(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("missing the address")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(fmt.Sprintf("invalid address：%s", path[0]))
+	}
+
+	htlcs := keeper.GetHTLCs(ctx, addr)
+	if htlcs == nil {
+		htlcs = []types.HTLC{}
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, htlcs)
+	if err != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
+
 func queryTokens(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
 	var tokens []types.Token
 	if len(path) > 0 && path[0] != "" {
@@ -124,7 +216,7 @@ func queryAccount(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 				}
 				// not found
 				if !found {
-					ci := types.NewCoinInfo(token.Symbol, "0", "0")
+					ci := types.NewCoinInfo(token.Symbol, "0", "0", "0")
 					coinsInfoChoosen = append(coinsInfoChoosen, *ci)
 				}
 			}
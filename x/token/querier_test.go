@@ -29,6 +29,7 @@ func TestQueryOrder(t *testing.T) {
 		OriginalTotalSupply: sdk.NewDec(1000000000),
 		Owner:               testAccounts[0].baseAccount.Address,
 		Mintable:            true,
+		MaxSupply:           sdk.ZeroDec(),
 	}
 
 	coins := sdk.NewCoins(sdk.NewDecCoinFromDec(token.Symbol, token.OriginalTotalSupply))
@@ -68,6 +69,7 @@ func TestQueryTokens(t *testing.T) {
 		OriginalTotalSupply: sdk.NewDec(1000000000),
 		Owner:               testAccounts[0].baseAccount.Address,
 		Mintable:            true,
+		MaxSupply:           sdk.ZeroDec(),
 	}
 
 	coins := sdk.NewCoins(sdk.NewDecCoinFromDec(token.Symbol, token.OriginalTotalSupply))
@@ -152,6 +154,7 @@ func TestQueryUserTokens(t *testing.T) {
 		OriginalTotalSupply: sdk.NewDec(1000000000),
 		Owner:               testAccounts[0].baseAccount.Address,
 		Mintable:            true,
+		MaxSupply:           sdk.ZeroDec(),
 	}
 
 	coins := sdk.NewCoins(sdk.NewDecCoinFromDec(token.Symbol, token.OriginalTotalSupply))
@@ -240,6 +243,7 @@ func TestQueryAccount(t *testing.T) {
 			Symbol:    common.NativeToken,
 			Available: "1000000000.00000000",
 			Locked:    "0",
+			Vesting:   "0",
 		},
 	}
 
@@ -342,11 +346,13 @@ func TestQueryAccount_ShowAll(t *testing.T) {
 			Symbol:    common.NativeToken,
 			Available: "1000000000.00000000",
 			Locked:    "0",
+			Vesting:   "0",
 		},
 		types.CoinInfo{
 			Symbol:    "xxb",
 			Available: "0",
 			Locked:    "0",
+			Vesting:   "0",
 		},
 	}
 
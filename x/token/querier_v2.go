@@ -43,7 +43,7 @@ func queryAccountV2(ctx sdk.Context, path []string, req abci.RequestQuery, keepe
 				}
 				// not found
 				if !found {
-					ci := types.NewCoinInfo(token.Symbol, "0", "0")
+					ci := types.NewCoinInfo(token.Symbol, "0", "0", "0")
 					coinsInfoChosen = append(coinsInfoChosen, *ci)
 				}
 			}
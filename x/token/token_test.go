@@ -1,9 +1,11 @@
 package token
 
 import (
+	"encoding/hex"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto"
 
@@ -425,11 +427,11 @@ func TestMsgTokenChown(t *testing.T) {
 	}
 
 	//issue token to FromAddress
-	tokenIssueMsg := types.NewMsgTokenIssue(common.NativeToken, common.NativeToken, common.NativeToken, "okcoin", "1000", testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg := types.NewMsgTokenIssue(common.NativeToken, common.NativeToken, common.NativeToken, "okcoin", "1000", "", testAccounts[0].baseAccount.Address, true, false)
 	TokenIssue = append(TokenIssue, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 
 	//test error supply coin issue(TotalSupply > (9*1e10))
-	MsgErrorSupply := types.NewMsgTokenIssue("okc", "okc", "okc", "okccc", strconv.FormatInt(int64(10*1e10), 10), testAccounts[0].baseAccount.Address, true)
+	MsgErrorSupply := types.NewMsgTokenIssue("okc", "okc", "okc", "okccc", strconv.FormatInt(int64(10*1e10), 10), "", testAccounts[0].baseAccount.Address, true, false)
 	TokenIssue = append(TokenIssue, createTokenMsg(t, app, ctx, testAccounts[0], MsgErrorSupply))
 
 	//test error tokenDesc (length > 256)
@@ -437,7 +439,7 @@ func TestMsgTokenChown(t *testing.T) {
 ok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-b
 ok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-b
 ok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-bok-b`,
-		common.NativeToken, common.NativeToken, "okcoin", "2100", testAccounts[0].baseAccount.Address, true)
+		common.NativeToken, common.NativeToken, "okcoin", "2100", "", testAccounts[0].baseAccount.Address, true, false)
 	TokenIssue = append(TokenIssue, createTokenMsg(t, app, ctx, testAccounts[0], MsgErrorName))
 
 	ctx = mockApplyBlock(t, app, TokenIssue, 3)
@@ -477,7 +479,7 @@ func TestUpdateUserTokenRelationship(t *testing.T) {
 	var tokenIssue []auth.StdTx
 
 	totalSupplyStr := "500"
-	tokenIssueMsg := types.NewMsgTokenIssue("bnb", "", "bnb", "binance coin", totalSupplyStr, testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg := types.NewMsgTokenIssue("bnb", "", "bnb", "binance coin", totalSupplyStr, "", testAccounts[0].baseAccount.Address, true, false)
 	tokenIssue = append(tokenIssue, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 
 	ctx = mockApplyBlock(t, app, tokenIssue, 3)
@@ -526,19 +528,19 @@ func TestCreateTokenIssue(t *testing.T) {
 
 	totalSupply := int64(500)
 	totalSupplyStr := "500"
-	tokenIssueMsg := types.NewMsgTokenIssue("bnb", "", "bnb", "binance coin", totalSupplyStr, testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg := types.NewMsgTokenIssue("bnb", "", "bnb", "binance coin", totalSupplyStr, "", testAccounts[0].baseAccount.Address, true, false)
 	tokenIssue = append(tokenIssue, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 
 	// not valid symbol
-	//tokenIssueMsg = types.NewMsgTokenIssue("bnba123451fadfasdf", "bnba123451fadfasdf", "bnba123451fadfasdf", totalSupply, testAccounts[0].baseAccount.Address, true)
+	//tokenIssueMsg = types.NewMsgTokenIssue("bnba123451fadfasdf", "bnba123451fadfasdf", "bnba123451fadfasdf", totalSupply, "", testAccounts[0].baseAccount.Address, true, false)
 	//tokenIssue = append(tokenIssue, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 
 	// Total exceeds the upper limit
-	tokenIssueMsg = types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", strconv.FormatInt(types.TotalSupplyUpperbound+1, 10), testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg = types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", strconv.FormatInt(types.TotalSupplyUpperbound+1, 10), "", testAccounts[0].baseAccount.Address, true, false)
 	tokenIssue = append(tokenIssue, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 
 	// not enough okbs
-	tokenIssueMsg = types.NewMsgTokenIssue("xmr", "xmr", "xmr", "Monero", totalSupplyStr, testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg = types.NewMsgTokenIssue("xmr", "xmr", "xmr", "Monero", totalSupplyStr, "", testAccounts[0].baseAccount.Address, true, false)
 	tokenIssue = append(tokenIssue, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 
 	ctx = mockApplyBlock(t, app, tokenIssue, 3)
@@ -579,7 +581,7 @@ func TestCreateTokenBurn(t *testing.T) {
 	ctx := app.NewContext(true, abci.Header{})
 	var tokenMsgs []auth.StdTx
 
-	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", "", testAccounts[0].baseAccount.Address, true, false)
 	tokenMsgs = append(tokenMsgs, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 	ctx = mockApplyBlock(t, app, tokenMsgs, 3)
 
@@ -649,12 +651,12 @@ func TestCreateTokenMint(t *testing.T) {
 	ctx := app.NewContext(true, abci.Header{})
 	var tokenMsgs []auth.StdTx
 
-	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", "", testAccounts[0].baseAccount.Address, true, false)
 	tokenMsgs = append(tokenMsgs, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 	ctx = mockApplyBlock(t, app, tokenMsgs, 3)
 	tokenMsgs = tokenMsgs[:0]
 
-	tokenIssueMsg = types.NewMsgTokenIssue("xmr", "xmr", "xmr", "monero", "1000", testAccounts[0].baseAccount.Address, false)
+	tokenIssueMsg = types.NewMsgTokenIssue("xmr", "xmr", "xmr", "monero", "1000", "", testAccounts[0].baseAccount.Address, false, false)
 	tokenMsgs = append(tokenMsgs, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 	ctx = mockApplyBlock(t, app, tokenMsgs, 4)
 
@@ -714,7 +716,7 @@ func TestCreateMsgTokenSend(t *testing.T) {
 	ctx := app.NewContext(true, abci.Header{})
 	var tokenMsgs []auth.StdTx
 
-	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", "", testAccounts[0].baseAccount.Address, true, false)
 	tokenMsgs = append(tokenMsgs, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 	ctx = mockApplyBlock(t, app, tokenMsgs, 3)
 	tokenMsgs = tokenMsgs[:0]
@@ -800,7 +802,7 @@ func TestCreateMsgMultiSend(t *testing.T) {
 	ctx := app.NewContext(true, abci.Header{})
 	var tokenMsgs []auth.StdTx
 
-	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", "", testAccounts[0].baseAccount.Address, true, false)
 	tokenMsgs = append(tokenMsgs, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 	ctx = mockApplyBlock(t, app, tokenMsgs, 3)
 
@@ -849,7 +851,7 @@ func TestCreateMsgTokenModify(t *testing.T) {
 	ctx := app.NewContext(true, abci.Header{})
 	var tokenMsgs []auth.StdTx
 
-	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", testAccounts[0].baseAccount.Address, true)
+	tokenIssueMsg := types.NewMsgTokenIssue("btc", "btc", "btc", "bitcoin", "1000", "", testAccounts[0].baseAccount.Address, true, false)
 	tokenMsgs = append(tokenMsgs, createTokenMsg(t, app, ctx, testAccounts[0], tokenIssueMsg))
 	ctx = mockApplyBlock(t, app, tokenMsgs, 3)
 
@@ -917,6 +919,27 @@ bnbbbbbbbbbbbnbbbbbbbbbbnbbbbbbbbbbbnbbbbbbbbb1234`, "whole name7", true, true,
 	token = keeper.GetTokenInfo(ctx, btcTokenSymbol)
 	require.EqualValues(t, "desc2", token.Description)
 	require.EqualValues(t, "whole name1", token.WholeName)
+
+	// metadata only
+	balance := keeper.GetCoins(ctx, testAccounts[0].baseAccount.Address).AmountOf(common.NativeToken)
+	tokenMsgs = tokenMsgs[:0]
+	tokenEditMsg = types.NewMsgTokenModify(btcTokenSymbol, "", "", false, false, testAccounts[0].baseAccount.Address)
+	tokenEditMsg.Decimals, tokenEditMsg.IsDecimalsModified = 8, true
+	tokenEditMsg.Logo, tokenEditMsg.IsLogoModified = "https://bitcoin.org/logo.png", true
+	tokenEditMsg.Attributes, tokenEditMsg.IsAttributesModified = []types.TokenAttribute{{Key: "chain", Value: "bitcoin"}}, true
+	tokenMsgs = append(tokenMsgs, createTokenMsg(t, app, ctx, testAccounts[0], tokenEditMsg))
+	ctx = mockApplyBlock(t, app, tokenMsgs, 12)
+
+	token = keeper.GetTokenInfo(ctx, btcTokenSymbol)
+	require.EqualValues(t, "desc2", token.Description)
+	require.EqualValues(t, int32(8), token.Decimals)
+	require.EqualValues(t, "", token.Website)
+	require.EqualValues(t, "https://bitcoin.org/logo.png", token.Logo)
+	require.EqualValues(t, []types.TokenAttribute{{Key: "chain", Value: "bitcoin"}}, token.Attributes)
+	tokenResp := types.GenTokenResp(token)
+	require.EqualValues(t, token.Attributes, tokenResp.Attributes)
+	fee := keeper.GetParams(ctx).FeeModify.Amount
+	require.True(t, balance.Sub(fee).Equal(keeper.GetCoins(ctx, testAccounts[0].baseAccount.Address).AmountOf(common.NativeToken)))
 }
 
 func getMockAppToHandleFee(t *testing.T, initBalance int64, numAcc int) (app *MockDexApp, testAccounts TestAccounts) {
@@ -956,7 +979,7 @@ func TestTxFailedFeeTable(t *testing.T) {
 	toAddr := sdk.AccAddress(toPubKey.Address())
 
 	// failed issue msg : not enough okbs .
-	failedIssueMsg := types.NewMsgTokenIssue("xmr", "xmr", "xmr", "Monero", "500", testAccounts[0].baseAccount.Address, true)
+	failedIssueMsg := types.NewMsgTokenIssue("xmr", "xmr", "xmr", "Monero", "500", "", testAccounts[0].baseAccount.Address, true, false)
 	// failed mint msg : no such token
 	decCoin := sdk.NewDecCoinFromDec("nob", sdk.NewDec(200))
 	failedMintMsg := types.NewMsgTokenMint(decCoin, testAccounts[0].baseAccount.Address)
@@ -1008,7 +1031,7 @@ func TestTxSuccessFeeTable(t *testing.T) {
 	toAddr := sdk.AccAddress(toPubKey.Address())
 
 	// successful issue msg
-	successfulIssueMsg := types.NewMsgTokenIssue("xxb", "xxb", "xxb", "xx coin", "500", testAccounts[0].baseAccount.Address, true)
+	successfulIssueMsg := types.NewMsgTokenIssue("xxb", "xxb", "xxb", "xx coin", "500", "", testAccounts[0].baseAccount.Address, true, false)
 
 	symbolAfterIssue, ok := addTokenSuffix(ctx, app.tokenKeeper, "xxb")
 	require.True(t, ok)
@@ -1115,7 +1138,7 @@ func TestHandleTransferOwnership(t *testing.T) {
 	// issue token
 	symbol := "xxb"
 	msgNewIssue := types.NewMsgTokenIssue("xxb desc", symbol, symbol, symbol,
-		"1000000", testAccounts[0], true)
+		"1000000", "", testAccounts[0], true, false)
 	result := handler(ctx, msgNewIssue)
 	require.True(t, result.IsOK())
 
@@ -1192,3 +1215,353 @@ func TestHandleTransferOwnership(t *testing.T) {
 	require.True(t, token.Owner.Equals(common.BlackHoleAddress()))
 
 }
+
+func TestHandleFreezeAndPause(t *testing.T) {
+	app, keeper, testAccounts := getMockDexApp(t, 3)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := app.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(3)
+	handler := NewTokenHandler(keeper, version.ProtocolVersionV0)
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	// issue a freezable token and a common token
+	result := handler(ctx, types.NewMsgTokenIssue("xxb desc", "xxb", "xxb", "xxb",
+		"1000000", "", testAccounts[0], true, true))
+	require.True(t, result.IsOK())
+	result = handler(ctx, types.NewMsgTokenIssue("yyb desc", "yyb", "yyb", "yyb",
+		"1000000", "", testAccounts[0], true, false))
+	require.True(t, result.IsOK())
+	freezable := getTokenSymbol(ctx, keeper, "xxb")
+	common := getTokenSymbol(ctx, keeper, "yyb")
+	require.True(t, keeper.GetTokenInfo(ctx, freezable).Freezable)
+
+	amount := sdk.NewDecCoinsFromDec(freezable, sdk.NewDec(10))
+	tests := []struct {
+		msg          sdk.Msg
+		expectedCode sdk.CodeType
+	}{
+		// sender is not the owner of token
+		{types.NewMsgTokenFreeze(testAccounts[1], freezable, testAccounts[2], true), sdk.CodeUnauthorized},
+		// token is not freezable
+		{types.NewMsgTokenFreeze(testAccounts[0], common, testAccounts[1], true), sdk.CodeUnauthorized},
+		{types.NewMsgTokenPause(testAccounts[0], common, true), sdk.CodeUnauthorized},
+		// freeze testAccounts[1] successfully
+		{types.NewMsgTokenFreeze(testAccounts[0], freezable, testAccounts[1], true), sdk.CodeOK},
+		{types.NewMsgTokenFreeze(testAccounts[0], freezable, testAccounts[1], true), sdk.CodeInternal},
+		// frozen account can neither receive nor send the token
+		{types.NewMsgTokenSend(testAccounts[0], testAccounts[1], amount), types.CodeTokenFrozen},
+		{types.NewMsgMultiSend(testAccounts[0], []types.TransferUnit{{To: testAccounts[1], Coins: amount}}), types.CodeTokenFrozen},
+		// other accounts are not affected
+		{types.NewMsgTokenSend(testAccounts[0], testAccounts[2], amount), sdk.CodeOK},
+		// unfreeze testAccounts[1] successfully
+		{types.NewMsgTokenFreeze(testAccounts[0], freezable, testAccounts[1], false), sdk.CodeOK},
+		{types.NewMsgTokenSend(testAccounts[0], testAccounts[1], amount), sdk.CodeOK},
+		// pause the transfers successfully
+		{types.NewMsgTokenPause(testAccounts[0], freezable, true), sdk.CodeOK},
+		{types.NewMsgTokenPause(testAccounts[0], freezable, true), sdk.CodeInternal},
+		{types.NewMsgTokenSend(testAccounts[1], testAccounts[2], amount), types.CodeTokenPaused},
+		{types.NewMsgTokenSend(testAccounts[0], testAccounts[2], sdk.NewDecCoinsFromDec(common, sdk.NewDec(10))), sdk.CodeOK},
+		// resume the transfers successfully
+		{types.NewMsgTokenPause(testAccounts[0], freezable, false), sdk.CodeOK},
+		{types.NewMsgTokenSend(testAccounts[1], testAccounts[2], amount), sdk.CodeOK},
+	}
+	for i, testCase := range tests {
+		result := handler(ctx, testCase.msg)
+		require.Equal(t, testCase.expectedCode, result.Code, "case %d: %s", i, result.Log)
+	}
+
+	// locking coins for orders is restricted as well
+	handler(ctx, types.NewMsgTokenFreeze(testAccounts[0], freezable, testAccounts[2], true))
+	require.Equal(t, []sdk.AccAddress{testAccounts[2]}, keeper.GetFrozenAccounts(ctx, freezable))
+	require.Error(t, keeper.LockCoins(ctx, testAccounts[2], amount, types.LockCoinsTypeQuantity))
+	require.NoError(t, keeper.LockCoins(ctx, testAccounts[0], amount, types.LockCoinsTypeQuantity))
+
+	// query and export the frozen accounts
+	querier := NewQuerier(keeper)
+	res, err := querier(ctx, []string{types.QueryFrozen, freezable}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var addrs []sdk.AccAddress
+	keeper.cdc.MustUnmarshalJSON(res, &addrs)
+	require.Equal(t, []sdk.AccAddress{testAccounts[2]}, addrs)
+
+	exported := ExportGenesis(ctx, keeper)
+	require.Equal(t, []types.FrozenAccount{{Symbol: freezable, Address: testAccounts[2]}}, exported.FrozenAccounts)
+}
+
+func TestBankMsgsFreezeAndPause(t *testing.T) {
+	app, keeper, testAccounts := getMockDexApp(t, 3)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := app.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(3)
+	handler := NewTokenHandler(keeper, version.ProtocolVersionV0)
+	keeper.SetParams(ctx, types.DefaultParams())
+	app.bankKeeper.SetSendEnabled(ctx, true)
+	bankHandler := bank.NewHandler(NewTransferCheckedBankKeeper(app.bankKeeper, keeper))
+
+	result := handler(ctx, types.NewMsgTokenIssue("xxb desc", "xxb", "xxb", "xxb",
+		"1000000", "", testAccounts[0], true, true))
+	require.True(t, result.IsOK())
+	freezable := getTokenSymbol(ctx, keeper, "xxb")
+	amount := sdk.NewDecCoinsFromDec(freezable, sdk.NewDec(10))
+	multiSend := func(from, to sdk.AccAddress) sdk.Msg {
+		return bank.MsgMultiSend{
+			Inputs:  []bank.Input{bank.NewInput(from, amount)},
+			Outputs: []bank.Output{bank.NewOutput(to, amount)},
+		}
+	}
+
+	tests := []struct {
+		msg          sdk.Msg
+		expectedCode sdk.CodeType
+	}{
+		{bank.MsgSend{FromAddress: testAccounts[0], ToAddress: testAccounts[1], Amount: amount}, sdk.CodeOK},
+		// freeze testAccounts[1]
+		{types.NewMsgTokenFreeze(testAccounts[0], freezable, testAccounts[1], true), sdk.CodeOK},
+		// frozen account can neither receive nor send the token by the bank msgs
+		{bank.MsgSend{FromAddress: testAccounts[0], ToAddress: testAccounts[1], Amount: amount}, types.CodeTokenFrozen},
+		{bank.MsgSend{FromAddress: testAccounts[1], ToAddress: testAccounts[2], Amount: amount}, types.CodeTokenFrozen},
+		{multiSend(testAccounts[0], testAccounts[1]), types.CodeTokenFrozen},
+		{multiSend(testAccounts[1], testAccounts[2]), types.CodeTokenFrozen},
+		{bank.MsgSend{FromAddress: testAccounts[0], ToAddress: testAccounts[2], Amount: amount}, sdk.CodeOK},
+		// pause the transfers
+		{types.NewMsgTokenFreeze(testAccounts[0], freezable, testAccounts[1], false), sdk.CodeOK},
+		{types.NewMsgTokenPause(testAccounts[0], freezable, true), sdk.CodeOK},
+		{bank.MsgSend{FromAddress: testAccounts[1], ToAddress: testAccounts[2], Amount: amount}, types.CodeTokenPaused},
+		{multiSend(testAccounts[0], testAccounts[2]), types.CodeTokenPaused},
+		// resume the transfers
+		{types.NewMsgTokenPause(testAccounts[0], freezable, false), sdk.CodeOK},
+		{bank.MsgSend{FromAddress: testAccounts[1], ToAddress: testAccounts[2], Amount: amount}, sdk.CodeOK},
+		{multiSend(testAccounts[0], testAccounts[1]), sdk.CodeOK},
+	}
+	for i, testCase := range tests {
+		var result sdk.Result
+		switch testCase.msg.Route() {
+		case bank.RouterKey:
+			result = bankHandler(ctx, testCase.msg)
+		default:
+			result = handler(ctx, testCase.msg)
+		}
+		require.Equal(t, testCase.expectedCode, result.Code, "case %d: %s", i, result.Log)
+	}
+}
+
+func TestHandleMaxSupply(t *testing.T) {
+	app, keeper, testAccounts := getMockDexApp(t, 2)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := app.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(3)
+	handler := NewTokenHandler(keeper, version.ProtocolVersionV0)
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	// total supply exceeds the max supply
+	result := handler(ctx, types.NewMsgTokenIssue("xxb desc", "xxb", "xxb", "xxb",
+		"1000", "999", testAccounts[0], true, false))
+	require.Equal(t, types.CodeExceedsMaxSupply, result.Code)
+	result = handler(ctx, types.NewMsgTokenIssue("xxb desc", "xxb", "xxb", "xxb",
+		"1000", "1500", testAccounts[0], true, false))
+	require.True(t, result.IsOK())
+	symbol := getTokenSymbol(ctx, keeper, "xxb")
+	require.Equal(t, sdk.NewDec(1500), keeper.GetTokenInfo(ctx, symbol).MaxSupply)
+
+	tests := []struct {
+		msg          sdk.Msg
+		expectedCode sdk.CodeType
+	}{
+		// mint up to the max supply
+		{types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(400)), testAccounts[0]), sdk.CodeOK},
+		{types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(101)), testAccounts[0]), types.CodeExceedsMaxSupply},
+		{types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(100)), testAccounts[0]), sdk.CodeOK},
+		// sender is not the owner of token
+		{types.NewMsgTokenSetMaxSupply(testAccounts[1], symbol, sdk.NewDec(1500)), sdk.CodeUnauthorized},
+		// max supply can only be lowered
+		{types.NewMsgTokenSetMaxSupply(testAccounts[0], symbol, sdk.NewDec(2000)), sdk.CodeInternal},
+		// max supply can't be lower than the total supply
+		{types.NewMsgTokenBurn(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(300)), testAccounts[0]), sdk.CodeOK},
+		{types.NewMsgTokenSetMaxSupply(testAccounts[0], symbol, sdk.NewDec(1199)), types.CodeExceedsMaxSupply},
+		{types.NewMsgTokenSetMaxSupply(testAccounts[0], symbol, sdk.NewDec(1200)), sdk.CodeOK},
+		{types.NewMsgTokenMint(sdk.NewDecCoinFromDec(symbol, sdk.NewDec(1)), testAccounts[0]), types.CodeExceedsMaxSupply},
+	}
+	for i, testCase := range tests {
+		result := handler(ctx, testCase.msg)
+		require.Equal(t, testCase.expectedCode, result.Code, "case %d: %s", i, result.Log)
+	}
+
+	// the max supply is reported by the token query
+	querier := NewQuerier(keeper)
+	res, err := querier(ctx, []string{types.QueryInfo, symbol}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var tokenResp types.TokenResp
+	keeper.cdc.MustUnmarshalJSON(res, &tokenResp)
+	require.Equal(t, sdk.NewDec(1200), tokenResp.MaxSupply)
+	require.Equal(t, sdk.NewDec(1200), tokenResp.TotalSupply)
+}
+
+func TestHandleVestingSend(t *testing.T) {
+	app, keeper, testAccounts := getMockDexApp(t, 2)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	startTime := time.Unix(1600000000, 0).UTC()
+	ctx := app.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(3).WithBlockTime(startTime)
+	handler := NewTokenHandler(keeper, version.ProtocolVersionV0)
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	from, to := testAccounts[0], testAccounts[1]
+	linear := sdk.NewDecCoinsFromDec(common.TestToken, sdk.NewDec(100))
+	cliff := sdk.NewDecCoinsFromDec(common.TestToken, sdk.NewDec(50))
+	balance := keeper.GetCoins(ctx, to).AmountOf(common.TestToken)
+
+	// end time has passed
+	result := handler(ctx, types.NewMsgVestingSend(from, to, linear, types.VestingTypeCliff, time.Time{}, startTime))
+	require.Equal(t, sdk.CodeUnknownRequest, result.Code)
+
+	result = handler(ctx, types.NewMsgVestingSend(from, to, linear, types.VestingTypeLinear,
+		startTime, startTime.Add(100*time.Hour)))
+	require.True(t, result.IsOK(), result.Log)
+	result = handler(ctx, types.NewMsgVestingSend(from, to, cliff, types.VestingTypeCliff,
+		time.Time{}, startTime.Add(50*time.Hour)))
+	require.True(t, result.IsOK(), result.Log)
+	require.Equal(t, 2, len(keeper.GetVestingSchedules(ctx, to)))
+	require.Equal(t, linear.Add(cliff), keeper.GetVestingCoins(ctx, to))
+	require.Equal(t, balance, keeper.GetCoins(ctx, to).AmountOf(common.TestToken))
+
+	tests := []struct {
+		elapsed   time.Duration
+		released  sdk.Dec
+		schedules int
+	}{
+		// the linear schedule isn't released again within the release interval
+		{40 * time.Hour, sdk.NewDec(40), 2},
+		{40*time.Hour + 30*time.Minute, sdk.NewDec(40), 2},
+		{50 * time.Hour, sdk.NewDec(100), 1},
+		{200 * time.Hour, sdk.NewDec(150), 0},
+	}
+	for _, testCase := range tests {
+		ctx = ctx.WithBlockTime(startTime.Add(testCase.elapsed))
+		beginBlocker(ctx, keeper)
+		require.True(t, balance.Add(testCase.released).Equal(keeper.GetCoins(ctx, to).AmountOf(common.TestToken)))
+		require.True(t, sdk.NewDec(150).Sub(testCase.released).Equal(keeper.GetVestingCoins(ctx, to).AmountOf(common.TestToken)))
+		require.Equal(t, testCase.schedules, len(keeper.GetVestingSchedules(ctx, to)))
+	}
+
+	// the vesting balance is shown by the account query
+	ctx = ctx.WithBlockTime(startTime)
+	result = handler(ctx, types.NewMsgVestingSend(from, to, linear, types.VestingTypeLinear,
+		startTime, startTime.Add(100*time.Hour)))
+	require.True(t, result.IsOK(), result.Log)
+	querier := NewQuerier(keeper)
+	bz, err := keeper.cdc.MarshalJSON(types.AccountParam{Symbol: common.TestToken})
+	require.Nil(t, err)
+	res, sdkErr := querier(ctx, []string{types.QueryAccount, to.String()}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var accountResponse types.AccountResponse
+	keeper.cdc.MustUnmarshalJSON(res, &accountResponse)
+	require.Equal(t, 1, len(accountResponse.Currencies))
+	require.Equal(t, "100.00000000", accountResponse.Currencies[0].Vesting)
+
+	// the recipient can't have more than MaxVestingSchedulesPerAccount schedules
+	coins := sdk.NewDecCoinsFromDec(common.TestToken, sdk.NewDecWithPrec(1, 8))
+	for i := 1; i < types.MaxVestingSchedulesPerAccount; i++ {
+		result = handler(ctx, types.NewMsgVestingSend(from, to, coins, types.VestingTypeCliff,
+			time.Time{}, startTime.Add(time.Hour)))
+		require.True(t, result.IsOK(), result.Log)
+	}
+	result = handler(ctx, types.NewMsgVestingSend(from, to, coins, types.VestingTypeCliff,
+		time.Time{}, startTime.Add(time.Hour)))
+	require.Equal(t, types.CodeTooManyVestingSchedules, result.Code)
+}
+
+func TestHandleHTLC(t *testing.T) {
+	app, keeper, testAccounts := getMockDexApp(t, 2)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	ctx := app.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(3)
+	handler := NewTokenHandler(keeper, version.ProtocolVersionV0)
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	sender, to := testAccounts[0], testAccounts[1]
+	amount := sdk.NewDecCoinsFromDec(common.TestToken, sdk.NewDec(100))
+	senderBalance := keeper.GetCoins(ctx, sender).AmountOf(common.TestToken)
+	toBalance := keeper.GetCoins(ctx, to).AmountOf(common.TestToken)
+
+	secret := make([]byte, types.SecretLength)
+	secret[0] = 1
+	hashLock := hex.EncodeToString(types.GetHashLock(secret))
+	result := handler(ctx, types.NewMsgCreateHTLC(sender, to, amount, hashLock, types.MinHTLCTimeLock))
+	require.True(t, result.IsOK(), result.Log)
+	require.True(t, senderBalance.Sub(sdk.NewDec(100)).Equal(keeper.GetCoins(ctx, sender).AmountOf(common.TestToken)))
+	// the hash lock can't be reused
+	result = handler(ctx, types.NewMsgCreateHTLC(sender, to, amount, hashLock, types.MinHTLCTimeLock))
+	require.Equal(t, types.CodeHTLCExists, result.Code)
+
+	// wrong secret
+	result = handler(ctx, types.NewMsgClaimHTLC(to, hashLock, hex.EncodeToString(make([]byte, types.SecretLength))))
+	require.Equal(t, types.CodeInvalidSecret, result.Code)
+
+	result = handler(ctx, types.NewMsgClaimHTLC(to, hashLock, hex.EncodeToString(secret)))
+	require.True(t, result.IsOK(), result.Log)
+	require.True(t, toBalance.Add(sdk.NewDec(100)).Equal(keeper.GetCoins(ctx, to).AmountOf(common.TestToken)))
+	var claimed bool
+	for _, event := range result.Events {
+		if event.Type == types.EventTypeClaimHTLC {
+			claimed = true
+			require.Contains(t, event.Attributes, sdk.NewAttribute(types.AttributeKeySecret, hex.EncodeToString(secret)).ToKVPair())
+		}
+	}
+	require.True(t, claimed)
+	// the completed contract is kept with the secret, and its hash lock can't be reused
+	querier := NewQuerier(keeper)
+	res, sdkErr := querier(ctx, []string{types.QueryHTLC, hashLock}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var completed types.HTLC
+	keeper.cdc.MustUnmarshalJSON(res, &completed)
+	require.Equal(t, types.HTLCStateCompleted, completed.State)
+	require.Equal(t, hex.EncodeToString(secret), completed.Secret)
+	result = handler(ctx, types.NewMsgClaimHTLC(to, hashLock, hex.EncodeToString(secret)))
+	require.Equal(t, types.CodeHTLCNotOpen, result.Code)
+	result = handler(ctx, types.NewMsgCreateHTLC(sender, to, amount, hashLock, types.MinHTLCTimeLock))
+	require.Equal(t, types.CodeHTLCExists, result.Code)
+	require.Equal(t, 0, len(keeper.GetHTLCs(ctx, sender)))
+
+	// the contract not claimed is refunded at the expire height
+	secret[0] = 2
+	hashLock = hex.EncodeToString(types.GetHashLock(secret))
+	result = handler(ctx, types.NewMsgCreateHTLC(sender, to, amount, hashLock, types.MinHTLCTimeLock))
+	require.True(t, result.IsOK(), result.Log)
+
+	ctx = ctx.WithBlockHeight(3 + types.MinHTLCTimeLock - 1)
+	endBlocker(ctx, keeper)
+	htlc, found := keeper.GetHTLC(ctx, types.GetHashLock(secret))
+	require.True(t, found)
+	require.Equal(t, types.HTLCStateOpen, htlc.State)
+
+	// the open contract is found by both addresses
+	for _, addr := range []sdk.AccAddress{sender, to} {
+		res, sdkErr := querier(ctx, []string{types.QueryHTLCs, addr.String()}, abci.RequestQuery{})
+		require.Nil(t, sdkErr)
+		var htlcs types.HTLCs
+		keeper.cdc.MustUnmarshalJSON(res, &htlcs)
+		require.Equal(t, 1, len(htlcs))
+		require.Equal(t, hashLock, htlcs[0].HashLock)
+	}
+	res, sdkErr = querier(ctx, []string{types.QueryHTLC, hashLock}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	keeper.cdc.MustUnmarshalJSON(res, htlc)
+	require.Equal(t, types.HTLCStateOpen, htlc.State)
+
+	ctx = ctx.WithBlockHeight(3 + types.MinHTLCTimeLock)
+	result = handler(ctx, types.NewMsgClaimHTLC(to, hashLock, hex.EncodeToString(secret)))
+	require.Equal(t, types.CodeHTLCExpired, result.Code)
+	endBlocker(ctx, keeper)
+	require.True(t, senderBalance.Sub(sdk.NewDec(100)).Equal(keeper.GetCoins(ctx, sender).AmountOf(common.TestToken)))
+
+	// the refunded contract is kept without its indexes, and its hash lock can't be reused
+	require.Equal(t, 0, len(keeper.GetHTLCs(ctx, sender)))
+	require.Equal(t, 0, len(keeper.GetHTLCs(ctx, to)))
+	res, sdkErr = querier(ctx, []string{types.QueryHTLC, hashLock}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var refunded types.HTLC
+	keeper.cdc.MustUnmarshalJSON(res, &refunded)
+	require.Equal(t, types.HTLCStateRefunded, refunded.State)
+	result = handler(ctx, types.NewMsgCreateHTLC(sender, to, amount, hashLock, types.MinHTLCTimeLock))
+	require.Equal(t, types.CodeHTLCExists, result.Code)
+
+	// the settled contracts are exported, and pass the genesis validation
+	exported := ExportGenesis(ctx, keeper)
+	require.Equal(t, 2, len(exported.HTLCs))
+	require.NoError(t, validateGenesis(exported))
+}
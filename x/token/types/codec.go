@@ -14,6 +14,12 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgTransferOwnership{}, "okexchain/token/MsgTransferOwnership", nil)
 	cdc.RegisterConcrete(MsgConfirmOwnership{}, "okexchain/token/MsgConfirmOwnership", nil)
 	cdc.RegisterConcrete(MsgTokenModify{}, "okexchain/token/MsgModify", nil)
+	cdc.RegisterConcrete(MsgTokenFreeze{}, "okexchain/token/MsgFreeze", nil)
+	cdc.RegisterConcrete(MsgTokenPause{}, "okexchain/token/MsgPause", nil)
+	cdc.RegisterConcrete(MsgTokenSetMaxSupply{}, "okexchain/token/MsgSetMaxSupply", nil)
+	cdc.RegisterConcrete(MsgVestingSend{}, "okexchain/token/MsgVestingTransfer", nil)
+	cdc.RegisterConcrete(MsgCreateHTLC{}, "okexchain/token/MsgCreateHTLC", nil)
+	cdc.RegisterConcrete(MsgClaimHTLC{}, "okexchain/token/MsgClaimHTLC", nil)
 
 	// for test
 	//cdc.RegisterConcrete(MsgTokenDestroy{}, "okexchain/token/MsgDestroy", nil)
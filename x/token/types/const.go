@@ -3,4 +3,5 @@ package types
 const (
 	LockCoinsTypeQuantity = 1
 	LockCoinsTypeFee      = 2
+	LockCoinsTypeVesting  = 3
 )
@@ -16,6 +16,15 @@ const (
 	CodeInvalidCommon           sdk.CodeType = 7
 	CodeBlockedRecipient        sdk.CodeType = 8
 	CodeSendDisabled            sdk.CodeType = 9
+	CodeTokenFrozen             sdk.CodeType = 10
+	CodeTokenPaused             sdk.CodeType = 11
+	CodeExceedsMaxSupply        sdk.CodeType = 12
+	CodeTooManyVestingSchedules sdk.CodeType = 13
+	CodeHTLCExists              sdk.CodeType = 14
+	CodeUnknownHTLC             sdk.CodeType = 15
+	CodeHTLCNotOpen             sdk.CodeType = 16
+	CodeHTLCExpired             sdk.CodeType = 17
+	CodeInvalidSecret           sdk.CodeType = 18
 )
 
 // ErrBlockedRecipient returns an error when a transfer is tried on a blocked recipient
@@ -28,6 +37,48 @@ func ErrSendDisabled(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeSendDisabled, "failed. send transactions are currently disabled")
 }
 
+// ErrTokenFrozen returns an error when the token of the account is frozen by the token owner
+func ErrTokenFrozen(codespace sdk.CodespaceType, symbol string, addr sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeTokenFrozen, "failed. token(%s) of %s is frozen", symbol, addr.String())
+}
+
+// ErrTokenPaused returns an error when the transfers of the token are paused by the token owner
+func ErrTokenPaused(codespace sdk.CodespaceType, symbol string) sdk.Error {
+	return sdk.NewError(codespace, CodeTokenPaused, "failed. transfers of token(%s) are paused", symbol)
+}
+
+// ErrExceedsMaxSupply returns an error when the total supply of the token would exceed its max supply
+func ErrExceedsMaxSupply(codespace sdk.CodespaceType, symbol string, totalSupply, maxSupply sdk.Dec) sdk.Error {
+	return sdk.NewError(codespace, CodeExceedsMaxSupply, "failed. total supply(%s) of token(%s) exceeds its max supply(%s)",
+		totalSupply.String(), symbol, maxSupply.String())
+}
+
+// ErrHTLCExists returns an error when a contract with the hash lock already exists
+func ErrHTLCExists(codespace sdk.CodespaceType, hashLock string) sdk.Error {
+	return sdk.NewError(codespace, CodeHTLCExists, "failed. htlc with hash lock %s already exists", hashLock)
+}
+
+// ErrUnknownHTLC returns an error when the contract of the hash lock doesn't exist
+func ErrUnknownHTLC(codespace sdk.CodespaceType, hashLock string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownHTLC, "failed. htlc with hash lock %s doesn't exist", hashLock)
+}
+
+// ErrHTLCNotOpen returns an error when the contract is already claimed or refunded
+func ErrHTLCNotOpen(codespace sdk.CodespaceType, hashLock, state string) sdk.Error {
+	return sdk.NewError(codespace, CodeHTLCNotOpen, "failed. htlc with hash lock %s is %s", hashLock, state)
+}
+
+// ErrHTLCExpired returns an error when the contract is claimed at or after its expire height
+func ErrHTLCExpired(codespace sdk.CodespaceType, hashLock string, expireHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeHTLCExpired, "failed. htlc with hash lock %s expired at height %d",
+		hashLock, expireHeight)
+}
+
+// ErrInvalidSecret returns an error when the secret doesn't match the hash lock of the contract
+func ErrInvalidSecret(codespace sdk.CodespaceType, hashLock string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidSecret, "failed. secret doesn't match the hash lock %s", hashLock)
+}
+
 func ErrInvalidDexList(codespace sdk.CodespaceType, message string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidDexList, message)
 }
@@ -43,3 +94,9 @@ func ErrInvalidHeight(codespace sdk.CodespaceType, h, ch, max int64) sdk.Error {
 func ErrInvalidCommon(codespace sdk.CodespaceType, message string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidCommon, message)
 }
+
+// ErrTooManyVestingSchedules returns an error when the recipient has too many vesting schedules being released
+func ErrTooManyVestingSchedules(codespace sdk.CodespaceType, addr sdk.AccAddress, maxSchedules int) sdk.Error {
+	return sdk.NewError(codespace, CodeTooManyVestingSchedules, "failed. %s already has %d vesting schedules",
+		addr.String(), maxSchedules)
+}
@@ -0,0 +1,15 @@
+package types
+
+// token module event types
+const (
+	EventTypeCreateHTLC = "create_htlc"
+	EventTypeClaimHTLC  = "claim_htlc"
+	EventTypeRefundHTLC = "refund_htlc"
+
+	AttributeKeyHashLock     = "hash_lock"
+	AttributeKeySender       = "sender"
+	AttributeKeyRecipient    = "recipient"
+	AttributeKeyAmount       = "amount"
+	AttributeKeySecret       = "secret"
+	AttributeKeyExpireHeight = "expire_height"
+)
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// states of the hash time-locked contracts
+const (
+	HTLCStateOpen      = "open"      // waiting to be claimed by the recipient
+	HTLCStateCompleted = "completed" // claimed with the secret by the recipient
+	HTLCStateRefunded  = "refunded"  // refunded to the sender after expiry
+)
+
+// the limits of the hash time-locked contracts
+const (
+	HashLockLength  = 32    // length of the sha256 hash lock in bytes
+	SecretLength    = 32    // length of the secret in bytes
+	MinHTLCTimeLock = 50    // min number of blocks before a contract expires
+	MaxHTLCTimeLock = 25480 // max number of blocks before a contract expires
+)
+
+// HTLC is a hash time-locked contract. The amount is held by the token module account until the recipient
+// claims it with the secret of the hash lock, or it is refunded to the sender at the expire height.
+// The settled contracts are kept in the store with their states, so their hash locks can't be reused
+type HTLC struct {
+	HashLock     string         `json:"hash_lock"` // hex encoded sha256 hash of the secret
+	Sender       sdk.AccAddress `json:"sender"`
+	Recipient    sdk.AccAddress `json:"recipient"`
+	Amount       sdk.DecCoins   `json:"amount"`
+	Secret       string         `json:"secret"` // hex encoded secret, revealed by the claim
+	ExpireHeight int64          `json:"expire_height"`
+	State        string         `json:"state"`
+}
+
+func (htlc HTLC) String() string {
+	b, err := json.Marshal(htlc)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// HTLCs is a slice of HTLC
+type HTLCs []HTLC
+
+func (htlcs HTLCs) String() string {
+	b, err := json.Marshal(htlcs)
+	if err != nil {
+		return "[{}]"
+	}
+	return string(b)
+}
+
+// GetHashLock returns the sha256 hash lock of the secret
+func GetHashLock(secret []byte) []byte {
+	hash := sha256.Sum256(secret)
+	return hash[:]
+}
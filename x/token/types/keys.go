@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -30,6 +32,10 @@ const (
 	QueryCurrency   = "currency"
 	QueryAccount    = "accounts"
 	QueryKeysNum    = "store"
+	QueryFrozen     = "frozen"
+	QueryVesting    = "vesting"
+	QueryHTLC       = "htlc"
+	QueryHTLCs      = "htlcs"
 
 	QueryAccountV2 = "accountsV2"
 	QueryTokensV2  = "tokensV2"
@@ -43,6 +49,14 @@ var (
 	PrefixUserTokenKey        = []byte{0x03} // the address prefix of the user-token relationship
 	LockedFeeKey              = []byte{0x04} // the address prefix of the locked order fee coins
 	PrefixConfirmOwnershipKey = []byte{0x05} // the prefix of the confirm ownership key
+	PrefixFrozenAccountKey    = []byte{0x06} // the prefix of the accounts frozen by the token owners
+	LockedVestingKey          = []byte{0x07} // the address prefix of the locked vesting coins
+	PrefixVestingScheduleKey  = []byte{0x08} // the prefix of the vesting schedules
+	VestingScheduleIDKey      = []byte{0x09} // key for the last vesting schedule id
+	PrefixVestingQueueKey     = []byte{0x0A} // the prefix of the vesting schedules by the next release time
+	PrefixHTLCKey             = []byte{0x0B} // the prefix of the hash time-locked contracts
+	PrefixHTLCExpireQueueKey  = []byte{0x0C} // the prefix of the open contracts by the expire height
+	PrefixHTLCAddressKey      = []byte{0x0D} // the prefix of the open contracts by the sender and the recipient
 )
 
 func GetUserTokenPrefix(owner sdk.AccAddress) []byte {
@@ -66,6 +80,66 @@ func GetLockFeeAddress(addr sdk.AccAddress) []byte {
 	return append(LockedFeeKey, addr.Bytes()...)
 }
 
+// GetLockVestingAddress gets the key for the locked vesting coins with address
+func GetLockVestingAddress(addr sdk.AccAddress) []byte {
+	return append(LockedVestingKey, addr.Bytes()...)
+}
+
+// GetVestingSchedulePrefix gets the prefix of the vesting schedules of the recipient
+func GetVestingSchedulePrefix(to sdk.AccAddress) []byte {
+	return append(PrefixVestingScheduleKey, to.Bytes()...)
+}
+
+// GetVestingScheduleKey gets the key of the vesting schedule
+func GetVestingScheduleKey(to sdk.AccAddress, id uint64) []byte {
+	return append(GetVestingSchedulePrefix(to), sdk.Uint64ToBigEndian(id)...)
+}
+
+// GetVestingQueuePrefix gets the prefix of the vesting schedules to be released at the time
+func GetVestingQueuePrefix(releaseTime time.Time) []byte {
+	return append(PrefixVestingQueueKey, sdk.FormatTimeBytes(releaseTime)...)
+}
+
+// GetVestingQueueKey gets the key of the vesting schedule in the release queue
+func GetVestingQueueKey(releaseTime time.Time, to sdk.AccAddress, id uint64) []byte {
+	return append(append(GetVestingQueuePrefix(releaseTime), to.Bytes()...), sdk.Uint64ToBigEndian(id)...)
+}
+
 func GetConfirmOwnershipKey(symbol string) []byte {
 	return append(PrefixConfirmOwnershipKey, []byte(symbol)...)
 }
+
+// GetFrozenAccountPrefix gets the prefix of the accounts frozen for the token
+func GetFrozenAccountPrefix(symbol string) []byte {
+	return append(append(PrefixFrozenAccountKey, []byte(symbol)...), 0x00)
+}
+
+// GetFrozenAccountKey gets the key of the account frozen for the token
+func GetFrozenAccountKey(symbol string, addr sdk.AccAddress) []byte {
+	return append(GetFrozenAccountPrefix(symbol), addr.Bytes()...)
+}
+
+// GetHTLCKey gets the key of the hash time-locked contract
+func GetHTLCKey(hashLock []byte) []byte {
+	return append(PrefixHTLCKey, hashLock...)
+}
+
+// GetHTLCAddressPrefix gets the prefix of the open contracts sent or received by the address
+func GetHTLCAddressPrefix(addr sdk.AccAddress) []byte {
+	return append(PrefixHTLCAddressKey, addr.Bytes()...)
+}
+
+// GetHTLCAddressKey gets the key of the open contract in the index of the address
+func GetHTLCAddressKey(addr sdk.AccAddress, hashLock []byte) []byte {
+	return append(GetHTLCAddressPrefix(addr), hashLock...)
+}
+
+// GetHTLCExpireQueuePrefix gets the prefix of the open contracts expiring at the height
+func GetHTLCExpireQueuePrefix(expireHeight int64) []byte {
+	return append(PrefixHTLCExpireQueueKey, sdk.Uint64ToBigEndian(uint64(expireHeight))...)
+}
+
+// GetHTLCExpireQueueKey gets the key of the open contract in the expire queue
+func GetHTLCExpireQueueKey(expireHeight int64, hashLock []byte) []byte {
+	return append(GetHTLCExpireQueuePrefix(expireHeight), hashLock...)
+}
@@ -2,6 +2,10 @@
 package types
 
 import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -9,6 +13,13 @@ const (
 	DescLenLimit   = 256
 	MultiSendLimit = 1000
 
+	// limits of the token metadata
+	DecimalsUpperbound     = 18
+	URLLenLimit            = 256
+	AttributesLimit        = 16
+	AttributeKeyLenLimit   = 32
+	AttributeValueLenLimit = 256
+
 	// 90 billion
 	TotalSupplyUpperbound = int64(9 * 1e10)
 )
@@ -20,19 +31,23 @@ type MsgTokenIssue struct {
 	OriginalSymbol string         `json:"original_symbol"`
 	WholeName      string         `json:"whole_name"`
 	TotalSupply    string         `json:"total_supply"`
+	MaxSupply      string         `json:"max_supply"` // empty or zero for no cap
 	Owner          sdk.AccAddress `json:"owner"`
 	Mintable       bool           `json:"mintable"`
+	Freezable      bool           `json:"freezable"`
 }
 
-func NewMsgTokenIssue(tokenDescription, symbol, originalSymbol, wholeName, totalSupply string, owner sdk.AccAddress, mintable bool) MsgTokenIssue {
+func NewMsgTokenIssue(tokenDescription, symbol, originalSymbol, wholeName, totalSupply, maxSupply string, owner sdk.AccAddress, mintable, freezable bool) MsgTokenIssue {
 	return MsgTokenIssue{
 		Description:    tokenDescription,
 		Symbol:         symbol,
 		OriginalSymbol: originalSymbol,
 		WholeName:      wholeName,
 		TotalSupply:    totalSupply,
+		MaxSupply:      maxSupply,
 		Owner:          owner,
 		Mintable:       mintable,
+		Freezable:      freezable,
 	}
 }
 
@@ -71,6 +86,16 @@ func (msg MsgTokenIssue) ValidateBasic() sdk.Error {
 	if totalSupply.GT(sdk.NewDec(TotalSupplyUpperbound)) || totalSupply.LTE(sdk.ZeroDec()) {
 		return sdk.ErrUnknownRequest("failed to check issue msg because invalid total supply")
 	}
+	// check maxSupply
+	if len(msg.MaxSupply) != 0 {
+		maxSupply, err := sdk.NewDecFromStr(msg.MaxSupply)
+		if err != nil {
+			return err
+		}
+		if maxSupply.GT(sdk.NewDec(TotalSupplyUpperbound)) || maxSupply.IsNegative() {
+			return sdk.ErrUnknownRequest("failed to check issue msg because invalid max supply")
+		}
+	}
 	return nil
 }
 
@@ -297,12 +322,20 @@ func (msg MsgTransferOwnership) GetSigners() []sdk.AccAddress {
 }
 
 type MsgTokenModify struct {
-	Owner                 sdk.AccAddress `json:"owner"`
-	Symbol                string         `json:"symbol"`
-	Description           string         `json:"description"`
-	WholeName             string         `json:"whole_name"`
-	IsDescriptionModified bool           `json:"description_modified"`
-	IsWholeNameModified   bool           `json:"whole_name_modified"`
+	Owner                 sdk.AccAddress   `json:"owner"`
+	Symbol                string           `json:"symbol"`
+	Description           string           `json:"description"`
+	WholeName             string           `json:"whole_name"`
+	Decimals              int32            `json:"decimals"`
+	Website               string           `json:"website"`
+	Logo                  string           `json:"logo"`
+	Attributes            []TokenAttribute `json:"attributes"`
+	IsDescriptionModified bool             `json:"description_modified"`
+	IsWholeNameModified   bool             `json:"whole_name_modified"`
+	IsDecimalsModified    bool             `json:"decimals_modified"`
+	IsWebsiteModified     bool             `json:"website_modified"`
+	IsLogoModified        bool             `json:"logo_modified"`
+	IsAttributesModified  bool             `json:"attributes_modified"`
 }
 
 func NewMsgTokenModify(symbol, desc, wholeName string, isDescEdit, isWholeNameEdit bool, owner sdk.AccAddress) MsgTokenModify {
@@ -345,6 +378,28 @@ func (msg MsgTokenModify) ValidateBasic() sdk.Error {
 			return sdk.ErrUnknownRequest("failed to check modify msg because invalid desc")
 		}
 	}
+	// check metadata
+	if msg.IsDecimalsModified {
+		if msg.Decimals < 0 || msg.Decimals > DecimalsUpperbound {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("failed to check modify msg because decimals must be between 0 and %d",
+				DecimalsUpperbound))
+		}
+	}
+	if msg.IsWebsiteModified {
+		if err := validateURL(msg.Website); err != nil {
+			return sdk.ErrUnknownRequest("failed to check modify msg because invalid website: " + err.Error())
+		}
+	}
+	if msg.IsLogoModified {
+		if err := validateURL(msg.Logo); err != nil {
+			return sdk.ErrUnknownRequest("failed to check modify msg because invalid logo: " + err.Error())
+		}
+	}
+	if msg.IsAttributesModified {
+		if err := validateAttributes(msg.Attributes); err != nil {
+			return sdk.ErrUnknownRequest("failed to check modify msg because invalid attributes: " + err.Error())
+		}
+	}
 	return nil
 }
 
@@ -397,3 +452,292 @@ func (msg MsgConfirmOwnership) GetSignBytes() []byte {
 func (msg MsgConfirmOwnership) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Address}
 }
+
+// MsgTokenFreeze - the owner of a freezable token freezes or unfreezes the token of an account
+type MsgTokenFreeze struct {
+	Owner   sdk.AccAddress `json:"owner"`
+	Symbol  string         `json:"symbol"`
+	Address sdk.AccAddress `json:"address"`
+	Freeze  bool           `json:"freeze"`
+}
+
+func NewMsgTokenFreeze(owner sdk.AccAddress, symbol string, addr sdk.AccAddress, freeze bool) MsgTokenFreeze {
+	return MsgTokenFreeze{
+		Owner:   owner,
+		Symbol:  symbol,
+		Address: addr,
+		Freeze:  freeze,
+	}
+}
+
+func (msg MsgTokenFreeze) Route() string { return RouterKey }
+
+func (msg MsgTokenFreeze) Type() string { return "freeze" }
+
+func (msg MsgTokenFreeze) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if msg.Address.Empty() {
+		return sdk.ErrInvalidAddress("failed to check freeze msg because miss the address to freeze")
+	}
+	if len(msg.Symbol) == 0 {
+		return sdk.ErrUnknownRequest("failed to check freeze msg because symbol cannot be empty")
+	}
+	if sdk.ValidateDenom(msg.Symbol) != nil {
+		return sdk.ErrUnknownRequest("failed to check freeze msg because invalid token symbol: " + msg.Symbol)
+	}
+	return nil
+}
+
+func (msg MsgTokenFreeze) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgTokenFreeze) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgTokenPause - the owner of a freezable token pauses or resumes all the transfers of the token
+type MsgTokenPause struct {
+	Owner  sdk.AccAddress `json:"owner"`
+	Symbol string         `json:"symbol"`
+	Pause  bool           `json:"pause"`
+}
+
+func NewMsgTokenPause(owner sdk.AccAddress, symbol string, pause bool) MsgTokenPause {
+	return MsgTokenPause{
+		Owner:  owner,
+		Symbol: symbol,
+		Pause:  pause,
+	}
+}
+
+func (msg MsgTokenPause) Route() string { return RouterKey }
+
+func (msg MsgTokenPause) Type() string { return "pause" }
+
+func (msg MsgTokenPause) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Symbol) == 0 {
+		return sdk.ErrUnknownRequest("failed to check pause msg because symbol cannot be empty")
+	}
+	if sdk.ValidateDenom(msg.Symbol) != nil {
+		return sdk.ErrUnknownRequest("failed to check pause msg because invalid token symbol: " + msg.Symbol)
+	}
+	return nil
+}
+
+func (msg MsgTokenPause) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgTokenPause) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgTokenSetMaxSupply - the owner of a token caps its total supply, or lowers the cap
+type MsgTokenSetMaxSupply struct {
+	Owner     sdk.AccAddress `json:"owner"`
+	Symbol    string         `json:"symbol"`
+	MaxSupply sdk.Dec        `json:"max_supply"`
+}
+
+func NewMsgTokenSetMaxSupply(owner sdk.AccAddress, symbol string, maxSupply sdk.Dec) MsgTokenSetMaxSupply {
+	return MsgTokenSetMaxSupply{
+		Owner:     owner,
+		Symbol:    symbol,
+		MaxSupply: maxSupply,
+	}
+}
+
+func (msg MsgTokenSetMaxSupply) Route() string { return RouterKey }
+
+func (msg MsgTokenSetMaxSupply) Type() string { return "max-supply" }
+
+func (msg MsgTokenSetMaxSupply) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Symbol) == 0 {
+		return sdk.ErrUnknownRequest("failed to check max supply msg because symbol cannot be empty")
+	}
+	if sdk.ValidateDenom(msg.Symbol) != nil {
+		return sdk.ErrUnknownRequest("failed to check max supply msg because invalid token symbol: " + msg.Symbol)
+	}
+	if msg.MaxSupply.IsNil() || !msg.MaxSupply.IsPositive() || msg.MaxSupply.GT(sdk.NewDec(TotalSupplyUpperbound)) {
+		return sdk.ErrUnknownRequest("failed to check max supply msg because invalid max supply")
+	}
+	return nil
+}
+
+func (msg MsgTokenSetMaxSupply) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgTokenSetMaxSupply) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgVestingSend - high level transaction of sending coins which are released to the recipient over time
+type MsgVestingSend struct {
+	FromAddress sdk.AccAddress `json:"from_address"`
+	ToAddress   sdk.AccAddress `json:"to_address"`
+	Amount      sdk.DecCoins   `json:"amount"`
+	VestingType string         `json:"vesting_type"`
+	StartTime   time.Time      `json:"start_time"`
+	EndTime     time.Time      `json:"end_time"`
+}
+
+func NewMsgVestingSend(from, to sdk.AccAddress, coins sdk.DecCoins, vestingType string, startTime, endTime time.Time) MsgVestingSend {
+	return MsgVestingSend{
+		FromAddress: from,
+		ToAddress:   to,
+		Amount:      coins,
+		VestingType: vestingType,
+		StartTime:   startTime,
+		EndTime:     endTime,
+	}
+}
+
+func (msg MsgVestingSend) Route() string { return RouterKey }
+
+func (msg MsgVestingSend) Type() string { return "vesting-send" }
+
+func (msg MsgVestingSend) ValidateBasic() sdk.Error {
+	if msg.FromAddress.Empty() {
+		return sdk.ErrInvalidAddress("failed to check vesting send msg because miss sender address")
+	}
+	if msg.ToAddress.Empty() {
+		return sdk.ErrInvalidAddress("failed to check vesting send msg because miss recipient address")
+	}
+	if !msg.Amount.IsValid() {
+		return sdk.ErrInvalidCoins("failed to check vesting send msg because send amount is invalid: " + msg.Amount.String())
+	}
+	if !msg.Amount.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("failed to check vesting send msg because send amount must be positive")
+	}
+	switch msg.VestingType {
+	case VestingTypeLinear:
+		if !msg.EndTime.After(msg.StartTime) {
+			return sdk.ErrUnknownRequest("failed to check vesting send msg because end time must be after start time")
+		}
+	case VestingTypeCliff:
+		if msg.EndTime.IsZero() {
+			return sdk.ErrUnknownRequest("failed to check vesting send msg because end time cannot be empty")
+		}
+	default:
+		return sdk.ErrUnknownRequest("failed to check vesting send msg because invalid vesting type: " + msg.VestingType)
+	}
+	return nil
+}
+
+func (msg MsgVestingSend) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgVestingSend) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.FromAddress}
+}
+
+// MsgCreateHTLC - high level transaction of locking coins for the recipient with a hash lock and a time lock
+type MsgCreateHTLC struct {
+	Sender   sdk.AccAddress `json:"sender"`
+	To       sdk.AccAddress `json:"to"`
+	Amount   sdk.DecCoins   `json:"amount"`
+	HashLock string         `json:"hash_lock"`
+	TimeLock int64          `json:"time_lock"`
+}
+
+func NewMsgCreateHTLC(sender, to sdk.AccAddress, coins sdk.DecCoins, hashLock string, timeLock int64) MsgCreateHTLC {
+	return MsgCreateHTLC{
+		Sender:   sender,
+		To:       to,
+		Amount:   coins,
+		HashLock: hashLock,
+		TimeLock: timeLock,
+	}
+}
+
+func (msg MsgCreateHTLC) Route() string { return RouterKey }
+
+func (msg MsgCreateHTLC) Type() string { return "create-htlc" }
+
+func (msg MsgCreateHTLC) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress("failed to check create htlc msg because miss sender address")
+	}
+	if msg.To.Empty() {
+		return sdk.ErrInvalidAddress("failed to check create htlc msg because miss recipient address")
+	}
+	if !msg.Amount.IsValid() {
+		return sdk.ErrInvalidCoins("failed to check create htlc msg because amount is invalid: " + msg.Amount.String())
+	}
+	if !msg.Amount.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("failed to check create htlc msg because amount must be positive")
+	}
+	if hashLock, err := hex.DecodeString(msg.HashLock); err != nil || len(hashLock) != HashLockLength {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("failed to check create htlc msg because hash lock must be %d bytes in hex", HashLockLength))
+	}
+	if msg.TimeLock < MinHTLCTimeLock || msg.TimeLock > MaxHTLCTimeLock {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("failed to check create htlc msg because time lock must be between %d and %d blocks",
+			MinHTLCTimeLock, MaxHTLCTimeLock))
+	}
+	return nil
+}
+
+func (msg MsgCreateHTLC) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgCreateHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgClaimHTLC - high level transaction of claiming the coins of a hash time-locked contract for its recipient
+type MsgClaimHTLC struct {
+	Sender   sdk.AccAddress `json:"sender"`
+	HashLock string         `json:"hash_lock"`
+	Secret   string         `json:"secret"`
+}
+
+func NewMsgClaimHTLC(sender sdk.AccAddress, hashLock, secret string) MsgClaimHTLC {
+	return MsgClaimHTLC{
+		Sender:   sender,
+		HashLock: hashLock,
+		Secret:   secret,
+	}
+}
+
+func (msg MsgClaimHTLC) Route() string { return RouterKey }
+
+func (msg MsgClaimHTLC) Type() string { return "claim-htlc" }
+
+func (msg MsgClaimHTLC) ValidateBasic() sdk.Error {
+	if msg.Sender.Empty() {
+		return sdk.ErrInvalidAddress("failed to check claim htlc msg because miss sender address")
+	}
+	if hashLock, err := hex.DecodeString(msg.HashLock); err != nil || len(hashLock) != HashLockLength {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("failed to check claim htlc msg because hash lock must be %d bytes in hex", HashLockLength))
+	}
+	if secret, err := hex.DecodeString(msg.Secret); err != nil || len(secret) != SecretLength {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("failed to check claim htlc msg because secret must be %d bytes in hex", SecretLength))
+	}
+	return nil
+}
+
+func (msg MsgClaimHTLC) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgClaimHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -20,20 +22,24 @@ func TestNewMsgTokenIssue(t *testing.T) {
 		issueMsg MsgTokenIssue
 		err      sdk.Error
 	}{
-		{NewMsgTokenIssue("bnb", "bnb", "bnb", "binance coin", totalSupply, addr, true),
+		{NewMsgTokenIssue("bnb", "bnb", "bnb", "binance coin", totalSupply, "", addr, true, false),
 			nil},
-		{NewMsgTokenIssue("", "", "", "binance coin", totalSupply, addr, true),
+		{NewMsgTokenIssue("", "", "", "binance coin", totalSupply, "", addr, true, false),
 			sdk.ErrUnknownRequest("failed to check issue msg because original symbol cannot be empty")},
-		{NewMsgTokenIssue("bnb", "bnb", "bnb", "binance 278343298$%%^&  coin", totalSupply, addr, true),
+		{NewMsgTokenIssue("bnb", "bnb", "bnb", "binance 278343298$%%^&  coin", totalSupply, "", addr, true, false),
 			sdk.ErrUnknownRequest("failed to check issue msg because invalid wholename")},
-		{NewMsgTokenIssue("bnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbn", "bnb", "bnb", "binance coin", totalSupply, addr, true),
+		{NewMsgTokenIssue("bnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbnbbn", "bnb", "bnb", "binance coin", totalSupply, "", addr, true, false),
 			sdk.ErrUnknownRequest("failed to check issue msg because invalid desc")},
-		{NewMsgTokenIssue("bnb", "bnb", "bnb", "binance coin", strconv.FormatInt(int64(99*1e10), 10), addr, true),
+		{NewMsgTokenIssue("bnb", "bnb", "bnb", "binance coin", strconv.FormatInt(int64(99*1e10), 10), "", addr, true, false),
 			sdk.ErrUnknownRequest("failed to check issue msg because invalid total supply")},
-		{NewMsgTokenIssue("", "", "", "binance coin", totalSupply, sdk.AccAddress{}, true),
+		{NewMsgTokenIssue("", "", "", "binance coin", totalSupply, "", sdk.AccAddress{}, true, false),
 			sdk.ErrInvalidAddress(sdk.AccAddress{}.String())},
-		{NewMsgTokenIssue("", "", "bnb-asd", "binance coin", totalSupply, addr, true),
+		{NewMsgTokenIssue("", "", "bnb-asd", "binance coin", totalSupply, "", addr, true, false),
 			sdk.ErrUnknownRequest("failed to check issue msg because invalid original symbol: bnb-asd")},
+		{NewMsgTokenIssue("bnb", "bnb", "bnb", "binance coin", totalSupply, "100000", addr, true, false),
+			nil},
+		{NewMsgTokenIssue("bnb", "bnb", "bnb", "binance coin", totalSupply, "-1", addr, true, false),
+			sdk.ErrUnknownRequest("failed to check issue msg because invalid max supply")},
 	}
 
 	for _, msgCase := range testCase {
@@ -342,3 +348,46 @@ bnbbbbbbbbbbbnbbbbbbbbbbnbbbbbbbbbbbnbbbbbbbbb1234`, "bnbbbbbbbbbb", false, fals
 	err := tokenEditMsg.ValidateBasic()
 	require.NoError(t, err)
 }
+
+func TestMsgTokenModifyMetadata(t *testing.T) {
+	addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	tooManyAttributes := make([]TokenAttribute, AttributesLimit+1)
+	for i := range tooManyAttributes {
+		tooManyAttributes[i] = TokenAttribute{Key: fmt.Sprintf("key%d", i)}
+	}
+
+	testCases := []struct {
+		modify  func(msg *MsgTokenModify)
+		isValid bool
+	}{
+		{func(msg *MsgTokenModify) { msg.Decimals, msg.IsDecimalsModified = 8, true }, true},
+		{func(msg *MsgTokenModify) { msg.Decimals, msg.IsDecimalsModified = DecimalsUpperbound+1, true }, false},
+		{func(msg *MsgTokenModify) { msg.Decimals, msg.IsDecimalsModified = -1, true }, false},
+		{func(msg *MsgTokenModify) { msg.Decimals, msg.IsDecimalsModified = -1, false }, true},
+		{func(msg *MsgTokenModify) { msg.Website, msg.IsWebsiteModified = "https://www.okex.com", true }, true},
+		{func(msg *MsgTokenModify) { msg.Website, msg.IsWebsiteModified = "", true }, true},
+		{func(msg *MsgTokenModify) { msg.Website, msg.IsWebsiteModified = "www.okex.com", true }, false},
+		{func(msg *MsgTokenModify) { msg.Logo, msg.IsLogoModified = "ftp://www.okex.com/okt.png", true }, false},
+		{func(msg *MsgTokenModify) {
+			msg.Logo, msg.IsLogoModified = "https://www.okex.com/"+strings.Repeat("a", URLLenLimit), true
+		}, false},
+		{func(msg *MsgTokenModify) {
+			msg.Attributes, msg.IsAttributesModified = []TokenAttribute{{"twitter", "okex"}, {"github", "okex"}}, true
+		}, true},
+		{func(msg *MsgTokenModify) {
+			msg.Attributes, msg.IsAttributesModified = []TokenAttribute{{"twitter", "okex"}, {"twitter", "okex"}}, true
+		}, false},
+		{func(msg *MsgTokenModify) {
+			msg.Attributes, msg.IsAttributesModified = []TokenAttribute{{"", "okex"}}, true
+		}, false},
+		{func(msg *MsgTokenModify) {
+			msg.Attributes, msg.IsAttributesModified = []TokenAttribute{{"twitter", strings.Repeat("a", AttributeValueLenLimit+1)}}, true
+		}, false},
+		{func(msg *MsgTokenModify) { msg.Attributes, msg.IsAttributesModified = tooManyAttributes, true }, false},
+	}
+	for i, testCase := range testCases {
+		msg := NewMsgTokenModify("bnb", "", "", false, false, addr)
+		testCase.modify(&msg)
+		require.Equal(t, testCase.isValid, msg.ValidateBasic() == nil, "test case %d", i)
+	}
+}
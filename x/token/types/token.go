@@ -7,14 +7,34 @@ import (
 )
 
 type Token struct {
-	Description         string         `json:"description" v2:"description"`                     // e.g. "OK Group Global Utility Token"
-	Symbol              string         `json:"symbol" v2:"symbol"`                               // e.g. "okt"
-	OriginalSymbol      string         `json:"original_symbol" v2:"original_symbol"`             // e.g. "OKT"
-	WholeName           string         `json:"whole_name" v2:"whole_name"`                       // e.g. "OKT"
-	OriginalTotalSupply sdk.Dec        `json:"original_total_supply" v2:"original_total_supply"` // e.g. 1000000000.00000000
-	Type                int            `json:"type"`                                             //e.g. 1 common token, 2 interest token
-	Owner               sdk.AccAddress `json:"owner" v2:"owner"`                                 // e.g. okexchain1upyg3vl6vqaxqvzts69zpus2c027p7paw63s99
-	Mintable            bool           `json:"mintable" v2:"mintable"`                           // e.g. false
+	Description         string           `json:"description" v2:"description"`                     // e.g. "OK Group Global Utility Token"
+	Symbol              string           `json:"symbol" v2:"symbol"`                               // e.g. "okt"
+	OriginalSymbol      string           `json:"original_symbol" v2:"original_symbol"`             // e.g. "OKT"
+	WholeName           string           `json:"whole_name" v2:"whole_name"`                       // e.g. "OKT"
+	OriginalTotalSupply sdk.Dec          `json:"original_total_supply" v2:"original_total_supply"` // e.g. 1000000000.00000000
+	Type                int              `json:"type"`                                             //e.g. 1 common token, 2 interest token
+	Owner               sdk.AccAddress   `json:"owner" v2:"owner"`                                 // e.g. okexchain1upyg3vl6vqaxqvzts69zpus2c027p7paw63s99
+	Mintable            bool             `json:"mintable" v2:"mintable"`                           // e.g. false
+	Freezable           bool             `json:"freezable" v2:"freezable"`                         // e.g. false, whether the owner can freeze accounts and pause transfers
+	Paused              bool             `json:"paused" v2:"paused"`                               // e.g. false, whether the transfers are paused by the owner
+	MaxSupply           sdk.Dec          `json:"max_supply" v2:"max_supply"`                       // e.g. 2000000000.00000000, zero for no cap
+	Decimals            int32            `json:"decimals" v2:"decimals"`                           // e.g. 8, decimals for wallets to display the token
+	Website             string           `json:"website" v2:"website"`                             // e.g. "https://www.okex.com"
+	Logo                string           `json:"logo" v2:"logo"`                                   // e.g. "https://www.okex.com/okt.png"
+	Attributes          []TokenAttribute `json:"attributes" v2:"attributes"`                       // extensible key/value attributes
+}
+
+// TokenAttribute is a key/value attribute of the token. The attributes are kept in a slice
+// because amino doesn't encode maps
+type TokenAttribute struct {
+	Key   string `json:"key" v2:"key"`
+	Value string `json:"value" v2:"value"`
+}
+
+// HasMaxSupply returns whether the total supply of the token is capped. The tokens issued before
+// the cap was introduced have a nil max supply
+func (token Token) HasMaxSupply() bool {
+	return !token.MaxSupply.IsNil() && token.MaxSupply.IsPositive()
 }
 
 func (token Token) String() string {
@@ -26,15 +46,22 @@ func (token Token) String() string {
 }
 
 type TokenResp struct {
-	Description         string         `json:"description" v2:"description"`
-	Symbol              string         `json:"symbol" v2:"symbol"`
-	OriginalSymbol      string         `json:"original_symbol" v2:"original_symbol"`
-	WholeName           string         `json:"whole_name" v2:"whole_name"`
-	OriginalTotalSupply sdk.Dec        `json:"original_total_supply" v2:"original_total_supply"`
-	Type                int            `json:"type"`
-	Owner               sdk.AccAddress `json:"owner" v2:"owner"`
-	Mintable            bool           `json:"mintable" v2:"mintable"`
-	TotalSupply         sdk.Dec        `json:"total_supply" v2:"total_supply"`
+	Description         string           `json:"description" v2:"description"`
+	Symbol              string           `json:"symbol" v2:"symbol"`
+	OriginalSymbol      string           `json:"original_symbol" v2:"original_symbol"`
+	WholeName           string           `json:"whole_name" v2:"whole_name"`
+	OriginalTotalSupply sdk.Dec          `json:"original_total_supply" v2:"original_total_supply"`
+	Type                int              `json:"type"`
+	Owner               sdk.AccAddress   `json:"owner" v2:"owner"`
+	Mintable            bool             `json:"mintable" v2:"mintable"`
+	Freezable           bool             `json:"freezable" v2:"freezable"`
+	Paused              bool             `json:"paused" v2:"paused"`
+	MaxSupply           sdk.Dec          `json:"max_supply" v2:"max_supply"`
+	Decimals            int32            `json:"decimals" v2:"decimals"`
+	Website             string           `json:"website" v2:"website"`
+	Logo                string           `json:"logo" v2:"logo"`
+	Attributes          []TokenAttribute `json:"attributes" v2:"attributes"`
+	TotalSupply         sdk.Dec          `json:"total_supply" v2:"total_supply"`
 }
 
 func (token TokenResp) String() string {
@@ -55,6 +82,12 @@ func (tokens Tokens) String() string {
 	return string(b)
 }
 
+// FrozenAccount is an account whose token is frozen by the token owner
+type FrozenAccount struct {
+	Symbol  string         `json:"symbol"`
+	Address sdk.AccAddress `json:"address"`
+}
+
 type Currency struct {
 	Description string  `json:"description"`
 	Symbol      string  `json:"symbol"`
@@ -101,13 +134,15 @@ type CoinInfo struct {
 	Symbol    string `json:"symbol" v2:"currency"`
 	Available string `json:"available" v2:"available"`
 	Locked    string `json:"locked" v2:"locked"`
+	Vesting   string `json:"vesting" v2:"vesting"` // locked by the vesting schedules, released over time
 }
 
-func NewCoinInfo(symbol, available, locked string) *CoinInfo {
+func NewCoinInfo(symbol, available, locked, vesting string) *CoinInfo {
 	return &CoinInfo{
 		Symbol:    symbol,
 		Available: available,
 		Locked:    locked,
+		Vesting:   vesting,
 	}
 }
 
@@ -25,9 +25,10 @@ func TestCoinInfo(t *testing.T) {
 		Symbol:    "btc",
 		Available: "1000001",
 		Locked:    "8888",
+		Vesting:   "100",
 	}
 
-	coinInfo1 := NewCoinInfo("btc", "1000001", "8888")
+	coinInfo1 := NewCoinInfo("btc", "1000001", "8888", "100")
 	require.EqualValues(t, coinInfo, *coinInfo1)
 }
 
@@ -72,7 +73,8 @@ func TestToken(t *testing.T) {
 			Type:                0,
 			Owner:               nil,
 			Mintable:            false,
-		}, `{"description":"my token","symbol":"` + common.NativeToken + `","original_symbol":"` + common.NativeToken + `","whole_name":"btc","original_total_supply":"1000000.00000000","type":0,"owner":"","mintable":false}`},
+			MaxSupply:           sdk.ZeroDec(),
+		}, `{"description":"my token","symbol":"` + common.NativeToken + `","original_symbol":"` + common.NativeToken + `","whole_name":"btc","original_total_supply":"1000000.00000000","type":0,"owner":"","mintable":false,"freezable":false,"paused":false,"max_supply":"0.00000000","decimals":0,"website":"","logo":"","attributes":null}`},
 		{Token{
 			Description:         "okblockchain coin",
 			Symbol:              common.NativeToken,
@@ -82,7 +84,12 @@ func TestToken(t *testing.T) {
 			Type:                0,
 			Owner:               addr,
 			Mintable:            true,
-		}, `{"description":"okblockchain coin","symbol":"` + common.NativeToken + `","original_symbol":"` + common.NativeToken + `","whole_name":"ok coin","original_total_supply":"1000000000.00000000","type":0,"owner":"okexchain1dfpljpe0g0206jch32fx95lyagq3z5ws850m6f","mintable":true}`},
+			MaxSupply:           sdk.ZeroDec(),
+			Decimals:            8,
+			Website:             "https://www.okex.com",
+			Logo:                "https://www.okex.com/okt.png",
+			Attributes:          []TokenAttribute{{Key: "twitter", Value: "okex"}},
+		}, `{"description":"okblockchain coin","symbol":"` + common.NativeToken + `","original_symbol":"` + common.NativeToken + `","whole_name":"ok coin","original_total_supply":"1000000000.00000000","type":0,"owner":"okexchain1dfpljpe0g0206jch32fx95lyagq3z5ws850m6f","mintable":true,"freezable":false,"paused":false,"max_supply":"0.00000000","decimals":8,"website":"https://www.okex.com","logo":"https://www.okex.com/okt.png","attributes":[{"key":"twitter","value":"okex"}]}`},
 	}
 	for _, tokenCase := range testCase {
 		b, err := json.Marshal(tokenCase.token)
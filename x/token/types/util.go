@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"regexp"
 	"sort"
 	"strings"
@@ -34,6 +35,45 @@ func wholeNameValid(wholeName string) bool {
 	return reWhole.MatchString(wholeName)
 }
 
+// validateURL checks the website or logo url of the token, empty to clear it
+func validateURL(rawURL string) error {
+	if len(rawURL) == 0 {
+		return nil
+	}
+	if len(rawURL) > URLLenLimit {
+		return fmt.Errorf("url is longer than %d", URLLenLimit)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url(%s) must be an absolute http or https url", rawURL)
+	}
+	return nil
+}
+
+// validateAttributes checks the key/value attributes of the token
+func validateAttributes(attributes []TokenAttribute) error {
+	if len(attributes) > AttributesLimit {
+		return fmt.Errorf("more than %d attributes", AttributesLimit)
+	}
+	keys := make(map[string]bool, len(attributes))
+	for _, attribute := range attributes {
+		if len(attribute.Key) == 0 || len(attribute.Key) > AttributeKeyLenLimit {
+			return fmt.Errorf("length of key(%s) must be between 1 and %d", attribute.Key, AttributeKeyLenLimit)
+		}
+		if len(attribute.Value) > AttributeValueLenLimit {
+			return fmt.Errorf("value of key(%s) is longer than %d", attribute.Key, AttributeValueLenLimit)
+		}
+		if keys[attribute.Key] {
+			return fmt.Errorf("duplicate key(%s)", attribute.Key)
+		}
+		keys[attribute.Key] = true
+	}
+	return nil
+}
+
 type BaseAccount struct {
 	Address       sdk.AccAddress `json:"address"`
 	Coins         sdk.Coins      `json:"coins"`
@@ -135,7 +175,7 @@ func DecAccountArrToBaseAccountArr(decAccounts []DecAccount) (baseAccountArr []a
 	return baseAccountArr
 }
 
-func MergeCoinInfo(availableCoins, lockedCoins sdk.DecCoins) (coinsInfo CoinsInfo) {
+func MergeCoinInfo(availableCoins, lockedCoins, vestingCoins sdk.DecCoins) (coinsInfo CoinsInfo) {
 	m := make(map[string]CoinInfo)
 
 	for _, availableCoin := range availableCoins {
@@ -145,6 +185,7 @@ func MergeCoinInfo(availableCoins, lockedCoins sdk.DecCoins) (coinsInfo CoinsInf
 
 			coinInfo.Available = availableCoin.Amount.String()
 			coinInfo.Locked = "0"
+			coinInfo.Vesting = "0"
 			m[availableCoin.Denom] = coinInfo
 		}
 	}
@@ -158,11 +199,27 @@ func MergeCoinInfo(availableCoins, lockedCoins sdk.DecCoins) (coinsInfo CoinsInf
 			coinInfo.Symbol = lockedCoin.Denom
 			coinInfo.Available = "0"
 			coinInfo.Locked = lockedCoin.Amount.String()
+			coinInfo.Vesting = "0"
 
 			m[lockedCoin.Denom] = coinInfo
 		}
 	}
 
+	for _, vestingCoin := range vestingCoins {
+		coinInfo, ok := m[vestingCoin.Denom]
+		if ok {
+			coinInfo.Vesting = vestingCoin.Amount.String()
+			m[vestingCoin.Denom] = coinInfo
+		} else {
+			coinInfo.Symbol = vestingCoin.Denom
+			coinInfo.Available = "0"
+			coinInfo.Locked = "0"
+			coinInfo.Vesting = vestingCoin.Amount.String()
+
+			m[vestingCoin.Denom] = coinInfo
+		}
+	}
+
 	for _, coinInfo := range m {
 		coinsInfo = append(coinsInfo, coinInfo)
 	}
@@ -171,6 +228,10 @@ func MergeCoinInfo(availableCoins, lockedCoins sdk.DecCoins) (coinsInfo CoinsInf
 }
 
 func GenTokenResp(token Token) TokenResp {
+	maxSupply := sdk.ZeroDec()
+	if token.HasMaxSupply() {
+		maxSupply = token.MaxSupply
+	}
 	return TokenResp{
 		Description:         token.Description,
 		Symbol:              token.Symbol,
@@ -180,5 +241,12 @@ func GenTokenResp(token Token) TokenResp {
 		Owner:               token.Owner,
 		Type:                token.Type,
 		Mintable:            token.Mintable,
+		Freezable:           token.Freezable,
+		Paused:              token.Paused,
+		MaxSupply:           maxSupply,
+		Decimals:            token.Decimals,
+		Website:             token.Website,
+		Logo:                token.Logo,
+		Attributes:          token.Attributes,
 	}
 }
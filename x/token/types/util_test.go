@@ -62,12 +62,18 @@ func TestMergeCoinInfo(t *testing.T) {
 		sdk.NewDecCoinFromDec("abc", sdk.NewDec(100)),
 	}
 
-	coinsInfo := MergeCoinInfo(availableCoins, lockedCoins)
+	vestingCoins := sdk.DecCoins{
+		sdk.NewDecCoinFromDec("bnb", sdk.NewDec(50)),
+		sdk.NewDecCoinFromDec("xyz", sdk.NewDec(50)),
+	}
+
+	coinsInfo := MergeCoinInfo(availableCoins, lockedCoins, vestingCoins)
 	expectedCoinsInfo := CoinsInfo{
-		CoinInfo{"abc", "0", "100.00000000"},
-		CoinInfo{"bnb", "100.00000000", "0"},
-		CoinInfo{"btc", "100.00000000", "100.00000000"},
-		CoinInfo{common.NativeToken, "100.00000000", "0"},
+		CoinInfo{"abc", "0", "100.00000000", "0"},
+		CoinInfo{"bnb", "100.00000000", "0", "50.00000000"},
+		CoinInfo{"btc", "100.00000000", "100.00000000", "0"},
+		CoinInfo{common.NativeToken, "100.00000000", "0", "0"},
+		CoinInfo{"xyz", "0", "0", "50.00000000"},
 	}
 	require.EqualValues(t, expectedCoinsInfo, coinsInfo)
 }
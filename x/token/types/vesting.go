@@ -0,0 +1,87 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// types of the vesting schedules
+const (
+	VestingTypeLinear = "linear" // released linearly between the start time and the end time
+	VestingTypeCliff  = "cliff"  // released all at once at the end time
+)
+
+// the limits of the vesting schedules
+const (
+	// VestingReleaseInterval is the min interval between two releases of a linear vesting schedule
+	VestingReleaseInterval = time.Hour
+	// MaxVestingSchedulesPerAccount is the max number of vesting schedules being released to an account
+	MaxVestingSchedulesPerAccount = 100
+)
+
+// VestingSchedule is the schedule to release the coins of a vesting transfer to the recipient.
+// The coins not released yet are locked for the recipient with LockCoinsTypeVesting
+type VestingSchedule struct {
+	ID          uint64         `json:"id"`
+	From        sdk.AccAddress `json:"from"`
+	To          sdk.AccAddress `json:"to"`
+	Amount      sdk.DecCoins   `json:"amount"`
+	Released    sdk.DecCoins   `json:"released"`
+	VestingType string         `json:"vesting_type"`
+	StartTime   time.Time      `json:"start_time"`
+	EndTime     time.Time      `json:"end_time"`
+}
+
+func (schedule VestingSchedule) String() string {
+	b, err := json.Marshal(schedule)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// VestingSchedules is a slice of VestingSchedule
+type VestingSchedules []VestingSchedule
+
+func (schedules VestingSchedules) String() string {
+	b, err := json.Marshal(schedules)
+	if err != nil {
+		return "[{}]"
+	}
+	return string(b)
+}
+
+// VestedCoins returns the coins vested until the time, including the released ones
+func (schedule VestingSchedule) VestedCoins(blockTime time.Time) sdk.DecCoins {
+	if !blockTime.Before(schedule.EndTime) {
+		return schedule.Amount
+	}
+	if schedule.VestingType == VestingTypeCliff || !blockTime.After(schedule.StartTime) {
+		return sdk.DecCoins{}
+	}
+	elapsed := sdk.NewDec(blockTime.Unix() - schedule.StartTime.Unix())
+	duration := sdk.NewDec(schedule.EndTime.Unix() - schedule.StartTime.Unix())
+	return schedule.Amount.MulDecTruncate(elapsed.Quo(duration))
+}
+
+// NextReleaseTime returns the time when the schedule should be released next after the block time,
+// a linear schedule is released every VestingReleaseInterval and a cliff schedule is released at its end time
+func (schedule VestingSchedule) NextReleaseTime(blockTime time.Time) time.Time {
+	if schedule.VestingType == VestingTypeCliff {
+		return schedule.EndTime
+	}
+	if blockTime.Before(schedule.StartTime) {
+		blockTime = schedule.StartTime
+	}
+	if releaseTime := blockTime.Add(VestingReleaseInterval); releaseTime.Before(schedule.EndTime) {
+		return releaseTime
+	}
+	return schedule.EndTime
+}
+
+// LockedCoins returns the coins not released yet
+func (schedule VestingSchedule) LockedCoins() sdk.DecCoins {
+	return schedule.Amount.Sub(schedule.Released)
+}
@@ -0,0 +1,190 @@
+package token
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/okexchain/x/token/types"
+)
+
+// VestingSend sends the coins which are locked for the recipient and released by the vesting schedule
+func (k Keeper) VestingSend(ctx sdk.Context, from, to sdk.AccAddress, amt sdk.DecCoins, vestingType string,
+	startTime, endTime time.Time) (*types.VestingSchedule, error) {
+	if k.bankKeeper.BlacklistedAddr(to) {
+		return nil, types.ErrBlockedRecipient(DefaultCodespace, to.String())
+	}
+	if err := k.CheckCoinsTransferable(ctx, from, amt); err != nil {
+		return nil, err
+	}
+	if err := k.CheckCoinsTransferable(ctx, to, amt); err != nil {
+		return nil, err
+	}
+	if k.countVestingSchedules(ctx, to) >= types.MaxVestingSchedulesPerAccount {
+		return nil, types.ErrTooManyVestingSchedules(DefaultCodespace, to, types.MaxVestingSchedulesPerAccount)
+	}
+
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, from, types.ModuleName, amt); err != nil {
+		return nil, err
+	}
+	if err := k.updateLockedCoins(ctx, to, amt, true, types.LockCoinsTypeVesting); err != nil {
+		return nil, err
+	}
+
+	schedule := &types.VestingSchedule{
+		ID:          k.getNextVestingScheduleID(ctx),
+		From:        from,
+		To:          to,
+		Amount:      amt,
+		Released:    sdk.DecCoins{},
+		VestingType: vestingType,
+		StartTime:   startTime,
+		EndTime:     endTime,
+	}
+	k.SetVestingSchedule(ctx, schedule)
+	k.insertVestingQueue(ctx, schedule.NextReleaseTime(ctx.BlockTime()), to, schedule.ID)
+	return schedule, nil
+}
+
+// ReleaseVestedCoins releases the vested coins of the vesting schedules due until the block time to the recipients,
+// and deletes the schedules released completely. The others are queued again by their next release time
+func (k Keeper) ReleaseVestedCoins(ctx sdk.Context) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := store.Iterator(types.PrefixVestingQueueKey,
+		sdk.PrefixEndBytes(types.GetVestingQueuePrefix(ctx.BlockTime())))
+	var queueKeys, scheduleKeys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		queueKeys = append(queueKeys, iter.Key())
+		scheduleKeys = append(scheduleKeys, iter.Value())
+	}
+	iter.Close()
+
+	for i, queueKey := range queueKeys {
+		store.Delete(queueKey)
+		bz := store.Get(scheduleKeys[i])
+		if bz == nil {
+			continue
+		}
+		var schedule types.VestingSchedule
+		k.cdc.MustUnmarshalBinaryBare(bz, &schedule)
+
+		releasing := schedule.VestedCoins(ctx.BlockTime()).Sub(schedule.Released)
+		if !releasing.IsZero() {
+			if err := k.UnlockCoins(ctx, schedule.To, releasing, types.LockCoinsTypeVesting); err != nil {
+				ctx.Logger().Error(fmt.Sprintf("failed to release vesting schedule %d: %s", schedule.ID, err.Error()))
+			} else {
+				schedule.Released = schedule.Released.Add(releasing)
+			}
+		}
+
+		if schedule.LockedCoins().IsZero() {
+			k.DeleteVestingSchedule(ctx, schedule.To, schedule.ID)
+			continue
+		}
+		k.SetVestingSchedule(ctx, &schedule)
+		k.insertVestingQueue(ctx, schedule.NextReleaseTime(ctx.BlockTime()), schedule.To, schedule.ID)
+	}
+}
+
+// GetVestingCoins gets the coins locked by the vesting schedules of the address
+func (k Keeper) GetVestingCoins(ctx sdk.Context, addr sdk.AccAddress) (coins sdk.DecCoins) {
+	store := ctx.KVStore(k.lockStoreKey)
+	coinsBytes := store.Get(types.GetLockVestingAddress(addr))
+	if coinsBytes == nil {
+		return coins
+	}
+	k.cdc.MustUnmarshalBinaryBare(coinsBytes, &coins)
+	return coins
+}
+
+// SetVestingSchedule sets the vesting schedule to db
+func (k Keeper) SetVestingSchedule(ctx sdk.Context, schedule *types.VestingSchedule) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetVestingScheduleKey(schedule.To, schedule.ID), k.cdc.MustMarshalBinaryBare(schedule))
+}
+
+// DeleteVestingSchedule deletes the vesting schedule from db
+func (k Keeper) DeleteVestingSchedule(ctx sdk.Context, to sdk.AccAddress, id uint64) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Delete(types.GetVestingScheduleKey(to, id))
+}
+
+// GetVestingSchedules returns the vesting schedules of the recipient
+func (k Keeper) GetVestingSchedules(ctx sdk.Context, to sdk.AccAddress) (schedules []types.VestingSchedule) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetVestingSchedulePrefix(to))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var schedule types.VestingSchedule
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &schedule)
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
+
+// IterateVestingSchedules iterates over all the vesting schedules and performs a callback function
+func (k Keeper) IterateVestingSchedules(ctx sdk.Context, cb func(schedule *types.VestingSchedule) (stop bool)) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.PrefixVestingScheduleKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var schedule types.VestingSchedule
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &schedule)
+		if cb(&schedule) {
+			break
+		}
+	}
+}
+
+// IterateVestingCoins iterates over the coins locked by the vesting schedules of all the addresses
+// and performs a callback function
+func (k Keeper) IterateVestingCoins(ctx sdk.Context, cb func(acc sdk.AccAddress, coins sdk.DecCoins) (stop bool)) {
+	store := ctx.KVStore(k.lockStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.LockedVestingKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		acc := iter.Key()[len(types.LockedVestingKey):]
+
+		var coins sdk.DecCoins
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &coins)
+
+		if cb(acc, coins) {
+			break
+		}
+	}
+}
+
+// countVestingSchedules returns the number of the vesting schedules of the recipient,
+// counting stops at MaxVestingSchedulesPerAccount
+func (k Keeper) countVestingSchedules(ctx sdk.Context, to sdk.AccAddress) int {
+	store := ctx.KVStore(k.tokenStoreKey)
+	iter := sdk.KVStorePrefixIterator(store, types.GetVestingSchedulePrefix(to))
+	defer iter.Close()
+	count := 0
+	for ; iter.Valid() && count < types.MaxVestingSchedulesPerAccount; iter.Next() {
+		count++
+	}
+	return count
+}
+
+func (k Keeper) insertVestingQueue(ctx sdk.Context, releaseTime time.Time, to sdk.AccAddress, id uint64) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.GetVestingQueueKey(releaseTime, to, id), types.GetVestingScheduleKey(to, id))
+}
+
+func (k Keeper) getNextVestingScheduleID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.tokenStoreKey)
+	var id uint64
+	if bz := store.Get(types.VestingScheduleIDKey); bz != nil {
+		id = binary.BigEndian.Uint64(bz)
+	}
+	id++
+	k.setLastVestingScheduleID(ctx, id)
+	return id
+}
+
+func (k Keeper) setLastVestingScheduleID(ctx sdk.Context, id uint64) {
+	store := ctx.KVStore(k.tokenStoreKey)
+	store.Set(types.VestingScheduleIDKey, sdk.Uint64ToBigEndian(id))
+}
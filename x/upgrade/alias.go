@@ -28,6 +28,7 @@ var (
 )
 
 type (
-	Keeper      = keeper.Keeper
-	VersionInfo = types.VersionInfo
+	Keeper         = keeper.Keeper
+	UpgradeHandler = keeper.UpgradeHandler
+	VersionInfo    = types.VersionInfo
 )
@@ -55,12 +55,18 @@ func TestEndBlocker(t *testing.T) {
 	// set new app upgrade config
 	keeper.ClearUpgradeConfig(ctx)
 	require.NoError(t, keeper.SetAppUpgradeConfig(ctx, 2, 2, 2048, "software2"))
+	upgraded := false
+	keeper.SetUpgradeHandler(2, func(ctx sdk.Context) {
+		upgraded = true
+	})
 
 	ctx = ctx.WithBlockHeight(2047)
 	require.NotPanics(t, func() {
 		EndBlocker(ctx, keeper)
 	})
 	// log "Tally Start" && "Software Upgrade is failure"
+	// the upgrade handlers aren't run if the upgrade fails
+	require.False(t, upgraded)
 }
 
 func TestEndBlockerTallySuccess(t *testing.T) {
@@ -92,12 +98,27 @@ func TestEndBlockerTallySuccess(t *testing.T) {
 		keeper.SetSignal(ctx, 1, validator.GetConsAddr().String())
 	}
 
+	var upgradeHeights []int64
+	for i := 0; i < 2; i++ {
+		keeper.SetUpgradeHandler(1, func(ctx sdk.Context) {
+			upgradeHeights = append(upgradeHeights, ctx.BlockHeight())
+		})
+	}
+
 	ctx = ctx.WithBlockHeader(abci.Header{Version: abci.Version{Block: 1, App: 1}, ProposerAddress: validatorPro.GetConsAddr()})
+	ctx = ctx.WithBlockHeight(1022)
+	require.NotPanics(t, func() {
+		EndBlocker(ctx, keeper)
+	})
+	require.Empty(t, upgradeHeights)
+
 	ctx = ctx.WithBlockHeight(1023)
 	require.NotPanics(t, func() {
 		EndBlocker(ctx, keeper)
 	})
 	// log "Software Upgrade is successful"
 	require.Equal(t, uint64(1), keeper.GetCurrentVersion(ctx))
+	// every upgrade handler is run in the last block before the upgrade height
+	require.Equal(t, []int64{1023, 1023}, upgradeHeights)
 
 }
@@ -41,6 +41,9 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) {
 			if success {
 				logger.Info("Software Upgrade is successful.", "version", upgradeConfig.ProtocolDef.Version)
 				keeper.SetCurrentVersion(ctx, upgradeConfig.ProtocolDef.Version)
+				// the state of the new version is migrated in the last block before the upgrade height,
+				// at the same height on every node whenever it runs the block
+				keeper.RunUpgradeHandlers(ctx, upgradeConfig.ProtocolDef.Version)
 			} else {
 				logger.Info("Software Upgrade is failure.", "version", upgradeConfig.ProtocolDef.Version)
 				keeper.SetLastFailedVersion(ctx, upgradeConfig.ProtocolDef.Version)
@@ -24,8 +24,13 @@ type Keeper struct {
 	stakingKeeper  StakingKeeper
 	bankKeeper     BankKeeper
 	paramSpace     params.Subspace
+	// the handlers run at the upgrade height by version
+	upgradeHandlers map[uint64][]UpgradeHandler
 }
 
+// UpgradeHandler migrates the state of a module when the app upgrades to a new version
+type UpgradeHandler func(ctx sdk.Context)
+
 // NewKeeper creates a new upgrade keeper
 func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, pk ProtocolKeeper, sk StakingKeeper, ck BankKeeper,
 	paramSpace params.Subspace) Keeper {
@@ -36,6 +41,19 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, pk ProtocolKeeper, sk Staking
 		sk,
 		ck,
 		paramSpace.WithKeyTable(types.ParamKeyTable()),
+		make(map[uint64][]UpgradeHandler),
+	}
+}
+
+// SetUpgradeHandler adds a handler which is run when the app upgrades to the version successfully
+func (k Keeper) SetUpgradeHandler(version uint64, handler UpgradeHandler) {
+	k.upgradeHandlers[version] = append(k.upgradeHandlers[version], handler)
+}
+
+// RunUpgradeHandlers runs the handlers of the version in the order of their registration
+func (k Keeper) RunUpgradeHandlers(ctx sdk.Context, version uint64) {
+	for _, handler := range k.upgradeHandlers[version] {
+		handler(ctx)
 	}
 }
 